@@ -0,0 +1,467 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/adapter/markdown"
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// DOCX paketindeki sabit parçalar
+const (
+	docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>
+<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>
+<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>
+</Types>`
+
+	docxPackageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>
+</Relationships>`
+
+	docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults>
+<w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:eastAsia="Calibri" w:cs="Calibri"/><w:sz w:val="22"/><w:szCs w:val="22"/><w:lang w:val="tr-TR"/></w:rPr></w:rPrDefault>
+<w:pPrDefault><w:pPr><w:spacing w:after="120" w:line="276" w:lineRule="auto"/></w:pPr></w:pPrDefault>
+</w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>
+<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:spacing w:after="60"/></w:pPr><w:rPr><w:b/><w:sz w:val="48"/><w:szCs w:val="48"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:spacing w:after="0"/></w:pPr><w:rPr><w:color w:val="666666"/><w:sz w:val="18"/><w:szCs w:val="18"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="36"/><w:szCs w:val="36"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="300" w:after="120"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="30"/><w:szCs w:val="30"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="2"/></w:pPr><w:rPr><w:b/><w:sz w:val="26"/><w:szCs w:val="26"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading4"><w:name w:val="heading 4"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="200" w:after="80"/><w:outlineLvl w:val="3"/></w:pPr><w:rPr><w:b/><w:sz w:val="24"/><w:szCs w:val="24"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading5"><w:name w:val="heading 5"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="200" w:after="60"/><w:outlineLvl w:val="4"/></w:pPr><w:rPr><w:b/><w:i/><w:sz w:val="22"/><w:szCs w:val="22"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Heading6"><w:name w:val="heading 6"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/><w:pPr><w:keepNext/><w:spacing w:before="200" w:after="60"/><w:outlineLvl w:val="5"/></w:pPr><w:rPr><w:i/><w:sz w:val="22"/><w:szCs w:val="22"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Code"><w:name w:val="Code"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:shd w:val="clear" w:color="auto" w:fill="F5F5F5"/><w:spacing w:after="120" w:line="240" w:lineRule="auto"/></w:pPr><w:rPr><w:rFonts w:ascii="Consolas" w:hAnsi="Consolas" w:cs="Consolas"/><w:sz w:val="19"/><w:szCs w:val="19"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Quote"><w:name w:val="Quote"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:pBdr><w:left w:val="single" w:sz="18" w:space="8" w:color="DDDDDD"/></w:pBdr><w:ind w:left="360"/></w:pPr><w:rPr><w:i/><w:color w:val="555555"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="ListParagraph"><w:name w:val="List Paragraph"/><w:basedOn w:val="Normal"/><w:qFormat/><w:pPr><w:spacing w:after="40"/><w:contextualSpacing/></w:pPr></w:style>
+<w:style w:type="character" w:styleId="CodeChar"><w:name w:val="Code Char"/><w:rPr><w:rFonts w:ascii="Consolas" w:hAnsi="Consolas" w:cs="Consolas"/><w:sz w:val="20"/><w:szCs w:val="20"/></w:rPr></w:style>
+<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="1E50B4"/><w:u w:val="single"/></w:rPr></w:style>
+<w:style w:type="table" w:styleId="TableGrid"><w:name w:val="Table Grid"/><w:tblPr><w:tblBorders><w:top w:val="single" w:sz="4" w:space="0" w:color="BBBBBB"/><w:left w:val="single" w:sz="4" w:space="0" w:color="BBBBBB"/><w:bottom w:val="single" w:sz="4" w:space="0" w:color="BBBBBB"/><w:right w:val="single" w:sz="4" w:space="0" w:color="BBBBBB"/><w:insideH w:val="single" w:sz="4" w:space="0" w:color="BBBBBB"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="BBBBBB"/></w:tblBorders><w:tblCellMar><w:left w:w="108" w:type="dxa"/><w:right w:w="108" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>
+</w:styles>`
+)
+
+// DOCX sayfa düzeni (twip, 1/20 punto): A4, 2 cm kenar boşluğu
+const (
+	docxTextWidth  = 11906 - 2*1134
+	docxListIndent = 360
+)
+
+// DOCXExporter, notu Word (Office Open XML) belgesine dönüştürür. Paket harici bir kütüphane
+// kullanılmadan oluşturulur; Markdown başlıkları Word başlık stillerine eşlenir.
+type DOCXExporter struct{}
+
+// NewDOCXExporter, yeni bir DOCXExporter örneği oluşturur
+func NewDOCXExporter() *DOCXExporter {
+	return &DOCXExporter{}
+}
+
+// Format, biçim adını döndürür
+func (e *DOCXExporter) Format() string {
+	return domain.ExportFormatDOCX
+}
+
+// ContentType, üretilen dosyanın MIME türünü döndürür
+func (e *DOCXExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+}
+
+// Export, notu DOCX paketine dönüştürür
+func (e *DOCXExporter) Export(doc *domain.NoteDocument) ([]byte, error) {
+	source := []byte(doc.Content)
+	w := &docxWriter{source: source}
+
+	// Başlık ve üst bilgiler
+	w.paragraph("Title", func() { w.run(doc.Title) })
+	for _, line := range metadataLines(doc) {
+		w.paragraph("Subtitle", func() { w.run(line) })
+	}
+	w.body.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="BBBBBB"/></w:pBdr></w:pPr></w:p>`)
+
+	w.blocks(markdown.Parse(source))
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	modified := doc.UpdatedAt
+	if modified.IsZero() {
+		modified = time.Now()
+	}
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", docxContentTypes},
+		{"_rels/.rels", docxPackageRels},
+		{"docProps/core.xml", docxCoreProperties(doc)},
+		{"word/document.xml", w.document()},
+		{"word/styles.xml", docxStyles},
+		{"word/_rels/document.xml.rels", w.relationships()},
+	}
+	for _, part := range parts {
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: part.name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return nil, fmt.Errorf("DOCX oluşturulamadı: %w", err)
+		}
+		if _, err := file.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("DOCX oluşturulamadı: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("DOCX oluşturulamadı: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// docxCoreProperties, belgenin başlık, yazar, anahtar kelime ve tarih bilgilerini içeren parçayı oluşturur
+func docxCoreProperties(doc *domain.NoteDocument) string {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">`)
+	buf.WriteString("<dc:title>" + escapeXML(doc.Title) + "</dc:title>")
+	if doc.Author != "" {
+		buf.WriteString("<dc:creator>" + escapeXML(doc.Author) + "</dc:creator>")
+	}
+	if len(doc.Tags) > 0 {
+		buf.WriteString("<cp:keywords>" + escapeXML(strings.Join(doc.Tags, ", ")) + "</cp:keywords>")
+	}
+	if !doc.CreatedAt.IsZero() {
+		buf.WriteString(`<dcterms:created xsi:type="dcterms:W3CDTF">` + doc.CreatedAt.UTC().Format(time.RFC3339) + "</dcterms:created>")
+	}
+	if !doc.UpdatedAt.IsZero() {
+		buf.WriteString(`<dcterms:modified xsi:type="dcterms:W3CDTF">` + doc.UpdatedAt.UTC().Format(time.RFC3339) + "</dcterms:modified>")
+	}
+	buf.WriteString("</cp:coreProperties>")
+	return buf.String()
+}
+
+// docxWriter, Markdown belge ağacını dolaşarak WordprocessingML gövdesini oluşturur
+type docxWriter struct {
+	source []byte
+	body   bytes.Buffer
+	links  []string // Bağlantı ilişkileri; ilişki ID'si rId(i+2)'dir (rId1 stiller)
+
+	// Blok durumu
+	quote  int    // İç içe alıntı derinliği
+	indent int    // Liste girintisi (twip)
+	marker string // Sıradaki paragrafın başına yazılacak liste işareti
+
+	// Satır içi biçim durumu
+	bold, italic, mono, strike int
+	linked                     bool
+}
+
+// document, belge gövdesini sayfa ayarlarıyla birlikte document.xml parçasına yerleştirir
+func (w *docxWriter) document() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><w:body>` +
+		w.body.String() +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="1134" w:bottom="1134" w:left="1134" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr></w:body></w:document>`
+}
+
+// relationships, stil ve bağlantı ilişkilerini içeren document.xml.rels parçasını oluşturur
+func (w *docxWriter) relationships() string {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`)
+	for i, link := range w.links {
+		fmt.Fprintf(&buf, "\n"+`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="%s" TargetMode="External"/>`, i+2, escapeXML(link))
+	}
+	buf.WriteString("\n</Relationships>")
+	return buf.String()
+}
+
+// blocks, bir düğümün alt bloklarını sırayla yazar
+func (w *docxWriter) blocks(parent ast.Node) {
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		w.block(n)
+	}
+}
+
+// block, tek bir blok düğümünü yazar
+func (w *docxWriter) block(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Heading:
+		level := min(max(n.Level, 1), 6)
+		w.paragraph("Heading"+strconv.Itoa(level), func() { w.inlines(n) })
+
+	case *ast.Paragraph, *ast.TextBlock:
+		w.paragraph(w.textStyle(), func() { w.inlines(n) })
+
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		w.preformatted(string(blockLines(n, w.source)), false)
+
+	case *markdown.MathBlock:
+		w.preformatted(string(n.Value(w.source)), true)
+
+	case *ast.Blockquote:
+		w.quote++
+		w.blocks(n)
+		w.quote--
+
+	case *ast.List:
+		w.list(n)
+
+	case *ast.ThematicBreak:
+		w.body.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="6" w:space="1" w:color="BBBBBB"/></w:pBdr></w:pPr></w:p>`)
+
+	case *extast.Table:
+		w.table(n)
+
+	case *ast.HTMLBlock:
+		// Ham HTML dışa aktarılmaz
+
+	default:
+		w.blocks(n)
+	}
+}
+
+// textStyle, metin paragraflarının bulunduğu yere göre stilini döndürür
+func (w *docxWriter) textStyle() string {
+	switch {
+	case w.quote > 0:
+		return "Quote"
+	case w.indent > 0:
+		return "ListParagraph"
+	default:
+		return "Normal"
+	}
+}
+
+// paragraph, verilen stilde bir paragraf açar, içeriğini yazar ve kapatır. Liste içindeyse paragraf
+// girintilenir ve bekleyen liste işareti paragrafın başına yazılır.
+func (w *docxWriter) paragraph(style string, content func()) {
+	w.paragraphWithProperties(style, "", content)
+}
+
+// paragraphWithProperties, paragraph gibidir; stilden sonra ek paragraf özellikleri yazar
+func (w *docxWriter) paragraphWithProperties(style, properties string, content func()) {
+	w.body.WriteString(`<w:p><w:pPr><w:pStyle w:val="` + style + `"/>`)
+	if w.indent > 0 {
+		fmt.Fprintf(&w.body, `<w:ind w:left="%d" w:hanging="%d"/>`, w.indent, docxListIndent)
+	}
+	w.body.WriteString(properties + "</w:pPr>")
+	if w.marker != "" {
+		w.body.WriteString(`<w:r><w:t xml:space="preserve">` + escapeXML(w.marker) + "</w:t></w:r><w:r><w:tab/></w:r>")
+		w.marker = ""
+	}
+	content()
+	w.body.WriteString("</w:p>")
+}
+
+// preformatted, kod ve matematik bloklarını satır sonlarını koruyarak Code stilinde yazar
+func (w *docxWriter) preformatted(content string, centered bool) {
+	properties := ""
+	if centered {
+		properties = `<w:jc w:val="center"/>`
+	}
+	w.paragraphWithProperties("Code", properties, func() {
+		for i, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+			if i > 0 {
+				w.body.WriteString("<w:r><w:br/></w:r>")
+			}
+			w.run(line)
+		}
+	})
+}
+
+// list, liste öğelerini madde işareti veya numarayla, girintili paragraflar olarak yazar
+func (w *docxWriter) list(n *ast.List) {
+	w.indent += 2 * docxListIndent
+	number := n.Start
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		w.marker = "•"
+		if n.IsOrdered() {
+			w.marker = strconv.Itoa(number) + string(n.Marker)
+			number++
+		}
+		w.blocks(item)
+		w.marker = ""
+	}
+	w.indent -= 2 * docxListIndent
+}
+
+// table, tabloyu eşit genişlikte sütunlarla yazar; başlık satırı kalın yazılır
+func (w *docxWriter) table(n *extast.Table) {
+	columns := max(len(n.Alignments), 1)
+	cellWidth := docxTextWidth / columns
+
+	w.body.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="0" w:type="auto"/></w:tblPr><w:tblGrid>`)
+	for i := 0; i < columns; i++ {
+		fmt.Fprintf(&w.body, `<w:gridCol w:w="%d"/>`, cellWidth)
+	}
+	w.body.WriteString("</w:tblGrid>")
+
+	indent := w.indent
+	w.indent = 0
+	for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+		_, header := row.(*extast.TableHeader)
+		w.body.WriteString("<w:tr>")
+		if header {
+			w.body.WriteString("<w:trPr><w:tblHeader/></w:trPr>")
+			w.bold++
+		}
+		i := 0
+		for cell := row.FirstChild(); cell != nil && i < columns; cell = cell.NextSibling() {
+			fmt.Fprintf(&w.body, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/>`, cellWidth)
+			if header {
+				w.body.WriteString(`<w:shd w:val="clear" w:color="auto" w:fill="EBEBEB"/>`)
+			}
+			w.body.WriteString("</w:tcPr>")
+
+			properties := `<w:spacing w:after="0"/>`
+			switch n.Alignments[i] {
+			case extast.AlignCenter:
+				properties += `<w:jc w:val="center"/>`
+			case extast.AlignRight:
+				properties += `<w:jc w:val="right"/>`
+			}
+			w.paragraphWithProperties("Normal", properties, func() { w.inlines(cell) })
+			w.body.WriteString("</w:tc>")
+			i++
+		}
+		// Eksik hücreler boş bırakılır; her hücrede en az bir paragraf bulunmalıdır
+		for ; i < columns; i++ {
+			fmt.Fprintf(&w.body, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/></w:tcPr><w:p/></w:tc>`, cellWidth)
+		}
+		if header {
+			w.bold--
+		}
+		w.body.WriteString("</w:tr>")
+	}
+	w.indent = indent
+	w.body.WriteString("</w:tbl><w:p/>")
+}
+
+// inlines, bir bloğun satır içi düğümlerini yazar
+func (w *docxWriter) inlines(parent ast.Node) {
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		w.inline(n)
+	}
+}
+
+// inline, tek bir satır içi düğümü geçerli biçimle yazar
+func (w *docxWriter) inline(n ast.Node) {
+	switch n := n.(type) {
+	case *ast.Text:
+		w.run(string(n.Segment.Value(w.source)))
+		if n.HardLineBreak() {
+			w.body.WriteString("<w:r><w:br/></w:r>")
+		} else if n.SoftLineBreak() {
+			w.run(" ")
+		}
+
+	case *ast.String:
+		w.run(string(n.Value))
+
+	case *ast.Emphasis:
+		level := &w.italic
+		if n.Level >= 2 {
+			level = &w.bold
+		}
+		*level++
+		w.inlines(n)
+		*level--
+
+	case *extast.Strikethrough:
+		w.strike++
+		w.inlines(n)
+		w.strike--
+
+	case *ast.CodeSpan:
+		w.mono++
+		w.run(markdown.PlainText(n, w.source))
+		w.mono--
+
+	case *markdown.MathInline:
+		w.mono++
+		w.run(string(n.Value(w.source)))
+		w.mono--
+
+	case *ast.Link:
+		w.hyperlink(string(n.Destination), func() { w.inlines(n) })
+
+	case *ast.AutoLink:
+		url := string(n.URL(w.source))
+		if n.AutoLinkType == ast.AutoLinkEmail && !strings.HasPrefix(strings.ToLower(url), "mailto:") {
+			url = "mailto:" + url
+		}
+		w.hyperlink(url, func() { w.run(string(n.Label(w.source))) })
+
+	case *ast.Image:
+		w.run("[" + markdown.PlainText(n, w.source) + "]")
+
+	case *extast.TaskCheckBox:
+		if n.IsChecked {
+			w.run("☑ ")
+		} else {
+			w.run("☐ ")
+		}
+
+	case *ast.RawHTML:
+		// Ham HTML dışa aktarılmaz
+
+	default:
+		w.inlines(n)
+	}
+}
+
+// hyperlink, içeriği harici bağlantı olarak yazar. Yalnızca http(s) ve e-posta bağlantıları eklenir.
+func (w *docxWriter) hyperlink(url string, content func()) {
+	if !safeLink(url) {
+		content()
+		return
+	}
+	w.links = append(w.links, url)
+	fmt.Fprintf(&w.body, `<w:hyperlink r:id="rId%d">`, len(w.links)+1)
+	w.linked = true
+	content()
+	w.linked = false
+	w.body.WriteString("</w:hyperlink>")
+}
+
+// run, metni geçerli biçimle bir metin parçası (run) olarak yazar
+func (w *docxWriter) run(text string) {
+	if text == "" {
+		return
+	}
+	w.body.WriteString("<w:r><w:rPr>")
+	switch {
+	case w.linked:
+		w.body.WriteString(`<w:rStyle w:val="Hyperlink"/>`)
+	case w.mono > 0:
+		w.body.WriteString(`<w:rStyle w:val="CodeChar"/>`)
+	}
+	if w.bold > 0 {
+		w.body.WriteString("<w:b/>")
+	}
+	if w.italic > 0 {
+		w.body.WriteString("<w:i/>")
+	}
+	if w.strike > 0 {
+		w.body.WriteString("<w:strike/>")
+	}
+	w.body.WriteString(`</w:rPr><w:t xml:space="preserve">` + escapeXML(text) + "</w:t></w:r>")
+}
+
+// escapeXML, metni XML içeriği veya öznitelik değeri olarak kaçışlar; XML'de geçersiz karakterler değiştirilir
+func escapeXML(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
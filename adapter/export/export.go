@@ -0,0 +1,50 @@
+// Package export, notları PDF, Markdown, HTML ve DOCX dosyalarına dönüştüren domain.NoteExporter
+// uygulamalarını içerir. Not içeriği Markdown olarak yorumlanır; matematik ifadeleri LaTeX
+// kaynaklarıyla korunur.
+package export
+
+import (
+	"strings"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// dateLayout, dışa aktarılan belgelerde gösterilen tarih biçimi
+const dateLayout = "02.01.2006"
+
+// Exporters, desteklenen tüm biçimlerin dışa aktarıcılarını döndürür
+func Exporters() []domain.NoteExporter {
+	return []domain.NoteExporter{
+		NewPDFExporter(),
+		NewMarkdownExporter(),
+		NewHTMLExporter(),
+		NewDOCXExporter(),
+	}
+}
+
+// formatDate, tarihi belgelerde gösterilecek biçimde yazar; sıfır tarih için boş döner
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// metadataLines, belge başlığının altında gösterilen yazar, etiket ve tarih satırlarını döndürür
+func metadataLines(doc *domain.NoteDocument) []string {
+	var lines []string
+	if doc.Author != "" {
+		lines = append(lines, "Yazar: "+doc.Author)
+	}
+	if len(doc.Tags) > 0 {
+		lines = append(lines, "Etiketler: "+strings.Join(doc.Tags, ", "))
+	}
+	if created := formatDate(doc.CreatedAt); created != "" {
+		lines = append(lines, "Oluşturulma: "+created)
+	}
+	if updated := formatDate(doc.UpdatedAt); updated != "" && !doc.UpdatedAt.Equal(doc.CreatedAt) {
+		lines = append(lines, "Son güncelleme: "+updated)
+	}
+	return lines
+}
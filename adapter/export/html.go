@@ -0,0 +1,107 @@
+package export
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/adapter/markdown"
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// htmlTemplate, dışa aktarılan notun tek başına açılabilen HTML belgesi. Matematik ifadeleri
+// tarayıcıda KaTeX ile işlenir.
+var htmlTemplate = template.Must(template.New("note").Parse(`<!DOCTYPE html>
+<html lang="tr">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+{{- if .Author}}
+<meta name="author" content="{{.Author}}">
+{{- end}}
+{{- if .Keywords}}
+<meta name="keywords" content="{{.Keywords}}">
+{{- end}}
+{{- if .Date}}
+<meta name="date" content="{{.Date}}">
+{{- end}}
+<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/katex@0.16.22/dist/katex.min.css">
+<script defer src="https://cdn.jsdelivr.net/npm/katex@0.16.22/dist/katex.min.js"></script>
+<script defer src="https://cdn.jsdelivr.net/npm/katex@0.16.22/dist/contrib/auto-render.min.js"
+  onload="renderMathInElement(document.body, {delimiters: [{left: '\\[', right: '\\]', display: true}, {left: '\\(', right: '\\)', display: false}]});"></script>
+<style>
+body { max-width: 48rem; margin: 2rem auto; padding: 0 1rem; font-family: sans-serif; line-height: 1.6; color: #222; }
+header { border-bottom: 1px solid #ddd; margin-bottom: 1.5rem; }
+header p { margin: 0.2rem 0; color: #666; font-size: 0.9rem; }
+pre { background: #f5f5f5; padding: 0.75rem; overflow-x: auto; }
+code { font-family: monospace; }
+blockquote { border-left: 4px solid #ddd; margin-left: 0; padding-left: 1rem; color: #555; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3rem 0.6rem; }
+</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+{{- range .Metadata}}
+<p>{{.}}</p>
+{{- end}}
+</header>
+<article>
+{{.Body}}
+</article>
+</body>
+</html>
+`))
+
+// HTMLExporter, notu tek başına açılabilen bir HTML belgesine dönüştürür
+type HTMLExporter struct{}
+
+// NewHTMLExporter, yeni bir HTMLExporter örneği oluşturur
+func NewHTMLExporter() *HTMLExporter {
+	return &HTMLExporter{}
+}
+
+// Format, biçim adını döndürür
+func (e *HTMLExporter) Format() string {
+	return domain.ExportFormatHTML
+}
+
+// ContentType, üretilen dosyanın MIME türünü döndürür
+func (e *HTMLExporter) ContentType() string {
+	return "text/html; charset=utf-8"
+}
+
+// Export, Markdown içeriğini HTML'e dönüştürür ve başlık ile üst verilerle birlikte belgeye yerleştirir
+func (e *HTMLExporter) Export(doc *domain.NoteDocument) ([]byte, error) {
+	body, err := markdown.ToHTML([]byte(doc.Content))
+	if err != nil {
+		return nil, err
+	}
+
+	data := struct {
+		Title    string
+		Author   string
+		Keywords string
+		Date     string
+		Metadata []string
+		Body     template.HTML
+	}{
+		Title:    doc.Title,
+		Author:   doc.Author,
+		Keywords: strings.Join(doc.Tags, ", "),
+		Metadata: metadataLines(doc),
+		// Markdown dönüştürücüsü ham HTML'i yazmaz ve tehlikeli bağlantıları boşaltır
+		Body: template.HTML(body),
+	}
+	if !doc.CreatedAt.IsZero() {
+		data.Date = doc.CreatedAt.UTC().Format("2006-01-02")
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
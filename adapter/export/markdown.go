@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// MarkdownExporter, notu YAML ön bilgisi (front matter) eklenmiş bir Markdown dosyasına dönüştürür
+type MarkdownExporter struct{}
+
+// NewMarkdownExporter, yeni bir MarkdownExporter örneği oluşturur
+func NewMarkdownExporter() *MarkdownExporter {
+	return &MarkdownExporter{}
+}
+
+// Format, biçim adını döndürür
+func (e *MarkdownExporter) Format() string {
+	return domain.ExportFormatMarkdown
+}
+
+// ContentType, üretilen dosyanın MIME türünü döndürür
+func (e *MarkdownExporter) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+// Export, notun başlık, yazar, etiket ve tarihlerini front matter olarak yazar ve içeriği olduğu gibi ekler
+func (e *MarkdownExporter) Export(doc *domain.NoteDocument) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.WriteString("title: " + strconv.Quote(doc.Title) + "\n")
+	if doc.Author != "" {
+		buf.WriteString("author: " + strconv.Quote(doc.Author) + "\n")
+	}
+	if !doc.CreatedAt.IsZero() {
+		buf.WriteString("date: " + doc.CreatedAt.UTC().Format(time.RFC3339) + "\n")
+	}
+	if !doc.UpdatedAt.IsZero() {
+		buf.WriteString("updated: " + doc.UpdatedAt.UTC().Format(time.RFC3339) + "\n")
+	}
+	if len(doc.Tags) > 0 {
+		tags := make([]string, len(doc.Tags))
+		for i, tag := range doc.Tags {
+			tags[i] = strconv.Quote(tag)
+		}
+		buf.WriteString("tags: [" + strings.Join(tags, ", ") + "]\n")
+	}
+	buf.WriteString("---\n\n")
+
+	buf.WriteString(doc.Content)
+	if !strings.HasSuffix(doc.Content, "\n") {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,470 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/adapter/markdown"
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/go-pdf/fpdf"
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/goregular"
+)
+
+// PDF belgesinde kullanılan yazı tipi aileleri. Go yazı tipleri Türkçe karakterleri içerir ve
+// ikili dosyaya gömülü geldiği için sunucuda yazı tipi kurulumu gerekmez.
+const (
+	pdfFontText = "go"
+	pdfFontMono = "gomono"
+)
+
+// PDF sayfa düzeni (milimetre ve punto)
+const (
+	pdfMargin     = 20.0
+	pdfFontSize   = 11.0
+	pdfLineHeight = 5.5
+	pdfListIndent = 6.0
+)
+
+// pdfHeadingSizes, başlık seviyelerine göre yazı boyutları
+var pdfHeadingSizes = [...]float64{20, 17, 15, 13, 12, 11}
+
+// PDFExporter, notu A4 boyutunda bir PDF belgesine dönüştürür. Markdown içeriği başlıklar, listeler,
+// kod blokları, alıntılar ve tablolarla birlikte yazılır; matematik ifadeleri LaTeX kaynaklarıyla
+// eş aralıklı yazı tipinde gösterilir.
+type PDFExporter struct{}
+
+// NewPDFExporter, yeni bir PDFExporter örneği oluşturur
+func NewPDFExporter() *PDFExporter {
+	return &PDFExporter{}
+}
+
+// Format, biçim adını döndürür
+func (e *PDFExporter) Format() string {
+	return domain.ExportFormatPDF
+}
+
+// ContentType, üretilen dosyanın MIME türünü döndürür
+func (e *PDFExporter) ContentType() string {
+	return "application/pdf"
+}
+
+// Export, notu PDF belgesine dönüştürür
+func (e *PDFExporter) Export(doc *domain.NoteDocument) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(pdfFontText, "", goregular.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontText, "B", gobold.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontText, "I", goitalic.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontText, "BI", gobolditalic.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontMono, "", gomono.TTF)
+	pdf.AddUTF8FontFromBytes(pdfFontMono, "B", gomonobold.TTF)
+
+	pdf.SetTitle(doc.Title, true)
+	pdf.SetAuthor(doc.Author, true)
+	pdf.SetKeywords(strings.Join(doc.Tags, ", "), true)
+	pdf.SetCreator("UniNotes", true)
+	pdf.SetLang("tr-TR")
+	if !doc.CreatedAt.IsZero() {
+		pdf.SetCreationDate(doc.CreatedAt)
+	}
+	if !doc.UpdatedAt.IsZero() {
+		pdf.SetModificationDate(doc.UpdatedAt)
+	}
+
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin / 2)
+		pdf.SetFont(pdfFontText, "", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// Başlık ve üst bilgiler
+	pdf.SetFont(pdfFontText, "B", 22)
+	pdf.MultiCell(0, 9, pdfText(doc.Title), "", "L", false)
+	pdf.Ln(1)
+	pdf.SetFont(pdfFontText, "", 9)
+	pdf.SetTextColor(100, 100, 100)
+	for _, line := range metadataLines(doc) {
+		pdf.MultiCell(0, 4.5, pdfText(line), "", "L", false)
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(2)
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.Line(left, pdf.GetY(), pageWidth-right, pdf.GetY())
+	pdf.Ln(4)
+
+	source := []byte(doc.Content)
+	w := &pdfWriter{pdf: pdf, source: source, size: pdfFontSize}
+	w.setFont()
+	w.blocks(markdown.Parse(source))
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("PDF oluşturulamadı: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// pdfWriter, Markdown belge ağacını dolaşarak PDF'e yazar
+type pdfWriter struct {
+	pdf    *fpdf.Fpdf
+	source []byte
+
+	// Satır içi biçim durumu
+	bold, italic, mono, strike int
+	size                       float64
+	link                       string
+}
+
+// lineHeight, geçerli yazı boyutuna göre satır yüksekliği
+func (w *pdfWriter) lineHeight() float64 {
+	return w.size * pdfLineHeight / pdfFontSize
+}
+
+// setFont, geçerli biçim durumuna uygun yazı tipini seçer
+func (w *pdfWriter) setFont() {
+	style := ""
+	if w.bold > 0 {
+		style += "B"
+	}
+	family := pdfFontText
+	if w.mono > 0 {
+		family = pdfFontMono // Eş aralıklı yazı tipinin italik biçimi yüklenmez
+	} else if w.italic > 0 {
+		style += "I"
+	}
+	if w.strike > 0 {
+		style += "S"
+	}
+	w.pdf.SetFont(family, style, w.size)
+}
+
+// blocks, bir düğümün alt bloklarını sırayla yazar
+func (w *pdfWriter) blocks(parent ast.Node) {
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		w.block(n)
+	}
+}
+
+// block, tek bir blok düğümünü yazar
+func (w *pdfWriter) block(n ast.Node) {
+	pdf := w.pdf
+	switch n := n.(type) {
+	case *ast.Heading:
+		level := min(max(n.Level, 1), len(pdfHeadingSizes))
+		pdf.Ln(2)
+		w.size = pdfHeadingSizes[level-1]
+		w.bold++
+		w.setFont()
+		w.inlines(n)
+		w.bold--
+		pdf.Ln(w.lineHeight())
+		w.size = pdfFontSize
+		w.setFont()
+		pdf.Ln(2)
+
+	case *ast.Paragraph:
+		w.inlines(n)
+		pdf.Ln(w.lineHeight())
+		pdf.Ln(2)
+
+	case *ast.TextBlock:
+		w.inlines(n)
+		pdf.Ln(w.lineHeight())
+
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		w.preformatted(string(blockLines(n, w.source)), "L")
+
+	case *markdown.MathBlock:
+		w.preformatted(string(n.Value(w.source)), "C")
+
+	case *ast.Blockquote:
+		left, _, _, _ := pdf.GetMargins()
+		pdf.SetLeftMargin(left + pdfListIndent)
+		pdf.SetX(left + pdfListIndent)
+		pdf.SetTextColor(90, 90, 90)
+		w.italic++
+		w.setFont()
+		w.blocks(n)
+		w.italic--
+		w.setFont()
+		pdf.SetTextColor(0, 0, 0)
+		pdf.SetLeftMargin(left)
+		pdf.SetX(left)
+
+	case *ast.List:
+		w.list(n)
+		if _, nested := n.Parent().(*ast.ListItem); !nested {
+			pdf.Ln(2)
+		}
+
+	case *ast.ThematicBreak:
+		left, _, right, _ := pdf.GetMargins()
+		pageWidth, _ := pdf.GetPageSize()
+		pdf.Ln(2)
+		pdf.SetDrawColor(180, 180, 180)
+		pdf.Line(left, pdf.GetY(), pageWidth-right, pdf.GetY())
+		pdf.SetDrawColor(0, 0, 0)
+		pdf.Ln(4)
+
+	case *extast.Table:
+		w.table(n)
+
+	case *ast.HTMLBlock:
+		// Ham HTML dışa aktarılmaz
+
+	default:
+		w.blocks(n)
+	}
+}
+
+// preformatted, kod ve matematik bloklarını gri zeminli, eş aralıklı yazı tipinde yazar
+func (w *pdfWriter) preformatted(content, align string) {
+	pdf := w.pdf
+	w.mono++
+	w.size = pdfFontSize - 1.5
+	w.setFont()
+	pdf.SetFillColor(245, 245, 245)
+	pdf.MultiCell(0, w.lineHeight(), pdfText(strings.TrimRight(content, "\n")), "", align, true)
+	w.mono--
+	w.size = pdfFontSize
+	w.setFont()
+	pdf.Ln(3)
+}
+
+// list, liste öğelerini madde işareti veya numarayla, girintili olarak yazar
+func (w *pdfWriter) list(n *ast.List) {
+	pdf := w.pdf
+	left, _, _, _ := pdf.GetMargins()
+	number := n.Start
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := "•"
+		if n.IsOrdered() {
+			marker = strconv.Itoa(number) + string(n.Marker)
+			number++
+		}
+		pdf.SetX(left)
+		pdf.CellFormat(pdfListIndent, w.lineHeight(), marker, "", 0, "L", false, 0, "")
+
+		pdf.SetLeftMargin(left + pdfListIndent)
+		w.blocks(item)
+		pdf.SetLeftMargin(left)
+		pdf.SetX(left)
+	}
+}
+
+// table, tabloyu eşit genişlikte sütunlarla ve kenarlıklı hücrelerle yazar
+func (w *pdfWriter) table(n *extast.Table) {
+	pdf := w.pdf
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, pageHeight := pdf.GetPageSize()
+	columns := max(len(n.Alignments), 1)
+	cellWidth := (pageWidth - right - left) / float64(columns)
+	lineHeight := w.lineHeight()
+
+	for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+		_, header := row.(*extast.TableHeader)
+		if header {
+			w.bold++
+			pdf.SetFillColor(235, 235, 235)
+		}
+		w.setFont()
+
+		// Satır yüksekliği en çok satıra bölünen hücreye göre belirlenir
+		var cells []string
+		lines := 1
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			text := pdfText(markdown.PlainText(cell, w.source))
+			cells = append(cells, text)
+			lines = max(lines, len(pdf.SplitText(text, cellWidth)))
+		}
+		height := float64(lines) * lineHeight
+		if pdf.GetY()+height > pageHeight-pdfMargin {
+			pdf.AddPage()
+		}
+
+		y := pdf.GetY()
+		for i, text := range cells {
+			if i >= columns {
+				break
+			}
+			align := "L"
+			switch n.Alignments[i] {
+			case extast.AlignCenter:
+				align = "C"
+			case extast.AlignRight:
+				align = "R"
+			}
+			x := left + float64(i)*cellWidth
+			if header {
+				pdf.Rect(x, y, cellWidth, height, "FD")
+			} else {
+				pdf.Rect(x, y, cellWidth, height, "D")
+			}
+			pdf.SetXY(x, y)
+			pdf.MultiCell(cellWidth, lineHeight, text, "", align, false)
+		}
+		pdf.SetXY(left, y+height)
+
+		if header {
+			w.bold--
+		}
+	}
+	w.setFont()
+	pdf.Ln(3)
+}
+
+// inlines, bir bloğun satır içi düğümlerini yazar
+func (w *pdfWriter) inlines(parent ast.Node) {
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		w.inline(n)
+	}
+}
+
+// inline, tek bir satır içi düğümü geçerli biçimle yazar
+func (w *pdfWriter) inline(n ast.Node) {
+	pdf := w.pdf
+	switch n := n.(type) {
+	case *ast.Text:
+		w.write(string(n.Segment.Value(w.source)))
+		if n.HardLineBreak() {
+			pdf.Ln(w.lineHeight())
+		} else if n.SoftLineBreak() {
+			w.write(" ")
+		}
+
+	case *ast.String:
+		w.write(string(n.Value))
+
+	case *ast.Emphasis:
+		if n.Level >= 2 {
+			w.bold++
+		} else {
+			w.italic++
+		}
+		w.setFont()
+		w.inlines(n)
+		if n.Level >= 2 {
+			w.bold--
+		} else {
+			w.italic--
+		}
+		w.setFont()
+
+	case *extast.Strikethrough:
+		w.strike++
+		w.setFont()
+		w.inlines(n)
+		w.strike--
+		w.setFont()
+
+	case *ast.CodeSpan:
+		w.mono++
+		w.setFont()
+		w.write(markdown.PlainText(n, w.source))
+		w.mono--
+		w.setFont()
+
+	case *markdown.MathInline:
+		w.mono++
+		w.setFont()
+		w.write(string(n.Value(w.source)))
+		w.mono--
+		w.setFont()
+
+	case *ast.Link:
+		w.linked(string(n.Destination), func() { w.inlines(n) })
+
+	case *ast.AutoLink:
+		url := string(n.URL(w.source))
+		if n.AutoLinkType == ast.AutoLinkEmail && !strings.HasPrefix(strings.ToLower(url), "mailto:") {
+			url = "mailto:" + url
+		}
+		w.linked(url, func() { w.write(string(n.Label(w.source))) })
+
+	case *ast.Image:
+		w.write("[" + markdown.PlainText(n, w.source) + "]")
+
+	case *extast.TaskCheckBox:
+		w.mono++
+		w.setFont()
+		if n.IsChecked {
+			w.write("[x] ")
+		} else {
+			w.write("[ ] ")
+		}
+		w.mono--
+		w.setFont()
+
+	case *ast.RawHTML:
+		// Ham HTML dışa aktarılmaz
+
+	default:
+		w.inlines(n)
+	}
+}
+
+// linked, içeriği bağlantı olarak mavi ve altı çizili yazar. Yalnızca http(s) ve e-posta bağlantıları
+// tıklanabilir yapılır.
+func (w *pdfWriter) linked(url string, content func()) {
+	if !safeLink(url) {
+		content()
+		return
+	}
+	w.link = url
+	w.pdf.SetTextColor(30, 80, 180)
+	content()
+	w.pdf.SetTextColor(0, 0, 0)
+	w.link = ""
+}
+
+// write, metni geçerli konumdan başlayarak sol kenar boşluğuna göre kaydırarak yazar
+func (w *pdfWriter) write(text string) {
+	text = pdfText(text)
+	if w.link != "" {
+		w.pdf.WriteLinkString(w.lineHeight(), text, w.link)
+		return
+	}
+	w.pdf.Write(w.lineHeight(), text)
+}
+
+// pdfText, yazı tiplerinin karakter tablosu dışındaki (Temel Çok Dilli Düzlem dışı, ör. emoji)
+// karakterleri "?" ile değiştirir
+func pdfText(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 0xFFFF {
+			return '?'
+		}
+		return r
+	}, text)
+}
+
+// blockLines, kod bloğunun satırlarını birleştirir
+func blockLines(n ast.Node, source []byte) []byte {
+	var buf bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		buf.Write(segment.Value(source))
+	}
+	return buf.Bytes()
+}
+
+// safeLink, bağlantının belgeye tıklanabilir olarak eklenebilecek bir adres olduğunu kontrol eder
+func safeLink(url string) bool {
+	lower := strings.ToLower(url)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "mailto:")
+}
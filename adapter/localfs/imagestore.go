@@ -0,0 +1,101 @@
+package localfs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// ImageStorage, domain.PDFImageStorage arayüzünün yerel dosya sistemi implementasyonu.
+// Her anahtar basePath altındaki göreli bir dosya yoludur.
+type ImageStorage struct {
+	basePath string
+}
+
+// NewImageStorage, yeni bir ImageStorage örneği oluşturur
+func NewImageStorage(basePath string) (*ImageStorage, error) {
+	// Dizin yoksa oluştur
+	if err := os.MkdirAll(basePath, 0755); err != nil {
+		return nil, fmt.Errorf("görsel depolama dizini oluşturulamadı: %w", err)
+	}
+	return &ImageStorage{basePath: basePath}, nil
+}
+
+// Save, görseli önce geçici bir dosyaya yazar ve tamamı yazıldıktan sonra anahtarın yoluna taşır;
+// böylece okuyucular hiçbir zaman yarım yazılmış bir görsel görmez
+func (s *ImageStorage) Save(key string, content []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("görsel kaydedilemedi: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".image-*")
+	if err != nil {
+		return fmt.Errorf("görsel kaydedilemedi: %w", err)
+	}
+	// Taşıma başarılı olduysa geçici dosya artık yoktur ve Remove hatası yok sayılır
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("görsel kaydedilemedi: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("görsel kaydedilemedi: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("görsel kaydedilemedi: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("görsel kaydedilemedi: %w", err)
+	}
+	return nil
+}
+
+// Open, görseli okumak için açar
+func (s *ImageStorage) Open(key string) (io.ReadSeekCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("görsel açılamadı: %w", err)
+	}
+	return file, nil
+}
+
+// DeleteAll, önekin dizinini ve içindeki tüm görselleri siler
+func (s *ImageStorage) DeleteAll(prefix string) error {
+	path, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("görseller silinemedi: %w", err)
+	}
+	return nil
+}
+
+// path, anahtarı basePath altındaki dosya yoluna çevirir; dizin dışına çıkan anahtarları reddeder
+func (s *ImageStorage) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == "." || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("güvenli olmayan görsel anahtarı: %s", key)
+	}
+	return filepath.Join(s.basePath, clean), nil
+}
+
+// Ensure ImageStorage implements domain.PDFImageStorage
+var _ domain.PDFImageStorage = (*ImageStorage)(nil)
@@ -1,8 +1,10 @@
 package localfs
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -23,37 +25,64 @@ func NewPDFStorage(basePath string) (*PDFStorage, error) {
 	return &PDFStorage{basePath: basePath}, nil
 }
 
-// Save, PDF dosyasını yerel dosya sistemine kaydeder
-func (s *PDFStorage) Save(fileContent []byte, fileName string) (string, error) {
-	// Dosya adını güvenli hale getir
-	safeFileName := filepath.Clean(fileName)
+// Save, PDF içeriğini yerel dosya sistemine akış halinde yazar ve SHA-256 özetini yazarken hesaplar.
+// İçerik önce aynı dizindeki geçici bir dosyaya yazılır ve tamamı yazıldıktan sonra özetinden türetilen
+// "<sha256>.pdf" adına taşınır; böylece yarıda kalan bir yükleme mevcut dosyayı bozmaz. Aynı içerik
+// zaten saklanıyorsa geçici dosya silinir ve mevcut dosya kullanılır.
+func (s *PDFStorage) Save(content io.Reader) (*domain.StoredFile, error) {
+	tmp, err := os.CreateTemp(s.basePath, ".upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+	// Taşıma başarılı olduysa geçici dosya artık yoktur ve Remove hatası yok sayılır
+	defer os.Remove(tmp.Name())
 
-	// Dosya yolunu oluştur
-	filePath := filepath.Join(s.basePath, safeFileName)
+	// Dosyayı yazarken özetini hesapla
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), content)
+	if err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
 
-	// Dosyayı kaydet
-	if err := ioutil.WriteFile(filePath, fileContent, 0644); err != nil {
-		return "", fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	sum := hex.EncodeToString(hash.Sum(nil))
+	stored := &domain.StoredFile{
+		Path:   filepath.Join(s.basePath, sum+".pdf"),
+		Size:   size,
+		SHA256: sum,
 	}
 
-	// Dosya yolunu döndür
-	return filePath, nil
+	// Aynı içerik zaten saklanıyorsa yeniden yazma
+	if _, err := os.Stat(stored.Path); err == nil {
+		return stored, nil
+	}
+
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), stored.Path); err != nil {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+	return stored, nil
 }
 
-// Get, PDF dosyasını yerel dosya sisteminden alır
-func (s *PDFStorage) Get(filePath string) ([]byte, error) {
+// Open, PDF dosyasını yerel dosya sisteminden okumak için açar
+func (s *PDFStorage) Open(filePath string) (io.ReadSeekCloser, error) {
 	// Dosya yolunu doğrula
 	if !s.isPathSafe(filePath) {
 		return nil, fmt.Errorf("güvenli olmayan dosya yolu: %s", filePath)
 	}
 
-	// Dosyayı oku
-	content, err := ioutil.ReadFile(filePath)
+	// Dosyayı aç
+	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("PDF dosyası okunamadı: %w", err)
+		return nil, fmt.Errorf("PDF dosyası açılamadı: %w", err)
 	}
 
-	return content, nil
+	return file, nil
 }
 
 // Delete, PDF dosyasını yerel dosya sisteminden siler
@@ -0,0 +1,90 @@
+// Package markdown, not içeriklerinin Markdown olarak ayrıştırılması ve HTML'e dönüştürülmesi için
+// goldmark yapılandırmasını ve LaTeX matematik ifadelerini olduğu gibi geçiren uzantıyı içerir.
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// New, GitHub uyumlu Markdown (tablolar, üstü çizili metin, görev listeleri, otomatik bağlantılar)
+// ve matematik ifadelerini destekleyen bir goldmark örneği oluşturur. Ham HTML çıktıya yazılmaz,
+// bağlantılar yalnızca göreli adreslere, http(s) ve mailto şemalarına izin verir; böylece üretilen
+// HTML kullanıcıya doğrudan gösterilebilir. Dönen örnek eşzamanlı kullanılabilir.
+func New() goldmark.Markdown {
+	return goldmark.New(
+		goldmark.WithExtensions(extension.GFM, Math),
+		goldmark.WithParserOptions(parser.WithASTTransformers(util.Prioritized(&linkPolicy{}, 1000))),
+	)
+}
+
+// defaultMarkdown, paket fonksiyonlarının kullandığı ortak örnek
+var defaultMarkdown = New()
+
+// Parse, Markdown kaynağını ayrıştırır ve belge ağacını döndürür. Düğümlerin metni kaynak
+// üzerindeki konumlarıyla tutulduğundan ağaç aynı kaynakla birlikte kullanılmalıdır.
+func Parse(source []byte) ast.Node {
+	return defaultMarkdown.Parser().Parse(text.NewReader(source))
+}
+
+// ToHTML, Markdown kaynağını HTML'e dönüştürür
+func ToHTML(source []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := defaultMarkdown.Convert(source, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// HTMLRenderer, not içeriklerini güvenli HTML'e dönüştüren domain.ContentRenderer uygulamasıdır
+type HTMLRenderer struct {
+	md goldmark.Markdown
+}
+
+// NewHTMLRenderer, yeni bir HTMLRenderer örneği oluşturur
+func NewHTMLRenderer() *HTMLRenderer {
+	return &HTMLRenderer{md: New()}
+}
+
+// RenderHTML, Markdown içeriğini HTML'e dönüştürür
+func (r *HTMLRenderer) RenderHTML(content string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.md.Convert([]byte(content), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// PlainText, bir düğümün altındaki metni biçimlendirme olmadan birleştirir. Satır sonları boşluğa
+// çevrilir; matematik ifadeleri LaTeX kaynaklarıyla yazılır.
+func PlainText(node ast.Node, source []byte) string {
+	var buf bytes.Buffer
+	ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Text:
+			buf.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(n.Value)
+		case *MathInline:
+			buf.Write(n.Value(source))
+			return ast.WalkSkipChildren, nil
+		case *MathBlock:
+			buf.Write(n.Value(source))
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return buf.String()
+}
@@ -0,0 +1,252 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// mathDelimiter, matematik ifadelerini çevreleyen işaret
+var mathDelimiter = []byte("$$")
+
+// KindMathInline, satır içi matematik ifadesi düğümünün türü
+var KindMathInline = ast.NewNodeKind("MathInline")
+
+// MathInline, metin içindeki "$...$" (veya satır içinde "$$...$$") LaTeX ifadesidir
+type MathInline struct {
+	ast.BaseInline
+	Display bool         // "$$" ile yazıldıysa true
+	Segment text.Segment // Sınırlayıcılar hariç ifade
+}
+
+// Kind, düğüm türünü döndürür
+func (n *MathInline) Kind() ast.NodeKind {
+	return KindMathInline
+}
+
+// Dump, düğümü hata ayıklama için yazdırır
+func (n *MathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Value": string(n.Value(source))}, nil)
+}
+
+// Value, ifadenin LaTeX kaynağını döndürür
+func (n *MathInline) Value(source []byte) []byte {
+	return n.Segment.Value(source)
+}
+
+// KindMathBlock, blok matematik ifadesi düğümünün türü
+var KindMathBlock = ast.NewNodeKind("MathBlock")
+
+// MathBlock, "$$" ile açılıp "$$" ile kapanan, bir veya daha fazla satırlık LaTeX ifadesidir
+type MathBlock struct {
+	ast.BaseBlock
+	closed bool // Açılış satırında kapandıysa true
+}
+
+// Kind, düğüm türünü döndürür
+func (n *MathBlock) Kind() ast.NodeKind {
+	return KindMathBlock
+}
+
+// IsRaw, düğüm satırlarının Markdown olarak ayrıştırılmadığını belirtir
+func (n *MathBlock) IsRaw() bool {
+	return true
+}
+
+// Dump, düğümü hata ayıklama için yazdırır
+func (n *MathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// Value, ifadenin satırlarını birleştirerek LaTeX kaynağını döndürür
+func (n *MathBlock) Value(source []byte) []byte {
+	var buf bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segment := lines.At(i)
+		buf.Write(segment.Value(source))
+	}
+	return bytes.TrimSpace(buf.Bytes())
+}
+
+// inlineMathParser, "$...$" ve "$$...$$" satır içi ifadelerini ayrıştırır. Para tutarlarıyla
+// karışmaması için açılış işaretinden hemen sonra ve kapanış işaretinden hemen önce boşluk
+// olamaz, tek "$" ile kapanan ifadeden sonra rakam gelemez (ör. "$5 ve $10" ifade değildir).
+type inlineMathParser struct{}
+
+// Trigger, ayrıştırıcıyı tetikleyen karakterleri döndürür
+func (p *inlineMathParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Parse, satırın başındaki matematik ifadesini ayrıştırır; ifade yoksa nil döner ve "$" metin olarak kalır
+func (p *inlineMathParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	delimiter := 1
+	if bytes.HasPrefix(line, mathDelimiter) {
+		delimiter = 2
+	}
+	body := line[delimiter:]
+	if len(body) == 0 || util.IsSpace(body[0]) || body[0] == '$' {
+		return nil
+	}
+
+	for i := 1; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++ // Kaçışlı karakter ("\$") ifadeyi kapatmaz
+			continue
+		case '$':
+		default:
+			continue
+		}
+		if util.IsSpace(body[i-1]) {
+			continue
+		}
+		if delimiter == 2 {
+			if i+1 >= len(body) || body[i+1] != '$' {
+				continue
+			}
+		} else if i+1 < len(body) && (body[i+1] == '$' || util.IsNumeric(body[i+1])) {
+			continue
+		}
+
+		start := segment.Start + delimiter
+		block.Advance(delimiter + i + delimiter)
+		return &MathInline{Display: delimiter == 2, Segment: text.NewSegment(start, start+i)}
+	}
+	return nil
+}
+
+// blockMathParser, "$$" ile başlayan satırdan "$$" ile biten satıra kadar olan blok ifadeleri ayrıştırır
+type blockMathParser struct{}
+
+// Trigger, ayrıştırıcıyı tetikleyen karakterleri döndürür
+func (p *blockMathParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Open, "$$" ile başlayan satırda yeni bir blok açar. Satır "$$...$$" biçimindeyse blok aynı satırda
+// kapanır; "$$" satırın ortasında kapanıyorsa satır paragraf olarak bırakılır.
+func (p *blockMathParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], mathDelimiter) {
+		return nil, parser.NoChildren
+	}
+
+	rest := util.TrimRightSpace(line[pos+len(mathDelimiter):])
+	start := segment.Start + pos + len(mathDelimiter)
+	node := &MathBlock{}
+	if closing := bytes.Index(rest, mathDelimiter); closing >= 0 {
+		if closing != len(rest)-len(mathDelimiter) {
+			return nil, parser.NoChildren
+		}
+		node.Lines().Append(text.NewSegment(start, start+closing))
+		node.closed = true
+	} else if !util.IsBlank(rest) {
+		node.Lines().Append(text.NewSegment(start, segment.Stop))
+	}
+	reader.Advance(segment.Len() - trailingNewline(line))
+	return node, parser.NoChildren
+}
+
+// Continue, kapanış işaretine kadar satırları bloğa ekler
+func (p *blockMathParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	block := node.(*MathBlock)
+	if block.closed {
+		return parser.Close
+	}
+
+	line, segment := reader.PeekLine()
+	trimmed := util.TrimRightSpace(line)
+	if bytes.HasSuffix(trimmed, mathDelimiter) {
+		block.Lines().Append(text.NewSegment(segment.Start, segment.Start+len(trimmed)-len(mathDelimiter)))
+		block.closed = true
+		reader.Advance(segment.Len() - trailingNewline(line))
+		return parser.Close
+	}
+
+	block.Lines().Append(segment)
+	reader.Advance(segment.Len() - trailingNewline(line))
+	return parser.Continue | parser.NoChildren
+}
+
+// Close, bloğu kapatır
+func (p *blockMathParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+// CanInterruptParagraph, blok ifadenin paragrafı bölebileceğini belirtir
+func (p *blockMathParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine, girintili satırların kod bloğu olarak kalacağını belirtir
+func (p *blockMathParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// trailingNewline, satır sonu karakteriyle bitiyorsa 1, aksi halde 0 döndürür
+func trailingNewline(line []byte) int {
+	if len(line) > 0 && line[len(line)-1] == '\n' {
+		return 1
+	}
+	return 0
+}
+
+// mathHTMLRenderer, matematik ifadelerini KaTeX ve MathJax'in tanıdığı "\(...\)" ve "\[...\]"
+// sınırlayıcılarıyla, içeriği kaçışlanmış olarak HTML'e yazar; ifade sunucuda işlenmez
+type mathHTMLRenderer struct{}
+
+// RegisterFuncs, düğüm türlerini işleyen fonksiyonları kaydeder
+func (r *mathHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindMathInline, r.renderMathInline)
+	reg.Register(KindMathBlock, r.renderMathBlock)
+}
+
+func (r *mathHTMLRenderer) renderMathInline(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*MathInline)
+	if n.Display {
+		w.WriteString(`<span class="math display">\[`)
+		w.Write(util.EscapeHTML(n.Value(source)))
+		w.WriteString(`\]</span>`)
+	} else {
+		w.WriteString(`<span class="math inline">\(`)
+		w.Write(util.EscapeHTML(n.Value(source)))
+		w.WriteString(`\)</span>`)
+	}
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *mathHTMLRenderer) renderMathBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	w.WriteString(`<div class="math display">\[`)
+	w.Write(util.EscapeHTML(node.(*MathBlock).Value(source)))
+	w.WriteString("\\]</div>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+// mathExtension, matematik ifadelerini ayrıştıran ve HTML'e yazan goldmark uzantısı
+type mathExtension struct{}
+
+// Math, "$...$", "$$...$$" ve "$$" bloklarındaki LaTeX ifadelerini Markdown olarak yorumlamadan
+// olduğu gibi geçiren goldmark uzantısıdır
+var Math goldmark.Extender = &mathExtension{}
+
+// Extend, uzantıyı goldmark örneğine ekler
+func (e *mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithInlineParsers(util.Prioritized(&inlineMathParser{}, 150)),
+		parser.WithBlockParsers(util.Prioritized(&blockMathParser{}, 750)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(&mathHTMLRenderer{}, 500)))
+}
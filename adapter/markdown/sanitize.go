@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// linkRel, kullanıcı içeriğindeki bağlantılara eklenen rel değeri. Arama motorlarına bağlantının
+// kullanıcı içeriği olduğunu bildirir ve açılan sayfanın window.opener'a erişmesini engeller.
+var linkRel = []byte("nofollow ugc noopener noreferrer")
+
+// linkPolicy, bağlantı ve görsel adreslerini izin verilen şemalarla sınırlar. Göreli adresler, http(s)
+// ve bağlantılarda mailto kabul edilir; diğer şemalar (javascript:, data:, file: vb.) boşaltılır.
+type linkPolicy struct{}
+
+// Transform, belge ağacındaki bağlantı ve görselleri denetler
+func (p *linkPolicy) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Link:
+			if !allowedURL(n.Destination, "http", "https", "mailto") {
+				n.Destination = nil
+			}
+			n.SetAttributeString("rel", linkRel)
+		case *ast.Image:
+			if !allowedURL(n.Destination, "http", "https") {
+				n.Destination = nil
+			}
+		case *ast.AutoLink:
+			n.SetAttributeString("rel", linkRel)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// allowedURL, adresin göreli olduğunu veya şemasının izin verilenlerden biri olduğunu kontrol eder.
+// Adres, HTML'e yazılırken çözülecek karakter referansları çözülmüş olarak denetlenir; tarayıcıların
+// şemada yok saydığı boşluk ve kontrol karakterleri denetimden önce atılır.
+func allowedURL(destination []byte, schemes ...string) bool {
+	destination = util.ResolveNumericReferences(util.ResolveEntityNames(util.UnescapePunctuations(destination)))
+	url := strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, string(destination))
+
+	colon := strings.IndexByte(url, ':')
+	if colon < 0 || strings.ContainsAny(url[:colon], "/?#") {
+		return true // Şema yok: göreli adres veya sayfa içi bağlantı
+	}
+	scheme := strings.ToLower(url[:colon])
+	for _, allowed := range schemes {
+		if scheme == allowed {
+			return true
+		}
+	}
+	return false
+}
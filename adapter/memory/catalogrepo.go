@@ -0,0 +1,526 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// CatalogRepository, domain.CatalogRepository arayüzünün bellek içi implementasyonu
+type CatalogRepository struct {
+	store *Store
+}
+
+// NewCatalogRepository, yeni bir CatalogRepository örneği oluşturur
+func NewCatalogRepository(store *Store) *CatalogRepository {
+	return &CatalogRepository{store: store}
+}
+
+// cloneUniversity, üniversitenin bağımsız bir kopyasını döndürür
+func cloneUniversity(university *domain.University) *domain.University {
+	u := *university
+	u.Aliases = append([]string{}, university.Aliases...)
+	return &u
+}
+
+// universityKeys, üniversitenin tüm adlarının anahtarlarını döndürür
+func universityKeys(university *domain.University) []string {
+	names := university.Names()
+	keys := make([]string, 0, len(names))
+	for _, name := range names {
+		keys = append(keys, domain.CatalogKey(name))
+	}
+	return keys
+}
+
+// universityKeyTaken, anahtarlardan birinin başka bir üniversiteye ait olup olmadığını kontrol eder
+// (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) universityKeyTaken(university *domain.University) bool {
+	keys := universityKeys(university)
+	for id, existing := range s.universities {
+		if id == university.ID {
+			continue
+		}
+		for _, key := range universityKeys(existing) {
+			for _, k := range keys {
+				if k == key {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// deleteCourseContent, içeriğin ders bağlantısını siler (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) deleteCourseContent(contentID uint, contentType string) {
+	for id, content := range s.courseContents {
+		if content.ContentID == contentID && content.ContentType == contentType {
+			delete(s.courseContents, id)
+		}
+	}
+}
+
+// findCourseContent, içeriğin ders bağlantısını bulur (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) findCourseContent(contentID uint, contentType string) *domain.CourseContent {
+	for _, content := range s.courseContents {
+		if content.ContentID == contentID && content.ContentType == contentType {
+			return content
+		}
+	}
+	return nil
+}
+
+// FindUniversityByID, ID'ye göre üniversiteyi bulur
+func (r *CatalogRepository) FindUniversityByID(id uint) (*domain.University, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	university, ok := r.store.universities[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneUniversity(university), nil
+}
+
+// FindUniversityByName, adlarından biri verilen adla eşleşen üniversiteyi bulur
+func (r *CatalogRepository) FindUniversityByName(name string) (*domain.University, error) {
+	key := domain.CatalogKey(name)
+	if key == "" {
+		return nil, nil
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range sortedIDs(r.store.universities) {
+		university := r.store.universities[id]
+		for _, k := range universityKeys(university) {
+			if k == key {
+				return cloneUniversity(university), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// FindUniversities, adlarından biri sorguyu içeren üniversiteleri ada göre sıralı getirir
+func (r *CatalogRepository) FindUniversities(query string, limit, offset int) ([]*domain.University, error) {
+	key := domain.CatalogKey(query)
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.University, 0)
+	for _, id := range sortedIDs(r.store.universities) {
+		university := r.store.universities[id]
+		for _, k := range universityKeys(university) {
+			if strings.Contains(k, key) {
+				matched = append(matched, university)
+				break
+			}
+		}
+	}
+
+	// name_key, id
+	sort.SliceStable(matched, func(i, j int) bool {
+		return domain.CatalogKey(matched[i].Name) < domain.CatalogKey(matched[j].Name)
+	})
+
+	universities := make([]*domain.University, 0)
+	for _, university := range paginate(matched, limit, offset) {
+		universities = append(universities, cloneUniversity(university))
+	}
+	return universities, nil
+}
+
+// CreateUniversity, yeni bir üniversiteyi adlarıyla birlikte oluşturur
+func (r *CatalogRepository) CreateUniversity(university *domain.University) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.universityKeyTaken(university) {
+		return fmt.Errorf("üniversite oluşturma hatası: %w", domain.ErrDuplicateEntry)
+	}
+
+	stored := cloneUniversity(university)
+	stored.ID = r.store.nextID("universities")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.universities[stored.ID] = stored
+
+	// ID'yi ve zaman damgalarını güncelle
+	university.ID = stored.ID
+	university.CreatedAt = stored.CreatedAt
+	university.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// UpdateUniversity, üniversitenin adını, kısa adını ve diğer adlarını günceller
+func (r *CatalogRepository) UpdateUniversity(university *domain.University) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.universities[university.ID]
+	if !ok {
+		return nil
+	}
+	if r.store.universityKeyTaken(university) {
+		return fmt.Errorf("üniversite güncelleme hatası: %w", domain.ErrDuplicateEntry)
+	}
+	stored.Name = university.Name
+	stored.ShortName = university.ShortName
+	stored.Aliases = append([]string{}, university.Aliases...)
+	stored.UpdatedAt = now()
+	university.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// FindDepartmentByID, ID'ye göre bölümü bulur
+func (r *CatalogRepository) FindDepartmentByID(id uint) (*domain.Department, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	department, ok := r.store.departments[id]
+	if !ok {
+		return nil, nil
+	}
+	d := *department
+	return &d, nil
+}
+
+// FindDepartmentByName, üniversitede adı verilen adla eşleşen bölümü bulur
+func (r *CatalogRepository) FindDepartmentByName(universityID uint, name string) (*domain.Department, error) {
+	key := domain.CatalogKey(name)
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, department := range r.store.departments {
+		if department.UniversityID == universityID && domain.CatalogKey(department.Name) == key {
+			d := *department
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindDepartments, üniversitenin bölümlerini ada göre sıralı getirir
+func (r *CatalogRepository) FindDepartments(universityID uint, limit, offset int) ([]*domain.Department, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.Department, 0)
+	for _, id := range sortedIDs(r.store.departments) {
+		if department := r.store.departments[id]; department.UniversityID == universityID {
+			matched = append(matched, department)
+		}
+	}
+
+	// lookup_key, id
+	sort.SliceStable(matched, func(i, j int) bool {
+		return domain.CatalogKey(matched[i].Name) < domain.CatalogKey(matched[j].Name)
+	})
+
+	departments := make([]*domain.Department, 0)
+	for _, department := range paginate(matched, limit, offset) {
+		d := *department
+		departments = append(departments, &d)
+	}
+	return departments, nil
+}
+
+// CreateDepartment, yeni bir bölüm oluşturur
+func (r *CatalogRepository) CreateDepartment(department *domain.Department) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := domain.CatalogKey(department.Name)
+	for _, existing := range r.store.departments {
+		if existing.UniversityID == department.UniversityID && domain.CatalogKey(existing.Name) == key {
+			return fmt.Errorf("bölüm oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+	}
+
+	stored := *department
+	stored.ID = r.store.nextID("departments")
+	stored.CreatedAt = now()
+	r.store.departments[stored.ID] = &stored
+
+	// ID'yi ve zaman damgasını güncelle
+	department.ID = stored.ID
+	department.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// FindCourseByID, ID'ye göre dersi bulur
+func (r *CatalogRepository) FindCourseByID(id uint) (*domain.Course, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	course, ok := r.store.courses[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *course
+	return &c, nil
+}
+
+// FindCourseByCode, bölümde kodu verilen kodla eşleşen dersi bulur
+func (r *CatalogRepository) FindCourseByCode(departmentID uint, code string) (*domain.Course, error) {
+	key := domain.CatalogKey(code)
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, course := range r.store.courses {
+		if course.DepartmentID == departmentID && domain.CatalogKey(course.Code) == key {
+			c := *course
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindCourses, bölümün derslerini koda göre sıralı getirir
+func (r *CatalogRepository) FindCourses(departmentID uint, limit, offset int) ([]*domain.Course, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.Course, 0)
+	for _, id := range sortedIDs(r.store.courses) {
+		if course := r.store.courses[id]; course.DepartmentID == departmentID {
+			matched = append(matched, course)
+		}
+	}
+
+	// code_key, id
+	sort.SliceStable(matched, func(i, j int) bool {
+		return domain.CatalogKey(matched[i].Code) < domain.CatalogKey(matched[j].Code)
+	})
+
+	courses := make([]*domain.Course, 0)
+	for _, course := range paginate(matched, limit, offset) {
+		c := *course
+		courses = append(courses, &c)
+	}
+	return courses, nil
+}
+
+// CreateCourse, yeni bir ders oluşturur
+func (r *CatalogRepository) CreateCourse(course *domain.Course) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := domain.CatalogKey(course.Code)
+	for _, existing := range r.store.courses {
+		if existing.DepartmentID == course.DepartmentID && domain.CatalogKey(existing.Code) == key {
+			return fmt.Errorf("ders oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+	}
+
+	stored := *course
+	stored.ID = r.store.nextID("courses")
+	stored.CreatedAt = now()
+	r.store.courses[stored.ID] = &stored
+
+	// ID'yi ve zaman damgasını güncelle
+	course.ID = stored.ID
+	course.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// FindTermByID, ID'ye göre dönemi bulur
+func (r *CatalogRepository) FindTermByID(id uint) (*domain.Term, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	term, ok := r.store.terms[id]
+	if !ok {
+		return nil, nil
+	}
+	t := *term
+	return &t, nil
+}
+
+// FindTerm, yıl ve türe göre dönemi bulur
+func (r *CatalogRepository) FindTerm(year int, season string) (*domain.Term, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, term := range r.store.terms {
+		if term.Year == year && term.Season == season {
+			t := *term
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+// seasonOrder, aynı akademik yıldaki dönemleri en yeniden başlayarak sıralamak için kullanılır
+var seasonOrder = map[string]int{
+	domain.TermSeasonSummer: 0,
+	domain.TermSeasonSpring: 1,
+	domain.TermSeasonFall:   2,
+}
+
+// FindTerms, dönemleri en yeniden başlayarak getirir
+func (r *CatalogRepository) FindTerms(limit, offset int) ([]*domain.Term, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.Term, 0, len(r.store.terms))
+	for _, id := range sortedIDs(r.store.terms) {
+		matched = append(matched, r.store.terms[id])
+	}
+
+	// year DESC, yaz, bahar, güz
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].Year != matched[j].Year {
+			return matched[i].Year > matched[j].Year
+		}
+		return seasonOrder[matched[i].Season] < seasonOrder[matched[j].Season]
+	})
+
+	terms := make([]*domain.Term, 0)
+	for _, term := range paginate(matched, limit, offset) {
+		t := *term
+		terms = append(terms, &t)
+	}
+	return terms, nil
+}
+
+// CreateTerm, yeni bir dönem oluşturur
+func (r *CatalogRepository) CreateTerm(term *domain.Term) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.terms {
+		if existing.Year == term.Year && existing.Season == term.Season {
+			return fmt.Errorf("dönem oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+	}
+
+	stored := *term
+	stored.ID = r.store.nextID("terms")
+	stored.CreatedAt = now()
+	r.store.terms[stored.ID] = &stored
+
+	// ID'yi ve zaman damgasını güncelle
+	term.ID = stored.ID
+	term.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// FindCourseContent, içeriğin ders bağlantısını bulur
+func (r *CatalogRepository) FindCourseContent(contentID uint, contentType string) (*domain.CourseContent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	content := r.store.findCourseContent(contentID, contentType)
+	if content == nil {
+		return nil, nil
+	}
+	c := *content
+	c.TermID = copyID(content.TermID)
+	return &c, nil
+}
+
+// SaveCourseContent, içeriğin ders bağlantısını oluşturur veya mevcut bağlantıyı değiştirir
+func (r *CatalogRepository) SaveCourseContent(content *domain.CourseContent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := r.store.findCourseContent(content.ContentID, content.ContentType)
+	if stored == nil {
+		stored = &domain.CourseContent{
+			ID:          r.store.nextID("course_contents"),
+			ContentID:   content.ContentID,
+			ContentType: content.ContentType,
+			CreatedAt:   now(),
+		}
+		r.store.courseContents[stored.ID] = stored
+	}
+	stored.CourseID = content.CourseID
+	stored.TermID = copyID(content.TermID)
+	stored.Week = content.Week
+	stored.Topic = content.Topic
+	stored.UpdatedAt = now()
+
+	// ID'yi ve zaman damgalarını güncelle
+	content.ID = stored.ID
+	content.CreatedAt = stored.CreatedAt
+	content.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// DeleteCourseContent, içeriğin ders bağlantısını siler
+func (r *CatalogRepository) DeleteCourseContent(contentID uint, contentType string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteCourseContent(contentID, contentType)
+	return nil
+}
+
+// filterCourseContents, filtreye uyan ders bağlantılarını haftaya göre sıralı döndürür (kilit çağıran
+// tarafından tutulmalıdır). visible, içeriğin görüntüleyen tarafından görülebilir olup olmadığını bildirir.
+func (s *Store) filterCourseContents(contentType string, filter *domain.CourseContentFilter, visible func(contentID uint) bool) []*domain.CourseContent {
+	matched := make([]*domain.CourseContent, 0)
+	for _, id := range sortedIDs(s.courseContents) {
+		content := s.courseContents[id]
+		if content.ContentType != contentType || content.CourseID != filter.CourseID {
+			continue
+		}
+		if filter.TermID != 0 && (content.TermID == nil || *content.TermID != filter.TermID) {
+			continue
+		}
+		if filter.Week != 0 && content.Week != filter.Week {
+			continue
+		}
+		if visible(content.ContentID) {
+			matched = append(matched, content)
+		}
+	}
+
+	// week, id
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Week < matched[j].Week })
+	return paginate(matched, filter.Limit, filter.Offset)
+}
+
+// FindCourseNotes, derse bağlı notları haftaya göre sıralı getirir
+func (r *CatalogRepository) FindCourseNotes(filter *domain.CourseContentFilter) ([]*domain.Note, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	contents := r.store.filterCourseContents("note", filter, func(contentID uint) bool {
+		note, ok := r.store.notes[contentID]
+		return ok && (note.IsPublic || (filter.ViewerID != 0 && note.UserID == filter.ViewerID))
+	})
+	notes := make([]*domain.Note, 0, len(contents))
+	for _, content := range contents {
+		notes = append(notes, cloneNote(r.store.notes[content.ContentID]))
+	}
+	return notes, nil
+}
+
+// FindCoursePDFs, derse bağlı PDF'leri haftaya göre sıralı getirir
+func (r *CatalogRepository) FindCoursePDFs(filter *domain.CourseContentFilter) ([]*domain.PDF, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	contents := r.store.filterCourseContents("pdf", filter, func(contentID uint) bool {
+		pdf, ok := r.store.pdfs[contentID]
+		return ok && (pdf.IsPublic || (filter.ViewerID != 0 && pdf.UserID == filter.ViewerID))
+	})
+	pdfs := make([]*domain.PDF, 0, len(contents))
+	for _, content := range contents {
+		pdfs = append(pdfs, clonePDF(r.store.pdfs[content.ContentID]))
+	}
+	return pdfs, nil
+}
+
+// Ensure CatalogRepository implements domain.CatalogRepository
+var _ domain.CatalogRepository = (*CatalogRepository)(nil)
@@ -0,0 +1,326 @@
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// CollectionRepository, domain.CollectionRepository arayüzünün bellek içi implementasyonu
+type CollectionRepository struct {
+	store *Store
+}
+
+// NewCollectionRepository, yeni bir CollectionRepository örneği oluşturur
+func NewCollectionRepository(store *Store) *CollectionRepository {
+	return &CollectionRepository{store: store}
+}
+
+// copyID, isteğe bağlı bir ID'nin bağımsız bir kopyasını döndürür
+func copyID(id *uint) *uint {
+	if id == nil {
+		return nil
+	}
+	v := *id
+	return &v
+}
+
+// cloneCollectionFolder, klasörün bağımsız bir kopyasını döndürür
+func cloneCollectionFolder(folder *domain.CollectionFolder) *domain.CollectionFolder {
+	f := *folder
+	f.ParentID = copyID(folder.ParentID)
+	return &f
+}
+
+// cloneCollectionItem, koleksiyon öğesinin bağımsız bir kopyasını döndürür
+func cloneCollectionItem(item *domain.CollectionItem) *domain.CollectionItem {
+	i := *item
+	i.FolderID = copyID(item.FolderID)
+	return &i
+}
+
+// deleteCollectionItems, bir içeriği tüm koleksiyonlardan çıkarır (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) deleteCollectionItems(contentID uint, contentType string) {
+	for id, item := range s.collectionItems {
+		if item.ContentID == contentID && item.ContentType == contentType {
+			delete(s.collectionItems, id)
+		}
+	}
+}
+
+// FindByID, ID'ye göre koleksiyonu bulur
+func (r *CollectionRepository) FindByID(id uint) (*domain.Collection, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	collection, ok := r.store.collections[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *collection
+	return &c, nil
+}
+
+// FindByUserID, kullanıcının koleksiyonlarını son güncellenenden başlayarak getirir
+func (r *CollectionRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.Collection, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.Collection, 0)
+	for _, collection := range r.store.collections {
+		if collection.UserID == userID {
+			matched = append(matched, collection)
+		}
+	}
+
+	// updated_at DESC, id DESC
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].UpdatedAt.Equal(matched[j].UpdatedAt) {
+			return matched[i].UpdatedAt.After(matched[j].UpdatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	collections := make([]*domain.Collection, 0)
+	for _, collection := range paginate(matched, limit, offset) {
+		c := *collection
+		collections = append(collections, &c)
+	}
+	return collections, nil
+}
+
+// Create, yeni bir koleksiyon oluşturur
+func (r *CollectionRepository) Create(collection *domain.Collection) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *collection
+	stored.ID = r.store.nextID("collections")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.collections[stored.ID] = &stored
+
+	// ID'yi ve zaman damgalarını güncelle
+	collection.ID = stored.ID
+	collection.CreatedAt = stored.CreatedAt
+	collection.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Update, koleksiyonun adını, açıklamasını ve görünürlüğünü günceller
+func (r *CollectionRepository) Update(collection *domain.Collection) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.collections[collection.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name = collection.Name
+	stored.Description = collection.Description
+	stored.IsPublic = collection.IsPublic
+	stored.UpdatedAt = now()
+	collection.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Delete, koleksiyonu klasörleri, öğeleri ve bildirimleriyle birlikte siler
+func (r *CollectionRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for itemID, item := range r.store.collectionItems {
+		if item.CollectionID == id {
+			delete(r.store.collectionItems, itemID)
+		}
+	}
+	for folderID, folder := range r.store.collectionFolders {
+		if folder.CollectionID == id {
+			delete(r.store.collectionFolders, folderID)
+		}
+	}
+	r.store.deleteContentNotifications(id, "collection")
+	delete(r.store.collections, id)
+	return nil
+}
+
+// FindFolderByID, ID'ye göre klasörü bulur
+func (r *CollectionRepository) FindFolderByID(id uint) (*domain.CollectionFolder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	folder, ok := r.store.collectionFolders[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneCollectionFolder(folder), nil
+}
+
+// FindFolders, koleksiyonun tüm klasörlerini konuma göre sıralı getirir
+func (r *CollectionRepository) FindFolders(collectionID uint) ([]*domain.CollectionFolder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	folders := make([]*domain.CollectionFolder, 0)
+	for _, id := range sortedIDs(r.store.collectionFolders) {
+		if folder := r.store.collectionFolders[id]; folder.CollectionID == collectionID {
+			folders = append(folders, cloneCollectionFolder(folder))
+		}
+	}
+
+	// position, id
+	sort.SliceStable(folders, func(i, j int) bool { return folders[i].Position < folders[j].Position })
+	return folders, nil
+}
+
+// CreateFolder, yeni bir klasör oluşturur
+func (r *CollectionRepository) CreateFolder(folder *domain.CollectionFolder) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := cloneCollectionFolder(folder)
+	stored.ID = r.store.nextID("collection_folders")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.collectionFolders[stored.ID] = stored
+
+	// ID'yi ve zaman damgalarını güncelle
+	folder.ID = stored.ID
+	folder.CreatedAt = stored.CreatedAt
+	folder.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// UpdateFolder, klasörün adını, üst klasörünü ve konumunu günceller
+func (r *CollectionRepository) UpdateFolder(folder *domain.CollectionFolder) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.collectionFolders[folder.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name = folder.Name
+	stored.ParentID = copyID(folder.ParentID)
+	stored.Position = folder.Position
+	stored.UpdatedAt = now()
+	folder.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// DeleteFolders, verilen klasörleri ve içlerindeki öğeleri siler
+func (r *CollectionRepository) DeleteFolders(collectionID uint, folderIDs []uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, item := range r.store.collectionItems {
+		if item.CollectionID == collectionID && item.FolderID != nil && slices.Contains(folderIDs, *item.FolderID) {
+			delete(r.store.collectionItems, id)
+		}
+	}
+	for _, id := range folderIDs {
+		if folder, ok := r.store.collectionFolders[id]; ok && folder.CollectionID == collectionID {
+			delete(r.store.collectionFolders, id)
+		}
+	}
+	return nil
+}
+
+// ArrangeFolders, klasörleri verilen üst klasöre taşır ve verilen sırayla konumlarını numaralar
+func (r *CollectionRepository) ArrangeFolders(collectionID uint, parentID *uint, folderIDs []uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	updatedAt := now()
+	for position, id := range folderIDs {
+		folder, ok := r.store.collectionFolders[id]
+		if !ok || folder.CollectionID != collectionID {
+			continue
+		}
+		folder.ParentID = copyID(parentID)
+		folder.Position = position
+		folder.UpdatedAt = updatedAt
+	}
+	return nil
+}
+
+// FindItemByID, ID'ye göre koleksiyon öğesini bulur
+func (r *CollectionRepository) FindItemByID(id uint) (*domain.CollectionItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	item, ok := r.store.collectionItems[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneCollectionItem(item), nil
+}
+
+// FindItems, koleksiyonun tüm öğelerini konuma göre sıralı getirir
+func (r *CollectionRepository) FindItems(collectionID uint) ([]*domain.CollectionItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	items := make([]*domain.CollectionItem, 0)
+	for _, id := range sortedIDs(r.store.collectionItems) {
+		if item := r.store.collectionItems[id]; item.CollectionID == collectionID {
+			items = append(items, cloneCollectionItem(item))
+		}
+	}
+
+	// position, id
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+	return items, nil
+}
+
+// CreateItem, koleksiyona yeni bir öğe ekler; içerik koleksiyonda zaten varsa ErrDuplicateEntry döner
+func (r *CollectionRepository) CreateItem(item *domain.CollectionItem) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.collectionItems {
+		if existing.CollectionID == item.CollectionID && existing.ContentID == item.ContentID && existing.ContentType == item.ContentType {
+			return fmt.Errorf("koleksiyon öğesi oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+	}
+
+	stored := cloneCollectionItem(item)
+	stored.ID = r.store.nextID("collection_items")
+	stored.CreatedAt = now()
+	r.store.collectionItems[stored.ID] = stored
+
+	// ID'yi ve zaman damgasını güncelle
+	item.ID = stored.ID
+	item.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// DeleteItem, koleksiyon öğesini siler
+func (r *CollectionRepository) DeleteItem(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.collectionItems, id)
+	return nil
+}
+
+// ArrangeItems, öğeleri verilen klasöre taşır ve verilen sırayla konumlarını numaralar
+func (r *CollectionRepository) ArrangeItems(collectionID uint, folderID *uint, itemIDs []uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for position, id := range itemIDs {
+		item, ok := r.store.collectionItems[id]
+		if !ok || item.CollectionID != collectionID {
+			continue
+		}
+		item.FolderID = copyID(folderID)
+		item.Position = position
+	}
+	return nil
+}
+
+// Ensure CollectionRepository implements domain.CollectionRepository
+var _ domain.CollectionRepository = (*CollectionRepository)(nil)
@@ -0,0 +1,485 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// GroupRepository, domain.GroupRepository arayüzünün bellek içi implementasyonu
+type GroupRepository struct {
+	store *Store
+}
+
+// NewGroupRepository, yeni bir GroupRepository örneği oluşturur
+func NewGroupRepository(store *Store) *GroupRepository {
+	return &GroupRepository{store: store}
+}
+
+// cloneGroupJoinRequest, üyelik isteğinin bağımsız bir kopyasını döndürür
+func cloneGroupJoinRequest(request *domain.GroupJoinRequest) *domain.GroupJoinRequest {
+	r := *request
+	r.ReviewedBy = copyID(request.ReviewedBy)
+	return &r
+}
+
+// deleteGroupContents, bir içeriği tüm grup alanlarından çıkarır (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) deleteGroupContents(contentID uint, contentType string) {
+	for id, content := range s.groupContents {
+		if content.ContentID == contentID && content.ContentType == contentType {
+			delete(s.groupContents, id)
+		}
+	}
+}
+
+// findGroupMember, kullanıcının gruptaki üyeliğini bulur (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) findGroupMember(groupID, userID uint) *domain.GroupMember {
+	for _, member := range s.groupMembers {
+		if member.GroupID == groupID && member.UserID == userID {
+			return member
+		}
+	}
+	return nil
+}
+
+// newestFirst, kayıtları created_at DESC, id DESC sırasına göre karşılaştırır
+func newestFirst(createdI, createdJ time.Time, idI, idJ uint) bool {
+	if !createdI.Equal(createdJ) {
+		return createdI.After(createdJ)
+	}
+	return idI > idJ
+}
+
+// oldestFirst, kayıtları created_at, id sırasına göre karşılaştırır
+func oldestFirst(createdI, createdJ time.Time, idI, idJ uint) bool {
+	if !createdI.Equal(createdJ) {
+		return createdI.Before(createdJ)
+	}
+	return idI < idJ
+}
+
+// FindByID, ID'ye göre grubu bulur
+func (r *GroupRepository) FindByID(id uint) (*domain.Group, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	group, ok := r.store.groups[id]
+	if !ok {
+		return nil, nil
+	}
+	g := *group
+	return &g, nil
+}
+
+// FindByUserID, kullanıcının üyesi olduğu grupları son güncellenenden başlayarak getirir
+func (r *GroupRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.Group, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.Group, 0)
+	for _, member := range r.store.groupMembers {
+		if member.UserID != userID {
+			continue
+		}
+		if group, ok := r.store.groups[member.GroupID]; ok {
+			matched = append(matched, group)
+		}
+	}
+
+	// updated_at DESC, id DESC
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].UpdatedAt.Equal(matched[j].UpdatedAt) {
+			return matched[i].UpdatedAt.After(matched[j].UpdatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	groups := make([]*domain.Group, 0)
+	for _, group := range paginate(matched, limit, offset) {
+		g := *group
+		groups = append(groups, &g)
+	}
+	return groups, nil
+}
+
+// Create, yeni bir grup oluşturur
+func (r *GroupRepository) Create(group *domain.Group) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *group
+	stored.ID = r.store.nextID("study_groups")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.groups[stored.ID] = &stored
+
+	// ID'yi ve zaman damgalarını güncelle
+	group.ID = stored.ID
+	group.CreatedAt = stored.CreatedAt
+	group.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Update, grubun adını ve açıklamasını günceller
+func (r *GroupRepository) Update(group *domain.Group) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.groups[group.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name = group.Name
+	stored.Description = group.Description
+	stored.UpdatedAt = now()
+	group.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Delete, grubu üyelikleri, istekleri, içerik paylaşımları, etkinlikleri ve davet bağlantılarıyla birlikte siler
+func (r *GroupRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for memberID, member := range r.store.groupMembers {
+		if member.GroupID == id {
+			delete(r.store.groupMembers, memberID)
+		}
+	}
+	for requestID, request := range r.store.groupJoinRequests {
+		if request.GroupID == id {
+			delete(r.store.groupJoinRequests, requestID)
+		}
+	}
+	for contentID, content := range r.store.groupContents {
+		if content.GroupID == id {
+			delete(r.store.groupContents, contentID)
+		}
+	}
+	for activityID, activity := range r.store.groupActivities {
+		if activity.GroupID == id {
+			delete(r.store.groupActivities, activityID)
+		}
+	}
+	r.store.deleteInvites(id, "group")
+	delete(r.store.groups, id)
+	return nil
+}
+
+// FindMember, kullanıcının gruptaki üyeliğini bulur
+func (r *GroupRepository) FindMember(groupID, userID uint) (*domain.GroupMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	member := r.store.findGroupMember(groupID, userID)
+	if member == nil {
+		return nil, nil
+	}
+	m := *member
+	return &m, nil
+}
+
+// FindMembers, grubun üyelerini katılma sırasına göre getirir
+func (r *GroupRepository) FindMembers(groupID uint, limit, offset int) ([]*domain.GroupMember, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.GroupMember, 0)
+	for _, member := range r.store.groupMembers {
+		if member.GroupID == groupID {
+			matched = append(matched, member)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return oldestFirst(matched[i].CreatedAt, matched[j].CreatedAt, matched[i].ID, matched[j].ID)
+	})
+
+	members := make([]*domain.GroupMember, 0)
+	for _, member := range paginate(matched, limit, offset) {
+		m := *member
+		members = append(members, &m)
+	}
+	return members, nil
+}
+
+// CountMembers, grubun üye sayısını döndürür
+func (r *GroupRepository) CountMembers(groupID uint) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, member := range r.store.groupMembers {
+		if member.GroupID == groupID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CreateMember, kullanıcıyı gruba üye olarak ekler; kullanıcı zaten üyeyse ErrDuplicateEntry döner
+func (r *GroupRepository) CreateMember(member *domain.GroupMember) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.findGroupMember(member.GroupID, member.UserID) != nil {
+		return fmt.Errorf("grup üyeliği oluşturma hatası: %w", domain.ErrDuplicateEntry)
+	}
+
+	stored := *member
+	stored.Username = ""
+	stored.ID = r.store.nextID("group_members")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.groupMembers[stored.ID] = &stored
+
+	// ID'yi ve zaman damgalarını güncelle
+	member.ID = stored.ID
+	member.CreatedAt = stored.CreatedAt
+	member.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// UpdateMember, üyenin rolünü günceller
+func (r *GroupRepository) UpdateMember(member *domain.GroupMember) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.groupMembers[member.ID]
+	if !ok {
+		return nil
+	}
+	stored.Role = member.Role
+	stored.UpdatedAt = now()
+	member.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// DeleteMember, üyeliği ve üyenin grup alanına paylaştığı içerikleri siler
+func (r *GroupRepository) DeleteMember(groupID, userID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, content := range r.store.groupContents {
+		if content.GroupID == groupID && content.UserID == userID {
+			delete(r.store.groupContents, id)
+		}
+	}
+	if member := r.store.findGroupMember(groupID, userID); member != nil {
+		delete(r.store.groupMembers, member.ID)
+	}
+	return nil
+}
+
+// FindJoinRequestByID, ID'ye göre üyelik isteğini bulur
+func (r *GroupRepository) FindJoinRequestByID(id uint) (*domain.GroupJoinRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	request, ok := r.store.groupJoinRequests[id]
+	if !ok {
+		return nil, nil
+	}
+	return cloneGroupJoinRequest(request), nil
+}
+
+// FindPendingJoinRequest, kullanıcının gruba bekleyen üyelik isteğini bulur
+func (r *GroupRepository) FindPendingJoinRequest(groupID, userID uint) (*domain.GroupJoinRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range sortedIDs(r.store.groupJoinRequests) {
+		request := r.store.groupJoinRequests[id]
+		if request.GroupID == groupID && request.UserID == userID && request.Status == domain.GroupJoinRequestPending {
+			return cloneGroupJoinRequest(request), nil
+		}
+	}
+	return nil, nil
+}
+
+// FindJoinRequests, grubun verilen durumdaki üyelik isteklerini eskiden yeniye getirir
+func (r *GroupRepository) FindJoinRequests(groupID uint, status string, limit, offset int) ([]*domain.GroupJoinRequest, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.GroupJoinRequest, 0)
+	for _, request := range r.store.groupJoinRequests {
+		if request.GroupID == groupID && request.Status == status {
+			matched = append(matched, request)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return oldestFirst(matched[i].CreatedAt, matched[j].CreatedAt, matched[i].ID, matched[j].ID)
+	})
+
+	requests := make([]*domain.GroupJoinRequest, 0)
+	for _, request := range paginate(matched, limit, offset) {
+		requests = append(requests, cloneGroupJoinRequest(request))
+	}
+	return requests, nil
+}
+
+// CreateJoinRequest, yeni bir üyelik isteği oluşturur
+func (r *GroupRepository) CreateJoinRequest(request *domain.GroupJoinRequest) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := cloneGroupJoinRequest(request)
+	stored.Username = ""
+	stored.ID = r.store.nextID("group_join_requests")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.groupJoinRequests[stored.ID] = stored
+
+	// ID'yi ve zaman damgalarını güncelle
+	request.ID = stored.ID
+	request.CreatedAt = stored.CreatedAt
+	request.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// UpdateJoinRequest, üyelik isteğinin durumunu ve değerlendiren yöneticiyi günceller
+func (r *GroupRepository) UpdateJoinRequest(request *domain.GroupJoinRequest) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.groupJoinRequests[request.ID]
+	if !ok {
+		return nil
+	}
+	stored.Status = request.Status
+	stored.ReviewedBy = copyID(request.ReviewedBy)
+	stored.UpdatedAt = now()
+	request.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// FindContent, içeriğin gruptaki paylaşımını bulur
+func (r *GroupRepository) FindContent(groupID, contentID uint, contentType string) (*domain.GroupContent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, content := range r.store.groupContents {
+		if content.GroupID == groupID && content.ContentID == contentID && content.ContentType == contentType {
+			c := *content
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindContents, grup alanındaki içerikleri son paylaşılandan başlayarak getirir
+func (r *GroupRepository) FindContents(groupID uint, limit, offset int) ([]*domain.GroupContent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.GroupContent, 0)
+	for _, content := range r.store.groupContents {
+		if content.GroupID == groupID {
+			matched = append(matched, content)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return newestFirst(matched[i].CreatedAt, matched[j].CreatedAt, matched[i].ID, matched[j].ID)
+	})
+
+	contents := make([]*domain.GroupContent, 0)
+	for _, content := range paginate(matched, limit, offset) {
+		c := *content
+		contents = append(contents, &c)
+	}
+	return contents, nil
+}
+
+// CreateContent, içeriği grup alanına paylaşır; içerik grupta zaten varsa ErrDuplicateEntry döner
+func (r *GroupRepository) CreateContent(content *domain.GroupContent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.groupContents {
+		if existing.GroupID == content.GroupID && existing.ContentID == content.ContentID && existing.ContentType == content.ContentType {
+			return fmt.Errorf("grup içeriği oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+	}
+
+	stored := *content
+	stored.ID = r.store.nextID("group_contents")
+	stored.CreatedAt = now()
+	r.store.groupContents[stored.ID] = &stored
+
+	// ID'yi ve zaman damgasını güncelle
+	content.ID = stored.ID
+	content.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// DeleteContent, içeriğin gruptaki paylaşımını kaldırır
+func (r *GroupRepository) DeleteContent(groupID, contentID uint, contentType string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, content := range r.store.groupContents {
+		if content.GroupID == groupID && content.ContentID == contentID && content.ContentType == contentType {
+			delete(r.store.groupContents, id)
+		}
+	}
+	return nil
+}
+
+// IsContentShared, içeriğin kullanıcının üyesi olduğu gruplardan birine paylaşılıp paylaşılmadığını döndürür
+func (r *GroupRepository) IsContentShared(contentID uint, contentType string, userID uint) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, content := range r.store.groupContents {
+		if content.ContentID == contentID && content.ContentType == contentType &&
+			r.store.findGroupMember(content.GroupID, userID) != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateActivity, grup etkinlik akışına yeni bir kayıt ekler
+func (r *GroupRepository) CreateActivity(activity *domain.GroupActivity) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *activity
+	stored.Username = ""
+	stored.ID = r.store.nextID("group_activities")
+	stored.CreatedAt = now()
+	r.store.groupActivities[stored.ID] = &stored
+
+	// ID'yi ve zaman damgasını güncelle
+	activity.ID = stored.ID
+	activity.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// FindActivities, grubun etkinliklerini en yeniden başlayarak getirir
+func (r *GroupRepository) FindActivities(groupID uint, limit, offset int) ([]*domain.GroupActivity, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.GroupActivity, 0)
+	for _, activity := range r.store.groupActivities {
+		if activity.GroupID == groupID {
+			matched = append(matched, activity)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return newestFirst(matched[i].CreatedAt, matched[j].CreatedAt, matched[i].ID, matched[j].ID)
+	})
+
+	activities := make([]*domain.GroupActivity, 0)
+	for _, activity := range paginate(matched, limit, offset) {
+		a := *activity
+		activities = append(activities, &a)
+	}
+	return activities, nil
+}
+
+// Ensure GroupRepository implements domain.GroupRepository
+var _ domain.GroupRepository = (*GroupRepository)(nil)
@@ -0,0 +1,250 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// InviteRepository, domain.InviteRepository arayüzünün bellek içi implementasyonu
+type InviteRepository struct {
+	store *Store
+}
+
+// NewInviteRepository, yeni bir InviteRepository örneği oluşturur
+func NewInviteRepository(store *Store) *InviteRepository {
+	return &InviteRepository{store: store}
+}
+
+// FindByID, ID'ye göre davet bağlantısını bulur
+func (r *InviteRepository) FindByID(id uint) (*domain.Invite, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	invite, ok := r.store.invites[id]
+	if !ok {
+		return nil, nil
+	}
+	i := *invite
+	return &i, nil
+}
+
+// FindByToken, token'a göre davet bağlantısını bulur
+func (r *InviteRepository) FindByToken(token string) (*domain.Invite, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, invite := range r.store.invites {
+		if invite.Token == token {
+			i := *invite
+			return &i, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindByContentID, içerik ID'sine göre davet bağlantılarını bulur
+func (r *InviteRepository) FindByContentID(contentID uint, contentType string) ([]*domain.Invite, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	invites := make([]*domain.Invite, 0)
+	for _, id := range sortedIDs(r.store.invites) {
+		invite := r.store.invites[id]
+		if invite.ContentID == contentID && invite.Type == contentType {
+			i := *invite
+			invites = append(invites, &i)
+		}
+	}
+	return invites, nil
+}
+
+// tokenTaken, token'ın başka bir davet tarafından kullanılıp kullanılmadığını kontrol eder (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) tokenTaken(token string, exceptID uint) bool {
+	for id, invite := range s.invites {
+		if id != exceptID && invite.Token == token {
+			return true
+		}
+	}
+	return false
+}
+
+// Create, yeni bir davet bağlantısı oluşturur
+func (r *InviteRepository) Create(invite *domain.Invite) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.tokenTaken(invite.Token, 0) {
+		return fmt.Errorf("davet bağlantısı oluşturma hatası: %w", domain.ErrDuplicateEntry)
+	}
+
+	stored := *invite
+	stored.ID = r.store.nextID("invites")
+	r.store.invites[stored.ID] = &stored
+
+	// ID'yi güncelle
+	invite.ID = stored.ID
+	return nil
+}
+
+// Update, bir davet bağlantısını günceller
+func (r *InviteRepository) Update(invite *domain.Invite) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.tokenTaken(invite.Token, invite.ID) {
+		return fmt.Errorf("davet bağlantısı güncelleme hatası: %w", domain.ErrDuplicateEntry)
+	}
+
+	// GORM Save gibi: kayıt yoksa oluşturulur
+	stored := *invite
+	if stored.ID == 0 {
+		stored.ID = r.store.nextID("invites")
+		invite.ID = stored.ID
+	}
+	r.store.invites[stored.ID] = &stored
+	return nil
+}
+
+// deleteInvite, daveti kullanım kayıtlarıyla birlikte siler (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) deleteInvite(id uint) {
+	for redemptionID, redemption := range s.inviteRedemptions {
+		if redemption.InviteID == id {
+			delete(s.inviteRedemptions, redemptionID)
+		}
+	}
+	delete(s.invites, id)
+}
+
+// deleteInvites, içeriğin davet bağlantılarını kullanım kayıtlarıyla birlikte siler (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) deleteInvites(contentID uint, contentType string) {
+	for id, invite := range s.invites {
+		if invite.ContentID == contentID && invite.Type == contentType {
+			s.deleteInvite(id)
+		}
+	}
+}
+
+// Delete, bir davet bağlantısını kullanım kayıtlarıyla birlikte siler
+func (r *InviteRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteInvite(id)
+	return nil
+}
+
+// DeleteByContentID, içerik ID'sine göre davet bağlantılarını kullanım kayıtlarıyla birlikte siler
+func (r *InviteRepository) DeleteByContentID(contentID uint, contentType string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteInvites(contentID, contentType)
+	return nil
+}
+
+// FindRedemption, kullanıcının davet kullanım kaydını bulur
+func (r *InviteRepository) FindRedemption(inviteID, userID uint) (*domain.InviteRedemption, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range sortedIDs(r.store.inviteRedemptions) {
+		redemption := r.store.inviteRedemptions[id]
+		if redemption.InviteID == inviteID && redemption.UserID == userID {
+			found := *redemption
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindRedemptionByVisitorKey, giriş yapmamış ziyaretçinin davet kullanım kaydını anahtarıyla bulur
+func (r *InviteRepository) FindRedemptionByVisitorKey(inviteID uint, visitorKey string) (*domain.InviteRedemption, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range sortedIDs(r.store.inviteRedemptions) {
+		redemption := r.store.inviteRedemptions[id]
+		if redemption.InviteID == inviteID && redemption.UserID == 0 && redemption.VisitorKey == visitorKey {
+			found := *redemption
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindRedemptions, davetin kullanım kayıtlarını son kullanılandan başlayarak getirir
+func (r *InviteRepository) FindRedemptions(inviteID uint, limit, offset int) ([]*domain.InviteRedemption, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.InviteRedemption, 0)
+	for _, redemption := range r.store.inviteRedemptions {
+		if redemption.InviteID == inviteID {
+			matched = append(matched, redemption)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return newestFirst(matched[i].LastUsedAt, matched[j].LastUsedAt, matched[i].ID, matched[j].ID)
+	})
+
+	page := paginate(matched, limit, offset)
+	redemptions := make([]*domain.InviteRedemption, len(page))
+	for i, redemption := range page {
+		found := *redemption
+		redemptions[i] = &found
+	}
+	return redemptions, nil
+}
+
+// Redeem, kullanım hakkı kalmışsa kullanım sayısını artırır ve kullanım kaydını ekler
+func (r *InviteRepository) Redeem(redemption *domain.InviteRedemption) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if redemption.UserID != 0 {
+		for _, existing := range r.store.inviteRedemptions {
+			if existing.InviteID == redemption.InviteID && existing.UserID == redemption.UserID {
+				return false, domain.ErrDuplicateEntry
+			}
+		}
+	}
+	invite, ok := r.store.invites[redemption.InviteID]
+	if !ok || invite.Exhausted() {
+		return false, nil
+	}
+	invite.UseCount++
+
+	stored := *redemption
+	stored.Username = ""
+	stored.ID = r.store.nextID("invite_redemptions")
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = now()
+	}
+	r.store.inviteRedemptions[stored.ID] = &stored
+
+	// ID'yi ve zaman damgasını güncelle
+	redemption.ID = stored.ID
+	redemption.CreatedAt = stored.CreatedAt
+	return true, nil
+}
+
+// UpdateRedemption, bir davet kullanım kaydını günceller
+func (r *InviteRepository) UpdateRedemption(redemption *domain.InviteRedemption) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// GORM Save gibi: kayıt yoksa oluşturulur
+	stored := *redemption
+	stored.Username = ""
+	if stored.ID == 0 {
+		stored.ID = r.store.nextID("invite_redemptions")
+		redemption.ID = stored.ID
+	}
+	r.store.inviteRedemptions[stored.ID] = &stored
+	return nil
+}
+
+// Ensure InviteRepository implements domain.InviteRepository
+var _ domain.InviteRepository = (*InviteRepository)(nil)
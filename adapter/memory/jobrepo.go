@@ -0,0 +1,210 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// JobRepository, domain.JobRepository arayüzünün bellek içi implementasyonu
+type JobRepository struct {
+	store *Store
+}
+
+// NewJobRepository, yeni bir JobRepository örneği oluşturur
+func NewJobRepository(store *Store) *JobRepository {
+	return &JobRepository{store: store}
+}
+
+// cloneJob, işin zaman işaretçileriyle birlikte bağımsız bir kopyasını döndürür
+func cloneJob(job *domain.Job) *domain.Job {
+	j := *job
+	if job.LockedUntil != nil {
+		t := *job.LockedUntil
+		j.LockedUntil = &t
+	}
+	if job.FinishedAt != nil {
+		t := *job.FinishedAt
+		j.FinishedAt = &t
+	}
+	return &j
+}
+
+// Create, yeni bir iş oluşturur
+func (r *JobRepository) Create(job *domain.Job) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := cloneJob(job)
+	stored.ID = r.store.nextID("jobs")
+	if stored.Status == "" {
+		stored.Status = domain.JobStatusPending
+	}
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	if stored.RunAt.IsZero() {
+		stored.RunAt = stored.CreatedAt
+	}
+	r.store.jobs[stored.ID] = stored
+
+	// ID ve varsayılanları güncelle
+	job.ID = stored.ID
+	job.Status = stored.Status
+	job.RunAt = stored.RunAt
+	job.CreatedAt = stored.CreatedAt
+	job.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// FindByID, ID'ye göre işi bulur
+func (r *JobRepository) FindByID(id uint) (*domain.Job, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok {
+		return nil, nil // İş bulunamadı
+	}
+	return cloneJob(job), nil
+}
+
+// Find, filtreye uyan işleri en yeniden eskiye getirir
+func (r *JobRepository) Find(filter domain.JobFilter, limit, offset int) ([]*domain.Job, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids := sortedIDs(r.store.jobs)
+	matches := make([]*domain.Job, 0)
+	for i := len(ids) - 1; i >= 0; i-- {
+		job := r.store.jobs[ids[i]]
+		if (filter.Status == "" || job.Status == filter.Status) && (filter.Type == "" || job.Type == filter.Type) {
+			matches = append(matches, job)
+		}
+	}
+
+	jobs := make([]*domain.Job, 0)
+	for _, job := range paginate(matches, limit, offset) {
+		jobs = append(jobs, cloneJob(job))
+	}
+	return jobs, nil
+}
+
+// CountByStatus, her durumdaki iş sayısını döndürür
+func (r *JobRepository) CountByStatus() (map[string]int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[string]int)
+	for _, job := range r.store.jobs {
+		counts[job.Status]++
+	}
+	return counts, nil
+}
+
+// Lease, çalıştırma zamanı gelmiş en eski işi workerID adına kiralar
+func (r *JobRepository) Lease(workerID string, now, until time.Time) (*domain.Job, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var due []*domain.Job
+	for _, job := range r.store.jobs {
+		pending := job.Status == domain.JobStatusPending && !job.RunAt.After(now)
+		expired := job.Status == domain.JobStatusRunning && job.LockedUntil != nil && job.LockedUntil.Before(now)
+		if pending || expired {
+			due = append(due, job)
+		}
+	}
+	if len(due) == 0 {
+		return nil, nil
+	}
+
+	// run_at ASC, id ASC
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].RunAt.Equal(due[j].RunAt) {
+			return due[i].RunAt.Before(due[j].RunAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	job := due[0]
+	job.Status = domain.JobStatusRunning
+	job.LockedBy = workerID
+	job.LockedUntil = &until
+	job.Attempts++
+	job.UpdatedAt = now
+	return cloneJob(job), nil
+}
+
+// leasedJob, workerID'nin hala kiraladığı işi döndürür (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) leasedJob(id uint, workerID string) (*domain.Job, error) {
+	job, ok := s.jobs[id]
+	if !ok || job.Status != domain.JobStatusRunning || job.LockedBy != workerID {
+		return nil, domain.ErrJobLeaseLost
+	}
+	return job, nil
+}
+
+// Complete, kiralanan işi başarılı olarak işaretler
+func (r *JobRepository) Complete(id uint, workerID string, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, err := r.store.leasedJob(id, workerID)
+	if err != nil {
+		return err
+	}
+	job.Status = domain.JobStatusSucceeded
+	job.LockedBy = ""
+	job.LockedUntil = nil
+	job.LastError = ""
+	job.FinishedAt = &now
+	job.UpdatedAt = now
+	return nil
+}
+
+// Fail, kiralanan işin hatasını kaydeder ve işi yeniden denemeye alır ya da ölü olarak işaretler
+func (r *JobRepository) Fail(id uint, workerID string, errMsg string, retryAt *time.Time, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, err := r.store.leasedJob(id, workerID)
+	if err != nil {
+		return err
+	}
+	job.LockedBy = ""
+	job.LockedUntil = nil
+	job.LastError = errMsg
+	job.UpdatedAt = now
+	if retryAt != nil {
+		job.Status = domain.JobStatusPending
+		job.RunAt = *retryAt
+	} else {
+		job.Status = domain.JobStatusDead
+		job.FinishedAt = &now
+	}
+	return nil
+}
+
+// Retry, ölü bir işi hemen çalıştırılmak üzere beklemeye alır
+func (r *JobRepository) Retry(id uint, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	job, ok := r.store.jobs[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	if job.Status != domain.JobStatusDead {
+		return domain.ErrInvalidInput
+	}
+	job.Status = domain.JobStatusPending
+	job.Attempts = 0
+	job.RunAt = now
+	job.FinishedAt = nil
+	job.UpdatedAt = now
+	return nil
+}
+
+// Ensure JobRepository implements domain.JobRepository
+var _ domain.JobRepository = (*JobRepository)(nil)
@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// LikeRepository, domain.LikeRepository arayüzünün bellek içi implementasyonu
+type LikeRepository struct {
+	store *Store
+}
+
+// NewLikeRepository, yeni bir LikeRepository örneği oluşturur
+func NewLikeRepository(store *Store) *LikeRepository {
+	return &LikeRepository{store: store}
+}
+
+// findLike, kullanıcı ve içerik bilgisine göre saklanan beğeniyi bulur (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) findLike(userID, contentID uint, contentType string) *domain.Like {
+	for _, like := range s.likes {
+		if like.UserID == userID && like.ContentID == contentID && like.Type == contentType {
+			return like
+		}
+	}
+	return nil
+}
+
+// addToLikeCount, içerik türüne göre beğeni sayısını değiştirir (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) addToLikeCount(contentID uint, contentType string, delta int) {
+	switch contentType {
+	case "note":
+		s.addToNoteCounter(contentID, func(n *domain.Note) *int { return &n.LikeCount }, delta)
+	case "pdf":
+		s.addToPDFCounter(contentID, func(p *domain.PDF) *int { return &p.LikeCount }, delta)
+	}
+}
+
+// findLikes, filtreye uyan beğenileri ID sırasına göre sayfalayarak döndürür
+func (r *LikeRepository) findLikes(match func(*domain.Like) bool, limit, offset int) []*domain.Like {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var likes []*domain.Like
+	for _, id := range sortedIDs(r.store.likes) {
+		like := r.store.likes[id]
+		if match(like) {
+			l := *like
+			likes = append(likes, &l)
+		}
+	}
+	return paginate(likes, limit, offset)
+}
+
+// FindByID, ID'ye göre beğeni bulur
+func (r *LikeRepository) FindByID(id uint) (*domain.Like, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	like, ok := r.store.likes[id]
+	if !ok {
+		return nil, nil // Beğeni bulunamadı
+	}
+	l := *like
+	return &l, nil
+}
+
+// FindByUserIDAndContent, kullanıcı ID'si ve içerik bilgisine göre beğeni bulur
+func (r *LikeRepository) FindByUserIDAndContent(userID, contentID uint, contentType string) (*domain.Like, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	like := r.store.findLike(userID, contentID, contentType)
+	if like == nil {
+		return nil, nil // Beğeni bulunamadı
+	}
+	l := *like
+	return &l, nil
+}
+
+// FindByContentID, içerik ID'sine göre beğenileri bulur
+func (r *LikeRepository) FindByContentID(contentID uint, contentType string, limit, offset int) ([]*domain.Like, error) {
+	return r.findLikes(func(l *domain.Like) bool {
+		return l.ContentID == contentID && l.Type == contentType
+	}, limit, offset), nil
+}
+
+// FindByUserID, kullanıcı ID'sine göre beğenileri bulur
+func (r *LikeRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.Like, error) {
+	return r.findLikes(func(l *domain.Like) bool { return l.UserID == userID }, limit, offset), nil
+}
+
+// FindLikedNotesByUserID, kullanıcının beğendiği notları getirir
+func (r *LikeRepository) FindLikedNotesByUserID(userID uint, limit, offset int) ([]*domain.Note, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var notes []*domain.Note
+	for _, id := range sortedIDs(r.store.notes) {
+		if r.store.findLike(userID, id, "note") != nil {
+			notes = append(notes, cloneNote(r.store.notes[id]))
+		}
+	}
+	return paginate(notes, limit, offset), nil
+}
+
+// FindLikedPDFsByUserID, kullanıcının beğendiği PDF'leri getirir
+func (r *LikeRepository) FindLikedPDFsByUserID(userID uint, limit, offset int) ([]*domain.PDF, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var pdfs []*domain.PDF
+	for _, id := range sortedIDs(r.store.pdfs) {
+		if r.store.findLike(userID, id, "pdf") != nil {
+			pdfs = append(pdfs, clonePDF(r.store.pdfs[id]))
+		}
+	}
+	return paginate(pdfs, limit, offset), nil
+}
+
+// Create, yeni bir beğeni oluşturur ve içeriğin beğeni sayısını artırır
+func (r *LikeRepository) Create(like *domain.Like) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Kullanıcı zaten bu içeriği beğenmişse, sayaç değişmeden başarılı olarak dön
+	if existing := r.store.findLike(like.UserID, like.ContentID, like.Type); existing != nil {
+		like.ID = existing.ID
+		return nil
+	}
+
+	stored := *like
+	stored.ID = r.store.nextID("likes")
+	stored.CreatedAt = now()
+	r.store.likes[stored.ID] = &stored
+
+	// İçerik türüne göre beğeni sayısını artır
+	r.store.addToLikeCount(like.ContentID, like.Type, 1)
+
+	// ID'yi güncelle
+	like.ID = stored.ID
+	return nil
+}
+
+// Delete, bir beğeniyi siler ve içeriğin beğeni sayısını azaltır
+func (r *LikeRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	like, ok := r.store.likes[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	r.store.addToLikeCount(like.ContentID, like.Type, -1)
+	delete(r.store.likes, id)
+	return nil
+}
+
+// DeleteByUserIDAndContent, kullanıcı ID'si ve içerik bilgisine göre beğeniyi siler
+func (r *LikeRepository) DeleteByUserIDAndContent(userID, contentID uint, contentType string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	like := r.store.findLike(userID, contentID, contentType)
+	if like == nil {
+		return nil // Beğeni zaten yok
+	}
+
+	r.store.addToLikeCount(contentID, contentType, -1)
+	delete(r.store.likes, like.ID)
+	return nil
+}
+
+// Ensure LikeRepository implements domain.LikeRepository
+var _ domain.LikeRepository = (*LikeRepository)(nil)
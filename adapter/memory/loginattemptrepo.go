@@ -0,0 +1,74 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// LoginAttemptRepository, giriş denemeleri için bellek içi repository implementasyonu
+type LoginAttemptRepository struct {
+	store *Store
+}
+
+// NewLoginAttemptRepository, yeni bir LoginAttemptRepository örneği oluşturur
+func NewLoginAttemptRepository(store *Store) *LoginAttemptRepository {
+	return &LoginAttemptRepository{store: store}
+}
+
+// RecordAttempt, bir giriş denemesini kaydeder
+func (r *LoginAttemptRepository) RecordAttempt(attempt *domain.LoginAttempt) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *attempt
+	stored.ID = r.store.nextID("login_attempts")
+	r.store.loginAttempts[stored.ID] = &stored
+
+	attempt.ID = stored.ID
+	return nil
+}
+
+// GetRecentAttempts, belirli bir IP veya e-posta için son başarısız giriş denemelerini getirir
+func (r *LoginAttemptRepository) GetRecentAttempts(ip, email string, since time.Time) ([]*domain.LoginAttempt, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	attempts := make([]*domain.LoginAttempt, 0)
+	for _, attempt := range r.store.loginAttempts {
+		if !attempt.CreatedAt.After(since) || attempt.Successful {
+			continue
+		}
+		if ip != "" && attempt.IP != ip {
+			continue
+		}
+		if email != "" && attempt.Email != email {
+			continue
+		}
+		a := *attempt
+		attempts = append(attempts, &a)
+	}
+
+	// created_at DESC
+	sort.Slice(attempts, func(i, j int) bool {
+		return attempts[i].CreatedAt.After(attempts[j].CreatedAt)
+	})
+	return attempts, nil
+}
+
+// CleanupOldAttempts, eski giriş denemelerini temizler
+func (r *LoginAttemptRepository) CleanupOldAttempts(before time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, attempt := range r.store.loginAttempts {
+		if attempt.CreatedAt.Before(before) {
+			delete(r.store.loginAttempts, id)
+		}
+	}
+	return nil
+}
+
+// Ensure LoginAttemptRepository implements domain.LoginAttemptRepository
+var _ domain.LoginAttemptRepository = (*LoginAttemptRepository)(nil)
@@ -0,0 +1,37 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/adapter/memory"
+	"github.com/OmerFErdogan/uninote/adapter/repotest"
+)
+
+// TestRepositories, bellek içi repository'lerin ortak sözleşmeyi sağladığını doğrular
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repotest.Repositories {
+		store := memory.NewStore()
+		return &repotest.Repositories{
+			Users:                   memory.NewUserRepository(store),
+			Notes:                   memory.NewNoteRepository(store),
+			Comments:                memory.NewCommentRepository(store),
+			PDFs:                    memory.NewPDFRepository(store),
+			PDFComments:             memory.NewPDFCommentRepository(store),
+			PDFAnnotations:          memory.NewPDFAnnotationRepository(store),
+			PDFPages:                memory.NewPDFPageRepository(store),
+			Likes:                   memory.NewLikeRepository(store),
+			Views:                   memory.NewViewRepository(store),
+			Invites:                 memory.NewInviteRepository(store),
+			Tokens:                  memory.NewTokenRepository(store),
+			LoginAttempts:           memory.NewLoginAttemptRepository(store),
+			NoteRevisions:           memory.NewNoteRevisionRepository(store),
+			Notifications:           memory.NewNotificationRepository(store),
+			NotificationPreferences: memory.NewNotificationPreferenceRepository(store),
+			Jobs:                    memory.NewJobRepository(store),
+			Collections:             memory.NewCollectionRepository(store),
+			Catalog:                 memory.NewCatalogRepository(store),
+			Groups:                  memory.NewGroupRepository(store),
+			Shares:                  memory.NewShareRepository(store),
+		}
+	})
+}
@@ -0,0 +1,268 @@
+package memory
+
+import (
+	"github.com/OmerFErdogan/uninote/adapter/textsearch"
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// NoteRepository, domain.NoteRepository arayüzünün bellek içi implementasyonu
+type NoteRepository struct {
+	store *Store
+}
+
+// NewNoteRepository, yeni bir NoteRepository örneği oluşturur
+func NewNoteRepository(store *Store) *NoteRepository {
+	return &NoteRepository{store: store}
+}
+
+// cloneNote, saklanan notun çağırana verilecek bağımsız bir kopyasını oluşturur
+func cloneNote(n *domain.Note) *domain.Note {
+	c := *n
+	c.Tags = copyTags(n.Tags)
+	return &c
+}
+
+// findNotes, filtreye uyan notları ID sırasına göre sayfalayarak döndürür
+func (r *NoteRepository) findNotes(match func(*domain.Note) bool, limit, offset int) []*domain.Note {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var notes []*domain.Note
+	for _, id := range sortedIDs(r.store.notes) {
+		note := r.store.notes[id]
+		if match(note) {
+			notes = append(notes, cloneNote(note))
+		}
+	}
+	return paginate(notes, limit, offset)
+}
+
+// FindByID, ID'ye göre not bulur
+func (r *NoteRepository) FindByID(id uint) (*domain.Note, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	note, ok := r.store.notes[id]
+	if !ok {
+		return nil, nil // Not bulunamadı
+	}
+	return cloneNote(note), nil
+}
+
+// FindByUserID, kullanıcı ID'sine göre notları bulur
+func (r *NoteRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.Note, error) {
+	return r.findNotes(func(n *domain.Note) bool { return n.UserID == userID }, limit, offset), nil
+}
+
+// FindPublic, herkese açık notları bulur
+func (r *NoteRepository) FindPublic(limit, offset int) ([]*domain.Note, error) {
+	return r.findNotes(func(n *domain.Note) bool { return n.IsPublic }, limit, offset), nil
+}
+
+// FindByTag, etikete göre notları bulur
+func (r *NoteRepository) FindByTag(tag string, limit, offset int) ([]*domain.Note, error) {
+	return r.findNotes(func(n *domain.Note) bool { return hasTag(n.Tags, tag) }, limit, offset), nil
+}
+
+// Search, tam metin arama sorgusuna uyan herkese açık (ve sorgudaki kullanıcının) notları puana göre sıralı döndürür
+func (r *NoteRepository) Search(query *domain.SearchQuery) ([]*domain.SearchHit, error) {
+	terms := textsearch.NormalizeTerms(query.Terms)
+	if terms == nil {
+		return []*domain.SearchHit{}, nil
+	}
+
+	notes := r.findNotes(func(n *domain.Note) bool { return n.IsPublic || n.UserID == query.UserID }, -1, 0)
+
+	var hits []*domain.SearchHit
+	for _, note := range notes {
+		if hit, ok := textsearch.NoteHit(note, terms); ok {
+			hits = append(hits, hit)
+		}
+	}
+
+	textsearch.SortHits(hits)
+	return textsearch.Paginate(hits, query.Limit, query.Offset), nil
+}
+
+// Create, yeni bir not oluşturur
+func (r *NoteRepository) Create(note *domain.Note) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := cloneNote(note)
+	stored.ID = r.store.nextID("notes")
+	stored.Version = 1
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.notes[stored.ID] = stored
+
+	// ID ve sürümü güncelle
+	note.ID = stored.ID
+	note.Version = stored.Version
+	return nil
+}
+
+// Update, bir notu günceller
+func (r *NoteRepository) Update(note *domain.Note) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.notes[note.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	// Başka bir güncelleme araya girdiyse değişiklik uygulanmaz
+	if stored.Version != note.Version {
+		return domain.ErrVersionConflict
+	}
+
+	// Sayaçlar korunur, yalnızca düzenlenebilir alanlar güncellenir
+	stored.Title = note.Title
+	stored.Content = note.Content
+	stored.IsPublic = note.IsPublic
+	stored.Tags = copyTags(note.Tags)
+	stored.Version++
+	stored.UpdatedAt = now()
+	note.Version = stored.Version
+	return nil
+}
+
+// Delete, bir notu ve ilişkili yorum, beğeni ve revizyonlarını siler
+func (r *NoteRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for commentID, comment := range r.store.comments {
+		if comment.NoteID == id {
+			delete(r.store.comments, commentID)
+		}
+	}
+	for likeID, like := range r.store.likes {
+		if like.ContentID == id && like.Type == "note" {
+			delete(r.store.likes, likeID)
+		}
+	}
+	for revisionID, revision := range r.store.noteRevisions {
+		if revision.NoteID == id {
+			delete(r.store.noteRevisions, revisionID)
+		}
+	}
+	r.store.deleteContentNotifications(id, "note")
+	r.store.deleteCollectionItems(id, "note")
+	r.store.deleteCourseContent(id, "note")
+	r.store.deleteGroupContents(id, "note")
+	r.store.deleteContentShares(id, "note")
+	delete(r.store.notes, id)
+	return nil
+}
+
+// addToNoteCounter, not sayaçlarından birini değiştirir (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) addToNoteCounter(id uint, counter func(*domain.Note) *int, delta int) {
+	if note, ok := s.notes[id]; ok {
+		*counter(note) += delta
+	}
+}
+
+// IncrementViewCount, görüntülenme sayısını artırır
+func (r *NoteRepository) IncrementViewCount(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.addToNoteCounter(id, func(n *domain.Note) *int { return &n.ViewCount }, 1)
+	return nil
+}
+
+// IncrementLikeCount, beğeni sayısını artırır
+func (r *NoteRepository) IncrementLikeCount(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.addToNoteCounter(id, func(n *domain.Note) *int { return &n.LikeCount }, 1)
+	return nil
+}
+
+// DecrementLikeCount, beğeni sayısını azaltır
+func (r *NoteRepository) DecrementLikeCount(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.addToNoteCounter(id, func(n *domain.Note) *int { return &n.LikeCount }, -1)
+	return nil
+}
+
+// CommentRepository, domain.CommentRepository arayüzünün bellek içi implementasyonu
+type CommentRepository struct {
+	store *Store
+}
+
+// NewCommentRepository, yeni bir CommentRepository örneği oluşturur
+func NewCommentRepository(store *Store) *CommentRepository {
+	return &CommentRepository{store: store}
+}
+
+// FindByNoteID, not ID'sine göre yorumları bulur
+func (r *CommentRepository) FindByNoteID(noteID uint, limit, offset int) ([]*domain.Comment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var comments []*domain.Comment
+	for _, id := range sortedIDs(r.store.comments) {
+		comment := r.store.comments[id]
+		if comment.NoteID == noteID {
+			c := *comment
+			comments = append(comments, &c)
+		}
+	}
+	return paginate(comments, limit, offset), nil
+}
+
+// Create, yeni bir yorum oluşturur ve notun yorum sayısını artırır
+func (r *CommentRepository) Create(comment *domain.Comment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *comment
+	stored.ID = r.store.nextID("comments")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.comments[stored.ID] = &stored
+
+	// Yorum sayısını artır
+	r.store.addToNoteCounter(comment.NoteID, func(n *domain.Note) *int { return &n.CommentCount }, 1)
+
+	// ID'yi güncelle
+	comment.ID = stored.ID
+	return nil
+}
+
+// Update, bir yorumu günceller
+func (r *CommentRepository) Update(comment *domain.Comment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if stored, ok := r.store.comments[comment.ID]; ok {
+		stored.Content = comment.Content
+		stored.UpdatedAt = now()
+	}
+	return nil
+}
+
+// Delete, bir yorumu siler ve notun yorum sayısını azaltır
+func (r *CommentRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	comment, ok := r.store.comments[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.store.comments, id)
+
+	// Yorum sayısını azalt
+	r.store.addToNoteCounter(comment.NoteID, func(n *domain.Note) *int { return &n.CommentCount }, -1)
+	return nil
+}
+
+// Ensure NoteRepository implements domain.NoteRepository
+var _ domain.NoteRepository = (*NoteRepository)(nil)
+
+// Ensure CommentRepository implements domain.CommentRepository
+var _ domain.CommentRepository = (*CommentRepository)(nil)
@@ -0,0 +1,188 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// NotificationRepository, domain.NotificationRepository arayüzünün bellek içi implementasyonu
+type NotificationRepository struct {
+	store *Store
+}
+
+// NewNotificationRepository, yeni bir NotificationRepository örneği oluşturur
+func NewNotificationRepository(store *Store) *NotificationRepository {
+	return &NotificationRepository{store: store}
+}
+
+// deleteContentNotifications, bir içerikle ilgili tüm bildirimleri siler (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) deleteContentNotifications(contentID uint, contentType string) {
+	for id, notification := range s.notifications {
+		if notification.ContentID == contentID && notification.ContentType == contentType {
+			delete(s.notifications, id)
+		}
+	}
+}
+
+// FindByID, ID'ye göre bildirimi bulur
+func (r *NotificationRepository) FindByID(id uint) (*domain.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	notification, ok := r.store.notifications[id]
+	if !ok {
+		return nil, nil
+	}
+	n := *notification
+	return &n, nil
+}
+
+// FindByUserID, kullanıcının bildirimlerini en yeniden eskiye getirir
+func (r *NotificationRepository) FindByUserID(userID uint, unreadOnly bool, limit, offset int) ([]*domain.Notification, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matches := make([]*domain.Notification, 0)
+	for _, notification := range r.store.notifications {
+		if notification.UserID == userID && (!unreadOnly || !notification.IsRead) {
+			matches = append(matches, notification)
+		}
+	}
+
+	// created_at DESC, id DESC
+	sort.Slice(matches, func(i, j int) bool {
+		if !matches[i].CreatedAt.Equal(matches[j].CreatedAt) {
+			return matches[i].CreatedAt.After(matches[j].CreatedAt)
+		}
+		return matches[i].ID > matches[j].ID
+	})
+
+	notifications := make([]*domain.Notification, 0)
+	for _, notification := range paginate(matches, limit, offset) {
+		n := *notification
+		notifications = append(notifications, &n)
+	}
+	return notifications, nil
+}
+
+// ExistsUnread, aynı alıcı, kişi, tür ve içerik için okunmamış bir bildirim olup olmadığını kontrol eder
+func (r *NotificationRepository) ExistsUnread(notification *domain.Notification) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, n := range r.store.notifications {
+		if n.UserID == notification.UserID && n.ActorID == notification.ActorID && n.Type == notification.Type &&
+			n.ContentID == notification.ContentID && n.ContentType == notification.ContentType && !n.IsRead {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CountUnread, kullanıcının okunmamış bildirim sayısını döndürür
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var count int64
+	for _, notification := range r.store.notifications {
+		if notification.UserID == userID && !notification.IsRead {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Create, yeni bir bildirim oluşturur
+func (r *NotificationRepository) Create(notification *domain.Notification) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *notification
+	stored.ID = r.store.nextID("notifications")
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = now()
+	}
+	r.store.notifications[stored.ID] = &stored
+
+	// ID ve zaman damgasını güncelle
+	notification.ID = stored.ID
+	notification.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// MarkRead, bildirimi okundu olarak işaretler
+func (r *NotificationRepository) MarkRead(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if notification, ok := r.store.notifications[id]; ok {
+		notification.IsRead = true
+	}
+	return nil
+}
+
+// MarkAllRead, kullanıcının tüm bildirimlerini okundu olarak işaretler
+func (r *NotificationRepository) MarkAllRead(userID uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, notification := range r.store.notifications {
+		if notification.UserID == userID {
+			notification.IsRead = true
+		}
+	}
+	return nil
+}
+
+// DeleteByContentID, bir içerikle ilgili tüm bildirimleri siler
+func (r *NotificationRepository) DeleteByContentID(contentID uint, contentType string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.deleteContentNotifications(contentID, contentType)
+	return nil
+}
+
+// NotificationPreferenceRepository, domain.NotificationPreferenceRepository arayüzünün bellek içi implementasyonu
+type NotificationPreferenceRepository struct {
+	store *Store
+}
+
+// NewNotificationPreferenceRepository, yeni bir NotificationPreferenceRepository örneği oluşturur
+func NewNotificationPreferenceRepository(store *Store) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{store: store}
+}
+
+// FindByUserID, kullanıcının kayıtlı tercihlerini bulur
+func (r *NotificationPreferenceRepository) FindByUserID(userID uint) (*domain.NotificationPreferences, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	preferences, ok := r.store.notificationPreferences[userID]
+	if !ok {
+		return nil, nil
+	}
+	p := *preferences
+	return &p, nil
+}
+
+// Save, kullanıcının tercihlerini oluşturur veya günceller
+func (r *NotificationPreferenceRepository) Save(preferences *domain.NotificationPreferences) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *preferences
+	stored.UpdatedAt = now()
+	r.store.notificationPreferences[stored.UserID] = &stored
+
+	preferences.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Ensure NotificationRepository implements domain.NotificationRepository
+var _ domain.NotificationRepository = (*NotificationRepository)(nil)
+
+// Ensure NotificationPreferenceRepository implements domain.NotificationPreferenceRepository
+var _ domain.NotificationPreferenceRepository = (*NotificationPreferenceRepository)(nil)
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// PDFPageRepository, domain.PDFPageRepository arayüzünün bellek içi implementasyonu
+type PDFPageRepository struct {
+	store *Store
+}
+
+// NewPDFPageRepository, yeni bir PDFPageRepository örneği oluşturur
+func NewPDFPageRepository(store *Store) *PDFPageRepository {
+	return &PDFPageRepository{store: store}
+}
+
+// FindByPDFID, PDF'in sayfa metinlerini sayfa numarasına göre sıralı getirir
+func (r *PDFPageRepository) FindByPDFID(pdfID uint, limit, offset int) ([]*domain.PDFPage, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	pages := make([]*domain.PDFPage, 0)
+	for _, page := range paginate(r.store.pdfPages[pdfID], limit, offset) {
+		p := *page
+		pages = append(pages, &p)
+	}
+	return pages, nil
+}
+
+// Replace, PDF'in saklanan tüm sayfa metinlerini verilenlerle değiştirir
+func (r *PDFPageRepository) Replace(pdfID uint, pages []*domain.PDFPage) error {
+	stored := make([]*domain.PDFPage, 0, len(pages))
+	for _, page := range pages {
+		p := *page
+		p.PDFID = pdfID
+		stored = append(stored, &p)
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].PageNumber < stored[j].PageNumber })
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if len(stored) == 0 {
+		delete(r.store.pdfPages, pdfID)
+		return nil
+	}
+	r.store.pdfPages[pdfID] = stored
+	return nil
+}
+
+// Ensure PDFPageRepository implements domain.PDFPageRepository
+var _ domain.PDFPageRepository = (*PDFPageRepository)(nil)
@@ -0,0 +1,504 @@
+package memory
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/adapter/textsearch"
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// PDFRepository, domain.PDFRepository arayüzünün bellek içi implementasyonu
+type PDFRepository struct {
+	store *Store
+}
+
+// NewPDFRepository, yeni bir PDFRepository örneği oluşturur
+func NewPDFRepository(store *Store) *PDFRepository {
+	return &PDFRepository{store: store}
+}
+
+// clonePDF, saklanan PDF'in çağırana verilecek bağımsız bir kopyasını oluşturur
+func clonePDF(p *domain.PDF) *domain.PDF {
+	c := *p
+	c.Tags = copyTags(p.Tags)
+	c.ActiveContent = append([]string(nil), p.ActiveContent...)
+	return &c
+}
+
+// copyQuote, alıntının kopyasını döndürür; saklanan kayıtların dışarıdan değiştirilmesini önler
+func copyQuote(q *domain.TextQuoteSelector) *domain.TextQuoteSelector {
+	if q == nil {
+		return nil
+	}
+	c := *q
+	return &c
+}
+
+// clonePDFComment, yorumun alıntısıyla birlikte kopyasını döndürür
+func clonePDFComment(comment *domain.PDFComment) *domain.PDFComment {
+	c := *comment
+	c.Quote = copyQuote(comment.Quote)
+	return &c
+}
+
+// clonePDFAnnotation, işaretlemenin dikdörtgenleri ve alıntısıyla birlikte kopyasını döndürür
+func clonePDFAnnotation(annotation *domain.PDFAnnotation) *domain.PDFAnnotation {
+	a := *annotation
+	a.Rects = append([]domain.PDFRect(nil), annotation.Rects...)
+	a.Quote = copyQuote(annotation.Quote)
+	return &a
+}
+
+// findPDFs, filtreye uyan PDF'leri ID sırasına göre sayfalayarak döndürür
+func (r *PDFRepository) findPDFs(match func(*domain.PDF) bool, limit, offset int) []*domain.PDF {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var pdfs []*domain.PDF
+	for _, id := range sortedIDs(r.store.pdfs) {
+		pdf := r.store.pdfs[id]
+		if match(pdf) {
+			pdfs = append(pdfs, clonePDF(pdf))
+		}
+	}
+	return paginate(pdfs, limit, offset)
+}
+
+// FindByID, ID'ye göre PDF bulur
+func (r *PDFRepository) FindByID(id uint) (*domain.PDF, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	pdf, ok := r.store.pdfs[id]
+	if !ok {
+		return nil, nil // PDF bulunamadı
+	}
+	return clonePDF(pdf), nil
+}
+
+// FindByUserID, kullanıcı ID'sine göre PDF'leri bulur
+func (r *PDFRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.PDF, error) {
+	return r.findPDFs(func(p *domain.PDF) bool { return p.UserID == userID }, limit, offset), nil
+}
+
+// FindPublic, herkese açık PDF'leri bulur
+func (r *PDFRepository) FindPublic(limit, offset int) ([]*domain.PDF, error) {
+	return r.findPDFs(func(p *domain.PDF) bool { return p.IsPublic }, limit, offset), nil
+}
+
+// FindByTag, etikete göre PDF'leri bulur
+func (r *PDFRepository) FindByTag(tag string, limit, offset int) ([]*domain.PDF, error) {
+	return r.findPDFs(func(p *domain.PDF) bool { return hasTag(p.Tags, tag) }, limit, offset), nil
+}
+
+// Search, tam metin arama sorgusuna uyan herkese açık (ve sorgudaki kullanıcının) PDF'leri puana göre sıralı döndürür.
+// PDF'lerin başlık ve açıklamalarının yanında sayfalarından çıkarılan metinler de aranır.
+func (r *PDFRepository) Search(query *domain.SearchQuery) ([]*domain.SearchHit, error) {
+	terms := textsearch.NormalizeTerms(query.Terms)
+	if terms == nil {
+		return []*domain.SearchHit{}, nil
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var hits []*domain.SearchHit
+	for _, id := range sortedIDs(r.store.pdfs) {
+		pdf := r.store.pdfs[id]
+		if !pdf.IsPublic && pdf.UserID != query.UserID {
+			continue
+		}
+		if hit, ok := textsearch.PDFHit(clonePDF(pdf), r.store.pdfPages[id], terms); ok {
+			hits = append(hits, hit)
+		}
+	}
+
+	textsearch.SortHits(hits)
+	return textsearch.Paginate(hits, query.Limit, query.Offset), nil
+}
+
+// Create, yeni bir PDF oluşturur
+func (r *PDFRepository) Create(pdf *domain.PDF) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := clonePDF(pdf)
+	stored.ID = r.store.nextID("pdfs")
+	stored.Version = 1
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.pdfs[stored.ID] = stored
+
+	// ID ve sürümü güncelle
+	pdf.ID = stored.ID
+	pdf.Version = stored.Version
+	return nil
+}
+
+// Update, bir PDF'i günceller
+func (r *PDFRepository) Update(pdf *domain.PDF) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.pdfs[pdf.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+
+	// Başka bir güncelleme araya girdiyse değişiklik uygulanmaz
+	if stored.Version != pdf.Version {
+		return domain.ErrVersionConflict
+	}
+
+	// Dosya bilgileri ve sayaçlar korunur, yalnızca düzenlenebilir alanlar güncellenir
+	stored.Title = pdf.Title
+	stored.Description = pdf.Description
+	stored.IsPublic = pdf.IsPublic
+	stored.Tags = copyTags(pdf.Tags)
+	stored.Version++
+	stored.UpdatedAt = now()
+	pdf.Version = stored.Version
+	return nil
+}
+
+// Delete, bir PDF'i ve ilişkili yorum, işaretleme, beğeni ve sayfa metinlerini siler
+func (r *PDFRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for commentID, comment := range r.store.pdfComments {
+		if comment.PDFID == id {
+			delete(r.store.pdfComments, commentID)
+		}
+	}
+	for annotationID, annotation := range r.store.pdfAnnotations {
+		if annotation.PDFID == id {
+			delete(r.store.pdfAnnotations, annotationID)
+		}
+	}
+	for likeID, like := range r.store.likes {
+		if like.ContentID == id && like.Type == "pdf" {
+			delete(r.store.likes, likeID)
+		}
+	}
+	r.store.deleteContentNotifications(id, "pdf")
+	r.store.deleteCollectionItems(id, "pdf")
+	r.store.deleteCourseContent(id, "pdf")
+	r.store.deleteGroupContents(id, "pdf")
+	r.store.deleteContentShares(id, "pdf")
+	delete(r.store.pdfPages, id)
+	delete(r.store.pdfs, id)
+	return nil
+}
+
+// addToPDFCounter, PDF sayaçlarından birini değiştirir (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) addToPDFCounter(id uint, counter func(*domain.PDF) *int, delta int) {
+	if pdf, ok := s.pdfs[id]; ok {
+		*counter(pdf) += delta
+	}
+}
+
+// IncrementViewCount, görüntülenme sayısını artırır
+func (r *PDFRepository) IncrementViewCount(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.addToPDFCounter(id, func(p *domain.PDF) *int { return &p.ViewCount }, 1)
+	return nil
+}
+
+// IncrementLikeCount, beğeni sayısını artırır
+func (r *PDFRepository) IncrementLikeCount(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.addToPDFCounter(id, func(p *domain.PDF) *int { return &p.LikeCount }, 1)
+	return nil
+}
+
+// DecrementLikeCount, beğeni sayısını azaltır
+func (r *PDFRepository) DecrementLikeCount(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	r.store.addToPDFCounter(id, func(p *domain.PDF) *int { return &p.LikeCount }, -1)
+	return nil
+}
+
+// UpdatePageCount, PDF'in sayfa sayısını günceller
+func (r *PDFRepository) UpdatePageCount(id uint, pageCount int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if pdf, ok := r.store.pdfs[id]; ok {
+		pdf.PageCount = pageCount
+	}
+	return nil
+}
+
+// FindAll, tüm PDF'leri ID sırasıyla getirir
+func (r *PDFRepository) FindAll(limit, offset int) ([]*domain.PDF, error) {
+	return r.findPDFs(func(p *domain.PDF) bool { return true }, limit, offset), nil
+}
+
+// UpdateFilePath, PDF'in dosya yolunu günceller
+func (r *PDFRepository) UpdateFilePath(id uint, filePath string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if pdf, ok := r.store.pdfs[id]; ok {
+		pdf.FilePath = filePath
+	}
+	return nil
+}
+
+// CountByFilePath, dosya yolunu paylaşan PDF sayısını döndürür
+func (r *PDFRepository) CountByFilePath(filePath string) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, pdf := range r.store.pdfs {
+		if pdf.FilePath == filePath {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// FindPublicByContentHash, içerik özeti eşleşen herkese açık PDF'leri bulur
+func (r *PDFRepository) FindPublicByContentHash(contentHash string, limit int) ([]*domain.PDF, error) {
+	return r.findPDFs(func(p *domain.PDF) bool { return p.IsPublic && p.ContentHash == contentHash }, limit, 0), nil
+}
+
+// CountByContentHash, içerik özeti eşleşen PDF sayısını döndürür
+func (r *PDFRepository) CountByContentHash(contentHash string) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, pdf := range r.store.pdfs {
+		if pdf.ContentHash == contentHash {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// UpdatePreviews, PDF'in kapak ve önizleme görseli bilgilerini günceller
+func (r *PDFRepository) UpdatePreviews(id uint, hasThumbnail bool, previewPages int) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if pdf, ok := r.store.pdfs[id]; ok {
+		pdf.HasThumbnail = hasThumbnail
+		pdf.PreviewPages = previewPages
+	}
+	return nil
+}
+
+// FindWithoutContentHash, içerik özeti olmayan eski PDF'leri ID sırasıyla bulur
+func (r *PDFRepository) FindWithoutContentHash(afterID uint, limit int) ([]*domain.PDF, error) {
+	return r.findPDFs(func(p *domain.PDF) bool { return p.ID > afterID && p.ContentHash == "" }, limit, 0), nil
+}
+
+// UpdateContentHash, PDF'in içerik özetini ve dosya yolunu günceller
+func (r *PDFRepository) UpdateContentHash(id uint, filePath, contentHash string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	if pdf, ok := r.store.pdfs[id]; ok {
+		pdf.FilePath = filePath
+		pdf.ContentHash = contentHash
+	}
+	return nil
+}
+
+// PDFCommentRepository, domain.PDFCommentRepository arayüzünün bellek içi implementasyonu
+type PDFCommentRepository struct {
+	store *Store
+}
+
+// NewPDFCommentRepository, yeni bir PDFCommentRepository örneği oluşturur
+func NewPDFCommentRepository(store *Store) *PDFCommentRepository {
+	return &PDFCommentRepository{store: store}
+}
+
+// FindByPDFID, PDF ID'sine göre yorumları bulur
+func (r *PDFCommentRepository) FindByPDFID(pdfID uint, limit, offset int) ([]*domain.PDFComment, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var comments []*domain.PDFComment
+	for _, id := range sortedIDs(r.store.pdfComments) {
+		comment := r.store.pdfComments[id]
+		if comment.PDFID == pdfID {
+			comments = append(comments, clonePDFComment(comment))
+		}
+	}
+	return paginate(comments, limit, offset), nil
+}
+
+// Create, yeni bir yorum oluşturur ve PDF'in yorum sayısını artırır
+func (r *PDFCommentRepository) Create(comment *domain.PDFComment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := clonePDFComment(comment)
+	stored.ID = r.store.nextID("pdf_comments")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.pdfComments[stored.ID] = stored
+
+	// Yorum sayısını artır
+	r.store.addToPDFCounter(comment.PDFID, func(p *domain.PDF) *int { return &p.CommentCount }, 1)
+
+	// ID'yi güncelle
+	comment.ID = stored.ID
+	return nil
+}
+
+// Update, bir yorumu günceller
+func (r *PDFCommentRepository) Update(comment *domain.PDFComment) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if stored, ok := r.store.pdfComments[comment.ID]; ok {
+		stored.Content = comment.Content
+		stored.PageNumber = comment.PageNumber
+		stored.Quote = copyQuote(comment.Quote)
+		stored.UpdatedAt = now()
+	}
+	return nil
+}
+
+// Delete, bir yorumu siler ve PDF'in yorum sayısını azaltır
+func (r *PDFCommentRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	comment, ok := r.store.pdfComments[id]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	delete(r.store.pdfComments, id)
+
+	// Yorum sayısını azalt
+	r.store.addToPDFCounter(comment.PDFID, func(p *domain.PDF) *int { return &p.CommentCount }, -1)
+	return nil
+}
+
+// PDFAnnotationRepository, domain.PDFAnnotationRepository arayüzünün bellek içi implementasyonu
+type PDFAnnotationRepository struct {
+	store *Store
+}
+
+// NewPDFAnnotationRepository, yeni bir PDFAnnotationRepository örneği oluşturur
+func NewPDFAnnotationRepository(store *Store) *PDFAnnotationRepository {
+	return &PDFAnnotationRepository{store: store}
+}
+
+// findAnnotations, filtreye uyan işaretlemeleri ID sırasına göre döndürür
+func (r *PDFAnnotationRepository) findAnnotations(match func(*domain.PDFAnnotation) bool) []*domain.PDFAnnotation {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var annotations []*domain.PDFAnnotation
+	for _, id := range sortedIDs(r.store.pdfAnnotations) {
+		annotation := r.store.pdfAnnotations[id]
+		if match(annotation) {
+			annotations = append(annotations, clonePDFAnnotation(annotation))
+		}
+	}
+	return annotations
+}
+
+// FindByID, ID'ye göre bir işaretleme bulur
+func (r *PDFAnnotationRepository) FindByID(id uint) (*domain.PDFAnnotation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	annotation, ok := r.store.pdfAnnotations[id]
+	if !ok {
+		return nil, nil
+	}
+	return clonePDFAnnotation(annotation), nil
+}
+
+// FindByPDFID, PDF ID'sine göre işaretlemeleri bulur
+func (r *PDFAnnotationRepository) FindByPDFID(pdfID uint, limit, offset int) ([]*domain.PDFAnnotation, error) {
+	annotations := r.findAnnotations(func(a *domain.PDFAnnotation) bool { return a.PDFID == pdfID })
+	return paginate(annotations, limit, offset), nil
+}
+
+// FindByPDFIDAndUserID, PDF ID'si ve kullanıcı ID'sine göre işaretlemeleri bulur
+func (r *PDFAnnotationRepository) FindByPDFIDAndUserID(pdfID, userID uint) ([]*domain.PDFAnnotation, error) {
+	return r.findAnnotations(func(a *domain.PDFAnnotation) bool {
+		return a.PDFID == pdfID && a.UserID == userID
+	}), nil
+}
+
+// FindVisibleByPDFID, PDF'in verilen görünürlüklerdeki, başka kullanıcılara ait işaretlemelerini
+// kullanıcıya göre gruplanmış olarak bulur
+func (r *PDFAnnotationRepository) FindVisibleByPDFID(pdfID uint, visibilities []string, excludeUserID uint, limit int) ([]*domain.PDFAnnotation, error) {
+	annotations := r.findAnnotations(func(a *domain.PDFAnnotation) bool {
+		return a.PDFID == pdfID && a.UserID != excludeUserID && slices.Contains(visibilities, a.Visibility)
+	})
+	sort.SliceStable(annotations, func(i, j int) bool { return annotations[i].UserID < annotations[j].UserID })
+	return paginate(annotations, limit, 0), nil
+}
+
+// Create, yeni bir işaretleme oluşturur
+func (r *PDFAnnotationRepository) Create(annotation *domain.PDFAnnotation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := clonePDFAnnotation(annotation)
+	stored.ID = r.store.nextID("pdf_annotations")
+	if stored.Visibility == "" {
+		stored.Visibility = domain.AnnotationVisibilityPrivate // Veritabanındaki varsayılan değerle aynı
+	}
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.pdfAnnotations[stored.ID] = stored
+
+	// ID'yi güncelle
+	annotation.ID = stored.ID
+	return nil
+}
+
+// Update, bir işaretlemeyi günceller
+func (r *PDFAnnotationRepository) Update(annotation *domain.PDFAnnotation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.pdfAnnotations[annotation.ID]
+	if !ok {
+		return nil
+	}
+	stored.Content = annotation.Content
+	stored.X = annotation.X
+	stored.Y = annotation.Y
+	stored.Width = annotation.Width
+	stored.Height = annotation.Height
+	stored.Rects = append([]domain.PDFRect(nil), annotation.Rects...)
+	stored.Quote = copyQuote(annotation.Quote)
+	stored.Type = annotation.Type
+	stored.Color = annotation.Color
+	stored.Visibility = annotation.Visibility
+	stored.PageNumber = annotation.PageNumber
+	stored.UpdatedAt = now()
+	return nil
+}
+
+// Delete, bir işaretlemeyi siler
+func (r *PDFAnnotationRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.pdfAnnotations, id)
+	return nil
+}
+
+// Ensure PDFRepository implements domain.PDFRepository
+var _ domain.PDFRepository = (*PDFRepository)(nil)
+
+// Ensure PDFCommentRepository implements domain.PDFCommentRepository
+var _ domain.PDFCommentRepository = (*PDFCommentRepository)(nil)
+
+// Ensure PDFAnnotationRepository implements domain.PDFAnnotationRepository
+var _ domain.PDFAnnotationRepository = (*PDFAnnotationRepository)(nil)
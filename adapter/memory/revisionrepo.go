@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// NoteRevisionRepository, domain.NoteRevisionRepository arayüzünün bellek içi implementasyonu
+type NoteRevisionRepository struct {
+	store *Store
+}
+
+// NewNoteRevisionRepository, yeni bir NoteRevisionRepository örneği oluşturur
+func NewNoteRevisionRepository(store *Store) *NoteRevisionRepository {
+	return &NoteRevisionRepository{store: store}
+}
+
+// cloneRevision, revizyonun etiketleriyle birlikte bağımsız bir kopyasını döndürür
+func cloneRevision(revision *domain.NoteRevision) *domain.NoteRevision {
+	r := *revision
+	r.Tags = copyTags(revision.Tags)
+	return &r
+}
+
+// findNoteRevisions, notun revizyonlarını en yeniden eskiye döndürür (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) findNoteRevisions(noteID uint) []*domain.NoteRevision {
+	revisions := make([]*domain.NoteRevision, 0)
+	for _, revision := range s.noteRevisions {
+		if revision.NoteID == noteID {
+			revisions = append(revisions, revision)
+		}
+	}
+
+	// number DESC
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number > revisions[j].Number
+	})
+	return revisions
+}
+
+// FindByNoteID, notun revizyonlarını en yeniden eskiye getirir
+func (r *NoteRevisionRepository) FindByNoteID(noteID uint, limit, offset int) ([]*domain.NoteRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	revisions := make([]*domain.NoteRevision, 0)
+	for _, revision := range paginate(r.store.findNoteRevisions(noteID), limit, offset) {
+		revisions = append(revisions, cloneRevision(revision))
+	}
+	return revisions, nil
+}
+
+// FindByNumber, notun belirli numaralı revizyonunu bulur
+func (r *NoteRevisionRepository) FindByNumber(noteID uint, number int) (*domain.NoteRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, revision := range r.store.noteRevisions {
+		if revision.NoteID == noteID && revision.Number == number {
+			return cloneRevision(revision), nil
+		}
+	}
+	return nil, nil
+}
+
+// FindLatest, notun en son revizyonunu bulur
+func (r *NoteRevisionRepository) FindLatest(noteID uint) (*domain.NoteRevision, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	revisions := r.store.findNoteRevisions(noteID)
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	return cloneRevision(revisions[0]), nil
+}
+
+// Create, yeni bir revizyon oluşturur ve nota özgü bir sonraki numarayı atar
+func (r *NoteRevisionRepository) Create(revision *domain.NoteRevision) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := cloneRevision(revision)
+	stored.ID = r.store.nextID("note_revisions")
+	stored.Number = 1
+	if revisions := r.store.findNoteRevisions(revision.NoteID); len(revisions) > 0 {
+		stored.Number = revisions[0].Number + 1
+	}
+	if stored.CreatedAt.IsZero() {
+		stored.CreatedAt = now()
+	}
+	r.store.noteRevisions[stored.ID] = stored
+
+	// ID ve numarayı güncelle
+	revision.ID = stored.ID
+	revision.Number = stored.Number
+	revision.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// Ensure NoteRevisionRepository implements domain.NoteRevisionRepository
+var _ domain.NoteRevisionRepository = (*NoteRevisionRepository)(nil)
@@ -0,0 +1,150 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// ShareRepository, domain.ShareRepository arayüzünün bellek içi implementasyonu
+type ShareRepository struct {
+	store *Store
+}
+
+// NewShareRepository, yeni bir ShareRepository örneği oluşturur
+func NewShareRepository(store *Store) *ShareRepository {
+	return &ShareRepository{store: store}
+}
+
+// deleteContentShares, bir içeriğin tüm kullanıcı paylaşımlarını siler (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) deleteContentShares(contentID uint, contentType string) {
+	for id, share := range s.contentShares {
+		if share.ContentID == contentID && share.ContentType == contentType {
+			delete(s.contentShares, id)
+		}
+	}
+}
+
+// FindByID, ID'ye göre paylaşımı bulur
+func (r *ShareRepository) FindByID(id uint) (*domain.ContentShare, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	share, ok := r.store.contentShares[id]
+	if !ok {
+		return nil, nil
+	}
+	s := *share
+	return &s, nil
+}
+
+// Find, içeriğin kullanıcıyla paylaşımını bulur
+func (r *ShareRepository) Find(contentID uint, contentType string, userID uint) (*domain.ContentShare, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, share := range r.store.contentShares {
+		if share.ContentID == contentID && share.ContentType == contentType && share.UserID == userID {
+			s := *share
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindByContent, içeriğin paylaşımlarını eskiden yeniye getirir
+func (r *ShareRepository) FindByContent(contentID uint, contentType string, limit, offset int) ([]*domain.ContentShare, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.ContentShare, 0)
+	for _, share := range r.store.contentShares {
+		if share.ContentID == contentID && share.ContentType == contentType {
+			matched = append(matched, share)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return oldestFirst(matched[i].CreatedAt, matched[j].CreatedAt, matched[i].ID, matched[j].ID)
+	})
+	return copyShares(paginate(matched, limit, offset)), nil
+}
+
+// FindByUserID, kullanıcıyla paylaşılan içerikleri son paylaşılandan başlayarak getirir
+func (r *ShareRepository) FindByUserID(userID uint, contentType string, limit, offset int) ([]*domain.ContentShare, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matched := make([]*domain.ContentShare, 0)
+	for _, share := range r.store.contentShares {
+		if share.UserID == userID && (contentType == "" || share.ContentType == contentType) {
+			matched = append(matched, share)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return newestFirst(matched[i].CreatedAt, matched[j].CreatedAt, matched[i].ID, matched[j].ID)
+	})
+	return copyShares(paginate(matched, limit, offset)), nil
+}
+
+// Create, içeriği kullanıcıyla paylaşır; içerik kullanıcıyla zaten paylaşılmışsa ErrDuplicateEntry döner
+func (r *ShareRepository) Create(share *domain.ContentShare) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.contentShares {
+		if existing.ContentID == share.ContentID && existing.ContentType == share.ContentType && existing.UserID == share.UserID {
+			return fmt.Errorf("paylaşım oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+	}
+
+	stored := *share
+	stored.Username = ""
+	stored.ID = r.store.nextID("content_shares")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.contentShares[stored.ID] = &stored
+
+	// ID'yi ve zaman damgalarını güncelle
+	share.ID = stored.ID
+	share.CreatedAt = stored.CreatedAt
+	share.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Update, paylaşımın rolünü günceller
+func (r *ShareRepository) Update(share *domain.ContentShare) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.contentShares[share.ID]
+	if !ok {
+		return nil
+	}
+	stored.Role = share.Role
+	stored.UpdatedAt = now()
+	share.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+// Delete, paylaşımı kaldırır
+func (r *ShareRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.contentShares, id)
+	return nil
+}
+
+// copyShares, paylaşımların bağımsız kopyalarını döndürür
+func copyShares(shares []*domain.ContentShare) []*domain.ContentShare {
+	copies := make([]*domain.ContentShare, 0, len(shares))
+	for _, share := range shares {
+		s := *share
+		copies = append(copies, &s)
+	}
+	return copies
+}
+
+// Ensure ShareRepository implements domain.ShareRepository
+var _ domain.ShareRepository = (*ShareRepository)(nil)
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// Store, bellek içi repository'lerin paylaştığı veri deposudur.
+// Postgres adaptöründeki *gorm.DB'nin karşılığıdır: sayaç güncellemeleri ve
+// silme sırasındaki ilişkili kayıt temizliği gibi tablolar arası yan etkiler
+// tek bir kilit altında burada gerçekleşir.
+type Store struct {
+	mu sync.RWMutex
+
+	users                   map[uint]*domain.User
+	notes                   map[uint]*domain.Note
+	comments                map[uint]*domain.Comment
+	pdfs                    map[uint]*domain.PDF
+	pdfComments             map[uint]*domain.PDFComment
+	pdfAnnotations          map[uint]*domain.PDFAnnotation
+	pdfPages                map[uint][]*domain.PDFPage // PDF ID'sine göre, sayfa numarasına göre sıralı
+	likes                   map[uint]*domain.Like
+	views                   map[uint]*domain.View
+	invites                 map[uint]*domain.Invite
+	inviteRedemptions       map[uint]*domain.InviteRedemption
+	revokedTokens           map[uint]*domain.RevokedToken
+	loginAttempts           map[uint]*domain.LoginAttempt
+	noteRevisions           map[uint]*domain.NoteRevision
+	notifications           map[uint]*domain.Notification
+	notificationPreferences map[uint]*domain.NotificationPreferences // kullanıcı ID'sine göre
+	jobs                    map[uint]*domain.Job
+	collections             map[uint]*domain.Collection
+	collectionFolders       map[uint]*domain.CollectionFolder
+	collectionItems         map[uint]*domain.CollectionItem
+	universities            map[uint]*domain.University
+	departments             map[uint]*domain.Department
+	courses                 map[uint]*domain.Course
+	terms                   map[uint]*domain.Term
+	courseContents          map[uint]*domain.CourseContent
+	groups                  map[uint]*domain.Group
+	groupMembers            map[uint]*domain.GroupMember
+	groupJoinRequests       map[uint]*domain.GroupJoinRequest
+	groupContents           map[uint]*domain.GroupContent
+	groupActivities         map[uint]*domain.GroupActivity
+	contentShares           map[uint]*domain.ContentShare
+
+	lastID map[string]uint
+}
+
+// NewStore, boş bir bellek içi veri deposu oluşturur
+func NewStore() *Store {
+	return &Store{
+		users:                   make(map[uint]*domain.User),
+		notes:                   make(map[uint]*domain.Note),
+		comments:                make(map[uint]*domain.Comment),
+		pdfs:                    make(map[uint]*domain.PDF),
+		pdfComments:             make(map[uint]*domain.PDFComment),
+		pdfAnnotations:          make(map[uint]*domain.PDFAnnotation),
+		pdfPages:                make(map[uint][]*domain.PDFPage),
+		likes:                   make(map[uint]*domain.Like),
+		views:                   make(map[uint]*domain.View),
+		invites:                 make(map[uint]*domain.Invite),
+		inviteRedemptions:       make(map[uint]*domain.InviteRedemption),
+		revokedTokens:           make(map[uint]*domain.RevokedToken),
+		loginAttempts:           make(map[uint]*domain.LoginAttempt),
+		noteRevisions:           make(map[uint]*domain.NoteRevision),
+		notifications:           make(map[uint]*domain.Notification),
+		notificationPreferences: make(map[uint]*domain.NotificationPreferences),
+		jobs:                    make(map[uint]*domain.Job),
+		collections:             make(map[uint]*domain.Collection),
+		collectionFolders:       make(map[uint]*domain.CollectionFolder),
+		collectionItems:         make(map[uint]*domain.CollectionItem),
+		universities:            make(map[uint]*domain.University),
+		departments:             make(map[uint]*domain.Department),
+		courses:                 make(map[uint]*domain.Course),
+		terms:                   make(map[uint]*domain.Term),
+		courseContents:          make(map[uint]*domain.CourseContent),
+		groups:                  make(map[uint]*domain.Group),
+		groupMembers:            make(map[uint]*domain.GroupMember),
+		groupJoinRequests:       make(map[uint]*domain.GroupJoinRequest),
+		groupContents:           make(map[uint]*domain.GroupContent),
+		groupActivities:         make(map[uint]*domain.GroupActivity),
+		contentShares:           make(map[uint]*domain.ContentShare),
+		lastID:                  make(map[string]uint),
+	}
+}
+
+// nextID, verilen tablo için bir sonraki ID'yi üretir (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) nextID(table string) uint {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// now, kayıt zaman damgaları için kullanılan zamanı döndürür
+func now() time.Time {
+	return domain.Now()
+}
+
+// sortedIDs, bir map'in anahtarlarını artan sırada döndürür (ekleme sırasını korur)
+func sortedIDs[T any](m map[uint]T) []uint {
+	ids := make([]uint, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// paginate, SQL LIMIT/OFFSET davranışını dilimler üzerinde uygular.
+// Negatif limit, GORM'daki Limit(-1) gibi sınırsız kabul edilir.
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(items) {
+		return nil
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+// hasTag, etiket listesinde verilen etiketin olup olmadığını kontrol eder
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// copyTags, etiket listesinin bağımsız bir kopyasını döndürür
+func copyTags(tags []string) []string {
+	out := make([]string, len(tags))
+	copy(out, tags)
+	return out
+}
@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// TokenRepository, iptal edilmiş token'lar için bellek içi repository implementasyonu
+type TokenRepository struct {
+	store *Store
+}
+
+// NewTokenRepository, yeni bir TokenRepository örneği oluşturur
+func NewTokenRepository(store *Store) *TokenRepository {
+	return &TokenRepository{store: store}
+}
+
+// RevokeToken, bir token'ı iptal eder
+func (r *TokenRepository) RevokeToken(token *domain.RevokedToken) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, existing := range r.store.revokedTokens {
+		if existing.Token == token.Token {
+			return domain.ErrDuplicateEntry
+		}
+	}
+
+	stored := *token
+	stored.ID = r.store.nextID("revoked_tokens")
+	r.store.revokedTokens[stored.ID] = &stored
+
+	token.ID = stored.ID
+	return nil
+}
+
+// IsTokenRevoked, bir token'ın iptal edilip edilmediğini kontrol eder
+func (r *TokenRepository) IsTokenRevoked(tokenString string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, existing := range r.store.revokedTokens {
+		if existing.Token == tokenString {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CleanupExpiredTokens, süresi dolmuş token'ları temizler
+func (r *TokenRepository) CleanupExpiredTokens() error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	current := time.Now()
+	for id, token := range r.store.revokedTokens {
+		if token.ExpiresAt.Before(current) {
+			delete(r.store.revokedTokens, id)
+		}
+	}
+	return nil
+}
+
+// Ensure TokenRepository implements domain.TokenRepository
+var _ domain.TokenRepository = (*TokenRepository)(nil)
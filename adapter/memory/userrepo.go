@@ -0,0 +1,128 @@
+package memory
+
+import (
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// UserRepository, domain.UserRepository arayüzünün bellek içi implementasyonu
+type UserRepository struct {
+	store *Store
+}
+
+// NewUserRepository, yeni bir UserRepository örneği oluşturur
+func NewUserRepository(store *Store) *UserRepository {
+	return &UserRepository{store: store}
+}
+
+// findUser, filtreye uyan ilk kullanıcıyı döndürür
+func (r *UserRepository) findUser(match func(*domain.User) bool) *domain.User {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range sortedIDs(r.store.users) {
+		if user := r.store.users[id]; match(user) {
+			u := *user
+			return &u
+		}
+	}
+	return nil
+}
+
+// FindByID, ID'ye göre kullanıcı bulur
+func (r *UserRepository) FindByID(id uint) (*domain.User, error) {
+	return r.findUser(func(u *domain.User) bool { return u.ID == id }), nil
+}
+
+// FindByEmail, e-posta adresine göre kullanıcı bulur
+func (r *UserRepository) FindByEmail(email string) (*domain.User, error) {
+	return r.findUser(func(u *domain.User) bool { return u.Email == email }), nil
+}
+
+// FindByUsername, kullanıcı adına göre kullanıcı bulur
+func (r *UserRepository) FindByUsername(username string) (*domain.User, error) {
+	return r.findUser(func(u *domain.User) bool { return u.Username == username }), nil
+}
+
+// isUniqueUser, kullanıcı adı ve e-posta benzersizlik kısıtını kontrol eder (kilit çağıran tarafından tutulmalıdır)
+func (s *Store) isUniqueUser(user *domain.User) bool {
+	for id, existing := range s.users {
+		if id == user.ID {
+			continue
+		}
+		if existing.Username == user.Username || existing.Email == user.Email {
+			return false
+		}
+	}
+	return true
+}
+
+// Create, yeni bir kullanıcı oluşturur
+func (r *UserRepository) Create(user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *user
+	stored.ID = 0
+	if !r.store.isUniqueUser(&stored) {
+		return domain.ErrDuplicateEntry
+	}
+
+	stored.ID = r.store.nextID("users")
+	stored.CreatedAt = now()
+	stored.UpdatedAt = stored.CreatedAt
+	r.store.users[stored.ID] = &stored
+
+	// ID'yi güncelle
+	user.ID = stored.ID
+	return nil
+}
+
+// Update, bir kullanıcıyı günceller
+func (r *UserRepository) Update(user *domain.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if !r.store.isUniqueUser(user) {
+		return domain.ErrDuplicateEntry
+	}
+
+	// GORM Save gibi: kayıt yoksa oluşturulur
+	stored := *user
+	if existing, ok := r.store.users[user.ID]; ok {
+		stored.CreatedAt = existing.CreatedAt
+	} else {
+		if stored.ID == 0 {
+			stored.ID = r.store.nextID("users")
+			user.ID = stored.ID
+		}
+		stored.CreatedAt = now()
+	}
+	stored.UpdatedAt = now()
+	r.store.users[stored.ID] = &stored
+	return nil
+}
+
+// Delete, bir kullanıcıyı siler
+func (r *UserRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.users, id)
+	return nil
+}
+
+// List, kullanıcıları listeler
+func (r *UserRepository) List(limit, offset int) ([]*domain.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var users []*domain.User
+	for _, id := range sortedIDs(r.store.users) {
+		u := *r.store.users[id]
+		users = append(users, &u)
+	}
+	return paginate(users, limit, offset), nil
+}
+
+// Ensure UserRepository implements domain.UserRepository
+var _ domain.UserRepository = (*UserRepository)(nil)
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// ViewRepository, domain.ViewRepository arayüzünün bellek içi implementasyonu
+type ViewRepository struct {
+	store *Store
+}
+
+// NewViewRepository, yeni bir ViewRepository oluşturur
+func NewViewRepository(store *Store) *ViewRepository {
+	return &ViewRepository{store: store}
+}
+
+// findViews, filtreye uyan görüntülemeleri en yeniden eskiye sayfalayarak döndürür
+func (r *ViewRepository) findViews(match func(*domain.View) bool, limit, offset int) []*domain.View {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	views := make([]*domain.View, 0)
+	for _, id := range sortedIDs(r.store.views) {
+		view := r.store.views[id]
+		if match(view) {
+			v := *view
+			views = append(views, &v)
+		}
+	}
+
+	// viewed_at DESC
+	sort.SliceStable(views, func(i, j int) bool {
+		return views[i].ViewedAt.After(views[j].ViewedAt)
+	})
+	return paginate(views, limit, offset)
+}
+
+// FindByID, belirtilen ID'ye sahip görüntülemeyi bulur
+func (r *ViewRepository) FindByID(id uint) (*domain.View, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	view, ok := r.store.views[id]
+	if !ok {
+		return nil, nil
+	}
+	v := *view
+	return &v, nil
+}
+
+// FindByUserIDAndContent, belirtilen kullanıcı ve içerik için görüntülemeyi bulur
+func (r *ViewRepository) FindByUserIDAndContent(userID, contentID uint, contentType string) (*domain.View, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, id := range sortedIDs(r.store.views) {
+		view := r.store.views[id]
+		if view.UserID == userID && view.ContentID == contentID && view.Type == contentType {
+			v := *view
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindByContentID, belirtilen içerik için görüntülemeleri bulur
+func (r *ViewRepository) FindByContentID(contentID uint, contentType string, limit, offset int) ([]*domain.View, error) {
+	return r.findViews(func(v *domain.View) bool {
+		return v.ContentID == contentID && v.Type == contentType
+	}, limit, offset), nil
+}
+
+// FindByUserID, belirtilen kullanıcı için görüntülemeleri bulur
+func (r *ViewRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.View, error) {
+	return r.findViews(func(v *domain.View) bool { return v.UserID == userID }, limit, offset), nil
+}
+
+// Create, yeni bir görüntüleme kaydı oluşturur
+func (r *ViewRepository) Create(view *domain.View) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored := *view
+	stored.ID = r.store.nextID("views")
+	stored.ViewedAt = now()
+	r.store.views[stored.ID] = &stored
+
+	view.ID = stored.ID
+	return nil
+}
+
+// Update, mevcut bir görüntüleme kaydını günceller
+func (r *ViewRepository) Update(view *domain.View) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// GORM Save gibi: kayıt yoksa oluşturulur
+	stored := *view
+	if stored.ID == 0 {
+		stored.ID = r.store.nextID("views")
+		view.ID = stored.ID
+	}
+	r.store.views[stored.ID] = &stored
+	return nil
+}
+
+// Delete, belirtilen ID'ye sahip görüntülemeyi siler
+func (r *ViewRepository) Delete(id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.views, id)
+	return nil
+}
+
+// DeleteByUserIDAndContent, belirtilen kullanıcı ve içerik için görüntülemeyi siler
+func (r *ViewRepository) DeleteByUserIDAndContent(userID, contentID uint, contentType string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, view := range r.store.views {
+		if view.UserID == userID && view.ContentID == contentID && view.Type == contentType {
+			delete(r.store.views, id)
+		}
+	}
+	return nil
+}
+
+// Ensure ViewRepository implements domain.ViewRepository
+var _ domain.ViewRepository = (*ViewRepository)(nil)
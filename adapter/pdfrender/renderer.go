@@ -0,0 +1,101 @@
+// Package pdfrender, PDF sayfalarını Poppler'ın pdftoppm aracıyla JPEG görsellerine dönüştüren
+// domain.PDFRenderer implementasyonunu içerir.
+package pdfrender
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// jpegQuality, üretilen JPEG görsellerinin kalitesi
+const jpegQuality = 80
+
+// Renderer, domain.PDFRenderer arayüzünün pdftoppm tabanlı implementasyonu
+type Renderer struct {
+	command string        // pdftoppm çalıştırılabilir dosyasının adı veya yolu
+	timeout time.Duration // Tek bir dönüştürme için izin verilen en uzun süre
+}
+
+// NewRenderer, yeni bir Renderer örneği oluşturur. Komut PATH üzerinde bulunamazsa hata döner.
+func NewRenderer(command string, timeout time.Duration) (*Renderer, error) {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, fmt.Errorf("PDF görselleştirme aracı bulunamadı (%s): %w", command, err)
+	}
+	return &Renderer{command: path, timeout: timeout}, nil
+}
+
+// RenderPages, dosyayı geçici bir dizine yazar ve istenen sayfaları pdftoppm ile görselleştirir
+func (r *Renderer) RenderPages(fileContent []byte, firstPage, lastPage, width int) ([][]byte, error) {
+	if firstPage < 1 || lastPage < firstPage || width <= 0 {
+		return nil, fmt.Errorf("geçersiz sayfa aralığı veya genişlik: %d-%d, %d", firstPage, lastPage, width)
+	}
+
+	dir, err := os.MkdirTemp("", "uninote-render-*")
+	if err != nil {
+		return nil, fmt.Errorf("geçici dizin oluşturulamadı: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	input := filepath.Join(dir, "input.pdf")
+	if err := os.WriteFile(input, fileContent, 0600); err != nil {
+		return nil, fmt.Errorf("geçici dosya yazılamadı: %w", err)
+	}
+
+	ctx := context.Background()
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	// Çıktılar "page-<n>.jpg" adıyla yazılır; n, belgenin sayfa sayısına göre sıfırla doldurulur
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.command,
+		"-f", strconv.Itoa(firstPage),
+		"-l", strconv.Itoa(lastPage),
+		"-scale-to-x", strconv.Itoa(width),
+		"-scale-to-y", "-1",
+		"-jpeg", "-jpegopt", "quality="+strconv.Itoa(jpegQuality),
+		input, filepath.Join(dir, "page"),
+	)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("PDF görselleştirme zaman aşımına uğradı: %w", ctx.Err())
+		}
+		return nil, fmt.Errorf("PDF görselleştirilemedi: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	outputs, err := filepath.Glob(filepath.Join(dir, "page-*.jpg"))
+	if err != nil {
+		return nil, fmt.Errorf("görseller okunamadı: %w", err)
+	}
+	if len(outputs) == 0 {
+		return nil, fmt.Errorf("PDF görselleştirilemedi: %d-%d aralığında sayfa yok", firstPage, lastPage)
+	}
+	// Sayfa numaraları aynı genişlikte doldurulduğu için ad sırası sayfa sırasıdır
+	sort.Strings(outputs)
+
+	images := make([][]byte, 0, len(outputs))
+	for _, output := range outputs {
+		image, err := os.ReadFile(output)
+		if err != nil {
+			return nil, fmt.Errorf("görseller okunamadı: %w", err)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// Ensure Renderer implements domain.PDFRenderer
+var _ domain.PDFRenderer = (*Renderer)(nil)
@@ -0,0 +1,193 @@
+// Package pdftext, PDF dosyalarından sayfa sayfa metin çıkaran domain.PDFTextExtractor ve
+// yüklenen dosyaları doğrulayan domain.PDFInspector implementasyonlarını içerir. Ayrıştırma saf
+// Go ile (github.com/ledongthuc/pdf) yapılır; taranmış (yalnızca görüntüden oluşan) sayfalardan
+// metin çıkarılamaz.
+package pdftext
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/ledongthuc/pdf"
+)
+
+const (
+	// maxPageTextBytes, bir sayfa için saklanacak en fazla metin uzunluğu
+	maxPageTextBytes = 64 << 10
+	// wordGap, TJ dizilerinde kelime arası boşluk sayılan en küçük kaydırma (em'in binde biri cinsinden)
+	wordGap = 150
+)
+
+// Extractor, domain.PDFTextExtractor arayüzünün saf Go implementasyonu
+type Extractor struct{}
+
+// NewExtractor, yeni bir Extractor örneği oluşturur
+func NewExtractor() *Extractor {
+	return &Extractor{}
+}
+
+// ExtractPages, PDF'in her sayfasının metnini sayfa sırasıyla döndürür.
+// Ayrıştırılamayan sayfalar boş metinle döner; belge hiç açılamazsa hata döner.
+func (e *Extractor) ExtractPages(fileContent []byte) (pages []string, err error) {
+	// Kütüphane bozuk belgelerde panic ile sonlanabilir
+	defer func() {
+		if r := recover(); r != nil {
+			pages = nil
+			err = fmt.Errorf("PDF ayrıştırılamadı: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(fileContent), int64(len(fileContent)))
+	if err != nil {
+		return nil, fmt.Errorf("PDF açılamadı: %w", err)
+	}
+
+	count := reader.NumPage()
+	pages = make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		pages = append(pages, pageText(reader.Page(i)))
+	}
+	return pages, nil
+}
+
+// CountPages, PDF'in sayfa sayısını belge kataloğundan okur
+func (e *Extractor) CountPages(fileContent []byte) (count int, err error) {
+	// Kütüphane bozuk belgelerde panic ile sonlanabilir
+	defer func() {
+		if r := recover(); r != nil {
+			count = 0
+			err = fmt.Errorf("PDF ayrıştırılamadı: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(fileContent), int64(len(fileContent)))
+	if err != nil {
+		return 0, fmt.Errorf("PDF açılamadı: %w", err)
+	}
+	return reader.NumPage(), nil
+}
+
+// rawEncoding, yazı tipi kodlaması bilinmediğinde baytları olduğu gibi metin kabul eder
+type rawEncoding struct{}
+
+// Decode, baytları değiştirmeden döndürür
+func (rawEncoding) Decode(raw string) string {
+	return raw
+}
+
+// pageText, sayfanın içerik akışındaki metin operatörlerini yorumlayarak sayfa metnini çıkarır.
+// Kütüphanenin düz metin çıktısı satır ve kelime aralarını atladığı için satır geçişleri ve
+// kelime aralığı kadar kaydırmalar burada boşluğa çevrilir.
+func pageText(page pdf.Page) (text string) {
+	defer func() {
+		if recover() != nil {
+			text = ""
+		}
+	}()
+
+	if page.V.IsNull() || page.V.Key("Contents").Kind() == pdf.Null {
+		return ""
+	}
+
+	var b strings.Builder
+	var enc pdf.TextEncoding = rawEncoding{}
+	encodings := make(map[string]pdf.TextEncoding)
+	show := func(s string) {
+		b.WriteString(enc.Decode(s))
+	}
+
+	pdf.Interpret(page.V.Key("Contents"), func(stk *pdf.Stack, op string) {
+		args := make([]pdf.Value, stk.Len())
+		for i := len(args) - 1; i >= 0; i-- {
+			args[i] = stk.Pop()
+		}
+
+		switch op {
+		case "Tf": // yazı tipini seç
+			if len(args) != 2 {
+				return
+			}
+			name := args[0].Name()
+			if _, ok := encodings[name]; !ok {
+				encodings[name] = rawEncoding{}
+				if font := page.Font(name); !font.V.IsNull() {
+					encodings[name] = font.Encoder()
+				}
+			}
+			enc = encodings[name]
+		case "BT", "T*", "Tm": // yeni metin nesnesi veya satır
+			b.WriteByte('\n')
+		case "Td", "TD": // konum değiştir: dikey hareket yeni satır, yatay hareket kelime arasıdır
+			if len(args) != 2 {
+				return
+			}
+			if args[1].Float64() != 0 {
+				b.WriteByte('\n')
+			} else if args[0].Float64() > 0 {
+				b.WriteByte(' ')
+			}
+		case "Tj":
+			if len(args) == 1 {
+				show(args[0].RawString())
+			}
+		case "'", "\"": // sonraki satıra geç ve metni yaz
+			if len(args) > 0 {
+				b.WriteByte('\n')
+				show(args[len(args)-1].RawString())
+			}
+		case "TJ": // konumlandırmalı metin; büyük kaydırmalar kelime arasıdır
+			if len(args) != 1 {
+				return
+			}
+			for i := 0; i < args[0].Len(); i++ {
+				x := args[0].Index(i)
+				if x.Kind() == pdf.String {
+					show(x.RawString())
+				} else if x.Float64() <= -wordGap {
+					b.WriteByte(' ')
+				}
+			}
+		}
+	})
+
+	return normalize(b.String())
+}
+
+// normalize, geçersiz UTF-8 ve kontrol karakterlerini temizler, satırlardaki fazla boşlukları
+// tekilleştirir, boş satırları atar ve metni maxPageTextBytes ile sınırlar
+func normalize(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.Map(func(r rune) rune {
+		if r == '\n' {
+			return r
+		}
+		if unicode.IsControl(r) || unicode.IsSpace(r) {
+			return ' '
+		}
+		return r
+	}, text)
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	text = strings.Join(lines, "\n")
+
+	if len(text) > maxPageTextBytes {
+		cut := maxPageTextBytes
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = text[:cut]
+	}
+	return text
+}
+
+// Ensure Extractor implements domain.PDFTextExtractor
+var _ domain.PDFTextExtractor = (*Extractor)(nil)
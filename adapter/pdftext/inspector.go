@@ -0,0 +1,252 @@
+package pdftext
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/ledongthuc/pdf"
+)
+
+const (
+	// headerSize, PDF başlığının ("%PDF-x.y") okunduğu bayt sayısı
+	headerSize = 16
+	// maxScanNodes, etkin içerik aranırken ziyaret edilen en fazla nesne sayısı. Nesne grafiği
+	// döngüler içerebildiği (ör. sayfaların /Parent bağlantıları) ve kütüphane nesne kimliği
+	// vermediği için tarama bu sınırla kesilir.
+	maxScanNodes = 20000
+	// maxActionDepth, birbirine /Next ile bağlı eylem zincirlerinde inilen en fazla derinlik
+	maxActionDepth = 16
+)
+
+// headerPattern, dosya başındaki PDF başlığıyla eşleşir ve sürümü yakalar
+var headerPattern = regexp.MustCompile(`^%PDF-([12]\.[0-9])`)
+
+// Inspector, domain.PDFInspector arayüzünün saf Go implementasyonu. Yapı github.com/ledongthuc/pdf
+// ile ayrıştırılır; etkin içerik belge kataloğu, sayfalar, işaretlemeler, form alanları ve yer
+// imlerindeki eylemler incelenerek aranır.
+type Inspector struct{}
+
+// NewInspector, yeni bir Inspector örneği oluşturur
+func NewInspector() *Inspector {
+	return &Inspector{}
+}
+
+// Inspect, dosyanın başlığını, xref tablosunu ve trailer'ını ayrıştırır; sürümü, sayfa sayısını
+// ve bulunan etkin içerik türlerini döndürür
+func (i *Inspector) Inspect(file io.ReaderAt, size int64) (inspection *domain.PDFInspection, err error) {
+	// Kütüphane bozuk belgelerde panic ile sonlanabilir
+	defer func() {
+		if r := recover(); r != nil {
+			inspection = nil
+			err = fmt.Errorf("%w: %v", domain.ErrPDFMalformed, r)
+		}
+	}()
+
+	header := make([]byte, headerSize)
+	n, _ := file.ReadAt(header, 0)
+	match := headerPattern.FindSubmatch(header[:n])
+	if match == nil {
+		return nil, fmt.Errorf("%w: %%PDF- başlığı bulunamadı", domain.ErrPDFMalformed)
+	}
+	version := string(match[1])
+
+	// Kütüphane yalnızca 1.x başlıklarını tanır; PDF 2.0 dosyaları 1.7 başlığıyla okunur
+	if version[0] == '2' {
+		file = &headerOverride{ReaderAt: file, header: []byte("%PDF-1.7")}
+	}
+
+	reader, err := pdf.NewReader(file, size)
+	if err != nil {
+		if errors.Is(err, pdf.ErrInvalidPassword) || strings.Contains(err.Error(), "encryption") {
+			return nil, fmt.Errorf("%w: %v", domain.ErrPDFEncrypted, err)
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrPDFMalformed, err)
+	}
+
+	// Boş kullanıcı parolasıyla şifrelenmiş dosyalar kütüphane tarafından açılabilir; yine de reddedilir
+	trailer := reader.Trailer()
+	if trailer.Key("Encrypt").Kind() != pdf.Null {
+		return nil, domain.ErrPDFEncrypted
+	}
+
+	catalog := trailer.Key("Root")
+	if catalog.Kind() != pdf.Dict {
+		return nil, fmt.Errorf("%w: belge kataloğu (/Root) bulunamadı", domain.ErrPDFMalformed)
+	}
+
+	// Katalogdaki /Version başlıktaki sürümden yeniyse geçerlidir (PDF 1.4+)
+	if v := catalog.Key("Version").Name(); v > version && headerPattern.MatchString("%PDF-"+v) {
+		version = v
+	}
+
+	pageCount := reader.NumPage()
+	if pageCount == 0 {
+		return nil, fmt.Errorf("%w: belgede sayfa yok", domain.ErrPDFMalformed)
+	}
+
+	return &domain.PDFInspection{
+		Version:       version,
+		PageCount:     pageCount,
+		ActiveContent: scanActiveContent(reader, catalog),
+	}, nil
+}
+
+// scanner, belgedeki etkin içerik türlerini toplar
+type scanner struct {
+	found map[string]bool
+	nodes int
+}
+
+// scanActiveContent, belgenin kod çalıştırabilecek veya dosya açabilecek yerlerini inceler ve
+// bulunan etkin içerik türlerini sıralı döndürür
+func scanActiveContent(reader *pdf.Reader, catalog pdf.Value) []string {
+	s := &scanner{found: make(map[string]bool)}
+
+	// Belge düzeyi: açılış eylemi, ek eylemler, adlandırılmış JavaScript ve gömülü dosyalar
+	s.action(catalog.Key("OpenAction"), 0)
+	s.additionalActions(catalog.Key("AA"))
+	names := catalog.Key("Names")
+	if !s.nameTreeEmpty(names.Key("JavaScript")) {
+		s.found[domain.PDFActiveJavaScript] = true
+	}
+	if !s.nameTreeEmpty(names.Key("EmbeddedFiles")) {
+		s.found[domain.PDFActiveEmbeddedFile] = true
+	}
+
+	// Sayfalar ve işaretlemeler
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i).V
+		s.additionalActions(page.Key("AA"))
+		annots := page.Key("Annots")
+		for j := 0; j < annots.Len() && s.visit(); j++ {
+			s.annotation(annots.Index(j))
+		}
+	}
+
+	// Form alanları
+	fields := catalog.Key("AcroForm").Key("Fields")
+	for i := 0; i < fields.Len(); i++ {
+		s.field(fields.Index(i), 0)
+	}
+
+	// Yer imleri
+	s.outline(catalog.Key("Outlines").Key("First"), 0)
+
+	found := make([]string, 0, len(s.found))
+	for kind := range s.found {
+		found = append(found, kind)
+	}
+	sort.Strings(found)
+	return found
+}
+
+// visit, ziyaret sınırı aşılmadıysa sayacı artırır ve true döndürür
+func (s *scanner) visit() bool {
+	s.nodes++
+	return s.nodes <= maxScanNodes
+}
+
+// action, bir eylem sözlüğünü (veya eylem dizisini) ve /Next ile bağlı eylemleri inceler
+func (s *scanner) action(action pdf.Value, depth int) {
+	if depth > maxActionDepth || !s.visit() {
+		return
+	}
+	switch action.Kind() {
+	case pdf.Array:
+		for i := 0; i < action.Len(); i++ {
+			s.action(action.Index(i), depth+1)
+		}
+		return
+	case pdf.Dict:
+	default:
+		return
+	}
+
+	switch action.Key("S").Name() {
+	case "JavaScript":
+		s.found[domain.PDFActiveJavaScript] = true
+	case "Launch":
+		s.found[domain.PDFActiveLaunch] = true
+	}
+	if action.Key("JS").Kind() != pdf.Null {
+		s.found[domain.PDFActiveJavaScript] = true
+	}
+	s.action(action.Key("Next"), depth+1)
+}
+
+// additionalActions, /AA (tetikleyiciye bağlı ek eylemler) sözlüğündeki tüm eylemleri inceler
+func (s *scanner) additionalActions(aa pdf.Value) {
+	for _, trigger := range aa.Keys() {
+		s.action(aa.Key(trigger), 0)
+	}
+}
+
+// annotation, bir işaretlemenin eylemlerini ve dosya eklerini inceler
+func (s *scanner) annotation(annot pdf.Value) {
+	if annot.Key("Subtype").Name() == "FileAttachment" || annot.Key("FS").Kind() != pdf.Null {
+		s.found[domain.PDFActiveEmbeddedFile] = true
+	}
+	s.action(annot.Key("A"), 0)
+	s.additionalActions(annot.Key("AA"))
+}
+
+// field, bir form alanının ve alt alanlarının eylemlerini inceler
+func (s *scanner) field(field pdf.Value, depth int) {
+	if depth > maxActionDepth || !s.visit() {
+		return
+	}
+	s.action(field.Key("A"), 0)
+	s.additionalActions(field.Key("AA"))
+	kids := field.Key("Kids")
+	for i := 0; i < kids.Len(); i++ {
+		s.field(kids.Index(i), depth+1)
+	}
+}
+
+// outline, yer imi ağacındaki öğelerin eylemlerini inceler
+func (s *scanner) outline(item pdf.Value, depth int) {
+	for ; item.Kind() == pdf.Dict && depth <= maxActionDepth && s.visit(); item = item.Key("Next") {
+		s.action(item.Key("A"), 0)
+		s.outline(item.Key("First"), depth+1)
+	}
+}
+
+// nameTreeEmpty, bir ad ağacında (/Names veya /Kids içeren) hiç kayıt olmadığını kontrol eder
+func (s *scanner) nameTreeEmpty(tree pdf.Value) bool {
+	if tree.Kind() != pdf.Dict || !s.visit() {
+		return true
+	}
+	if tree.Key("Names").Len() > 0 {
+		return false
+	}
+	kids := tree.Key("Kids")
+	for i := 0; i < kids.Len(); i++ {
+		if !s.nameTreeEmpty(kids.Index(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+// headerOverride, dosyanın ilk baytlarını verilen başlıkla değiştirerek okur
+type headerOverride struct {
+	io.ReaderAt
+	header []byte
+}
+
+// ReadAt, io.ReaderAt arayüzünü uygular
+func (h *headerOverride) ReadAt(p []byte, off int64) (int, error) {
+	n, err := h.ReaderAt.ReadAt(p, off)
+	if off < int64(len(h.header)) {
+		copy(p[:n], h.header[off:])
+	}
+	return n, err
+}
+
+// Ensure Inspector implements domain.PDFInspector
+var _ domain.PDFInspector = (*Inspector)(nil)
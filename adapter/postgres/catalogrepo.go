@@ -0,0 +1,599 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// UniversityModel, üniversitelerin veritabanı modeli
+type UniversityModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"size:200;not null"`
+	ShortName string `gorm:"size:50"`
+	NameKey   string `gorm:"size:200;not null;index"` // Sıralama için adın domain.CatalogKey'i
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName, tablo adını belirtir
+func (UniversityModel) TableName() string {
+	return "universities"
+}
+
+// UniversityNameModel, bir üniversitenin aranabilir adlarından (ad, kısa ad ve diğer adlar) biridir.
+// LookupKey, domain.CatalogKey ile üretilir ve tüm üniversiteler arasında benzersizdir.
+type UniversityNameModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	UniversityID uint   `gorm:"not null;index"`
+	Name         string `gorm:"size:200;not null"`
+	LookupKey    string `gorm:"size:200;not null;uniqueIndex"`
+}
+
+// TableName, tablo adını belirtir
+func (UniversityNameModel) TableName() string {
+	return "university_names"
+}
+
+// ToEntity, veritabanı modelini ve adlarını domain entity'sine dönüştürür. Ad ve kısa ad dışındaki
+// adlar Aliases alanına eklenir.
+func (m *UniversityModel) ToEntity(names []UniversityNameModel) *domain.University {
+	university := &domain.University{
+		ID:        m.ID,
+		Name:      m.Name,
+		ShortName: m.ShortName,
+		Aliases:   []string{},
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+	nameKey, shortKey := domain.CatalogKey(m.Name), domain.CatalogKey(m.ShortName)
+	for _, name := range names {
+		if name.LookupKey != nameKey && name.LookupKey != shortKey {
+			university.Aliases = append(university.Aliases, name.Name)
+		}
+	}
+	return university
+}
+
+// DepartmentModel, bölümlerin veritabanı modeli
+type DepartmentModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	UniversityID uint   `gorm:"not null;uniqueIndex:idx_department_key"`
+	Name         string `gorm:"size:200;not null"`
+	LookupKey    string `gorm:"size:200;not null;uniqueIndex:idx_department_key"`
+	CreatedAt    time.Time
+}
+
+// TableName, tablo adını belirtir
+func (DepartmentModel) TableName() string {
+	return "departments"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *DepartmentModel) ToEntity() *domain.Department {
+	return &domain.Department{
+		ID:           m.ID,
+		UniversityID: m.UniversityID,
+		Name:         m.Name,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// CourseModel, derslerin veritabanı modeli
+type CourseModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	DepartmentID uint   `gorm:"not null;uniqueIndex:idx_course_code"`
+	Code         string `gorm:"size:20;not null"`
+	CodeKey      string `gorm:"size:20;not null;uniqueIndex:idx_course_code"`
+	Name         string `gorm:"size:200;not null"`
+	CreatedAt    time.Time
+}
+
+// TableName, tablo adını belirtir
+func (CourseModel) TableName() string {
+	return "courses"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *CourseModel) ToEntity() *domain.Course {
+	return &domain.Course{
+		ID:           m.ID,
+		DepartmentID: m.DepartmentID,
+		Code:         m.Code,
+		Name:         m.Name,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// TermModel, akademik dönemlerin veritabanı modeli
+type TermModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	Year      int    `gorm:"not null;uniqueIndex:idx_term_year_season"`
+	Season    string `gorm:"size:10;not null;uniqueIndex:idx_term_year_season"`
+	Name      string `gorm:"size:50;not null"`
+	CreatedAt time.Time
+}
+
+// TableName, tablo adını belirtir
+func (TermModel) TableName() string {
+	return "terms"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *TermModel) ToEntity() *domain.Term {
+	return &domain.Term{
+		ID:        m.ID,
+		Year:      m.Year,
+		Season:    m.Season,
+		Name:      m.Name,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// CourseContentModel, içeriklerin ders bağlantılarının veritabanı modeli. Bir içerik en fazla bir derse bağlanabilir.
+type CourseContentModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	CourseID    uint   `gorm:"not null;index:idx_course_content_week"`
+	TermID      *uint  `gorm:"index"`
+	ContentID   uint   `gorm:"not null;uniqueIndex:idx_course_content"`
+	ContentType string `gorm:"size:10;not null;uniqueIndex:idx_course_content"` // "note" veya "pdf"
+	Week        int    `gorm:"not null;index:idx_course_content_week"`
+	Topic       string `gorm:"size:200"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName, tablo adını belirtir
+func (CourseContentModel) TableName() string {
+	return "course_contents"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *CourseContentModel) ToEntity() *domain.CourseContent {
+	return &domain.CourseContent{
+		ID:          m.ID,
+		CourseID:    m.CourseID,
+		TermID:      m.TermID,
+		ContentID:   m.ContentID,
+		ContentType: m.ContentType,
+		Week:        m.Week,
+		Topic:       m.Topic,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// CatalogRepository, domain.CatalogRepository arayüzünün PostgreSQL implementasyonu
+type CatalogRepository struct {
+	db *gorm.DB
+}
+
+// NewCatalogRepository, yeni bir CatalogRepository örneği oluşturur
+func NewCatalogRepository(db *gorm.DB) *CatalogRepository {
+	return &CatalogRepository{db: db}
+}
+
+// universityEntities, üniversite modellerini adlarıyla birlikte domain entity'lerine dönüştürür
+func (r *CatalogRepository) universityEntities(models []UniversityModel) ([]*domain.University, error) {
+	universities := make([]*domain.University, 0, len(models))
+	if len(models) == 0 {
+		return universities, nil
+	}
+
+	ids := make([]uint, len(models))
+	for i := range models {
+		ids[i] = models[i].ID
+	}
+	var names []UniversityNameModel
+	if err := r.db.Where("university_id IN ?", ids).Order("id").Find(&names).Error; err != nil {
+		return nil, err
+	}
+	byUniversity := make(map[uint][]UniversityNameModel, len(models))
+	for _, name := range names {
+		byUniversity[name.UniversityID] = append(byUniversity[name.UniversityID], name)
+	}
+
+	for i := range models {
+		universities = append(universities, models[i].ToEntity(byUniversity[models[i].ID]))
+	}
+	return universities, nil
+}
+
+// findUniversity, koşula uyan ilk üniversiteyi adlarıyla birlikte getirir
+func (r *CatalogRepository) findUniversity(query interface{}, args ...interface{}) (*domain.University, error) {
+	var model UniversityModel
+	if err := r.db.Where(query, args...).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Üniversite bulunamadı
+		}
+		return nil, err
+	}
+	universities, err := r.universityEntities([]UniversityModel{model})
+	if err != nil {
+		return nil, err
+	}
+	return universities[0], nil
+}
+
+// FindUniversityByID, ID'ye göre üniversiteyi bulur
+func (r *CatalogRepository) FindUniversityByID(id uint) (*domain.University, error) {
+	return r.findUniversity("id = ?", id)
+}
+
+// FindUniversityByName, adlarından biri verilen adla eşleşen üniversiteyi bulur
+func (r *CatalogRepository) FindUniversityByName(name string) (*domain.University, error) {
+	key := domain.CatalogKey(name)
+	if key == "" {
+		return nil, nil
+	}
+	return r.findUniversity("id IN (?)", r.db.Model(&UniversityNameModel{}).Select("university_id").Where("lookup_key = ?", key))
+}
+
+// FindUniversities, adlarından biri sorguyu içeren üniversiteleri ada göre (aksanlar yok sayılarak) sıralı getirir
+func (r *CatalogRepository) FindUniversities(query string, limit, offset int) ([]*domain.University, error) {
+	db := r.db.Model(&UniversityModel{})
+	if key := domain.CatalogKey(query); key != "" {
+		// Anahtarlar yalnızca harf ve rakamdan oluştuğu için LIKE kaçışı gerekmez
+		db = db.Where("id IN (?)", r.db.Model(&UniversityNameModel{}).Select("university_id").Where("lookup_key LIKE ?", "%"+key+"%"))
+	}
+
+	var models []UniversityModel
+	if err := db.Order("name_key, id").Limit(limit).Offset(offset).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	return r.universityEntities(models)
+}
+
+// universityNameModels, üniversitenin tüm adları için arama kayıtlarını oluşturur
+func universityNameModels(university *domain.University) []UniversityNameModel {
+	names := university.Names()
+	models := make([]UniversityNameModel, 0, len(names))
+	for _, name := range names {
+		models = append(models, UniversityNameModel{
+			UniversityID: university.ID,
+			Name:         name,
+			LookupKey:    domain.CatalogKey(name),
+		})
+	}
+	return models
+}
+
+// CreateUniversity, yeni bir üniversiteyi adlarıyla birlikte oluşturur
+func (r *CatalogRepository) CreateUniversity(university *domain.University) error {
+	model := UniversityModel{
+		Name:      university.Name,
+		ShortName: university.ShortName,
+		NameKey:   domain.CatalogKey(university.Name),
+	}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		university.ID = model.ID
+		return tx.Create(universityNameModels(university)).Error
+	})
+	if err != nil {
+		university.ID = 0
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("üniversite oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// Zaman damgalarını güncelle
+	university.CreatedAt = model.CreatedAt
+	university.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// UpdateUniversity, üniversitenin adını, kısa adını ve diğer adlarını günceller
+func (r *CatalogRepository) UpdateUniversity(university *domain.University) error {
+	updatedAt := time.Now()
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&UniversityModel{}).Where("id = ?", university.ID).Updates(map[string]interface{}{
+			"name":       university.Name,
+			"short_name": university.ShortName,
+			"name_key":   domain.CatalogKey(university.Name),
+			"updated_at": updatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("university_id = ?", university.ID).Delete(&UniversityNameModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(universityNameModels(university)).Error
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("üniversite güncelleme hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+	university.UpdatedAt = updatedAt
+	return nil
+}
+
+// FindDepartmentByID, ID'ye göre bölümü bulur
+func (r *CatalogRepository) FindDepartmentByID(id uint) (*domain.Department, error) {
+	var model DepartmentModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Bölüm bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindDepartmentByName, üniversitede adı verilen adla eşleşen bölümü bulur
+func (r *CatalogRepository) FindDepartmentByName(universityID uint, name string) (*domain.Department, error) {
+	var model DepartmentModel
+	if err := r.db.Where("university_id = ? AND lookup_key = ?", universityID, domain.CatalogKey(name)).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Bölüm bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindDepartments, üniversitenin bölümlerini ada göre sıralı getirir
+func (r *CatalogRepository) FindDepartments(universityID uint, limit, offset int) ([]*domain.Department, error) {
+	var models []DepartmentModel
+	result := r.db.Where("university_id = ?", universityID).
+		Order("lookup_key, id").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	departments := make([]*domain.Department, 0, len(models))
+	for i := range models {
+		departments = append(departments, models[i].ToEntity())
+	}
+	return departments, nil
+}
+
+// CreateDepartment, yeni bir bölüm oluşturur
+func (r *CatalogRepository) CreateDepartment(department *domain.Department) error {
+	model := DepartmentModel{
+		UniversityID: department.UniversityID,
+		Name:         department.Name,
+		LookupKey:    domain.CatalogKey(department.Name),
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("bölüm oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// ID'yi ve zaman damgasını güncelle
+	department.ID = model.ID
+	department.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// FindCourseByID, ID'ye göre dersi bulur
+func (r *CatalogRepository) FindCourseByID(id uint) (*domain.Course, error) {
+	var model CourseModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Ders bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindCourseByCode, bölümde kodu verilen kodla eşleşen dersi bulur
+func (r *CatalogRepository) FindCourseByCode(departmentID uint, code string) (*domain.Course, error) {
+	var model CourseModel
+	if err := r.db.Where("department_id = ? AND code_key = ?", departmentID, domain.CatalogKey(code)).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Ders bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindCourses, bölümün derslerini koda göre sıralı getirir
+func (r *CatalogRepository) FindCourses(departmentID uint, limit, offset int) ([]*domain.Course, error) {
+	var models []CourseModel
+	result := r.db.Where("department_id = ?", departmentID).
+		Order("code_key, id").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	courses := make([]*domain.Course, 0, len(models))
+	for i := range models {
+		courses = append(courses, models[i].ToEntity())
+	}
+	return courses, nil
+}
+
+// CreateCourse, yeni bir ders oluşturur
+func (r *CatalogRepository) CreateCourse(course *domain.Course) error {
+	model := CourseModel{
+		DepartmentID: course.DepartmentID,
+		Code:         course.Code,
+		CodeKey:      domain.CatalogKey(course.Code),
+		Name:         course.Name,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("ders oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// ID'yi ve zaman damgasını güncelle
+	course.ID = model.ID
+	course.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// FindTermByID, ID'ye göre dönemi bulur
+func (r *CatalogRepository) FindTermByID(id uint) (*domain.Term, error) {
+	var model TermModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Dönem bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindTerm, yıl ve türe göre dönemi bulur
+func (r *CatalogRepository) FindTerm(year int, season string) (*domain.Term, error) {
+	var model TermModel
+	if err := r.db.Where("year = ? AND season = ?", year, season).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Dönem bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindTerms, dönemleri en yeniden başlayarak getirir
+func (r *CatalogRepository) FindTerms(limit, offset int) ([]*domain.Term, error) {
+	var models []TermModel
+	// Aynı akademik yılda yaz dönemi bahardan, bahar da güzden sonra gelir
+	result := r.db.Order("year DESC").
+		Order(fmt.Sprintf("CASE season WHEN '%s' THEN 0 WHEN '%s' THEN 1 ELSE 2 END", domain.TermSeasonSummer, domain.TermSeasonSpring)).
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	terms := make([]*domain.Term, 0, len(models))
+	for i := range models {
+		terms = append(terms, models[i].ToEntity())
+	}
+	return terms, nil
+}
+
+// CreateTerm, yeni bir dönem oluşturur
+func (r *CatalogRepository) CreateTerm(term *domain.Term) error {
+	model := TermModel{
+		Year:   term.Year,
+		Season: term.Season,
+		Name:   term.Name,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("dönem oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// ID'yi ve zaman damgasını güncelle
+	term.ID = model.ID
+	term.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// FindCourseContent, içeriğin ders bağlantısını bulur
+func (r *CatalogRepository) FindCourseContent(contentID uint, contentType string) (*domain.CourseContent, error) {
+	var model CourseContentModel
+	if err := r.db.Where("content_id = ? AND content_type = ?", contentID, contentType).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Bağlantı bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// SaveCourseContent, içeriğin ders bağlantısını oluşturur veya mevcut bağlantıyı değiştirir
+func (r *CatalogRepository) SaveCourseContent(content *domain.CourseContent) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var model CourseContentModel
+		err := tx.Where("content_id = ? AND content_type = ?", content.ContentID, content.ContentType).First(&model).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		model.CourseID = content.CourseID
+		model.TermID = content.TermID
+		model.ContentID = content.ContentID
+		model.ContentType = content.ContentType
+		model.Week = content.Week
+		model.Topic = content.Topic
+		if err := tx.Save(&model).Error; err != nil {
+			return err
+		}
+
+		// ID'yi ve zaman damgalarını güncelle
+		content.ID = model.ID
+		content.CreatedAt = model.CreatedAt
+		content.UpdatedAt = model.UpdatedAt
+		return nil
+	})
+}
+
+// DeleteCourseContent, içeriğin ders bağlantısını siler
+func (r *CatalogRepository) DeleteCourseContent(contentID uint, contentType string) error {
+	return r.db.Where("content_id = ? AND content_type = ?", contentID, contentType).Delete(&CourseContentModel{}).Error
+}
+
+// courseContentQuery, derse bağlı ve görüntüleyenin erişebildiği içerikleri seçen sorguyu oluşturur
+func (r *CatalogRepository) courseContentQuery(table, contentType string, filter *domain.CourseContentFilter) *gorm.DB {
+	db := r.db.Preload("Tags").
+		Joins(fmt.Sprintf("JOIN course_contents ON course_contents.content_id = %s.id AND course_contents.content_type = ?", table), contentType).
+		Where("course_contents.course_id = ?", filter.CourseID)
+	if filter.TermID != 0 {
+		db = db.Where("course_contents.term_id = ?", filter.TermID)
+	}
+	if filter.Week != 0 {
+		db = db.Where("course_contents.week = ?", filter.Week)
+	}
+	if filter.ViewerID != 0 {
+		db = db.Where(fmt.Sprintf("(%[1]s.is_public = ? OR %[1]s.user_id = ?)", table), true, filter.ViewerID)
+	} else {
+		db = db.Where(table+".is_public = ?", true)
+	}
+	return db.Order("course_contents.week, course_contents.id").Limit(filter.Limit).Offset(filter.Offset)
+}
+
+// FindCourseNotes, derse bağlı notları haftaya göre sıralı getirir
+func (r *CatalogRepository) FindCourseNotes(filter *domain.CourseContentFilter) ([]*domain.Note, error) {
+	var models []NoteModel
+	if err := r.courseContentQuery("note_models", "note", filter).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	notes := make([]*domain.Note, 0, len(models))
+	for _, note := range models {
+		notes = append(notes, note.ToEntity())
+	}
+	return notes, nil
+}
+
+// FindCoursePDFs, derse bağlı PDF'leri haftaya göre sıralı getirir
+func (r *CatalogRepository) FindCoursePDFs(filter *domain.CourseContentFilter) ([]*domain.PDF, error) {
+	var models []PDFModel
+	if err := r.courseContentQuery("pdf_models", "pdf", filter).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	pdfs := make([]*domain.PDF, 0, len(models))
+	for _, pdf := range models {
+		pdfs = append(pdfs, pdf.ToEntity())
+	}
+	return pdfs, nil
+}
+
+// Ensure CatalogRepository implements domain.CatalogRepository
+var _ domain.CatalogRepository = (*CatalogRepository)(nil)
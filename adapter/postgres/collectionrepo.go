@@ -0,0 +1,357 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// CollectionModel, koleksiyonların veritabanı modeli
+type CollectionModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index"`
+	Name        string `gorm:"size:100;not null"`
+	Description string `gorm:"type:text"`
+	IsPublic    bool   `gorm:"not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName, tablo adını belirtir
+func (CollectionModel) TableName() string {
+	return "collections"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *CollectionModel) ToEntity() *domain.Collection {
+	return &domain.Collection{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Name:        m.Name,
+		Description: m.Description,
+		IsPublic:    m.IsPublic,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// CollectionFolderModel, koleksiyon klasörlerinin veritabanı modeli
+type CollectionFolderModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	CollectionID uint   `gorm:"not null;index"`
+	ParentID     *uint  `gorm:"index"`
+	Name         string `gorm:"size:100;not null"`
+	Position     int    `gorm:"not null"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// TableName, tablo adını belirtir
+func (CollectionFolderModel) TableName() string {
+	return "collection_folders"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *CollectionFolderModel) ToEntity() *domain.CollectionFolder {
+	return &domain.CollectionFolder{
+		ID:           m.ID,
+		CollectionID: m.CollectionID,
+		ParentID:     m.ParentID,
+		Name:         m.Name,
+		Position:     m.Position,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+// CollectionItemModel, koleksiyon öğelerinin veritabanı modeli. Bir içerik aynı koleksiyona
+// yalnızca bir kez eklenebilir; içerik silindiğinde öğeler içerik sütunlarıyla bulunur.
+type CollectionItemModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	CollectionID uint   `gorm:"not null;uniqueIndex:idx_collection_item_content"`
+	FolderID     *uint  `gorm:"index"`
+	ContentID    uint   `gorm:"not null;uniqueIndex:idx_collection_item_content;index:idx_collection_item_ref"`
+	ContentType  string `gorm:"size:10;not null;uniqueIndex:idx_collection_item_content;index:idx_collection_item_ref"` // "note" veya "pdf"
+	Position     int    `gorm:"not null"`
+	CreatedAt    time.Time
+}
+
+// TableName, tablo adını belirtir
+func (CollectionItemModel) TableName() string {
+	return "collection_items"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *CollectionItemModel) ToEntity() *domain.CollectionItem {
+	return &domain.CollectionItem{
+		ID:           m.ID,
+		CollectionID: m.CollectionID,
+		FolderID:     m.FolderID,
+		ContentID:    m.ContentID,
+		ContentType:  m.ContentType,
+		Position:     m.Position,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// CollectionRepository, domain.CollectionRepository arayüzünün PostgreSQL implementasyonu
+type CollectionRepository struct {
+	db *gorm.DB
+}
+
+// NewCollectionRepository, yeni bir CollectionRepository örneği oluşturur
+func NewCollectionRepository(db *gorm.DB) *CollectionRepository {
+	return &CollectionRepository{db: db}
+}
+
+// FindByID, ID'ye göre koleksiyonu bulur
+func (r *CollectionRepository) FindByID(id uint) (*domain.Collection, error) {
+	var model CollectionModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Koleksiyon bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindByUserID, kullanıcının koleksiyonlarını son güncellenenden başlayarak getirir
+func (r *CollectionRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.Collection, error) {
+	var models []CollectionModel
+	result := r.db.Where("user_id = ?", userID).
+		Order("updated_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	collections := make([]*domain.Collection, 0, len(models))
+	for i := range models {
+		collections = append(collections, models[i].ToEntity())
+	}
+	return collections, nil
+}
+
+// Create, yeni bir koleksiyon oluşturur
+func (r *CollectionRepository) Create(collection *domain.Collection) error {
+	model := CollectionModel{
+		UserID:      collection.UserID,
+		Name:        collection.Name,
+		Description: collection.Description,
+		IsPublic:    collection.IsPublic,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// ID'yi ve zaman damgalarını güncelle
+	collection.ID = model.ID
+	collection.CreatedAt = model.CreatedAt
+	collection.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Update, koleksiyonun adını, açıklamasını ve görünürlüğünü günceller
+func (r *CollectionRepository) Update(collection *domain.Collection) error {
+	updatedAt := time.Now()
+	result := r.db.Model(&CollectionModel{}).Where("id = ?", collection.ID).Updates(map[string]interface{}{
+		"name":        collection.Name,
+		"description": collection.Description,
+		"is_public":   collection.IsPublic,
+		"updated_at":  updatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	collection.UpdatedAt = updatedAt
+	return nil
+}
+
+// Delete, koleksiyonu klasörleri, öğeleri ve bildirimleriyle birlikte siler
+func (r *CollectionRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ?", id).Delete(&CollectionItemModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("collection_id = ?", id).Delete(&CollectionFolderModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("content_id = ? AND content_type = ?", id, "collection").Delete(&NotificationModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&CollectionModel{}, id).Error
+	})
+}
+
+// FindFolderByID, ID'ye göre klasörü bulur
+func (r *CollectionRepository) FindFolderByID(id uint) (*domain.CollectionFolder, error) {
+	var model CollectionFolderModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Klasör bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindFolders, koleksiyonun tüm klasörlerini konuma göre sıralı getirir
+func (r *CollectionRepository) FindFolders(collectionID uint) ([]*domain.CollectionFolder, error) {
+	var models []CollectionFolderModel
+	result := r.db.Where("collection_id = ?", collectionID).Order("position, id").Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	folders := make([]*domain.CollectionFolder, 0, len(models))
+	for i := range models {
+		folders = append(folders, models[i].ToEntity())
+	}
+	return folders, nil
+}
+
+// CreateFolder, yeni bir klasör oluşturur
+func (r *CollectionRepository) CreateFolder(folder *domain.CollectionFolder) error {
+	model := CollectionFolderModel{
+		CollectionID: folder.CollectionID,
+		ParentID:     folder.ParentID,
+		Name:         folder.Name,
+		Position:     folder.Position,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// ID'yi ve zaman damgalarını güncelle
+	folder.ID = model.ID
+	folder.CreatedAt = model.CreatedAt
+	folder.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// UpdateFolder, klasörün adını, üst klasörünü ve konumunu günceller
+func (r *CollectionRepository) UpdateFolder(folder *domain.CollectionFolder) error {
+	updatedAt := time.Now()
+	result := r.db.Model(&CollectionFolderModel{}).Where("id = ?", folder.ID).Updates(map[string]interface{}{
+		"name":       folder.Name,
+		"parent_id":  folder.ParentID,
+		"position":   folder.Position,
+		"updated_at": updatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	folder.UpdatedAt = updatedAt
+	return nil
+}
+
+// DeleteFolders, verilen klasörleri ve içlerindeki öğeleri siler
+func (r *CollectionRepository) DeleteFolders(collectionID uint, folderIDs []uint) error {
+	if len(folderIDs) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("collection_id = ? AND folder_id IN ?", collectionID, folderIDs).Delete(&CollectionItemModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("collection_id = ? AND id IN ?", collectionID, folderIDs).Delete(&CollectionFolderModel{}).Error
+	})
+}
+
+// ArrangeFolders, klasörleri verilen üst klasöre taşır ve verilen sırayla konumlarını numaralar
+func (r *CollectionRepository) ArrangeFolders(collectionID uint, parentID *uint, folderIDs []uint) error {
+	updatedAt := time.Now()
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range folderIDs {
+			if err := tx.Model(&CollectionFolderModel{}).
+				Where("id = ? AND collection_id = ?", id, collectionID).
+				Updates(map[string]interface{}{
+					"parent_id":  parentID,
+					"position":   position,
+					"updated_at": updatedAt,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindItemByID, ID'ye göre koleksiyon öğesini bulur
+func (r *CollectionRepository) FindItemByID(id uint) (*domain.CollectionItem, error) {
+	var model CollectionItemModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Öğe bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindItems, koleksiyonun tüm öğelerini konuma göre sıralı getirir
+func (r *CollectionRepository) FindItems(collectionID uint) ([]*domain.CollectionItem, error) {
+	var models []CollectionItemModel
+	result := r.db.Where("collection_id = ?", collectionID).Order("position, id").Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	items := make([]*domain.CollectionItem, 0, len(models))
+	for i := range models {
+		items = append(items, models[i].ToEntity())
+	}
+	return items, nil
+}
+
+// CreateItem, koleksiyona yeni bir öğe ekler; içerik koleksiyonda zaten varsa ErrDuplicateEntry döner
+func (r *CollectionRepository) CreateItem(item *domain.CollectionItem) error {
+	model := CollectionItemModel{
+		CollectionID: item.CollectionID,
+		FolderID:     item.FolderID,
+		ContentID:    item.ContentID,
+		ContentType:  item.ContentType,
+		Position:     item.Position,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("koleksiyon öğesi oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// ID'yi ve zaman damgasını güncelle
+	item.ID = model.ID
+	item.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// DeleteItem, koleksiyon öğesini siler
+func (r *CollectionRepository) DeleteItem(id uint) error {
+	return r.db.Delete(&CollectionItemModel{}, id).Error
+}
+
+// ArrangeItems, öğeleri verilen klasöre taşır ve verilen sırayla konumlarını numaralar
+func (r *CollectionRepository) ArrangeItems(collectionID uint, folderID *uint, itemIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for position, id := range itemIDs {
+			if err := tx.Model(&CollectionItemModel{}).
+				Where("id = ? AND collection_id = ?", id, collectionID).
+				Updates(map[string]interface{}{
+					"folder_id": folderID,
+					"position":  position,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Ensure CollectionRepository implements domain.CollectionRepository
+var _ domain.CollectionRepository = (*CollectionRepository)(nil)
@@ -1,8 +1,10 @@
 package postgres
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -27,7 +29,8 @@ func NewConnection(config *Config) (*gorm.DB, error) {
 	)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("veritabani bağlantisi kurulamadi: %w", err)
@@ -46,12 +49,12 @@ func Close(db *gorm.DB) error {
 	return sqlDB.Close()
 }
 
-// Migrate, veritabanı şemasını oluşturur veya günceller
-func Migrate(db *gorm.DB, models ...interface{}) error {
-	err := db.AutoMigrate(models...)
-	if err != nil {
-		return fmt.Errorf("veritabanı migrasyonu başarısız: %w", err)
+// isDuplicateKeyError, hatanın benzersiz indeks ihlalinden kaynaklanıp kaynaklanmadığını kontrol eder
+func isDuplicateKeyError(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
 	}
-	log.Println("Veritabanı şeması başarıyla oluşturuldu/güncellendi")
-	return nil
+	// TranslateError kapalı bağlantılar için sürücü mesajlarını kontrol et
+	message := err.Error()
+	return strings.Contains(message, "SQLSTATE 23505") || strings.Contains(message, "UNIQUE constraint failed")
 }
@@ -0,0 +1,523 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// GroupModel, çalışma gruplarının veritabanı modeli
+type GroupModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	OwnerID     uint   `gorm:"not null;index"`
+	Name        string `gorm:"size:100;not null"`
+	Description string `gorm:"type:text"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName, tablo adını belirtir
+func (GroupModel) TableName() string {
+	return "study_groups"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *GroupModel) ToEntity() *domain.Group {
+	return &domain.Group{
+		ID:          m.ID,
+		OwnerID:     m.OwnerID,
+		Name:        m.Name,
+		Description: m.Description,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// GroupMemberModel, grup üyeliklerinin veritabanı modeli. Bir kullanıcı bir grubun yalnızca bir kez üyesi olabilir.
+type GroupMemberModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	GroupID   uint   `gorm:"not null;uniqueIndex:idx_group_member"`
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_group_member;index"`
+	Role      string `gorm:"size:10;not null"` // "owner", "admin" veya "member"
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName, tablo adını belirtir
+func (GroupMemberModel) TableName() string {
+	return "group_members"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *GroupMemberModel) ToEntity() *domain.GroupMember {
+	return &domain.GroupMember{
+		ID:        m.ID,
+		GroupID:   m.GroupID,
+		UserID:    m.UserID,
+		Role:      m.Role,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// GroupJoinRequestModel, gruba katılma isteklerinin veritabanı modeli
+type GroupJoinRequestModel struct {
+	ID         uint   `gorm:"primaryKey"`
+	GroupID    uint   `gorm:"not null;index:idx_group_join_request"`
+	UserID     uint   `gorm:"not null;index"`
+	Message    string `gorm:"size:500"`
+	Status     string `gorm:"size:10;not null;index:idx_group_join_request"` // "pending", "approved" veya "rejected"
+	ReviewedBy *uint
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// TableName, tablo adını belirtir
+func (GroupJoinRequestModel) TableName() string {
+	return "group_join_requests"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *GroupJoinRequestModel) ToEntity() *domain.GroupJoinRequest {
+	return &domain.GroupJoinRequest{
+		ID:         m.ID,
+		GroupID:    m.GroupID,
+		UserID:     m.UserID,
+		Message:    m.Message,
+		Status:     m.Status,
+		ReviewedBy: m.ReviewedBy,
+		CreatedAt:  m.CreatedAt,
+		UpdatedAt:  m.UpdatedAt,
+	}
+}
+
+// GroupContentModel, grup alanına paylaşılan içeriklerin veritabanı modeli. Bir içerik aynı gruba
+// yalnızca bir kez paylaşılabilir; içerik silindiğinde paylaşımlar içerik sütunlarıyla bulunur.
+type GroupContentModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	GroupID     uint   `gorm:"not null;uniqueIndex:idx_group_content"`
+	ContentID   uint   `gorm:"not null;uniqueIndex:idx_group_content;index:idx_group_content_ref"`
+	ContentType string `gorm:"size:10;not null;uniqueIndex:idx_group_content;index:idx_group_content_ref"` // "note" veya "pdf"
+	UserID      uint   `gorm:"not null"`
+	CreatedAt   time.Time
+}
+
+// TableName, tablo adını belirtir
+func (GroupContentModel) TableName() string {
+	return "group_contents"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *GroupContentModel) ToEntity() *domain.GroupContent {
+	return &domain.GroupContent{
+		ID:          m.ID,
+		GroupID:     m.GroupID,
+		ContentID:   m.ContentID,
+		ContentType: m.ContentType,
+		UserID:      m.UserID,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// GroupActivityModel, grup etkinliklerinin veritabanı modeli
+type GroupActivityModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	GroupID      uint   `gorm:"not null;index"`
+	UserID       uint   `gorm:"not null"`
+	Type         string `gorm:"size:20;not null"`
+	TargetUserID uint
+	ContentID    uint
+	ContentType  string `gorm:"size:10"`
+	Title        string `gorm:"size:255"`
+	Role         string `gorm:"size:10"`
+	CreatedAt    time.Time
+}
+
+// TableName, tablo adını belirtir
+func (GroupActivityModel) TableName() string {
+	return "group_activities"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *GroupActivityModel) ToEntity() *domain.GroupActivity {
+	return &domain.GroupActivity{
+		ID:           m.ID,
+		GroupID:      m.GroupID,
+		UserID:       m.UserID,
+		Type:         m.Type,
+		TargetUserID: m.TargetUserID,
+		ContentID:    m.ContentID,
+		ContentType:  m.ContentType,
+		Title:        m.Title,
+		Role:         m.Role,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// GroupRepository, domain.GroupRepository arayüzünün PostgreSQL implementasyonu
+type GroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupRepository, yeni bir GroupRepository örneği oluşturur
+func NewGroupRepository(db *gorm.DB) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// FindByID, ID'ye göre grubu bulur
+func (r *GroupRepository) FindByID(id uint) (*domain.Group, error) {
+	var model GroupModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Grup bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindByUserID, kullanıcının üyesi olduğu grupları son güncellenenden başlayarak getirir
+func (r *GroupRepository) FindByUserID(userID uint, limit, offset int) ([]*domain.Group, error) {
+	var models []GroupModel
+	result := r.db.Where("id IN (?)", r.db.Model(&GroupMemberModel{}).Select("group_id").Where("user_id = ?", userID)).
+		Order("updated_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	groups := make([]*domain.Group, 0, len(models))
+	for i := range models {
+		groups = append(groups, models[i].ToEntity())
+	}
+	return groups, nil
+}
+
+// Create, yeni bir grup oluşturur
+func (r *GroupRepository) Create(group *domain.Group) error {
+	model := GroupModel{
+		OwnerID:     group.OwnerID,
+		Name:        group.Name,
+		Description: group.Description,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// ID'yi ve zaman damgalarını güncelle
+	group.ID = model.ID
+	group.CreatedAt = model.CreatedAt
+	group.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Update, grubun adını ve açıklamasını günceller
+func (r *GroupRepository) Update(group *domain.Group) error {
+	updatedAt := time.Now()
+	result := r.db.Model(&GroupModel{}).Where("id = ?", group.ID).Updates(map[string]interface{}{
+		"name":        group.Name,
+		"description": group.Description,
+		"updated_at":  updatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	group.UpdatedAt = updatedAt
+	return nil
+}
+
+// Delete, grubu üyelikleri, istekleri, içerik paylaşımları, etkinlikleri ve davet bağlantılarıyla birlikte siler
+func (r *GroupRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&GroupMemberModel{}, &GroupJoinRequestModel{}, &GroupContentModel{}, &GroupActivityModel{}} {
+			if err := tx.Where("group_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := deleteInvites(tx, id, "group"); err != nil {
+			return err
+		}
+		return tx.Delete(&GroupModel{}, id).Error
+	})
+}
+
+// FindMember, kullanıcının gruptaki üyeliğini bulur
+func (r *GroupRepository) FindMember(groupID, userID uint) (*domain.GroupMember, error) {
+	var model GroupMemberModel
+	if err := r.db.Where("group_id = ? AND user_id = ?", groupID, userID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Kullanıcı grubun üyesi değil
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindMembers, grubun üyelerini katılma sırasına göre getirir
+func (r *GroupRepository) FindMembers(groupID uint, limit, offset int) ([]*domain.GroupMember, error) {
+	var models []GroupMemberModel
+	result := r.db.Where("group_id = ?", groupID).
+		Order("created_at, id").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	members := make([]*domain.GroupMember, 0, len(models))
+	for i := range models {
+		members = append(members, models[i].ToEntity())
+	}
+	return members, nil
+}
+
+// CountMembers, grubun üye sayısını döndürür
+func (r *GroupRepository) CountMembers(groupID uint) (int, error) {
+	var count int64
+	if err := r.db.Model(&GroupMemberModel{}).Where("group_id = ?", groupID).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// CreateMember, kullanıcıyı gruba üye olarak ekler
+func (r *GroupRepository) CreateMember(member *domain.GroupMember) error {
+	model := GroupMemberModel{
+		GroupID: member.GroupID,
+		UserID:  member.UserID,
+		Role:    member.Role,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("grup üyeliği oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// ID'yi ve zaman damgalarını güncelle
+	member.ID = model.ID
+	member.CreatedAt = model.CreatedAt
+	member.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// UpdateMember, üyenin rolünü günceller
+func (r *GroupRepository) UpdateMember(member *domain.GroupMember) error {
+	updatedAt := time.Now()
+	result := r.db.Model(&GroupMemberModel{}).Where("id = ?", member.ID).Updates(map[string]interface{}{
+		"role":       member.Role,
+		"updated_at": updatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	member.UpdatedAt = updatedAt
+	return nil
+}
+
+// DeleteMember, üyeliği ve üyenin grup alanına paylaştığı içerikleri siler
+func (r *GroupRepository) DeleteMember(groupID, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&GroupContentModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("group_id = ? AND user_id = ?", groupID, userID).Delete(&GroupMemberModel{}).Error
+	})
+}
+
+// FindJoinRequestByID, ID'ye göre üyelik isteğini bulur
+func (r *GroupRepository) FindJoinRequestByID(id uint) (*domain.GroupJoinRequest, error) {
+	var model GroupJoinRequestModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // İstek bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindPendingJoinRequest, kullanıcının gruba bekleyen üyelik isteğini bulur
+func (r *GroupRepository) FindPendingJoinRequest(groupID, userID uint) (*domain.GroupJoinRequest, error) {
+	var model GroupJoinRequestModel
+	err := r.db.Where("group_id = ? AND user_id = ? AND status = ?", groupID, userID, domain.GroupJoinRequestPending).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Bekleyen istek yok
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindJoinRequests, grubun verilen durumdaki üyelik isteklerini eskiden yeniye getirir
+func (r *GroupRepository) FindJoinRequests(groupID uint, status string, limit, offset int) ([]*domain.GroupJoinRequest, error) {
+	var models []GroupJoinRequestModel
+	result := r.db.Where("group_id = ? AND status = ?", groupID, status).
+		Order("created_at, id").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	requests := make([]*domain.GroupJoinRequest, 0, len(models))
+	for i := range models {
+		requests = append(requests, models[i].ToEntity())
+	}
+	return requests, nil
+}
+
+// CreateJoinRequest, yeni bir üyelik isteği oluşturur
+func (r *GroupRepository) CreateJoinRequest(request *domain.GroupJoinRequest) error {
+	model := GroupJoinRequestModel{
+		GroupID: request.GroupID,
+		UserID:  request.UserID,
+		Message: request.Message,
+		Status:  request.Status,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// ID'yi ve zaman damgalarını güncelle
+	request.ID = model.ID
+	request.CreatedAt = model.CreatedAt
+	request.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// UpdateJoinRequest, üyelik isteğinin durumunu ve değerlendiren yöneticiyi günceller
+func (r *GroupRepository) UpdateJoinRequest(request *domain.GroupJoinRequest) error {
+	updatedAt := time.Now()
+	result := r.db.Model(&GroupJoinRequestModel{}).Where("id = ?", request.ID).Updates(map[string]interface{}{
+		"status":      request.Status,
+		"reviewed_by": request.ReviewedBy,
+		"updated_at":  updatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	request.UpdatedAt = updatedAt
+	return nil
+}
+
+// FindContent, içeriğin gruptaki paylaşımını bulur
+func (r *GroupRepository) FindContent(groupID, contentID uint, contentType string) (*domain.GroupContent, error) {
+	var model GroupContentModel
+	err := r.db.Where("group_id = ? AND content_id = ? AND content_type = ?", groupID, contentID, contentType).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // İçerik grupta paylaşılmamış
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindContents, grup alanındaki içerikleri son paylaşılandan başlayarak getirir
+func (r *GroupRepository) FindContents(groupID uint, limit, offset int) ([]*domain.GroupContent, error) {
+	var models []GroupContentModel
+	result := r.db.Where("group_id = ?", groupID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	contents := make([]*domain.GroupContent, 0, len(models))
+	for i := range models {
+		contents = append(contents, models[i].ToEntity())
+	}
+	return contents, nil
+}
+
+// CreateContent, içeriği grup alanına paylaşır
+func (r *GroupRepository) CreateContent(content *domain.GroupContent) error {
+	model := GroupContentModel{
+		GroupID:     content.GroupID,
+		ContentID:   content.ContentID,
+		ContentType: content.ContentType,
+		UserID:      content.UserID,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("grup içeriği oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// ID'yi ve zaman damgasını güncelle
+	content.ID = model.ID
+	content.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// DeleteContent, içeriğin gruptaki paylaşımını kaldırır
+func (r *GroupRepository) DeleteContent(groupID, contentID uint, contentType string) error {
+	return r.db.Where("group_id = ? AND content_id = ? AND content_type = ?", groupID, contentID, contentType).
+		Delete(&GroupContentModel{}).Error
+}
+
+// IsContentShared, içeriğin kullanıcının üyesi olduğu gruplardan birine paylaşılıp paylaşılmadığını döndürür
+func (r *GroupRepository) IsContentShared(contentID uint, contentType string, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&GroupContentModel{}).
+		Joins("JOIN group_members ON group_members.group_id = group_contents.group_id").
+		Where("group_contents.content_id = ? AND group_contents.content_type = ? AND group_members.user_id = ?", contentID, contentType, userID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateActivity, grup etkinlik akışına yeni bir kayıt ekler
+func (r *GroupRepository) CreateActivity(activity *domain.GroupActivity) error {
+	model := GroupActivityModel{
+		GroupID:      activity.GroupID,
+		UserID:       activity.UserID,
+		Type:         activity.Type,
+		TargetUserID: activity.TargetUserID,
+		ContentID:    activity.ContentID,
+		ContentType:  activity.ContentType,
+		Title:        activity.Title,
+		Role:         activity.Role,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		return err
+	}
+
+	// ID'yi ve zaman damgasını güncelle
+	activity.ID = model.ID
+	activity.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// FindActivities, grubun etkinliklerini en yeniden başlayarak getirir
+func (r *GroupRepository) FindActivities(groupID uint, limit, offset int) ([]*domain.GroupActivity, error) {
+	var models []GroupActivityModel
+	result := r.db.Where("group_id = ?", groupID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	activities := make([]*domain.GroupActivity, 0, len(models))
+	for i := range models {
+		activities = append(activities, models[i].ToEntity())
+	}
+	return activities, nil
+}
+
+// Ensure GroupRepository implements domain.GroupRepository
+var _ domain.GroupRepository = (*GroupRepository)(nil)
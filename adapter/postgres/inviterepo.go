@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,15 +11,19 @@ import (
 
 // InviteModel, davet bağlantısı için veritabanı modeli
 type InviteModel struct {
-	ID        uint   `gorm:"primaryKey"`
-	ContentID uint   `gorm:"index"`
-	Type      string `gorm:"size:10;index"` // "note" veya "pdf"
-	Token     string `gorm:"size:100;uniqueIndex"`
-	CreatedBy uint   `gorm:"index"`
-	ExpiresAt time.Time
-	IsActive  bool `gorm:"default:true"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID           uint   `gorm:"primaryKey"`
+	ContentID    uint   `gorm:"index"`
+	Type         string `gorm:"size:10;index"` // "note", "pdf", "collection" veya "group"
+	Token        string `gorm:"size:100;uniqueIndex"`
+	CreatedBy    uint   `gorm:"index"`
+	Permission   string `gorm:"size:10;not null;default:'read'"`
+	MaxUses      int    `gorm:"not null;default:0"`
+	UseCount     int    `gorm:"not null;default:0"`
+	PasswordHash string `gorm:"size:100"`
+	ExpiresAt    time.Time
+	IsActive     bool `gorm:"default:true"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 // TableName, tablo adını belirtir
@@ -29,15 +34,19 @@ func (InviteModel) TableName() string {
 // ToDomain, veritabanı modelini domain modeline dönüştürür
 func (m *InviteModel) ToDomain() *domain.Invite {
 	return &domain.Invite{
-		ID:        m.ID,
-		ContentID: m.ContentID,
-		Type:      m.Type,
-		Token:     m.Token,
-		CreatedBy: m.CreatedBy,
-		ExpiresAt: m.ExpiresAt,
-		IsActive:  m.IsActive,
-		CreatedAt: m.CreatedAt,
-		UpdatedAt: m.UpdatedAt,
+		ID:           m.ID,
+		ContentID:    m.ContentID,
+		Type:         m.Type,
+		Token:        m.Token,
+		CreatedBy:    m.CreatedBy,
+		Permission:   m.Permission,
+		MaxUses:      m.MaxUses,
+		UseCount:     m.UseCount,
+		PasswordHash: m.PasswordHash,
+		ExpiresAt:    m.ExpiresAt,
+		IsActive:     m.IsActive,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
 	}
 }
 
@@ -48,12 +57,53 @@ func (m *InviteModel) FromDomain(invite *domain.Invite) {
 	m.Type = invite.Type
 	m.Token = invite.Token
 	m.CreatedBy = invite.CreatedBy
+	m.Permission = invite.Permission
+	m.MaxUses = invite.MaxUses
+	m.UseCount = invite.UseCount
+	m.PasswordHash = invite.PasswordHash
 	m.ExpiresAt = invite.ExpiresAt
 	m.IsActive = invite.IsActive
 	m.CreatedAt = invite.CreatedAt
 	m.UpdatedAt = invite.UpdatedAt
 }
 
+// InviteRedemptionModel, davet bağlantısı kullanım kaydı için veritabanı modeli
+type InviteRedemptionModel struct {
+	ID         uint   `gorm:"primaryKey"`
+	InviteID   uint   `gorm:"not null;index:idx_invite_redemption;uniqueIndex:idx_invite_redemption_user,where:user_id <> 0"`
+	UserID     uint   `gorm:"not null;index:idx_invite_redemption;uniqueIndex:idx_invite_redemption_user,where:user_id <> 0"` // Giriş yapmamış kullanıcılar için 0
+	VisitorKey string `gorm:"size:64;index:idx_invite_redemption_visitor"`                                                    // Giriş yapmamış ziyaretçinin anahtarı
+	CreatedAt  time.Time
+	LastUsedAt time.Time `gorm:"not null"`
+}
+
+// TableName, tablo adını belirtir
+func (InviteRedemptionModel) TableName() string {
+	return "invite_redemptions"
+}
+
+// ToDomain, veritabanı modelini domain modeline dönüştürür
+func (m *InviteRedemptionModel) ToDomain() *domain.InviteRedemption {
+	return &domain.InviteRedemption{
+		ID:         m.ID,
+		InviteID:   m.InviteID,
+		UserID:     m.UserID,
+		VisitorKey: m.VisitorKey,
+		CreatedAt:  m.CreatedAt,
+		LastUsedAt: m.LastUsedAt,
+	}
+}
+
+// FromDomain, domain modelini veritabanı modeline dönüştürür
+func (m *InviteRedemptionModel) FromDomain(redemption *domain.InviteRedemption) {
+	m.ID = redemption.ID
+	m.InviteID = redemption.InviteID
+	m.UserID = redemption.UserID
+	m.VisitorKey = redemption.VisitorKey
+	m.CreatedAt = redemption.CreatedAt
+	m.LastUsedAt = redemption.LastUsedAt
+}
+
 // InviteRepository, davet bağlantısı için veritabanı işlemlerini içerir
 type InviteRepository struct {
 	db *gorm.DB
@@ -129,21 +179,132 @@ func (r *InviteRepository) Update(invite *domain.Invite) error {
 	return nil
 }
 
-// Delete, bir davet bağlantısını siler
+// Delete, bir davet bağlantısını kullanım kayıtlarıyla birlikte siler
 func (r *InviteRepository) Delete(id uint) error {
-	if err := r.db.Delete(&InviteModel{}, id).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("invite_id = ?", id).Delete(&InviteRedemptionModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&InviteModel{}, id).Error
+	})
+	if err != nil {
 		return fmt.Errorf("davet bağlantısı silme hatası: %w", err)
 	}
 	return nil
 }
 
-// DeleteByContentID, içerik ID'sine göre davet bağlantılarını siler
+// DeleteByContentID, içerik ID'sine göre davet bağlantılarını kullanım kayıtlarıyla birlikte siler
 func (r *InviteRepository) DeleteByContentID(contentID uint, contentType string) error {
-	if err := r.db.Where("content_id = ? AND type = ?", contentID, contentType).Delete(&InviteModel{}).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteInvites(tx, contentID, contentType)
+	})
+	if err != nil {
 		return fmt.Errorf("davet bağlantıları silme hatası: %w", err)
 	}
 	return nil
 }
 
+// deleteInvites, içeriğin davet bağlantılarını ve kullanım kayıtlarını verilen işlem içinde siler
+func deleteInvites(tx *gorm.DB, contentID uint, contentType string) error {
+	inviteIDs := tx.Model(&InviteModel{}).Select("id").Where("content_id = ? AND type = ?", contentID, contentType)
+	if err := tx.Where("invite_id IN (?)", inviteIDs).Delete(&InviteRedemptionModel{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("content_id = ? AND type = ?", contentID, contentType).Delete(&InviteModel{}).Error
+}
+
+// FindRedemption, kullanıcının davet kullanım kaydını bulur
+func (r *InviteRepository) FindRedemption(inviteID, userID uint) (*domain.InviteRedemption, error) {
+	var model InviteRedemptionModel
+	if err := r.db.Where("invite_id = ? AND user_id = ?", inviteID, userID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("davet kullanım kaydı arama hatası: %w", err)
+	}
+	return model.ToDomain(), nil
+}
+
+// FindRedemptionByVisitorKey, giriş yapmamış ziyaretçinin davet kullanım kaydını anahtarıyla bulur
+func (r *InviteRepository) FindRedemptionByVisitorKey(inviteID uint, visitorKey string) (*domain.InviteRedemption, error) {
+	var model InviteRedemptionModel
+	if err := r.db.Where("invite_id = ? AND user_id = 0 AND visitor_key = ?", inviteID, visitorKey).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("davet kullanım kaydı arama hatası: %w", err)
+	}
+	return model.ToDomain(), nil
+}
+
+// FindRedemptions, davetin kullanım kayıtlarını son kullanılandan başlayarak getirir
+func (r *InviteRepository) FindRedemptions(inviteID uint, limit, offset int) ([]*domain.InviteRedemption, error) {
+	var models []InviteRedemptionModel
+	if err := r.db.Where("invite_id = ?", inviteID).
+		Order("last_used_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("davet kullanım kayıtları arama hatası: %w", err)
+	}
+
+	redemptions := make([]*domain.InviteRedemption, len(models))
+	for i, model := range models {
+		redemptions[i] = model.ToDomain()
+	}
+	return redemptions, nil
+}
+
+// errInviteExhausted, kullanım hakkı kalmadığında Redeem işlemini geri almak için kullanılır
+var errInviteExhausted = errors.New("davet kullanım hakkı dolmuş")
+
+// Redeem, kullanım kaydını ekler ve kullanım sayısını aynı işlemde artırır. Kullanım sayısı koşullu
+// güncellendiği için eşzamanlı kullanımlar sınırı aşamaz; aynı kullanıcının eşzamanlı ilk kullanımları
+// benzersiz indekse takılır ve işlem geri alındığı için hak harcanmaz.
+func (r *InviteRepository) Redeem(redemption *domain.InviteRedemption) (bool, error) {
+	model := InviteRedemptionModel{}
+	model.FromDomain(redemption)
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&InviteModel{}).
+			Where("id = ? AND (max_uses = 0 OR use_count < max_uses)", redemption.InviteID).
+			Update("use_count", gorm.Expr("use_count + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errInviteExhausted
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errInviteExhausted) {
+			return false, nil
+		}
+		if isDuplicateKeyError(err) {
+			return false, fmt.Errorf("davet kullanım kaydı oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return false, fmt.Errorf("davet kullanım kaydı oluşturma hatası: %w", err)
+	}
+
+	// ID'yi güncelle
+	redemption.ID = model.ID
+	return true, nil
+}
+
+// UpdateRedemption, bir davet kullanım kaydını günceller
+func (r *InviteRepository) UpdateRedemption(redemption *domain.InviteRedemption) error {
+	model := InviteRedemptionModel{}
+	model.FromDomain(redemption)
+
+	if err := r.db.Save(&model).Error; err != nil {
+		return fmt.Errorf("davet kullanım kaydı güncelleme hatası: %w", err)
+	}
+	return nil
+}
+
 // Ensure InviteRepository implements domain.InviteRepository
 var _ domain.InviteRepository = (*InviteRepository)(nil)
@@ -0,0 +1,260 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// leaseCandidates, bir kiralama denemesinde sırayla denenen en fazla aday iş sayısı
+const leaseCandidates = 5
+
+// JobModel, arka plan işlerinin veritabanı modeli
+type JobModel struct {
+	ID          uint      `gorm:"primaryKey"`
+	Type        string    `gorm:"size:50;not null;index"`
+	Payload     string    `gorm:"type:text"`
+	Status      string    `gorm:"size:20;not null;index:idx_job_due,priority:1"`
+	Attempts    int       `gorm:"not null"`
+	MaxAttempts int       `gorm:"not null"`
+	RunAt       time.Time `gorm:"not null;index:idx_job_due,priority:2"`
+	LockedBy    string    `gorm:"size:100"`
+	LockedUntil *time.Time
+	LastError   string `gorm:"type:text"`
+	FinishedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName, tablo adını belirtir
+func (JobModel) TableName() string {
+	return "jobs"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *JobModel) ToEntity() *domain.Job {
+	return &domain.Job{
+		ID:          m.ID,
+		Type:        m.Type,
+		Payload:     m.Payload,
+		Status:      m.Status,
+		Attempts:    m.Attempts,
+		MaxAttempts: m.MaxAttempts,
+		RunAt:       m.RunAt,
+		LockedBy:    m.LockedBy,
+		LockedUntil: m.LockedUntil,
+		LastError:   m.LastError,
+		FinishedAt:  m.FinishedAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// JobRepository, domain.JobRepository arayüzünün PostgreSQL implementasyonu
+type JobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository, yeni bir JobRepository örneği oluşturur
+func NewJobRepository(db *gorm.DB) *JobRepository {
+	return &JobRepository{db: db}
+}
+
+// Create, yeni bir iş oluşturur
+func (r *JobRepository) Create(job *domain.Job) error {
+	model := JobModel{
+		Type:        job.Type,
+		Payload:     job.Payload,
+		Status:      job.Status,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+	}
+	if model.Status == "" {
+		model.Status = domain.JobStatusPending
+	}
+	if model.RunAt.IsZero() {
+		model.RunAt = domain.Now().UTC()
+	}
+
+	result := r.db.Create(&model)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// ID ve varsayılanları güncelle
+	job.ID = model.ID
+	job.Status = model.Status
+	job.RunAt = model.RunAt
+	job.CreatedAt = model.CreatedAt
+	job.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// FindByID, ID'ye göre işi bulur
+func (r *JobRepository) FindByID(id uint) (*domain.Job, error) {
+	var model JobModel
+	result := r.db.First(&model, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // İş bulunamadı
+		}
+		return nil, result.Error
+	}
+	return model.ToEntity(), nil
+}
+
+// Find, filtreye uyan işleri en yeniden eskiye getirir
+func (r *JobRepository) Find(filter domain.JobFilter, limit, offset int) ([]*domain.Job, error) {
+	query := r.db.Model(&JobModel{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	var models []JobModel
+	result := query.Order("id DESC").Limit(limit).Offset(offset).Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	jobs := make([]*domain.Job, 0, len(models))
+	for i := range models {
+		jobs = append(jobs, models[i].ToEntity())
+	}
+	return jobs, nil
+}
+
+// CountByStatus, her durumdaki iş sayısını döndürür
+func (r *JobRepository) CountByStatus() (map[string]int, error) {
+	var rows []struct {
+		Status string
+		Count  int
+	}
+	result := r.db.Model(&JobModel{}).Select("status, COUNT(*) AS count").Group("status").Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Lease, çalıştırma zamanı gelmiş en eski işi workerID adına kiralar. Aynı anda çalışan
+// worker'lar aynı adayı seçebilir; koşullu güncelleme adayı yalnızca birinin almasını sağlar,
+// diğerleri sıradaki adayı dener.
+func (r *JobRepository) Lease(workerID string, now, until time.Time) (*domain.Job, error) {
+	due := "(status = ? AND run_at <= ?) OR (status = ? AND locked_until < ?)"
+	dueArgs := []interface{}{domain.JobStatusPending, now, domain.JobStatusRunning, now}
+
+	var candidates []uint
+	result := r.db.Model(&JobModel{}).
+		Where(due, dueArgs...).
+		Order("run_at, id").
+		Limit(leaseCandidates).
+		Pluck("id", &candidates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, id := range candidates {
+		result := r.db.Model(&JobModel{}).
+			Where("id = ?", id).
+			Where(due, dueArgs...).
+			Updates(map[string]interface{}{
+				"status":       domain.JobStatusRunning,
+				"locked_by":    workerID,
+				"locked_until": until,
+				"attempts":     gorm.Expr("attempts + 1"),
+				"updated_at":   now,
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return r.FindByID(id)
+		}
+	}
+	return nil, nil
+}
+
+// finishLease, workerID'nin hala kiraladığı işi günceller; kira kaybedildiyse ErrJobLeaseLost döner
+func (r *JobRepository) finishLease(id uint, workerID string, updates map[string]interface{}) error {
+	result := r.db.Model(&JobModel{}).
+		Where("id = ? AND status = ? AND locked_by = ?", id, domain.JobStatusRunning, workerID).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrJobLeaseLost
+	}
+	return nil
+}
+
+// Complete, kiralanan işi başarılı olarak işaretler
+func (r *JobRepository) Complete(id uint, workerID string, now time.Time) error {
+	return r.finishLease(id, workerID, map[string]interface{}{
+		"status":       domain.JobStatusSucceeded,
+		"locked_by":    "",
+		"locked_until": nil,
+		"last_error":   "",
+		"finished_at":  now,
+		"updated_at":   now,
+	})
+}
+
+// Fail, kiralanan işin hatasını kaydeder ve işi yeniden denemeye alır ya da ölü olarak işaretler
+func (r *JobRepository) Fail(id uint, workerID string, errMsg string, retryAt *time.Time, now time.Time) error {
+	updates := map[string]interface{}{
+		"locked_by":    "",
+		"locked_until": nil,
+		"last_error":   errMsg,
+		"updated_at":   now,
+	}
+	if retryAt != nil {
+		updates["status"] = domain.JobStatusPending
+		updates["run_at"] = *retryAt
+	} else {
+		updates["status"] = domain.JobStatusDead
+		updates["finished_at"] = now
+	}
+	return r.finishLease(id, workerID, updates)
+}
+
+// Retry, ölü bir işi hemen çalıştırılmak üzere beklemeye alır
+func (r *JobRepository) Retry(id uint, now time.Time) error {
+	job, err := r.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return domain.ErrNotFound
+	}
+
+	result := r.db.Model(&JobModel{}).
+		Where("id = ? AND status = ?", id, domain.JobStatusDead).
+		Updates(map[string]interface{}{
+			"status":      domain.JobStatusPending,
+			"attempts":    0,
+			"run_at":      now,
+			"finished_at": nil,
+			"updated_at":  now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrInvalidInput
+	}
+	return nil
+}
+
+// Ensure JobRepository implements domain.JobRepository
+var _ domain.JobRepository = (*JobRepository)(nil)
@@ -99,9 +99,9 @@ func (r *LikeRepository) FindByUserID(userID uint, limit, offset int) ([]*domain
 // FindLikedNotesByUserID, kullanıcının beğendiği notları doğrudan veritabanından getirir
 func (r *LikeRepository) FindLikedNotesByUserID(userID uint, limit, offset int) ([]*domain.Note, error) {
 	var notes []NoteModel
-	result := r.db.Table("notes").
-		Joins("JOIN content_like_models ON notes.id = content_like_models.content_id").
-		Where("content_like_models.user_id = ? AND content_like_models.type = ?", userID, "note").
+	result := r.db.Preload("Tags").
+		Joins("JOIN content_like_models ON note_models.id = content_like_models.content_id").
+		Where("content_like_models.user_id = ? AND content_like_models.type = ? AND content_like_models.deleted_at IS NULL", userID, "note").
 		Limit(limit).Offset(offset).
 		Find(&notes)
 
@@ -119,9 +119,9 @@ func (r *LikeRepository) FindLikedNotesByUserID(userID uint, limit, offset int)
 // FindLikedPDFsByUserID, kullanıcının beğendiği PDF'leri doğrudan veritabanından getirir
 func (r *LikeRepository) FindLikedPDFsByUserID(userID uint, limit, offset int) ([]*domain.PDF, error) {
 	var pdfs []PDFModel
-	result := r.db.Table("pdfs").
-		Joins("JOIN content_like_models ON pdfs.id = content_like_models.content_id").
-		Where("content_like_models.user_id = ? AND content_like_models.type = ?", userID, "pdf").
+	result := r.db.Preload("Tags").
+		Joins("JOIN content_like_models ON pdf_models.id = content_like_models.content_id").
+		Where("content_like_models.user_id = ? AND content_like_models.type = ? AND content_like_models.deleted_at IS NULL", userID, "pdf").
 		Limit(limit).Offset(offset).
 		Find(&pdfs)
 
@@ -162,7 +162,7 @@ func (r *LikeRepository) Create(like *domain.Like) error {
 		err := tx.Create(&likeModel).Error
 		if err != nil {
 			// Eğer benzersiz indeks ihlali nedeniyle hata oluştuysa, başarılı olarak dön
-			if err.Error() == "ERROR: duplicate key value violates unique constraint \"idx_user_content_type\" (SQLSTATE 23505)" {
+			if isDuplicateKeyError(err) {
 				// Kullanıcı zaten bu içeriği beğenmiş, başarılı olarak dön
 				return nil
 			}
@@ -207,8 +207,9 @@ func (r *LikeRepository) Delete(id uint) error {
 			}
 		}
 
-		// Beğeniyi sil
-		return tx.Delete(&like).Error
+		// Beğeniyi kalıcı olarak sil; soft delete edilen satır benzersiz indekste
+		// kalacağı için aynı içeriğin yeniden beğenilmesini engeller
+		return tx.Unscoped().Delete(&like).Error
 	})
 }
 
@@ -237,8 +238,9 @@ func (r *LikeRepository) DeleteByUserIDAndContent(userID, contentID uint, conten
 			}
 		}
 
-		// Beğeniyi sil
-		return tx.Delete(&like).Error
+		// Beğeniyi kalıcı olarak sil; soft delete edilen satır benzersiz indekste
+		// kalacağı için aynı içeriğin yeniden beğenilmesini engeller
+		return tx.Unscoped().Delete(&like).Error
 	})
 }
 
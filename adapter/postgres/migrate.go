@@ -0,0 +1,257 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrSchemaOutdated, veritabanı şemasının uygulamanın beklediği sürümün gerisinde olduğunu belirtir
+var ErrSchemaOutdated = errors.New("veritabanı şeması güncel değil")
+
+// ErrUnknownSchemaVersion, veritabanında uygulamanın bilmediği bir migrasyonun uygulandığını belirtir
+var ErrUnknownSchemaVersion = errors.New("veritabanı şeması uygulamadan daha yeni")
+
+// Migration, numaralı ve geri alınabilir bir şema değişikliğini temsil eder
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// MigrationStatus, bir migrasyonun veritabanındaki durumunu içerir
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// SchemaMigrationModel, uygulanan migrasyonların kaydını tutan veritabanı modeli
+type SchemaMigrationModel struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName, tablo adını belirtir
+func (SchemaMigrationModel) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator, sürümlü şema migrasyonlarını yönetir
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator, uygulamanın migrasyon listesiyle yeni bir Migrator oluşturur
+func NewMigrator(db *gorm.DB) *Migrator {
+	return &Migrator{db: db, migrations: migrations}
+}
+
+// LatestVersion, uygulamanın bildiği en yüksek migrasyon sürümünü döndürür
+func (m *Migrator) LatestVersion() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// ensureTable, schema_migrations tablosunu oluşturur (yoksa)
+func (m *Migrator) ensureTable() error {
+	if err := m.db.AutoMigrate(&SchemaMigrationModel{}); err != nil {
+		return fmt.Errorf("schema_migrations tablosu oluşturulurken hata: %w", err)
+	}
+	return nil
+}
+
+// applied, uygulanmış migrasyon kayıtlarını sürüme göre döndürür
+func (m *Migrator) applied() (map[uint]SchemaMigrationModel, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+
+	var records []SchemaMigrationModel
+	if err := m.db.Order("version").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("uygulanan migrasyonlar okunurken hata: %w", err)
+	}
+
+	applied := make(map[uint]SchemaMigrationModel, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// CurrentVersion, veritabanına uygulanmış en yüksek migrasyon sürümünü döndürür
+func (m *Migrator) CurrentVersion() (uint, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+
+	var current uint
+	for version := range applied {
+		if version > current {
+			current = version
+		}
+	}
+	return current, nil
+}
+
+// Status, tüm migrasyonların uygulanma durumunu döndürür
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = record.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// Up, bekleyen tüm migrasyonları uygular
+func (m *Migrator) Up() error {
+	return m.To(m.LatestVersion())
+}
+
+// Down, son uygulanan migrasyonu geri alır
+func (m *Migrator) Down() error {
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	if current == 0 {
+		return nil
+	}
+
+	// Bir önceki sürüme in
+	var target uint
+	for _, migration := range m.migrations {
+		if migration.Version < current {
+			target = migration.Version
+		}
+	}
+	return m.To(target)
+}
+
+// To, şemayı verilen sürüme getirir; gerekirse migrasyonları uygular veya geri alır
+func (m *Migrator) To(version uint) error {
+	if version > m.LatestVersion() {
+		return fmt.Errorf("bilinmeyen migrasyon sürümü: %d", version)
+	}
+
+	applied, err := m.applied()
+	if err != nil {
+		return err
+	}
+
+	// Hedefe kadar uygulanmamış migrasyonları artan sırayla uygula
+	for _, migration := range m.migrations {
+		if migration.Version > version {
+			break
+		}
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(migration); err != nil {
+			return err
+		}
+	}
+
+	// Hedefin üzerindeki uygulanmış migrasyonları azalan sırayla geri al
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version <= version {
+			break
+		}
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if err := m.revert(migration); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// apply, tek bir migrasyonu transaction içinde uygular ve kaydeder
+func (m *Migrator) apply(migration Migration) error {
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Up(tx); err != nil {
+			return err
+		}
+		return tx.Create(&SchemaMigrationModel{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("%d_%s migrasyonu uygulanırken hata: %w", migration.Version, migration.Name, err)
+	}
+
+	log.Printf("Migrasyon uygulandı: %d_%s", migration.Version, migration.Name)
+	return nil
+}
+
+// revert, tek bir migrasyonu transaction içinde geri alır ve kaydını siler
+func (m *Migrator) revert(migration Migration) error {
+	if migration.Down == nil {
+		return fmt.Errorf("%d_%s migrasyonu geri alınamaz", migration.Version, migration.Name)
+	}
+
+	err := m.db.Transaction(func(tx *gorm.DB) error {
+		if err := migration.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&SchemaMigrationModel{}, migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("%d_%s migrasyonu geri alınırken hata: %w", migration.Version, migration.Name, err)
+	}
+
+	log.Printf("Migrasyon geri alındı: %d_%s", migration.Version, migration.Name)
+	return nil
+}
+
+// CheckSchema, veritabanı şemasının uygulamanın beklediği sürümde olduğunu doğrular
+func (m *Migrator) CheckSchema() error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+
+	current, err := m.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	if current > m.LatestVersion() {
+		return fmt.Errorf("%w: veritabanı sürümü %d, uygulama sürümü %d", ErrUnknownSchemaVersion, current, m.LatestVersion())
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return fmt.Errorf("%w: %d bekleyen migrasyon var (veritabanı sürümü %d, beklenen %d)", ErrSchemaOutdated, pending, current, m.LatestVersion())
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"errors"
+	"time"
 
 	"github.com/OmerFErdogan/uninote/domain"
 	"gorm.io/gorm"
@@ -18,6 +19,7 @@ type NoteModel struct {
 	ViewCount    int
 	LikeCount    int
 	CommentCount int
+	Version      int `gorm:"not null;default:1"`
 }
 
 // CommentModel, Comment varlığının veritabanı modelini temsil eder
@@ -52,6 +54,7 @@ func (n *NoteModel) ToEntity() *domain.Note {
 		ViewCount:    n.ViewCount,
 		LikeCount:    n.LikeCount,
 		CommentCount: n.CommentCount,
+		Version:      n.Version,
 		CreatedAt:    n.CreatedAt,
 		UpdatedAt:    n.UpdatedAt,
 	}
@@ -142,22 +145,49 @@ func (r *NoteRepository) FindByTag(tag string, limit, offset int) ([]*domain.Not
 	return domainNotes, nil
 }
 
-// Search, arama sorgusuna göre notları bulur
-func (r *NoteRepository) Search(query string, limit, offset int) ([]*domain.Note, error) {
-	var notes []NoteModel
-	result := r.db.Preload("Tags").
-		Where("title ILIKE ? OR content ILIKE ?", "%"+query+"%", "%"+query+"%").
-		Limit(limit).Offset(offset).
-		Find(&notes)
-	if result.Error != nil {
-		return nil, result.Error
+// Search, tam metin arama sorgusuna uyan herkese açık (ve sorgudaki kullanıcının) notları puana göre sıralı döndürür
+func (r *NoteRepository) Search(query *domain.SearchQuery) ([]*domain.SearchHit, error) {
+	search := fullTextSearch
+	if !supportsFullTextSearch(r.db) {
+		search = sqliteFullTextSearch
 	}
 
-	var domainNotes []*domain.Note
+	rows, err := search(r.db, "note_models", "content", query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []*domain.SearchHit{}, nil
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var notes []NoteModel
+	if err := r.db.Preload("Tags").Where("id IN ?", ids).Find(&notes).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*domain.Note, len(notes))
 	for _, note := range notes {
-		domainNotes = append(domainNotes, note.ToEntity())
+		byID[note.ID] = note.ToEntity()
 	}
-	return domainNotes, nil
+
+	hits := make([]*domain.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		note, ok := byID[row.ID]
+		if !ok {
+			continue // Sorgular arasında silinmiş
+		}
+		hits = append(hits, &domain.SearchHit{
+			ContentType: "note",
+			Rank:        row.Rank,
+			Headline:    row.Headline,
+			Snippet:     row.Snippet,
+			Note:        note,
+		})
+	}
+	return hits, nil
 }
 
 // Create, yeni bir not oluşturur
@@ -171,6 +201,7 @@ func (r *NoteRepository) Create(note *domain.Note) error {
 		ViewCount:    note.ViewCount,
 		LikeCount:    note.LikeCount,
 		CommentCount: note.CommentCount,
+		Version:      1,
 	}
 
 	// Etiketleri işle
@@ -192,44 +223,62 @@ func (r *NoteRepository) Create(note *domain.Note) error {
 		return result.Error
 	}
 
-	// ID'yi güncelle
+	// ID ve sürümü güncelle
 	note.ID = uint(noteModel.ID)
+	note.Version = noteModel.Version
 	return nil
 }
 
-// Update, bir notu günceller
+// Update, bir notu günceller. Güncelleme yalnızca saklanan sürüm note.Version ile
+// eşleşiyorsa yapılır; başarılı olursa sürüm bir artırılır ve note.Version'a yazılır.
 func (r *NoteRepository) Update(note *domain.Note) error {
-	// Mevcut notu bul
-	var noteModel NoteModel
-	result := r.db.First(&noteModel, note.ID)
-	if result.Error != nil {
-		return result.Error
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Mevcut notu bul
+		var noteModel NoteModel
+		if err := tx.First(&noteModel, note.ID).Error; err != nil {
+			return err
+		}
+		if noteModel.Version != note.Version {
+			return domain.ErrVersionConflict
+		}
 
-	// Notu güncelle
-	noteModel.Title = note.Title
-	noteModel.Content = note.Content
-	noteModel.IsPublic = note.IsPublic
+		// Sürüm hala aynıysa notu güncelle; sayaçlara dokunulmaz
+		result := tx.Model(&NoteModel{}).
+			Where("id = ? AND version = ?", note.ID, note.Version).
+			Updates(map[string]interface{}{
+				"title":      note.Title,
+				"content":    note.Content,
+				"is_public":  note.IsPublic,
+				"version":    gorm.Expr("version + 1"),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrVersionConflict
+		}
 
-	// Etiketleri temizle
-	r.db.Model(&noteModel).Association("Tags").Clear()
+		// Etiketleri temizle
+		if err := tx.Model(&noteModel).Association("Tags").Clear(); err != nil {
+			return err
+		}
 
-	// Etiketleri işle
-	if len(note.Tags) > 0 {
+		// Etiketleri işle
 		for _, tagName := range note.Tags {
 			var tag TagModel
 			// Etiketi bul veya oluştur
-			result := r.db.Where("name = ?", tagName).FirstOrCreate(&tag, TagModel{Name: tagName})
-			if result.Error != nil {
-				return result.Error
+			if err := tx.Where("name = ?", tagName).FirstOrCreate(&tag, TagModel{Name: tagName}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&noteModel).Association("Tags").Append(&tag); err != nil {
+				return err
 			}
-			r.db.Model(&noteModel).Association("Tags").Append(&tag)
 		}
-	}
 
-	// Notu kaydet
-	result = r.db.Save(&noteModel)
-	return result.Error
+		note.Version = noteModel.Version + 1
+		return nil
+	})
 }
 
 // Delete, bir notu siler
@@ -238,7 +287,25 @@ func (r *NoteRepository) Delete(id uint) error {
 	r.db.Where("note_id = ?", id).Delete(&CommentModel{})
 
 	// İlişkili beğenileri sil
-	r.db.Where("content_id = ? AND type = ?", id, "note").Delete(&ContentLikeModel{})
+	r.db.Unscoped().Where("content_id = ? AND type = ?", id, "note").Delete(&ContentLikeModel{})
+
+	// İlişkili revizyonları sil
+	r.db.Where("note_id = ?", id).Delete(&NoteRevisionModel{})
+
+	// İlişkili bildirimleri sil
+	r.db.Where("content_id = ? AND content_type = ?", id, "note").Delete(&NotificationModel{})
+
+	// Koleksiyonlardan çıkar
+	r.db.Where("content_id = ? AND content_type = ?", id, "note").Delete(&CollectionItemModel{})
+
+	// Ders bağlantısını sil
+	r.db.Where("content_id = ? AND content_type = ?", id, "note").Delete(&CourseContentModel{})
+
+	// Grup alanlarından çıkar
+	r.db.Where("content_id = ? AND content_type = ?", id, "note").Delete(&GroupContentModel{})
+
+	// Kullanıcı paylaşımlarını sil
+	r.db.Where("content_id = ? AND content_type = ?", id, "note").Delete(&ContentShareModel{})
 
 	// Notu sil
 	result := r.db.Delete(&NoteModel{}, id)
@@ -0,0 +1,231 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// NotificationModel, bildirimlerin veritabanı modeli
+type NotificationModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index:idx_notification_user"`
+	ActorID     uint   `gorm:"not null"`
+	Type        string `gorm:"size:20;not null"`
+	ContentID   uint   `gorm:"not null;index:idx_notification_content"`
+	ContentType string `gorm:"size:10;not null;index:idx_notification_content"` // "note", "pdf" veya "collection"
+	Message     string `gorm:"type:text"`
+	IsRead      bool   `gorm:"not null;index:idx_notification_user"`
+	CreatedAt   time.Time
+}
+
+// TableName, tablo adını belirtir
+func (NotificationModel) TableName() string {
+	return "notifications"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *NotificationModel) ToEntity() *domain.Notification {
+	return &domain.Notification{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		ActorID:     m.ActorID,
+		Type:        m.Type,
+		ContentID:   m.ContentID,
+		ContentType: m.ContentType,
+		Message:     m.Message,
+		IsRead:      m.IsRead,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// NotificationPreferenceModel, bildirim tercihlerinin veritabanı modeli
+type NotificationPreferenceModel struct {
+	ID        uint `gorm:"primaryKey"`
+	UserID    uint `gorm:"not null;uniqueIndex"`
+	Likes     bool `gorm:"not null"`
+	Comments  bool `gorm:"not null"`
+	Mentions  bool `gorm:"not null"`
+	Invites   bool `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+// TableName, tablo adını belirtir
+func (NotificationPreferenceModel) TableName() string {
+	return "notification_preferences"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *NotificationPreferenceModel) ToEntity() *domain.NotificationPreferences {
+	return &domain.NotificationPreferences{
+		UserID:    m.UserID,
+		Likes:     m.Likes,
+		Comments:  m.Comments,
+		Mentions:  m.Mentions,
+		Invites:   m.Invites,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// NotificationRepository, domain.NotificationRepository arayüzünün PostgreSQL implementasyonu
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository, yeni bir NotificationRepository örneği oluşturur
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// FindByID, ID'ye göre bildirimi bulur
+func (r *NotificationRepository) FindByID(id uint) (*domain.Notification, error) {
+	var model NotificationModel
+	result := r.db.First(&model, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Bildirim bulunamadı
+		}
+		return nil, result.Error
+	}
+	return model.ToEntity(), nil
+}
+
+// FindByUserID, kullanıcının bildirimlerini en yeniden eskiye getirir
+func (r *NotificationRepository) FindByUserID(userID uint, unreadOnly bool, limit, offset int) ([]*domain.Notification, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	var models []NotificationModel
+	result := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	notifications := make([]*domain.Notification, 0, len(models))
+	for i := range models {
+		notifications = append(notifications, models[i].ToEntity())
+	}
+	return notifications, nil
+}
+
+// ExistsUnread, aynı alıcı, kişi, tür ve içerik için okunmamış bir bildirim olup olmadığını kontrol eder
+func (r *NotificationRepository) ExistsUnread(notification *domain.Notification) (bool, error) {
+	var count int64
+	result := r.db.Model(&NotificationModel{}).
+		Where("user_id = ? AND actor_id = ? AND type = ? AND content_id = ? AND content_type = ? AND is_read = ?",
+			notification.UserID, notification.ActorID, notification.Type, notification.ContentID, notification.ContentType, false).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return count > 0, nil
+}
+
+// CountUnread, kullanıcının okunmamış bildirim sayısını döndürür
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&NotificationModel{}).Where("user_id = ? AND is_read = ?", userID, false).Count(&count)
+	return count, result.Error
+}
+
+// Create, yeni bir bildirim oluşturur
+func (r *NotificationRepository) Create(notification *domain.Notification) error {
+	model := NotificationModel{
+		UserID:      notification.UserID,
+		ActorID:     notification.ActorID,
+		Type:        notification.Type,
+		ContentID:   notification.ContentID,
+		ContentType: notification.ContentType,
+		Message:     notification.Message,
+		IsRead:      notification.IsRead,
+		CreatedAt:   notification.CreatedAt,
+	}
+
+	result := r.db.Create(&model)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// ID ve zaman damgasını güncelle
+	notification.ID = model.ID
+	notification.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// MarkRead, bildirimi okundu olarak işaretler
+func (r *NotificationRepository) MarkRead(id uint) error {
+	result := r.db.Model(&NotificationModel{}).Where("id = ?", id).Update("is_read", true)
+	return result.Error
+}
+
+// MarkAllRead, kullanıcının tüm bildirimlerini okundu olarak işaretler
+func (r *NotificationRepository) MarkAllRead(userID uint) error {
+	result := r.db.Model(&NotificationModel{}).Where("user_id = ? AND is_read = ?", userID, false).Update("is_read", true)
+	return result.Error
+}
+
+// DeleteByContentID, bir içerikle ilgili tüm bildirimleri siler
+func (r *NotificationRepository) DeleteByContentID(contentID uint, contentType string) error {
+	result := r.db.Where("content_id = ? AND content_type = ?", contentID, contentType).Delete(&NotificationModel{})
+	return result.Error
+}
+
+// NotificationPreferenceRepository, domain.NotificationPreferenceRepository arayüzünün PostgreSQL implementasyonu
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPreferenceRepository, yeni bir NotificationPreferenceRepository örneği oluşturur
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+// FindByUserID, kullanıcının kayıtlı tercihlerini bulur
+func (r *NotificationPreferenceRepository) FindByUserID(userID uint) (*domain.NotificationPreferences, error) {
+	var model NotificationPreferenceModel
+	result := r.db.Where("user_id = ?", userID).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Tercih kaydı yok
+		}
+		return nil, result.Error
+	}
+	return model.ToEntity(), nil
+}
+
+// Save, kullanıcının tercihlerini oluşturur veya günceller
+func (r *NotificationPreferenceRepository) Save(preferences *domain.NotificationPreferences) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var existing NotificationPreferenceModel
+		result := tx.Where("user_id = ?", preferences.UserID).Limit(1).Find(&existing)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		model := NotificationPreferenceModel{
+			ID:       existing.ID,
+			UserID:   preferences.UserID,
+			Likes:    preferences.Likes,
+			Comments: preferences.Comments,
+			Mentions: preferences.Mentions,
+			Invites:  preferences.Invites,
+		}
+		// Save, ID boşsa kayıt oluşturur; doluysa false değerler dahil tüm alanları günceller
+		if err := tx.Save(&model).Error; err != nil {
+			return err
+		}
+
+		preferences.UpdatedAt = model.UpdatedAt
+		return nil
+	})
+}
+
+// Ensure NotificationRepository implements domain.NotificationRepository
+var _ domain.NotificationRepository = (*NotificationRepository)(nil)
+
+// Ensure NotificationPreferenceRepository implements domain.NotificationPreferenceRepository
+var _ domain.NotificationPreferenceRepository = (*NotificationPreferenceRepository)(nil)
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// PDFPageModel, PDF sayfalarından çıkarılan metinlerin veritabanı modeli.
+// Metinler her çıkarmada toptan değiştirildiği için güncelleme ve silme zaman damgası tutulmaz.
+type PDFPageModel struct {
+	ID         uint   `gorm:"primaryKey"`
+	PDFID      uint   `gorm:"not null;uniqueIndex:idx_pdf_page_number"`
+	PageNumber int    `gorm:"not null;uniqueIndex:idx_pdf_page_number"`
+	Text       string `gorm:"type:text"`
+	CreatedAt  time.Time
+}
+
+// TableName, tablo adını belirtir
+func (PDFPageModel) TableName() string {
+	return "pdf_pages"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *PDFPageModel) ToEntity() *domain.PDFPage {
+	return &domain.PDFPage{
+		PDFID:      m.PDFID,
+		PageNumber: m.PageNumber,
+		Text:       m.Text,
+	}
+}
+
+// PDFPageRepository, domain.PDFPageRepository arayüzünün PostgreSQL implementasyonu
+type PDFPageRepository struct {
+	db *gorm.DB
+}
+
+// NewPDFPageRepository, yeni bir PDFPageRepository örneği oluşturur
+func NewPDFPageRepository(db *gorm.DB) *PDFPageRepository {
+	return &PDFPageRepository{db: db}
+}
+
+// FindByPDFID, PDF'in sayfa metinlerini sayfa numarasına göre sıralı getirir
+func (r *PDFPageRepository) FindByPDFID(pdfID uint, limit, offset int) ([]*domain.PDFPage, error) {
+	var models []PDFPageModel
+	result := r.db.Where("pdf_id = ?", pdfID).
+		Order("page_number").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	pages := make([]*domain.PDFPage, 0, len(models))
+	for i := range models {
+		pages = append(pages, models[i].ToEntity())
+	}
+	return pages, nil
+}
+
+// Replace, PDF'in saklanan tüm sayfa metinlerini tek bir işlemde verilenlerle değiştirir
+func (r *PDFPageRepository) Replace(pdfID uint, pages []*domain.PDFPage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("pdf_id = ?", pdfID).Delete(&PDFPageModel{}).Error; err != nil {
+			return err
+		}
+		if len(pages) == 0 {
+			return nil
+		}
+
+		models := make([]PDFPageModel, 0, len(pages))
+		for _, page := range pages {
+			models = append(models, PDFPageModel{
+				PDFID:      pdfID,
+				PageNumber: page.PageNumber,
+				Text:       page.Text,
+			})
+		}
+		return tx.CreateInBatches(models, 100).Error
+	})
+}
+
+// Ensure PDFPageRepository implements domain.PDFPageRepository
+var _ domain.PDFPageRepository = (*PDFPageRepository)(nil)
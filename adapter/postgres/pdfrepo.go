@@ -1,7 +1,11 @@
 package postgres
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/OmerFErdogan/uninote/domain"
 	"gorm.io/gorm"
@@ -10,40 +14,91 @@ import (
 // PDFModel, PDF varlığının veritabanı modelini temsil eder
 type PDFModel struct {
 	gorm.Model
-	Title        string     `gorm:"not null"`
-	Description  string     `gorm:"type:text"`
-	FilePath     string     `gorm:"not null"`
-	FileSize     int64      `gorm:"not null"`
-	UserID       uint       `gorm:"not null"`
-	Tags         []TagModel `gorm:"many2many:pdf_tags;"`
-	IsPublic     bool
-	ViewCount    int
-	LikeCount    int
-	CommentCount int
+	Title         string     `gorm:"not null"`
+	Description   string     `gorm:"type:text"`
+	FilePath      string     `gorm:"not null;index"`
+	FileSize      int64      `gorm:"not null"`
+	ContentHash   string     `gorm:"size:64;index"`
+	PageCount     int        `gorm:"not null;default:0"`
+	PDFVersion    string     `gorm:"size:10"`
+	ActiveContent string     `gorm:"size:255"` // Virgülle ayrılmış etkin içerik türleri
+	HasThumbnail  bool       `gorm:"not null;default:false"`
+	PreviewPages  int        `gorm:"not null;default:0"`
+	UserID        uint       `gorm:"not null"`
+	Tags          []TagModel `gorm:"many2many:pdf_tags;"`
+	IsPublic      bool
+	ViewCount     int
+	LikeCount     int
+	CommentCount  int
+	Version       int `gorm:"not null;default:1"`
 }
 
 // PDFCommentModel, PDFComment varlığının veritabanı modelini temsil eder
 type PDFCommentModel struct {
 	gorm.Model
-	PDFID      uint   `gorm:"not null"`
-	UserID     uint   `gorm:"not null"`
-	Content    string `gorm:"type:text;not null"`
-	PageNumber int
+	PDFID       uint   `gorm:"not null"`
+	UserID      uint   `gorm:"not null"`
+	Content     string `gorm:"type:text;not null"`
+	PageNumber  int
+	QuoteExact  string `gorm:"type:text"` // Alıntılanan metin; boşsa yorum metne bağlı değildir
+	QuotePrefix string `gorm:"type:text"`
+	QuoteSuffix string `gorm:"type:text"`
 }
 
 // PDFAnnotationModel, PDFAnnotation varlığının veritabanı modelini temsil eder
 type PDFAnnotationModel struct {
 	gorm.Model
-	PDFID      uint    `gorm:"not null"`
-	UserID     uint    `gorm:"not null"`
-	PageNumber int     `gorm:"not null"`
-	Content    string  `gorm:"type:text"`
-	X          float64 `gorm:"not null"`
-	Y          float64 `gorm:"not null"`
-	Width      float64 `gorm:"not null"`
-	Height     float64 `gorm:"not null"`
-	Type       string  `gorm:"not null"` // highlight, underline, note, etc.
-	Color      string  `gorm:"not null"`
+	PDFID       uint    `gorm:"not null"`
+	UserID      uint    `gorm:"not null"`
+	PageNumber  int     `gorm:"not null"`
+	Content     string  `gorm:"type:text"`
+	X           float64 `gorm:"not null"`
+	Y           float64 `gorm:"not null"`
+	Width       float64 `gorm:"not null"`
+	Height      float64 `gorm:"not null"`
+	Type        string  `gorm:"not null"` // highlight, underline, note, etc.
+	Color       string  `gorm:"not null"`
+	Rects       string  `gorm:"type:text"` // JSON dizisi olarak saklanır
+	QuoteExact  string  `gorm:"type:text"` // İşaretlenen metin; boşsa işaretleme metne bağlı değildir
+	QuotePrefix string  `gorm:"type:text"`
+	QuoteSuffix string  `gorm:"type:text"`
+	Visibility  string  `gorm:"size:20;not null;default:'private';index"` // private, shared veya public
+}
+
+// quoteFromColumns, alıntı sütunlarından TextQuoteSelector oluşturur; alıntı yoksa nil döner
+func quoteFromColumns(exact, prefix, suffix string) *domain.TextQuoteSelector {
+	if exact == "" {
+		return nil
+	}
+	return &domain.TextQuoteSelector{Exact: exact, Prefix: prefix, Suffix: suffix}
+}
+
+// quoteColumns, alıntıyı sütun değerlerine ayırır; alıntı yoksa boş değerler döner
+func quoteColumns(quote *domain.TextQuoteSelector) (exact, prefix, suffix string) {
+	if quote == nil {
+		return "", "", ""
+	}
+	return quote.Exact, quote.Prefix, quote.Suffix
+}
+
+// encodeRects, satır dikdörtgenlerini JSON dizisi olarak kodlar; dikdörtgen yoksa boş değer döner
+func encodeRects(rects []domain.PDFRect) (string, error) {
+	if len(rects) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(rects)
+	if err != nil {
+		return "", fmt.Errorf("işaretleme dikdörtgenleri kodlanırken hata: %w", err)
+	}
+	return string(data), nil
+}
+
+// splitList, virgülle ayrılmış sütun değerini listeye çevirir; boş değer için nil döner
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
 }
 
 // ToEntity, veritabanı modelini domain varlığına dönüştürür
@@ -54,19 +109,26 @@ func (p *PDFModel) ToEntity() *domain.PDF {
 	}
 
 	return &domain.PDF{
-		ID:           uint(p.ID),
-		Title:        p.Title,
-		Description:  p.Description,
-		FilePath:     p.FilePath,
-		FileSize:     p.FileSize,
-		UserID:       p.UserID,
-		Tags:         tags,
-		IsPublic:     p.IsPublic,
-		ViewCount:    p.ViewCount,
-		LikeCount:    p.LikeCount,
-		CommentCount: p.CommentCount,
-		CreatedAt:    p.CreatedAt,
-		UpdatedAt:    p.UpdatedAt,
+		ID:            uint(p.ID),
+		Title:         p.Title,
+		Description:   p.Description,
+		FilePath:      p.FilePath,
+		FileSize:      p.FileSize,
+		ContentHash:   p.ContentHash,
+		PageCount:     p.PageCount,
+		PDFVersion:    p.PDFVersion,
+		ActiveContent: splitList(p.ActiveContent),
+		HasThumbnail:  p.HasThumbnail,
+		PreviewPages:  p.PreviewPages,
+		UserID:        p.UserID,
+		Tags:          tags,
+		IsPublic:      p.IsPublic,
+		ViewCount:     p.ViewCount,
+		LikeCount:     p.LikeCount,
+		CommentCount:  p.CommentCount,
+		Version:       p.Version,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
 	}
 }
 
@@ -78,6 +140,7 @@ func (c *PDFCommentModel) ToEntity() *domain.PDFComment {
 		UserID:     c.UserID,
 		Content:    c.Content,
 		PageNumber: c.PageNumber,
+		Quote:      quoteFromColumns(c.QuoteExact, c.QuotePrefix, c.QuoteSuffix),
 		CreatedAt:  c.CreatedAt,
 		UpdatedAt:  c.UpdatedAt,
 	}
@@ -85,6 +148,11 @@ func (c *PDFCommentModel) ToEntity() *domain.PDFComment {
 
 // ToEntity, veritabanı modelini domain varlığına dönüştürür
 func (a *PDFAnnotationModel) ToEntity() *domain.PDFAnnotation {
+	var rects []domain.PDFRect
+	if a.Rects != "" {
+		json.Unmarshal([]byte(a.Rects), &rects)
+	}
+
 	return &domain.PDFAnnotation{
 		ID:         uint(a.ID),
 		PDFID:      a.PDFID,
@@ -95,8 +163,11 @@ func (a *PDFAnnotationModel) ToEntity() *domain.PDFAnnotation {
 		Y:          a.Y,
 		Width:      a.Width,
 		Height:     a.Height,
+		Rects:      rects,
+		Quote:      quoteFromColumns(a.QuoteExact, a.QuotePrefix, a.QuoteSuffix),
 		Type:       a.Type,
 		Color:      a.Color,
+		Visibility: a.Visibility,
 		CreatedAt:  a.CreatedAt,
 		UpdatedAt:  a.UpdatedAt,
 	}
@@ -175,37 +246,71 @@ func (r *PDFRepository) FindByTag(tag string, limit, offset int) ([]*domain.PDF,
 	return domainPDFs, nil
 }
 
-// Search, arama sorgusuna göre PDF'leri bulur
-func (r *PDFRepository) Search(query string, limit, offset int) ([]*domain.PDF, error) {
-	var pdfs []PDFModel
-	result := r.db.Preload("Tags").
-		Where("title ILIKE ? OR description ILIKE ?", "%"+query+"%", "%"+query+"%").
-		Limit(limit).Offset(offset).
-		Find(&pdfs)
-	if result.Error != nil {
-		return nil, result.Error
+// Search, tam metin arama sorgusuna uyan herkese açık (ve sorgudaki kullanıcının) PDF'leri puana göre sıralı döndürür.
+// PDF'lerin başlık ve açıklamalarının yanında sayfalarından çıkarılan metinler de aranır.
+func (r *PDFRepository) Search(query *domain.SearchQuery) ([]*domain.SearchHit, error) {
+	search := pdfFullTextSearch
+	if !supportsFullTextSearch(r.db) {
+		search = sqlitePDFFullTextSearch
 	}
 
-	var domainPDFs []*domain.PDF
+	rows, err := search(r.db, query)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return []*domain.SearchHit{}, nil
+	}
+
+	ids := make([]uint, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var pdfs []PDFModel
+	if err := r.db.Preload("Tags").Where("id IN ?", ids).Find(&pdfs).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*domain.PDF, len(pdfs))
 	for _, pdf := range pdfs {
-		domainPDFs = append(domainPDFs, pdf.ToEntity())
+		byID[pdf.ID] = pdf.ToEntity()
 	}
-	return domainPDFs, nil
+
+	hits := make([]*domain.SearchHit, 0, len(rows))
+	for _, row := range rows {
+		pdf, ok := byID[row.ID]
+		if !ok {
+			continue // Sorgular arasında silinmiş
+		}
+		hits = append(hits, &domain.SearchHit{
+			ContentType: "pdf",
+			Rank:        row.Rank,
+			Headline:    row.Headline,
+			Snippet:     row.Snippet,
+			PageNumber:  row.PageNumber,
+			PDF:         pdf,
+		})
+	}
+	return hits, nil
 }
 
 // Create, yeni bir PDF oluşturur
 func (r *PDFRepository) Create(pdf *domain.PDF) error {
 	// PDF modelini oluştur
 	pdfModel := PDFModel{
-		Title:        pdf.Title,
-		Description:  pdf.Description,
-		FilePath:     pdf.FilePath,
-		FileSize:     pdf.FileSize,
-		UserID:       pdf.UserID,
-		IsPublic:     pdf.IsPublic,
-		ViewCount:    pdf.ViewCount,
-		LikeCount:    pdf.LikeCount,
-		CommentCount: pdf.CommentCount,
+		Title:         pdf.Title,
+		Description:   pdf.Description,
+		FilePath:      pdf.FilePath,
+		FileSize:      pdf.FileSize,
+		ContentHash:   pdf.ContentHash,
+		PageCount:     pdf.PageCount,
+		PDFVersion:    pdf.PDFVersion,
+		ActiveContent: strings.Join(pdf.ActiveContent, ","),
+		UserID:        pdf.UserID,
+		IsPublic:      pdf.IsPublic,
+		ViewCount:     pdf.ViewCount,
+		LikeCount:     pdf.LikeCount,
+		CommentCount:  pdf.CommentCount,
+		Version:       1,
 	}
 
 	// Etiketleri işle
@@ -227,44 +332,62 @@ func (r *PDFRepository) Create(pdf *domain.PDF) error {
 		return result.Error
 	}
 
-	// ID'yi güncelle
+	// ID ve sürümü güncelle
 	pdf.ID = uint(pdfModel.ID)
+	pdf.Version = pdfModel.Version
 	return nil
 }
 
-// Update, bir PDF'i günceller
+// Update, bir PDF'in meta verilerini günceller. Güncelleme yalnızca saklanan sürüm
+// pdf.Version ile eşleşiyorsa yapılır; başarılı olursa sürüm bir artırılır ve pdf.Version'a yazılır.
 func (r *PDFRepository) Update(pdf *domain.PDF) error {
-	// Mevcut PDF'i bul
-	var pdfModel PDFModel
-	result := r.db.First(&pdfModel, pdf.ID)
-	if result.Error != nil {
-		return result.Error
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Mevcut PDF'i bul
+		var pdfModel PDFModel
+		if err := tx.First(&pdfModel, pdf.ID).Error; err != nil {
+			return err
+		}
+		if pdfModel.Version != pdf.Version {
+			return domain.ErrVersionConflict
+		}
 
-	// PDF'i güncelle
-	pdfModel.Title = pdf.Title
-	pdfModel.Description = pdf.Description
-	pdfModel.IsPublic = pdf.IsPublic
+		// Sürüm hala aynıysa PDF'i güncelle; dosya bilgileri ve sayaçlara dokunulmaz
+		result := tx.Model(&PDFModel{}).
+			Where("id = ? AND version = ?", pdf.ID, pdf.Version).
+			Updates(map[string]interface{}{
+				"title":       pdf.Title,
+				"description": pdf.Description,
+				"is_public":   pdf.IsPublic,
+				"version":     gorm.Expr("version + 1"),
+				"updated_at":  time.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrVersionConflict
+		}
 
-	// Etiketleri temizle
-	r.db.Model(&pdfModel).Association("Tags").Clear()
+		// Etiketleri temizle
+		if err := tx.Model(&pdfModel).Association("Tags").Clear(); err != nil {
+			return err
+		}
 
-	// Etiketleri işle
-	if len(pdf.Tags) > 0 {
+		// Etiketleri işle
 		for _, tagName := range pdf.Tags {
 			var tag TagModel
 			// Etiketi bul veya oluştur
-			result := r.db.Where("name = ?", tagName).FirstOrCreate(&tag, TagModel{Name: tagName})
-			if result.Error != nil {
-				return result.Error
+			if err := tx.Where("name = ?", tagName).FirstOrCreate(&tag, TagModel{Name: tagName}).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&pdfModel).Association("Tags").Append(&tag); err != nil {
+				return err
 			}
-			r.db.Model(&pdfModel).Association("Tags").Append(&tag)
 		}
-	}
 
-	// PDF'i kaydet
-	result = r.db.Save(&pdfModel)
-	return result.Error
+		pdf.Version = pdfModel.Version + 1
+		return nil
+	})
 }
 
 // Delete, bir PDF'i siler
@@ -276,7 +399,25 @@ func (r *PDFRepository) Delete(id uint) error {
 	r.db.Where("pdf_id = ?", id).Delete(&PDFAnnotationModel{})
 
 	// İlişkili beğenileri sil
-	r.db.Where("content_id = ? AND type = ?", id, "pdf").Delete(&ContentLikeModel{})
+	r.db.Unscoped().Where("content_id = ? AND type = ?", id, "pdf").Delete(&ContentLikeModel{})
+
+	// İlişkili bildirimleri sil
+	r.db.Where("content_id = ? AND content_type = ?", id, "pdf").Delete(&NotificationModel{})
+
+	// Sayfa metinlerini sil
+	r.db.Where("pdf_id = ?", id).Delete(&PDFPageModel{})
+
+	// Koleksiyonlardan çıkar
+	r.db.Where("content_id = ? AND content_type = ?", id, "pdf").Delete(&CollectionItemModel{})
+
+	// Ders bağlantısını sil
+	r.db.Where("content_id = ? AND content_type = ?", id, "pdf").Delete(&CourseContentModel{})
+
+	// Grup alanlarından çıkar
+	r.db.Where("content_id = ? AND content_type = ?", id, "pdf").Delete(&GroupContentModel{})
+
+	// Kullanıcı paylaşımlarını sil
+	r.db.Where("content_id = ? AND content_type = ?", id, "pdf").Delete(&ContentShareModel{})
 
 	// PDF'i sil
 	result := r.db.Delete(&PDFModel{}, id)
@@ -301,6 +442,98 @@ func (r *PDFRepository) DecrementLikeCount(id uint) error {
 	return result.Error
 }
 
+// UpdatePageCount, PDF'in sayfa sayısını günceller; sürüm ve güncelleme zamanı değişmez
+func (r *PDFRepository) UpdatePageCount(id uint, pageCount int) error {
+	result := r.db.Model(&PDFModel{}).Where("id = ?", id).UpdateColumn("page_count", pageCount)
+	return result.Error
+}
+
+// FindAll, tüm PDF'leri ID sırasıyla getirir
+func (r *PDFRepository) FindAll(limit, offset int) ([]*domain.PDF, error) {
+	var pdfs []PDFModel
+	result := r.db.Preload("Tags").Order("id").Limit(limit).Offset(offset).Find(&pdfs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var domainPDFs []*domain.PDF
+	for _, pdf := range pdfs {
+		domainPDFs = append(domainPDFs, pdf.ToEntity())
+	}
+	return domainPDFs, nil
+}
+
+// UpdateFilePath, PDF'in dosya yolunu günceller; sürüm ve güncelleme zamanı değişmez
+func (r *PDFRepository) UpdateFilePath(id uint, filePath string) error {
+	result := r.db.Model(&PDFModel{}).Where("id = ?", id).UpdateColumn("file_path", filePath)
+	return result.Error
+}
+
+// CountByFilePath, dosya yolunu paylaşan PDF sayısını döndürür
+func (r *PDFRepository) CountByFilePath(filePath string) (int, error) {
+	var count int64
+	result := r.db.Model(&PDFModel{}).Where("file_path = ?", filePath).Count(&count)
+	return int(count), result.Error
+}
+
+// FindPublicByContentHash, içerik özeti eşleşen herkese açık PDF'leri bulur
+func (r *PDFRepository) FindPublicByContentHash(contentHash string, limit int) ([]*domain.PDF, error) {
+	var pdfs []PDFModel
+	result := r.db.Preload("Tags").Where("content_hash = ? AND is_public = ?", contentHash, true).Order("id").Limit(limit).Find(&pdfs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var domainPDFs []*domain.PDF
+	for _, pdf := range pdfs {
+		domainPDFs = append(domainPDFs, pdf.ToEntity())
+	}
+	return domainPDFs, nil
+}
+
+// CountByContentHash, içerik özeti eşleşen PDF sayısını döndürür
+func (r *PDFRepository) CountByContentHash(contentHash string) (int, error) {
+	var count int64
+	result := r.db.Model(&PDFModel{}).Where("content_hash = ?", contentHash).Count(&count)
+	return int(count), result.Error
+}
+
+// UpdatePreviews, PDF'in kapak ve önizleme görseli bilgilerini günceller; sürüm ve güncelleme zamanı değişmez
+func (r *PDFRepository) UpdatePreviews(id uint, hasThumbnail bool, previewPages int) error {
+	result := r.db.Model(&PDFModel{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"has_thumbnail": hasThumbnail,
+		"preview_pages": previewPages,
+	})
+	return result.Error
+}
+
+// FindWithoutContentHash, içerik özeti olmayan eski PDF'leri ID sırasıyla bulur. content_hash sütunu
+// sonradan eklendiği için eski kayıtlarda NULL olabilir.
+func (r *PDFRepository) FindWithoutContentHash(afterID uint, limit int) ([]*domain.PDF, error) {
+	var pdfs []PDFModel
+	result := r.db.Preload("Tags").
+		Where("id > ? AND (content_hash IS NULL OR content_hash = '')", afterID).
+		Order("id").Limit(limit).Find(&pdfs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var domainPDFs []*domain.PDF
+	for _, pdf := range pdfs {
+		domainPDFs = append(domainPDFs, pdf.ToEntity())
+	}
+	return domainPDFs, nil
+}
+
+// UpdateContentHash, PDF'in içerik özetini ve dosya yolunu günceller; sürüm ve güncelleme zamanı değişmez
+func (r *PDFRepository) UpdateContentHash(id uint, filePath, contentHash string) error {
+	result := r.db.Model(&PDFModel{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"file_path":    filePath,
+		"content_hash": contentHash,
+	})
+	return result.Error
+}
+
 // PDFCommentRepository, domain.PDFCommentRepository arayüzünün PostgreSQL implementasyonu
 type PDFCommentRepository struct {
 	db *gorm.DB
@@ -334,6 +567,7 @@ func (r *PDFCommentRepository) Create(comment *domain.PDFComment) error {
 		Content:    comment.Content,
 		PageNumber: comment.PageNumber,
 	}
+	commentModel.QuoteExact, commentModel.QuotePrefix, commentModel.QuoteSuffix = quoteColumns(comment.Quote)
 
 	result := r.db.Create(&commentModel)
 	if result.Error != nil {
@@ -358,9 +592,13 @@ func (r *PDFCommentRepository) Update(comment *domain.PDFComment) error {
 		PageNumber: comment.PageNumber,
 	}
 
+	exact, prefix, suffix := quoteColumns(comment.Quote)
 	result := r.db.Model(&commentModel).Updates(map[string]interface{}{
-		"content":     comment.Content,
-		"page_number": comment.PageNumber,
+		"content":      comment.Content,
+		"page_number":  comment.PageNumber,
+		"quote_exact":  exact,
+		"quote_prefix": prefix,
+		"quote_suffix": suffix,
 	})
 	return result.Error
 }
@@ -396,6 +634,19 @@ func NewPDFAnnotationRepository(db *gorm.DB) *PDFAnnotationRepository {
 	return &PDFAnnotationRepository{db: db}
 }
 
+// FindByID, ID'ye göre bir işaretleme bulur
+func (r *PDFAnnotationRepository) FindByID(id uint) (*domain.PDFAnnotation, error) {
+	var annotation PDFAnnotationModel
+	result := r.db.First(&annotation, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // İşaretleme bulunamadı
+		}
+		return nil, result.Error
+	}
+	return annotation.ToEntity(), nil
+}
+
 // FindByPDFID, PDF ID'sine göre işaretlemeleri bulur
 func (r *PDFAnnotationRepository) FindByPDFID(pdfID uint, limit, offset int) ([]*domain.PDFAnnotation, error) {
 	var annotations []PDFAnnotationModel
@@ -426,8 +677,32 @@ func (r *PDFAnnotationRepository) FindByPDFIDAndUserID(pdfID, userID uint) ([]*d
 	return domainAnnotations, nil
 }
 
+// FindVisibleByPDFID, PDF'in verilen görünürlüklerdeki, başka kullanıcılara ait işaretlemelerini
+// kullanıcıya göre gruplanmış olarak bulur
+func (r *PDFAnnotationRepository) FindVisibleByPDFID(pdfID uint, visibilities []string, excludeUserID uint, limit int) ([]*domain.PDFAnnotation, error) {
+	var annotations []PDFAnnotationModel
+	result := r.db.Where("pdf_id = ? AND visibility IN ? AND user_id <> ?", pdfID, visibilities, excludeUserID).
+		Order("user_id, id").
+		Limit(limit).
+		Find(&annotations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var domainAnnotations []*domain.PDFAnnotation
+	for _, annotation := range annotations {
+		domainAnnotations = append(domainAnnotations, annotation.ToEntity())
+	}
+	return domainAnnotations, nil
+}
+
 // Create, yeni bir işaretleme oluşturur
 func (r *PDFAnnotationRepository) Create(annotation *domain.PDFAnnotation) error {
+	rects, err := encodeRects(annotation.Rects)
+	if err != nil {
+		return err
+	}
+
 	annotationModel := PDFAnnotationModel{
 		PDFID:      annotation.PDFID,
 		UserID:     annotation.UserID,
@@ -439,7 +714,10 @@ func (r *PDFAnnotationRepository) Create(annotation *domain.PDFAnnotation) error
 		Height:     annotation.Height,
 		Type:       annotation.Type,
 		Color:      annotation.Color,
+		Rects:      rects,
+		Visibility: annotation.Visibility,
 	}
+	annotationModel.QuoteExact, annotationModel.QuotePrefix, annotationModel.QuoteSuffix = quoteColumns(annotation.Quote)
 
 	result := r.db.Create(&annotationModel)
 	if result.Error != nil {
@@ -453,6 +731,11 @@ func (r *PDFAnnotationRepository) Create(annotation *domain.PDFAnnotation) error
 
 // Update, bir işaretlemeyi günceller
 func (r *PDFAnnotationRepository) Update(annotation *domain.PDFAnnotation) error {
+	rects, err := encodeRects(annotation.Rects)
+	if err != nil {
+		return err
+	}
+
 	annotationModel := PDFAnnotationModel{
 		Model: gorm.Model{
 			ID: uint(annotation.ID),
@@ -467,15 +750,21 @@ func (r *PDFAnnotationRepository) Update(annotation *domain.PDFAnnotation) error
 		PageNumber: annotation.PageNumber,
 	}
 
+	exact, prefix, suffix := quoteColumns(annotation.Quote)
 	result := r.db.Model(&annotationModel).Updates(map[string]interface{}{
-		"content":     annotation.Content,
-		"x":           annotation.X,
-		"y":           annotation.Y,
-		"width":       annotation.Width,
-		"height":      annotation.Height,
-		"type":        annotation.Type,
-		"color":       annotation.Color,
-		"page_number": annotation.PageNumber,
+		"content":      annotation.Content,
+		"x":            annotation.X,
+		"y":            annotation.Y,
+		"width":        annotation.Width,
+		"height":       annotation.Height,
+		"rects":        rects,
+		"quote_exact":  exact,
+		"quote_prefix": prefix,
+		"quote_suffix": suffix,
+		"type":         annotation.Type,
+		"color":        annotation.Color,
+		"visibility":   annotation.Visibility,
+		"page_number":  annotation.PageNumber,
 	})
 	return result.Error
 }
@@ -0,0 +1,181 @@
+package postgres_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/adapter/postgres"
+	"github.com/OmerFErdogan/uninote/adapter/repotest"
+	"github.com/OmerFErdogan/uninote/adapter/sqlite"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestRepositories, GORM repository'lerinin ortak sözleşmeyi sağladığını SQLite üzerinde doğrular.
+// Her alt test, tüm migrasyonları uygulanmış boş bir veritabanı kullanır.
+func TestRepositories(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) *repotest.Repositories {
+		db, err := sqlite.NewConnection(&sqlite.Config{Path: filepath.Join(t.TempDir(), "uninotes.db")})
+		if err != nil {
+			t.Fatalf("veritabanı bağlantısı kurulamadı: %v", err)
+		}
+		db.Logger = logger.Default.LogMode(logger.Silent)
+		t.Cleanup(func() {
+			if sqlDB, err := db.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		if err := postgres.NewMigrator(db).Up(); err != nil {
+			t.Fatalf("migrasyonlar uygulanamadı: %v", err)
+		}
+		return newRepositories(db)
+	})
+}
+
+// TestRepositoriesPostgres, aynı sözleşmeyi TEST_POSTGRES_DSN ile verilen PostgreSQL veritabanında doğrular.
+// Her alt test kendi şemasında çalışır ve şema test sonunda silinir; DSN verilmezse test atlanır.
+func TestRepositoriesPostgres(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN tanımlı değil")
+	}
+	admin := openPostgres(t, dsn)
+
+	var counter int
+	repotest.Run(t, func(t *testing.T) *repotest.Repositories {
+		counter++
+		schema := fmt.Sprintf("repotest_%d_%d", os.Getpid(), counter)
+		if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+			t.Fatalf("şema oluşturulamadı: %v", err)
+		}
+		t.Cleanup(func() {
+			admin.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE")
+		})
+
+		db := openPostgres(t, withSearchPath(dsn, schema))
+		if err := postgres.NewMigrator(db).Up(); err != nil {
+			t.Fatalf("migrasyonlar uygulanamadı: %v", err)
+		}
+		return newRepositories(db)
+	})
+}
+
+// openPostgres, verilen DSN ile sessiz loglu bir PostgreSQL bağlantısı açar ve test sonunda kapatır
+func openPostgres(t *testing.T, dsn string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(gormpostgres.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Silent),
+		TranslateError: true,
+	})
+	if err != nil {
+		t.Fatalf("veritabanı bağlantısı kurulamadı: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// withSearchPath, DSN'e şemayı arama yolu olarak ekler; URL ve anahtar=değer biçimlerini destekler
+func withSearchPath(dsn, schema string) string {
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		return dsn + separator + "search_path=" + schema
+	}
+	return dsn + " search_path=" + schema
+}
+
+// newRepositories, verilen bağlantı üzerinde tüm GORM repository'lerini oluşturur
+func newRepositories(db *gorm.DB) *repotest.Repositories {
+	return &repotest.Repositories{
+		Users:                   postgres.NewUserRepository(db),
+		Notes:                   postgres.NewNoteRepository(db),
+		Comments:                postgres.NewCommentRepository(db),
+		PDFs:                    postgres.NewPDFRepository(db),
+		PDFComments:             postgres.NewPDFCommentRepository(db),
+		PDFAnnotations:          postgres.NewPDFAnnotationRepository(db),
+		PDFPages:                postgres.NewPDFPageRepository(db),
+		Likes:                   postgres.NewLikeRepository(db),
+		Views:                   postgres.NewViewRepository(db),
+		Invites:                 postgres.NewInviteRepository(db),
+		Tokens:                  postgres.NewTokenRepository(db),
+		LoginAttempts:           postgres.NewLoginAttemptRepository(db),
+		NoteRevisions:           postgres.NewNoteRevisionRepository(db),
+		Notifications:           postgres.NewNotificationRepository(db),
+		NotificationPreferences: postgres.NewNotificationPreferenceRepository(db),
+		Jobs:                    postgres.NewJobRepository(db),
+		Collections:             postgres.NewCollectionRepository(db),
+		Catalog:                 postgres.NewCatalogRepository(db),
+		Groups:                  postgres.NewGroupRepository(db),
+		Shares:                  postgres.NewShareRepository(db),
+	}
+}
+
+// TestMigrationsRollback, tüm migrasyonların geri alınıp yeniden uygulanabildiğini doğrular
+func TestMigrationsRollback(t *testing.T) {
+	db, err := sqlite.NewConnection(&sqlite.Config{Path: filepath.Join(t.TempDir(), "uninotes.db")})
+	if err != nil {
+		t.Fatalf("veritabanı bağlantısı kurulamadı: %v", err)
+	}
+	db.Logger = logger.Default.LogMode(logger.Silent)
+	migrator := postgres.NewMigrator(db)
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("migrasyonlar uygulanamadı: %v", err)
+	}
+	if err := migrator.To(0); err != nil {
+		t.Fatalf("migrasyonlar geri alınamadı: %v", err)
+	}
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("migrasyonlar yeniden uygulanamadı: %v", err)
+	}
+}
+
+// TestLegacyPDFContentHash, content_hash sütunu eklenmeden önce oluşturulmuş bir PDF'in migrasyonlardan
+// sonra özeti olmayan PDF olarak bulunduğunu ve özeti kaydedildiğinde kopya olarak eşleştiğini doğrular
+func TestLegacyPDFContentHash(t *testing.T) {
+	db, err := sqlite.NewConnection(&sqlite.Config{Path: filepath.Join(t.TempDir(), "uninotes.db")})
+	if err != nil {
+		t.Fatalf("veritabanı bağlantısı kurulamadı: %v", err)
+	}
+	db.Logger = logger.Default.LogMode(logger.Silent)
+	migrator := postgres.NewMigrator(db)
+	if err := migrator.To(8); err != nil {
+		t.Fatalf("migrasyonlar uygulanamadı: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO pdf_models (created_at, updated_at, title, file_path, file_size, user_id, is_public)
+		VALUES (CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, 'Eski PDF', 'uploads/eski.pdf', 42, 1, true)`).Error; err != nil {
+		t.Fatalf("eski PDF eklenemedi: %v", err)
+	}
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("migrasyonlar uygulanamadı: %v", err)
+	}
+
+	pdfs := postgres.NewPDFRepository(db)
+	legacy, err := pdfs.FindWithoutContentHash(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(legacy) != 1 || legacy[0].FilePath != "uploads/eski.pdf" || legacy[0].ContentHash != "" {
+		t.Fatalf("eski PDF özeti olmayan PDF olarak bulunmalıydı: %+v", legacy)
+	}
+
+	if err := pdfs.UpdateContentHash(legacy[0].ID, "abc123.pdf", "abc123"); err != nil {
+		t.Fatal(err)
+	}
+	duplicates, err := pdfs.FindPublicByContentHash("abc123", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(duplicates) != 1 || duplicates[0].ID != legacy[0].ID || duplicates[0].FilePath != "abc123.pdf" {
+		t.Fatalf("özeti kaydedilen eski PDF kopya olarak bulunmalıydı: %+v", duplicates)
+	}
+}
@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// NoteRevisionModel, not revizyonlarının veritabanı modeli.
+// Revizyonlar değiştirilemez olduğu için güncelleme ve silme zaman damgası tutulmaz.
+type NoteRevisionModel struct {
+	ID           uint   `gorm:"primaryKey"`
+	NoteID       uint   `gorm:"not null;uniqueIndex:idx_note_revision_number"`
+	Number       int    `gorm:"not null;uniqueIndex:idx_note_revision_number"`
+	AuthorID     uint   `gorm:"not null;index"`
+	Title        string `gorm:"not null"`
+	Content      string `gorm:"type:text"`
+	Tags         string `gorm:"type:text"` // JSON dizisi olarak saklanır
+	RestoredFrom int
+	CreatedAt    time.Time `gorm:"not null"`
+}
+
+// TableName, tablo adını belirtir
+func (NoteRevisionModel) TableName() string {
+	return "note_revisions"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *NoteRevisionModel) ToEntity() *domain.NoteRevision {
+	tags := make([]string, 0)
+	if m.Tags != "" {
+		json.Unmarshal([]byte(m.Tags), &tags)
+	}
+
+	return &domain.NoteRevision{
+		ID:           m.ID,
+		NoteID:       m.NoteID,
+		Number:       m.Number,
+		AuthorID:     m.AuthorID,
+		Title:        m.Title,
+		Content:      m.Content,
+		Tags:         tags,
+		RestoredFrom: m.RestoredFrom,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// NoteRevisionRepository, NoteRevisionRepository arayüzünün PostgreSQL implementasyonu
+type NoteRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewNoteRevisionRepository, yeni bir NoteRevisionRepository örneği oluşturur
+func NewNoteRevisionRepository(db *gorm.DB) *NoteRevisionRepository {
+	return &NoteRevisionRepository{db: db}
+}
+
+// FindByNoteID, notun revizyonlarını en yeniden eskiye getirir
+func (r *NoteRevisionRepository) FindByNoteID(noteID uint, limit, offset int) ([]*domain.NoteRevision, error) {
+	var models []NoteRevisionModel
+	result := r.db.Where("note_id = ?", noteID).
+		Order("number DESC").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	revisions := make([]*domain.NoteRevision, 0, len(models))
+	for i := range models {
+		revisions = append(revisions, models[i].ToEntity())
+	}
+	return revisions, nil
+}
+
+// FindByNumber, notun belirli numaralı revizyonunu bulur
+func (r *NoteRevisionRepository) FindByNumber(noteID uint, number int) (*domain.NoteRevision, error) {
+	var model NoteRevisionModel
+	result := r.db.Where("note_id = ? AND number = ?", noteID, number).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Revizyon bulunamadı
+		}
+		return nil, result.Error
+	}
+	return model.ToEntity(), nil
+}
+
+// FindLatest, notun en son revizyonunu bulur
+func (r *NoteRevisionRepository) FindLatest(noteID uint) (*domain.NoteRevision, error) {
+	var model NoteRevisionModel
+	result := r.db.Where("note_id = ?", noteID).Order("number DESC").First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil // Revizyon bulunamadı
+		}
+		return nil, result.Error
+	}
+	return model.ToEntity(), nil
+}
+
+// Create, yeni bir revizyon oluşturur ve nota özgü bir sonraki numarayı atar
+func (r *NoteRevisionRepository) Create(revision *domain.NoteRevision) error {
+	tags, err := json.Marshal(revision.Tags)
+	if err != nil {
+		return fmt.Errorf("etiketler kodlanırken hata: %w", err)
+	}
+	if revision.Tags == nil {
+		tags = []byte("[]")
+	}
+
+	createdAt := revision.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	// Eşzamanlı kayıtlar aynı numarayı almaya çalışırsa benzersiz indeks ihlal edilir; tekrar dene
+	for attempt := 0; ; attempt++ {
+		model := NoteRevisionModel{
+			NoteID:       revision.NoteID,
+			AuthorID:     revision.AuthorID,
+			Title:        revision.Title,
+			Content:      revision.Content,
+			Tags:         string(tags),
+			RestoredFrom: revision.RestoredFrom,
+			CreatedAt:    createdAt,
+		}
+
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var last int
+			if err := tx.Model(&NoteRevisionModel{}).
+				Where("note_id = ?", revision.NoteID).
+				Select("COALESCE(MAX(number), 0)").
+				Scan(&last).Error; err != nil {
+				return err
+			}
+
+			model.Number = last + 1
+			return tx.Create(&model).Error
+		})
+		if err != nil {
+			if isDuplicateKeyError(err) && attempt < 3 {
+				continue
+			}
+			return err
+		}
+
+		// ID ve numarayı güncelle
+		revision.ID = model.ID
+		revision.Number = model.Number
+		revision.CreatedAt = model.CreatedAt
+		return nil
+	}
+}
+
+// Ensure NoteRevisionRepository implements domain.NoteRevisionRepository
+var _ domain.NoteRevisionRepository = (*NoteRevisionRepository)(nil)
@@ -0,0 +1,194 @@
+// Package schemav1, sürüm 1 veritabanı şemasının anlık görüntüsünü içerir.
+//
+// Tipler, eski sürümlerde AutoMigrate ile kullanılan postgres modelleriyle aynı
+// adları taşır; GORM indeks ve kısıt adlarını tip adlarından ürettiği için bu
+// sayede mevcut veritabanlarında ilk migrasyon hiçbir değişiklik yapmaz.
+// Bu paketteki tipler değiştirilmemelidir.
+package schemav1
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserModel, sürüm 1 şemasındaki user_models tablosunu temsil eder
+type UserModel struct {
+	gorm.Model
+	Username   string `gorm:"uniqueIndex;not null"`
+	Email      string `gorm:"uniqueIndex;not null"`
+	Password   string `gorm:"not null"`
+	FirstName  string
+	LastName   string
+	University string
+	Department string
+	Class      string
+}
+
+// TableName, tablo adını belirtir
+func (UserModel) TableName() string { return "user_models" }
+
+// TagModel, sürüm 1 şemasındaki tag_models tablosunu temsil eder
+type TagModel struct {
+	gorm.Model
+	Name  string      `gorm:"uniqueIndex;not null"`
+	Notes []NoteModel `gorm:"many2many:note_tags;"`
+}
+
+// TableName, tablo adını belirtir
+func (TagModel) TableName() string { return "tag_models" }
+
+// NoteModel, sürüm 1 şemasındaki note_models tablosunu temsil eder
+type NoteModel struct {
+	gorm.Model
+	Title        string     `gorm:"not null"`
+	Content      string     `gorm:"type:text"`
+	UserID       uint       `gorm:"not null"`
+	Tags         []TagModel `gorm:"many2many:note_tags;"`
+	IsPublic     bool
+	ViewCount    int
+	LikeCount    int
+	CommentCount int
+}
+
+// TableName, tablo adını belirtir
+func (NoteModel) TableName() string { return "note_models" }
+
+// CommentModel, sürüm 1 şemasındaki comment_models tablosunu temsil eder
+type CommentModel struct {
+	gorm.Model
+	NoteID  uint   `gorm:"not null"`
+	UserID  uint   `gorm:"not null"`
+	Content string `gorm:"type:text;not null"`
+}
+
+// TableName, tablo adını belirtir
+func (CommentModel) TableName() string { return "comment_models" }
+
+// PDFModel, sürüm 1 şemasındaki pdf_models tablosunu temsil eder
+type PDFModel struct {
+	gorm.Model
+	Title        string     `gorm:"not null"`
+	Description  string     `gorm:"type:text"`
+	FilePath     string     `gorm:"not null"`
+	FileSize     int64      `gorm:"not null"`
+	UserID       uint       `gorm:"not null"`
+	Tags         []TagModel `gorm:"many2many:pdf_tags;"`
+	IsPublic     bool
+	ViewCount    int
+	LikeCount    int
+	CommentCount int
+}
+
+// TableName, tablo adını belirtir
+func (PDFModel) TableName() string { return "pdf_models" }
+
+// PDFCommentModel, sürüm 1 şemasındaki pdf_comment_models tablosunu temsil eder
+type PDFCommentModel struct {
+	gorm.Model
+	PDFID      uint   `gorm:"not null"`
+	UserID     uint   `gorm:"not null"`
+	Content    string `gorm:"type:text;not null"`
+	PageNumber int
+}
+
+// TableName, tablo adını belirtir
+func (PDFCommentModel) TableName() string { return "pdf_comment_models" }
+
+// PDFAnnotationModel, sürüm 1 şemasındaki pdf_annotation_models tablosunu temsil eder
+type PDFAnnotationModel struct {
+	gorm.Model
+	PDFID      uint    `gorm:"not null"`
+	UserID     uint    `gorm:"not null"`
+	PageNumber int     `gorm:"not null"`
+	Content    string  `gorm:"type:text"`
+	X          float64 `gorm:"not null"`
+	Y          float64 `gorm:"not null"`
+	Width      float64 `gorm:"not null"`
+	Height     float64 `gorm:"not null"`
+	Type       string  `gorm:"not null"`
+	Color      string  `gorm:"not null"`
+}
+
+// TableName, tablo adını belirtir
+func (PDFAnnotationModel) TableName() string { return "pdf_annotation_models" }
+
+// ContentLikeModel, sürüm 1 şemasındaki content_like_models tablosunu temsil eder
+type ContentLikeModel struct {
+	gorm.Model
+	UserID    uint   `gorm:"not null;uniqueIndex:idx_user_content_type"`
+	ContentID uint   `gorm:"not null;uniqueIndex:idx_user_content_type"`
+	Type      string `gorm:"not null;uniqueIndex:idx_user_content_type"`
+}
+
+// TableName, tablo adını belirtir
+func (ContentLikeModel) TableName() string { return "content_like_models" }
+
+// InviteModel, sürüm 1 şemasındaki invites tablosunu temsil eder
+type InviteModel struct {
+	ID        uint   `gorm:"primaryKey"`
+	ContentID uint   `gorm:"index"`
+	Type      string `gorm:"size:10;index"`
+	Token     string `gorm:"size:100;uniqueIndex"`
+	CreatedBy uint   `gorm:"index"`
+	ExpiresAt time.Time
+	IsActive  bool `gorm:"default:true"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TableName, tablo adını belirtir
+func (InviteModel) TableName() string { return "invites" }
+
+// RevokedTokenModel, sürüm 1 şemasındaki revoked_tokens tablosunu temsil eder
+type RevokedTokenModel struct {
+	ID        uint      `gorm:"primaryKey"`
+	Token     string    `gorm:"type:text;not null;uniqueIndex"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	RevokedAt time.Time `gorm:"not null"`
+}
+
+// TableName, tablo adını belirtir
+func (RevokedTokenModel) TableName() string { return "revoked_tokens" }
+
+// LoginAttemptModel, sürüm 1 şemasındaki login_attempts tablosunu temsil eder
+type LoginAttemptModel struct {
+	ID         uint      `gorm:"primaryKey"`
+	IP         string    `gorm:"type:varchar(45);not null;index"`
+	Email      string    `gorm:"type:varchar(255);not null;index"`
+	Successful bool      `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"not null;index"`
+}
+
+// TableName, tablo adını belirtir
+func (LoginAttemptModel) TableName() string { return "login_attempts" }
+
+// ViewModel, sürüm 1 şemasındaki views tablosunu temsil eder
+type ViewModel struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index:idx_view_user"`
+	ContentID uint      `gorm:"not null;index:idx_view_content"`
+	Type      string    `gorm:"not null;index:idx_view_type"`
+	ViewedAt  time.Time `gorm:"not null"`
+}
+
+// TableName, tablo adını belirtir
+func (ViewModel) TableName() string { return "views" }
+
+// Models, sürüm 1 şemasındaki tüm modelleri döndürür
+func Models() []interface{} {
+	return []interface{}{
+		&UserModel{},
+		&NoteModel{},
+		&CommentModel{},
+		&PDFModel{},
+		&PDFCommentModel{},
+		&PDFAnnotationModel{},
+		&ContentLikeModel{},
+		&InviteModel{},
+		&RevokedTokenModel{},
+		&LoginAttemptModel{},
+		&ViewModel{},
+	}
+}
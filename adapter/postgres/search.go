@@ -0,0 +1,308 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/adapter/textsearch"
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// Eşleşen bölümleri işaretleyen ts_headline seçenekleri. İşaretler textsearch.FromMarkers ile
+// <mark> etiketine çevrilir; böylece içerikteki HTML kaçırılır, yalnızca vurgular etiket olur.
+var (
+	headlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, HighlightAll=true", textsearch.StartMarker, textsearch.StopMarker)
+	snippetOptions  = fmt.Sprintf(`StartSel=%s, StopSel=%s, MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=" … "`, textsearch.StartMarker, textsearch.StopMarker)
+)
+
+// searchRow, tam metin arama sorgusunun döndürdüğü satırdır
+type searchRow struct {
+	ID         uint
+	Rank       float64
+	Headline   string
+	Snippet    string
+	PageNumber int // Yalnızca PDF aramasında; eşleşen sayfa yoksa 0
+}
+
+// fullTextSearch, tablonun tsvector sütununda arama yapar ve eşleşen satırları puana göre sıralı döndürür.
+// Vurgulanmış metinler yalnızca istenen sayfadaki satırlar için hesaplanır.
+func fullTextSearch(db *gorm.DB, table, bodyColumn string, query *domain.SearchQuery) ([]searchRow, error) {
+	if !domain.IsSearchLanguage(query.Language) {
+		return nil, fmt.Errorf("desteklenmeyen arama dili: %q", query.Language)
+	}
+	terms := textsearch.NormalizeTerms(query.Terms)
+	if terms == nil {
+		return nil, nil
+	}
+	column := "search_" + query.Language
+
+	sql := `SELECT id, rank,
+			ts_headline(?::regconfig, title, q, ?) AS headline,
+			ts_headline(?::regconfig, ` + bodyColumn + `, q, ?) AS snippet
+		FROM (
+			SELECT t.id, t.title, t.` + bodyColumn + `, q, ts_rank_cd(t.` + column + `, q) AS rank
+			FROM ` + table + ` t, to_tsquery(?::regconfig, ?) q
+			WHERE t.deleted_at IS NULL AND t.` + column + ` @@ q AND (t.is_public OR t.user_id = ?)
+			ORDER BY rank DESC, t.id DESC
+			LIMIT ? OFFSET ?
+		) matches
+		ORDER BY rank DESC, id DESC`
+
+	var rows []searchRow
+	result := db.Raw(sql,
+		query.Language, headlineOptions,
+		query.Language, snippetOptions,
+		query.Language, tsQuery(terms),
+		query.UserID, query.Limit, query.Offset,
+	).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range rows {
+		rows[i].Headline = textsearch.FromMarkers(rows[i].Headline)
+		rows[i].Snippet = textsearch.FromMarkers(rows[i].Snippet)
+	}
+	return rows, nil
+}
+
+// pdfFullTextSearch, PDF'lerin başlık ve açıklamalarında ve sayfa metinlerinde (pdf_pages) arama yapar.
+// Aday PDF'ler iki tablonun GIN indeksleri üzerinden bulunur; her aday için en yüksek puanlı
+// eşleşen sayfa seçilir ve puanı PDF'in kendi puanına eklenir. Özet, eşleşen sayfa varsa o
+// sayfanın metninden, yoksa açıklamadan oluşturulur.
+func pdfFullTextSearch(db *gorm.DB, query *domain.SearchQuery) ([]searchRow, error) {
+	if !domain.IsSearchLanguage(query.Language) {
+		return nil, fmt.Errorf("desteklenmeyen arama dili: %q", query.Language)
+	}
+	terms := textsearch.NormalizeTerms(query.Terms)
+	if terms == nil {
+		return nil, nil
+	}
+	column := "search_" + query.Language
+
+	sql := `WITH search AS (
+				SELECT to_tsquery(?::regconfig, ?) AS q
+			), candidates AS (
+				SELECT t.id FROM pdf_models t, search WHERE t.` + column + ` @@ search.q
+				UNION
+				SELECT pg.pdf_id FROM pdf_pages pg, search WHERE pg.` + column + ` @@ search.q
+			)
+			SELECT id, rank, page_number,
+				ts_headline(?::regconfig, title, q, ?) AS headline,
+				ts_headline(?::regconfig, CASE WHEN page_number > 0 THEN page_text ELSE description END, q, ?) AS snippet
+			FROM (
+				SELECT t.id, t.title, t.description, search.q,
+					COALESCE(p.page_number, 0) AS page_number, p.text AS page_text,
+					CASE WHEN t.` + column + ` @@ search.q THEN ts_rank_cd(t.` + column + `, search.q) ELSE 0 END + COALESCE(p.rank, 0) AS rank
+				FROM candidates c
+				JOIN pdf_models t ON t.id = c.id
+				CROSS JOIN search
+				LEFT JOIN LATERAL (
+					SELECT pg.page_number, pg.text, ts_rank_cd(pg.` + column + `, search.q) AS rank
+					FROM pdf_pages pg
+					WHERE pg.pdf_id = t.id AND pg.` + column + ` @@ search.q
+					ORDER BY rank DESC, pg.page_number
+					LIMIT 1
+				) p ON true
+				WHERE t.deleted_at IS NULL AND (t.is_public OR t.user_id = ?)
+					AND (t.` + column + ` @@ search.q OR p.page_number IS NOT NULL)
+				ORDER BY rank DESC, t.id DESC
+				LIMIT ? OFFSET ?
+			) matches
+			ORDER BY rank DESC, id DESC`
+
+	var rows []searchRow
+	result := db.Raw(sql,
+		query.Language, tsQuery(terms),
+		query.Language, headlineOptions,
+		query.Language, snippetOptions,
+		query.UserID, query.Limit, query.Offset,
+	).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range rows {
+		rows[i].Headline = textsearch.FromMarkers(rows[i].Headline)
+		rows[i].Snippet = textsearch.FromMarkers(rows[i].Snippet)
+	}
+	return rows, nil
+}
+
+// tsQuery, textsearch.NormalizeTerms ile normalleştirilmiş arama terimlerini to_tsquery sözdizimine
+// çevirir: terimler & ile birleşir, ifadeler <-> ile, önek aramaları :* ile, hariç tutulan terimler ! ile
+// yazılır. Kelimeler yalnızca harf ve rakam içerdiğinden kullanıcı girdisi operatör olarak yorumlanamaz.
+func tsQuery(terms []domain.SearchTerm) string {
+	var parts []string
+	for _, term := range terms {
+		if len(term.Words) == 0 {
+			continue
+		}
+
+		words := make([]string, len(term.Words))
+		for i, word := range term.Words {
+			words[i] = "'" + strings.ReplaceAll(word, "'", "''") + "'"
+		}
+		if term.Prefix {
+			words[len(words)-1] += ":*"
+		}
+
+		expr := strings.Join(words, " <-> ")
+		if len(words) > 1 {
+			expr = "(" + expr + ")"
+		}
+		if term.Exclude {
+			expr = "!" + expr
+		}
+		parts = append(parts, expr)
+	}
+	return strings.Join(parts, " & ")
+}
+
+// supportsFullTextSearch, veritabanının tsvector sütunlarına sahip olup olmadığını kontrol eder.
+// SQLite'ta arama sürüm 21'de oluşturulan FTS5 tabloları üzerinden yapılır.
+func supportsFullTextSearch(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
+
+// ftsMarkers, FTS5 highlight ve snippet fonksiyonlarına verilen vurgu işaretleridir
+var ftsMarkers = fmt.Sprintf("char(%d), char(%d)", textsearch.StartMarker[0], textsearch.StopMarker[0])
+
+// ftsMatches, FTS5 tablosunun bir sütununda vurgulanan eşleşme sayısını hesaplayan SQL ifadesini
+// döndürür. Her eşleşme iki işaret karakteri eklediğinden uzunluk farkının yarısı eşleşme sayısıdır.
+func ftsMatches(fts string, column int, text string) string {
+	return fmt.Sprintf("(LENGTH(highlight(%s, %d, %s)) - LENGTH(COALESCE(%s, ''))) / 2", fts, column, ftsMarkers, text)
+}
+
+// sqliteFullTextSearch, SQLite'ta tablonun FTS5 dizininde arama yapar ve eşleşen satırları puana göre
+// sıralı döndürür. Puan textsearch paketindeki gibi alan ağırlıklarıyla çarpılmış eşleşme sayısıdır.
+func sqliteFullTextSearch(db *gorm.DB, table, bodyColumn string, query *domain.SearchQuery) ([]searchRow, error) {
+	if !domain.IsSearchLanguage(query.Language) {
+		return nil, fmt.Errorf("desteklenmeyen arama dili: %q", query.Language)
+	}
+	terms := textsearch.NormalizeTerms(query.Terms)
+	if terms == nil {
+		return nil, nil
+	}
+	fts := table + "_fts"
+
+	sql := fmt.Sprintf(`SELECT t.id,
+			%s * %g + %s * %g AS rank,
+			highlight(%s, 0, %s) AS headline,
+			snippet(%s, 1, %s, '…', 30) AS snippet
+		FROM %s JOIN %s t ON t.id = %s.rowid
+		WHERE %s MATCH ? AND t.deleted_at IS NULL AND (t.is_public OR t.user_id = ?)
+		ORDER BY rank DESC, t.id DESC
+		LIMIT ? OFFSET ?`,
+		ftsMatches(fts, 0, "t.title"), textsearch.TitleWeight, ftsMatches(fts, 1, "t."+bodyColumn), textsearch.BodyWeight,
+		fts, ftsMarkers,
+		fts, ftsMarkers,
+		fts, table, fts,
+		fts,
+	)
+
+	var rows []searchRow
+	result := db.Raw(sql, ftsQuery(terms), query.UserID, query.Limit, query.Offset).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range rows {
+		rows[i].Headline = textsearch.FromMarkers(rows[i].Headline)
+		rows[i].Snippet = textsearch.FromMarkers(rows[i].Snippet)
+	}
+	return rows, nil
+}
+
+// sqlitePDFFullTextSearch, SQLite'ta PDF'lerin başlık ve açıklamalarında ve sayfa metinlerinde FTS5
+// dizinleri üzerinden arama yapar. pdfFullTextSearch gibi her aday için en yüksek puanlı eşleşen
+// sayfa seçilir, puanı PDF'in kendi puanına eklenir ve özet varsa o sayfadan oluşturulur.
+func sqlitePDFFullTextSearch(db *gorm.DB, query *domain.SearchQuery) ([]searchRow, error) {
+	if !domain.IsSearchLanguage(query.Language) {
+		return nil, fmt.Errorf("desteklenmeyen arama dili: %q", query.Language)
+	}
+	terms := textsearch.NormalizeTerms(query.Terms)
+	if terms == nil {
+		return nil, nil
+	}
+
+	sql := fmt.Sprintf(`WITH docs AS (
+				SELECT pdf_models_fts.rowid AS id,
+					%s * %g + %s * %g AS rank,
+					highlight(pdf_models_fts, 0, %s) AS headline,
+					snippet(pdf_models_fts, 1, %s, '…', 30) AS snippet
+				FROM pdf_models_fts
+				WHERE pdf_models_fts MATCH ?
+			), pages AS (
+				SELECT pg.pdf_id, pg.page_number,
+					%s * %g AS rank,
+					snippet(pdf_pages_fts, 0, %s, '…', 30) AS snippet
+				FROM pdf_pages_fts JOIN pdf_pages pg ON pg.id = pdf_pages_fts.rowid
+				WHERE pdf_pages_fts MATCH ?
+			), best_pages AS (
+				SELECT pdf_id, page_number, rank, snippet FROM (
+					SELECT pages.*, ROW_NUMBER() OVER (PARTITION BY pdf_id ORDER BY rank DESC, page_number) AS position
+					FROM pages
+				) WHERE position = 1
+			)
+			SELECT t.id,
+				COALESCE(d.rank, 0) + COALESCE(p.rank, 0) AS rank,
+				COALESCE(p.page_number, 0) AS page_number,
+				COALESCE(d.headline, t.title) AS headline,
+				COALESCE(p.snippet, d.snippet, '') AS snippet
+			FROM pdf_models t
+			LEFT JOIN docs d ON d.id = t.id
+			LEFT JOIN best_pages p ON p.pdf_id = t.id
+			WHERE t.id IN (SELECT id FROM docs UNION SELECT pdf_id FROM best_pages)
+				AND t.deleted_at IS NULL AND (t.is_public OR t.user_id = ?)
+			ORDER BY rank DESC, t.id DESC
+			LIMIT ? OFFSET ?`,
+		ftsMatches("pdf_models_fts", 0, "pdf_models_fts.title"), textsearch.TitleWeight,
+		ftsMatches("pdf_models_fts", 1, "pdf_models_fts.description"), textsearch.BodyWeight,
+		ftsMarkers, ftsMarkers,
+		ftsMatches("pdf_pages_fts", 0, "pg.text"), textsearch.PageWeight, ftsMarkers,
+	)
+
+	match := ftsQuery(terms)
+	var rows []searchRow
+	result := db.Raw(sql, match, match, query.UserID, query.Limit, query.Offset).Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i := range rows {
+		rows[i].Headline = textsearch.FromMarkers(rows[i].Headline)
+		rows[i].Snippet = textsearch.FromMarkers(rows[i].Snippet)
+	}
+	return rows, nil
+}
+
+// ftsQuery, arama terimlerini FTS5 MATCH sözdizimine çevirir. textsearch paketindeki gibi her kelime
+// önek olarak eşleşir; ifadeler + ile birleşir, hariç tutulan terimler NOT ile çıkarılır.
+// Terimler textsearch.NormalizeTerms ile normalleştirildiğinden en az bir hariç tutulmayan terim vardır.
+func ftsQuery(terms []domain.SearchTerm) string {
+	var include, exclude []string
+	for _, term := range terms {
+		if len(term.Words) == 0 {
+			continue
+		}
+
+		words := make([]string, len(term.Words))
+		for i, word := range term.Words {
+			words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+		}
+		expr := strings.Join(words, " + ")
+		if term.Exclude {
+			exclude = append(exclude, expr)
+		} else {
+			include = append(include, expr)
+		}
+	}
+
+	query := "(" + strings.Join(include, " AND ") + ")"
+	if len(exclude) > 0 {
+		query += " NOT (" + strings.Join(exclude, " OR ") + ")"
+	}
+	return query
+}
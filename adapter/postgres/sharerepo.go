@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"gorm.io/gorm"
+)
+
+// ContentShareModel, kullanıcı paylaşımlarının veritabanı modeli. Bir içerik aynı kullanıcıyla
+// yalnızca bir kez paylaşılabilir.
+type ContentShareModel struct {
+	ID          uint   `gorm:"primaryKey"`
+	ContentID   uint   `gorm:"not null;uniqueIndex:idx_content_share"`
+	ContentType string `gorm:"size:10;not null;uniqueIndex:idx_content_share"` // "note" veya "pdf"
+	OwnerID     uint   `gorm:"not null"`
+	UserID      uint   `gorm:"not null;uniqueIndex:idx_content_share;index"`
+	Role        string `gorm:"size:10;not null"` // "viewer", "commenter" veya "editor"
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName, tablo adını belirtir
+func (ContentShareModel) TableName() string {
+	return "content_shares"
+}
+
+// ToEntity, veritabanı modelini domain entity'sine dönüştürür
+func (m *ContentShareModel) ToEntity() *domain.ContentShare {
+	return &domain.ContentShare{
+		ID:          m.ID,
+		ContentID:   m.ContentID,
+		ContentType: m.ContentType,
+		OwnerID:     m.OwnerID,
+		UserID:      m.UserID,
+		Role:        m.Role,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// ShareRepository, domain.ShareRepository arayüzünün PostgreSQL implementasyonu
+type ShareRepository struct {
+	db *gorm.DB
+}
+
+// NewShareRepository, yeni bir ShareRepository örneği oluşturur
+func NewShareRepository(db *gorm.DB) *ShareRepository {
+	return &ShareRepository{db: db}
+}
+
+// FindByID, ID'ye göre paylaşımı bulur
+func (r *ShareRepository) FindByID(id uint) (*domain.ContentShare, error) {
+	var model ContentShareModel
+	if err := r.db.First(&model, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // Paylaşım bulunamadı
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// Find, içeriğin kullanıcıyla paylaşımını bulur
+func (r *ShareRepository) Find(contentID uint, contentType string, userID uint) (*domain.ContentShare, error) {
+	var model ContentShareModel
+	err := r.db.Where("content_id = ? AND content_type = ? AND user_id = ?", contentID, contentType, userID).
+		First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // İçerik kullanıcıyla paylaşılmamış
+		}
+		return nil, err
+	}
+	return model.ToEntity(), nil
+}
+
+// FindByContent, içeriğin paylaşımlarını eskiden yeniye getirir
+func (r *ShareRepository) FindByContent(contentID uint, contentType string, limit, offset int) ([]*domain.ContentShare, error) {
+	var models []ContentShareModel
+	result := r.db.Where("content_id = ? AND content_type = ?", contentID, contentType).
+		Order("created_at, id").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toShareEntities(models), nil
+}
+
+// FindByUserID, kullanıcıyla paylaşılan içerikleri son paylaşılandan başlayarak getirir
+func (r *ShareRepository) FindByUserID(userID uint, contentType string, limit, offset int) ([]*domain.ContentShare, error) {
+	query := r.db.Where("user_id = ?", userID)
+	if contentType != "" {
+		query = query.Where("content_type = ?", contentType)
+	}
+
+	var models []ContentShareModel
+	result := query.Order("created_at DESC, id DESC").
+		Limit(limit).Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return toShareEntities(models), nil
+}
+
+// Create, içeriği kullanıcıyla paylaşır
+func (r *ShareRepository) Create(share *domain.ContentShare) error {
+	model := ContentShareModel{
+		ContentID:   share.ContentID,
+		ContentType: share.ContentType,
+		OwnerID:     share.OwnerID,
+		UserID:      share.UserID,
+		Role:        share.Role,
+	}
+	if err := r.db.Create(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return fmt.Errorf("paylaşım oluşturma hatası: %w", domain.ErrDuplicateEntry)
+		}
+		return err
+	}
+
+	// ID'yi ve zaman damgalarını güncelle
+	share.ID = model.ID
+	share.CreatedAt = model.CreatedAt
+	share.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Update, paylaşımın rolünü günceller
+func (r *ShareRepository) Update(share *domain.ContentShare) error {
+	updatedAt := time.Now()
+	result := r.db.Model(&ContentShareModel{}).Where("id = ?", share.ID).Updates(map[string]interface{}{
+		"role":       share.Role,
+		"updated_at": updatedAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	share.UpdatedAt = updatedAt
+	return nil
+}
+
+// Delete, paylaşımı kaldırır
+func (r *ShareRepository) Delete(id uint) error {
+	return r.db.Delete(&ContentShareModel{}, id).Error
+}
+
+func toShareEntities(models []ContentShareModel) []*domain.ContentShare {
+	shares := make([]*domain.ContentShare, 0, len(models))
+	for i := range models {
+		shares = append(shares, models[i].ToEntity())
+	}
+	return shares
+}
+
+// Ensure ShareRepository implements domain.ShareRepository
+var _ domain.ShareRepository = (*ShareRepository)(nil)
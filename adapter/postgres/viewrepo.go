@@ -119,7 +119,14 @@ func (r *ViewRepository) FindByUserID(userID uint, limit, offset int) ([]*domain
 func (r *ViewRepository) Create(view *domain.View) error {
 	model := toViewModel(view)
 	model.ViewedAt = time.Now()
-	return r.db.Create(model).Error
+	if err := r.db.Create(model).Error; err != nil {
+		return err
+	}
+
+	// ID'yi ve zamanı güncelle
+	view.ID = uint(model.ID)
+	view.ViewedAt = model.ViewedAt
+	return nil
 }
 
 // Update, mevcut bir görüntüleme kaydını günceller
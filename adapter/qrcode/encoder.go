@@ -0,0 +1,33 @@
+// Package qrcode, davet bağlantılarını derslerde yansıtılabilecek QR kod görsellerine dönüştüren
+// domain.QRCodeEncoder implementasyonunu içerir.
+package qrcode
+
+import (
+	"fmt"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	goqrcode "github.com/skip2/go-qrcode"
+)
+
+// Encoder, domain.QRCodeEncoder arayüzünün go-qrcode tabanlı implementasyonu
+type Encoder struct {
+	level goqrcode.RecoveryLevel // Hata düzeltme düzeyi
+}
+
+// NewEncoder, yeni bir Encoder örneği oluşturur. Yansıtılan veya kısmen görünen kodların da okunabilmesi
+// için orta düzey (%15) hata düzeltme kullanılır.
+func NewEncoder() *Encoder {
+	return &Encoder{level: goqrcode.Medium}
+}
+
+// EncodePNG, içeriği kenar uzunluğu size piksel olan siyah beyaz bir PNG görseline dönüştürür
+func (e *Encoder) EncodePNG(content string, size int) ([]byte, error) {
+	image, err := goqrcode.Encode(content, e.level, size)
+	if err != nil {
+		return nil, fmt.Errorf("QR kod oluşturulamadı: %w", err)
+	}
+	return image, nil
+}
+
+// Ensure Encoder implements domain.QRCodeEncoder
+var _ domain.QRCodeEncoder = (*Encoder)(nil)
@@ -0,0 +1,502 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+func testLikeRepository(t *testing.T, repos *Repositories) {
+	note := createNote(t, repos, &domain.Note{Title: "Not", UserID: 1, IsPublic: true})
+	pdf := createPDF(t, repos, &domain.PDF{Title: "PDF", UserID: 1, IsPublic: true})
+
+	like := &domain.Like{UserID: 2, ContentID: note.ID, Type: "note"}
+	must(t, repos.Likes.Create(like))
+	if like.ID == 0 {
+		t.Fatalf("Create sonrası beğeni ID'si atanmadı")
+	}
+	if got := reloadNote(t, repos, note.ID).LikeCount; got != 1 {
+		t.Fatalf("Create notun beğeni sayısını artırmalıydı, %d", got)
+	}
+
+	// Aynı beğeni ikinci kez oluşturulduğunda sayaç değişmemeli
+	again := &domain.Like{UserID: 2, ContentID: note.ID, Type: "note"}
+	must(t, repos.Likes.Create(again))
+	if again.ID != like.ID {
+		t.Fatalf("tekrarlanan beğeni mevcut ID'yi döndürmeliydi: %d != %d", again.ID, like.ID)
+	}
+	if got := reloadNote(t, repos, note.ID).LikeCount; got != 1 {
+		t.Fatalf("tekrarlanan beğeni sayacı artırmamalıydı, %d", got)
+	}
+
+	must(t, repos.Likes.Create(&domain.Like{UserID: 2, ContentID: pdf.ID, Type: "pdf"}))
+	must(t, repos.Likes.Create(&domain.Like{UserID: 3, ContentID: pdf.ID, Type: "pdf"}))
+	if got := reloadPDF(t, repos, pdf.ID).LikeCount; got != 2 {
+		t.Fatalf("PDF beğeni sayısı 2 olmalıydı, %d", got)
+	}
+
+	found, err := repos.Likes.FindByUserIDAndContent(2, note.ID, "note")
+	must(t, err)
+	if found == nil || found.ID != like.ID {
+		t.Fatalf("FindByUserIDAndContent beklenen beğeniyi döndürmedi: %+v", found)
+	}
+
+	byContent, err := repos.Likes.FindByContentID(pdf.ID, "pdf", 10, 0)
+	must(t, err)
+	if len(byContent) != 2 {
+		t.Fatalf("FindByContentID 2 beğeni döndürmeliydi, %d döndü", len(byContent))
+	}
+
+	byUser, err := repos.Likes.FindByUserID(2, 10, 0)
+	must(t, err)
+	if len(byUser) != 2 {
+		t.Fatalf("FindByUserID 2 beğeni döndürmeliydi, %d döndü", len(byUser))
+	}
+
+	likedNotes, err := repos.Likes.FindLikedNotesByUserID(2, 10, 0)
+	must(t, err)
+	if len(likedNotes) != 1 || likedNotes[0].ID != note.ID {
+		t.Fatalf("FindLikedNotesByUserID beklenen notu döndürmedi: %+v", likedNotes)
+	}
+
+	likedPDFs, err := repos.Likes.FindLikedPDFsByUserID(3, 10, 0)
+	must(t, err)
+	if len(likedPDFs) != 1 || likedPDFs[0].ID != pdf.ID {
+		t.Fatalf("FindLikedPDFsByUserID beklenen PDF'i döndürmedi: %+v", likedPDFs)
+	}
+
+	must(t, repos.Likes.DeleteByUserIDAndContent(2, note.ID, "note"))
+	if got := reloadNote(t, repos, note.ID).LikeCount; got != 0 {
+		t.Fatalf("beğeni kaldırma sayacı azaltmalıydı, %d", got)
+	}
+
+	// Olmayan beğeniyi kaldırmak hata değildir ve sayacı değiştirmez
+	must(t, repos.Likes.DeleteByUserIDAndContent(2, note.ID, "note"))
+	if got := reloadNote(t, repos, note.ID).LikeCount; got != 0 {
+		t.Fatalf("olmayan beğeniyi kaldırmak sayacı değiştirmemeliydi, %d", got)
+	}
+
+	// Kaldırılan beğeni yeniden verilebilmeli
+	relike := &domain.Like{UserID: 2, ContentID: note.ID, Type: "note"}
+	must(t, repos.Likes.Create(relike))
+	if got := reloadNote(t, repos, note.ID).LikeCount; got != 1 {
+		t.Fatalf("yeniden beğeni sayacı artırmalıydı, %d", got)
+	}
+
+	must(t, repos.Likes.Delete(relike.ID))
+	if got := reloadNote(t, repos, note.ID).LikeCount; got != 0 {
+		t.Fatalf("Delete sayacı azaltmalıydı, %d", got)
+	}
+	deleted, err := repos.Likes.FindByID(relike.ID)
+	must(t, err)
+	if deleted != nil {
+		t.Fatalf("silinen beğeni hala bulunuyor")
+	}
+}
+
+func testViewRepository(t *testing.T, repos *Repositories) {
+	view := &domain.View{UserID: 2, ContentID: 7, Type: "note"}
+	must(t, repos.Views.Create(view))
+	if view.ID == 0 {
+		t.Fatalf("Create sonrası görüntüleme ID'si atanmadı")
+	}
+	must(t, repos.Views.Create(&domain.View{UserID: 3, ContentID: 7, Type: "note"}))
+	must(t, repos.Views.Create(&domain.View{UserID: 2, ContentID: 7, Type: "pdf"}))
+
+	found, err := repos.Views.FindByUserIDAndContent(2, 7, "note")
+	must(t, err)
+	if found == nil || found.ID != view.ID || found.ViewedAt.IsZero() {
+		t.Fatalf("FindByUserIDAndContent beklenen görüntülemeyi döndürmedi: %+v", found)
+	}
+
+	byContent, err := repos.Views.FindByContentID(7, "note", 10, 0)
+	must(t, err)
+	if len(byContent) != 2 {
+		t.Fatalf("FindByContentID 2 görüntüleme döndürmeliydi, %d döndü", len(byContent))
+	}
+
+	// Güncellenen görüntüleme en yeni kayıt olarak listelenmeli
+	found.ViewedAt = time.Now().Add(time.Hour)
+	must(t, repos.Views.Update(found))
+	byContent, err = repos.Views.FindByContentID(7, "note", 10, 0)
+	must(t, err)
+	if byContent[0].ID != view.ID {
+		t.Fatalf("FindByContentID görüntülemeleri yeniden eskiye sıralamadı: %+v", byContent)
+	}
+
+	byUser, err := repos.Views.FindByUserID(2, 10, 0)
+	must(t, err)
+	if len(byUser) != 2 {
+		t.Fatalf("FindByUserID 2 görüntüleme döndürmeliydi, %d döndü", len(byUser))
+	}
+
+	must(t, repos.Views.DeleteByUserIDAndContent(2, 7, "pdf"))
+	must(t, repos.Views.Delete(view.ID))
+	byUser, err = repos.Views.FindByUserID(2, 10, 0)
+	must(t, err)
+	if len(byUser) != 0 {
+		t.Fatalf("silinen görüntülemeler hala bulunuyor: %+v", byUser)
+	}
+}
+
+func testInviteRepository(t *testing.T, repos *Repositories) {
+	expires := time.Now().Add(24 * time.Hour)
+	invite := &domain.Invite{ContentID: 5, Type: "note", Token: "tok-1", CreatedBy: 1, ExpiresAt: expires, IsActive: true}
+	must(t, repos.Invites.Create(invite))
+	if invite.ID == 0 {
+		t.Fatalf("Create sonrası davet ID'si atanmadı")
+	}
+	must(t, repos.Invites.Create(&domain.Invite{ContentID: 5, Type: "note", Token: "tok-2", CreatedBy: 1, ExpiresAt: expires, IsActive: true}))
+	must(t, repos.Invites.Create(&domain.Invite{ContentID: 5, Type: "pdf", Token: "tok-3", CreatedBy: 1, ExpiresAt: expires, IsActive: true}))
+
+	if err := repos.Invites.Create(&domain.Invite{ContentID: 6, Type: "note", Token: "tok-1", CreatedBy: 1, ExpiresAt: expires}); err == nil {
+		t.Fatalf("aynı token ile ikinci davet oluşturulabildi")
+	}
+
+	found, err := repos.Invites.FindByToken("tok-1")
+	must(t, err)
+	if found == nil || found.ID != invite.ID || !found.IsActive {
+		t.Fatalf("FindByToken beklenen daveti döndürmedi: %+v", found)
+	}
+
+	missing, err := repos.Invites.FindByToken("yok")
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("olmayan token için nil beklenirken %+v döndü", missing)
+	}
+
+	byContent, err := repos.Invites.FindByContentID(5, "note")
+	must(t, err)
+	if len(byContent) != 2 {
+		t.Fatalf("FindByContentID 2 davet döndürmeliydi, %d döndü", len(byContent))
+	}
+
+	found.IsActive = false
+	must(t, repos.Invites.Update(found))
+	updated, err := repos.Invites.FindByID(invite.ID)
+	must(t, err)
+	if updated.IsActive {
+		t.Fatalf("Update daveti devre dışı bırakmadı")
+	}
+
+	must(t, repos.Invites.DeleteByContentID(5, "note"))
+	byContent, err = repos.Invites.FindByContentID(5, "note")
+	must(t, err)
+	if len(byContent) != 0 {
+		t.Fatalf("DeleteByContentID davetleri silmedi")
+	}
+	pdfInvites, err := repos.Invites.FindByContentID(5, "pdf")
+	must(t, err)
+	if len(pdfInvites) != 1 {
+		t.Fatalf("DeleteByContentID başka içerik türündeki daveti silmemeliydi")
+	}
+
+	must(t, repos.Invites.Delete(pdfInvites[0].ID))
+	deleted, err := repos.Invites.FindByID(pdfInvites[0].ID)
+	must(t, err)
+	if deleted != nil {
+		t.Fatalf("silinen davet hala bulunuyor")
+	}
+}
+
+func testInviteLimits(t *testing.T, repos *Repositories) {
+	expires := time.Now().Add(24 * time.Hour)
+	invite := &domain.Invite{ContentID: 5, Type: "pdf", Token: "sinirli", CreatedBy: 1, Permission: domain.InvitePermissionAnnotate, MaxUses: 2, PasswordHash: "ozet", ExpiresAt: expires, IsActive: true}
+	must(t, repos.Invites.Create(invite))
+
+	found, err := repos.Invites.FindByToken("sinirli")
+	must(t, err)
+	if found == nil || found.Permission != domain.InvitePermissionAnnotate || found.MaxUses != 2 || found.UseCount != 0 || found.PasswordHash != "ozet" {
+		t.Fatalf("davet yetki, sınır ve parola alanları korunmadı: %+v", found)
+	}
+
+	first := time.Now().Add(-time.Hour).Truncate(time.Second)
+	guest := &domain.InviteRedemption{InviteID: invite.ID, UserID: 0, VisitorKey: "ziyaretci", CreatedAt: first, LastUsedAt: first}
+	ok, err := repos.Invites.Redeem(guest)
+	must(t, err)
+	if !ok || guest.ID == 0 {
+		t.Fatalf("Redeem kullanım kaydı oluşturmadı: %v, %+v", ok, guest)
+	}
+	member := &domain.InviteRedemption{InviteID: invite.ID, UserID: 7, CreatedAt: first.Add(time.Minute), LastUsedAt: first.Add(time.Minute)}
+	ok, err = repos.Invites.Redeem(member)
+	must(t, err)
+	if !ok {
+		t.Fatalf("2. kullanım reddedildi")
+	}
+	ok, err = repos.Invites.Redeem(&domain.InviteRedemption{InviteID: invite.ID, UserID: 8, CreatedAt: first, LastUsedAt: first})
+	must(t, err)
+	if ok {
+		t.Fatalf("kullanım hakkı dolmuş davet için Redeem true döndürdü")
+	}
+	found, err = repos.Invites.FindByID(invite.ID)
+	must(t, err)
+	if found.UseCount != 2 || !found.Exhausted() {
+		t.Fatalf("kullanım sayısı 2 olmalıydı: %d", found.UseCount)
+	}
+	if ok, err := repos.Invites.Redeem(&domain.InviteRedemption{InviteID: 9999, UserID: 7, CreatedAt: first, LastUsedAt: first}); err != nil || ok {
+		t.Fatalf("olmayan davet için Redeem false döndürmeliydi: %v, %v", ok, err)
+	}
+
+	// Misafirlerin her kullanımı ayrı kayıttır; giriş yapmış kullanıcı davet başına tek kayıt alır
+	unlimited := &domain.Invite{ContentID: 5, Type: "note", Token: "sinirsiz", CreatedBy: 1, ExpiresAt: expires, IsActive: true}
+	must(t, repos.Invites.Create(unlimited))
+	for i := 0; i < 3; i++ {
+		if ok, err := repos.Invites.Redeem(&domain.InviteRedemption{InviteID: unlimited.ID, CreatedAt: first, LastUsedAt: first}); err != nil || !ok {
+			t.Fatalf("sınırsız davetin misafir kullanımı reddedildi: %v", err)
+		}
+	}
+	if ok, err := repos.Invites.Redeem(&domain.InviteRedemption{InviteID: unlimited.ID, UserID: 7, CreatedAt: first, LastUsedAt: first}); err != nil || !ok {
+		t.Fatalf("sınırsız davetin kullanımı reddedildi: %v", err)
+	}
+	if _, err := repos.Invites.Redeem(&domain.InviteRedemption{InviteID: unlimited.ID, UserID: 7, CreatedAt: first, LastUsedAt: first}); !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı kullanıcının ikinci kaydı için ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+	found, err = repos.Invites.FindByID(unlimited.ID)
+	must(t, err)
+	if found.UseCount != 4 {
+		t.Fatalf("reddedilen kayıt kullanım hakkı harcamamalıydı: %d", found.UseCount)
+	}
+
+	redemption, err := repos.Invites.FindRedemption(invite.ID, 7)
+	must(t, err)
+	if redemption == nil || redemption.ID != member.ID {
+		t.Fatalf("FindRedemption beklenen kaydı döndürmedi: %+v", redemption)
+	}
+	if missing, err := repos.Invites.FindRedemption(invite.ID, 8); err != nil || missing != nil {
+		t.Fatalf("olmayan kullanım kaydı için nil beklenirken %+v, %v döndü", missing, err)
+	}
+	visitor, err := repos.Invites.FindRedemptionByVisitorKey(invite.ID, "ziyaretci")
+	must(t, err)
+	if visitor == nil || visitor.ID != guest.ID || visitor.VisitorKey != "ziyaretci" {
+		t.Fatalf("FindRedemptionByVisitorKey beklenen kaydı döndürmedi: %+v", visitor)
+	}
+	if missing, err := repos.Invites.FindRedemptionByVisitorKey(invite.ID, "baska"); err != nil || missing != nil {
+		t.Fatalf("olmayan ziyaretçi anahtarı için nil beklenirken %+v, %v döndü", missing, err)
+	}
+
+	// En son kullanılan kayıt önce gelir
+	guest.LastUsedAt = first.Add(2 * time.Minute)
+	must(t, repos.Invites.UpdateRedemption(guest))
+	redemptions, err := repos.Invites.FindRedemptions(invite.ID, 10, 0)
+	must(t, err)
+	if len(redemptions) != 2 || redemptions[0].ID != guest.ID || redemptions[1].ID != member.ID {
+		t.Fatalf("FindRedemptions son kullanılandan başlayarak sıralanmadı: %+v", redemptions)
+	}
+	if !redemptions[0].CreatedAt.Equal(first) || !redemptions[0].LastUsedAt.Equal(first.Add(2*time.Minute)) {
+		t.Fatalf("kullanım kaydı zamanları korunmadı: %+v", redemptions[0])
+	}
+	paged, err := repos.Invites.FindRedemptions(invite.ID, 1, 1)
+	must(t, err)
+	if len(paged) != 1 || paged[0].ID != member.ID {
+		t.Fatalf("FindRedemptions sayfalaması hatalı: %+v", paged)
+	}
+
+	// Davet silindiğinde kullanım kayıtları da silinir
+	must(t, repos.Invites.Delete(invite.ID))
+	if redemption, err := repos.Invites.FindRedemption(invite.ID, 7); err != nil || redemption != nil {
+		t.Fatalf("silinen davetin kullanım kaydı hala bulunuyor: %+v, %v", redemption, err)
+	}
+	must(t, repos.Invites.DeleteByContentID(5, "note"))
+	if redemptions, err := repos.Invites.FindRedemptions(unlimited.ID, 10, 0); err != nil || len(redemptions) != 0 {
+		t.Fatalf("DeleteByContentID kullanım kayıtlarını silmedi: %+v, %v", redemptions, err)
+	}
+}
+
+func testTokenRepository(t *testing.T, repos *Repositories) {
+	current := time.Now()
+	must(t, repos.Tokens.RevokeToken(&domain.RevokedToken{Token: "aktif", UserID: 1, ExpiresAt: current.Add(time.Hour), RevokedAt: current}))
+	must(t, repos.Tokens.RevokeToken(&domain.RevokedToken{Token: "eski", UserID: 1, ExpiresAt: current.Add(-time.Hour), RevokedAt: current}))
+
+	revoked, err := repos.Tokens.IsTokenRevoked("aktif")
+	must(t, err)
+	if !revoked {
+		t.Fatalf("iptal edilen token iptal edilmiş görünmüyor")
+	}
+
+	revoked, err = repos.Tokens.IsTokenRevoked("bilinmeyen")
+	must(t, err)
+	if revoked {
+		t.Fatalf("iptal edilmemiş token iptal edilmiş görünüyor")
+	}
+
+	must(t, repos.Tokens.CleanupExpiredTokens())
+	revoked, err = repos.Tokens.IsTokenRevoked("eski")
+	must(t, err)
+	if revoked {
+		t.Fatalf("süresi dolmuş token temizlenmedi")
+	}
+	revoked, err = repos.Tokens.IsTokenRevoked("aktif")
+	must(t, err)
+	if !revoked {
+		t.Fatalf("süresi dolmamış token temizlenmemeliydi")
+	}
+}
+
+func testLoginAttemptRepository(t *testing.T, repos *Repositories) {
+	current := time.Now()
+	attempts := []*domain.LoginAttempt{
+		{IP: "10.0.0.1", Email: "a@example.com", Successful: false, CreatedAt: current.Add(-2 * time.Minute)},
+		{IP: "10.0.0.1", Email: "a@example.com", Successful: false, CreatedAt: current.Add(-1 * time.Minute)},
+		{IP: "10.0.0.1", Email: "a@example.com", Successful: true, CreatedAt: current.Add(-1 * time.Minute)},
+		{IP: "10.0.0.2", Email: "b@example.com", Successful: false, CreatedAt: current.Add(-1 * time.Minute)},
+		{IP: "10.0.0.1", Email: "a@example.com", Successful: false, CreatedAt: current.Add(-48 * time.Hour)},
+	}
+	for _, attempt := range attempts {
+		must(t, repos.LoginAttempts.RecordAttempt(attempt))
+		if attempt.ID == 0 {
+			t.Fatalf("RecordAttempt sonrası ID atanmadı")
+		}
+	}
+
+	recent, err := repos.LoginAttempts.GetRecentAttempts("10.0.0.1", "a@example.com", current.Add(-time.Hour))
+	must(t, err)
+	if len(recent) != 2 {
+		t.Fatalf("son başarısız denemeler 2 olmalıydı, %d", len(recent))
+	}
+	if !recent[0].CreatedAt.After(recent[1].CreatedAt) {
+		t.Fatalf("denemeler yeniden eskiye sıralanmalıydı")
+	}
+
+	byIP, err := repos.LoginAttempts.GetRecentAttempts("10.0.0.2", "", current.Add(-time.Hour))
+	must(t, err)
+	if len(byIP) != 1 {
+		t.Fatalf("yalnızca IP ile filtreleme 1 deneme döndürmeliydi, %d", len(byIP))
+	}
+
+	must(t, repos.LoginAttempts.CleanupOldAttempts(current.Add(-24*time.Hour)))
+	all, err := repos.LoginAttempts.GetRecentAttempts("", "", current.Add(-72*time.Hour))
+	must(t, err)
+	if len(all) != 3 {
+		t.Fatalf("temizlik sonrası 3 başarısız deneme kalmalıydı, %d", len(all))
+	}
+}
+
+func testNotificationRepository(t *testing.T, repos *Repositories) {
+	note := createNote(t, repos, &domain.Note{Title: "Not", UserID: 1})
+	pdf := createPDF(t, repos, &domain.PDF{Title: "PDF", UserID: 1})
+
+	like := &domain.Notification{UserID: 1, ActorID: 2, Type: domain.NotificationLike, ContentID: note.ID, ContentType: "note", Message: "beğendi"}
+	must(t, repos.Notifications.Create(like))
+	if like.ID == 0 || like.CreatedAt.IsZero() {
+		t.Fatalf("Create ID ve zaman atamadı: %+v", like)
+	}
+	time.Sleep(10 * time.Millisecond)
+	comment := &domain.Notification{UserID: 1, ActorID: 3, Type: domain.NotificationComment, ContentID: pdf.ID, ContentType: "pdf", Message: "yorum yaptı"}
+	must(t, repos.Notifications.Create(comment))
+	must(t, repos.Notifications.Create(&domain.Notification{UserID: 2, ActorID: 1, Type: domain.NotificationMention, ContentID: note.ID, ContentType: "note"}))
+
+	found, err := repos.Notifications.FindByID(like.ID)
+	must(t, err)
+	if found == nil || found.UserID != 1 || found.ActorID != 2 || found.Message != "beğendi" || found.IsRead {
+		t.Fatalf("FindByID beklenen bildirimi döndürmedi: %+v", found)
+	}
+	missing, err := repos.Notifications.FindByID(9999)
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("olmayan bildirim için nil beklenirken %+v döndü", missing)
+	}
+
+	list, err := repos.Notifications.FindByUserID(1, false, 10, 0)
+	must(t, err)
+	if len(list) != 2 || list[0].ID != comment.ID || list[1].ID != like.ID {
+		t.Fatalf("FindByUserID bildirimleri yeniden eskiye döndürmedi: %+v", list)
+	}
+	paged, err := repos.Notifications.FindByUserID(1, false, 1, 1)
+	must(t, err)
+	if len(paged) != 1 || paged[0].ID != like.ID {
+		t.Fatalf("FindByUserID(limit=1, offset=1) en eski bildirimi döndürmeliydi: %+v", paged)
+	}
+
+	exists, err := repos.Notifications.ExistsUnread(&domain.Notification{UserID: 1, ActorID: 2, Type: domain.NotificationLike, ContentID: note.ID, ContentType: "note"})
+	must(t, err)
+	if !exists {
+		t.Fatalf("ExistsUnread okunmamış bildirimi bulamadı")
+	}
+	exists, err = repos.Notifications.ExistsUnread(&domain.Notification{UserID: 1, ActorID: 3, Type: domain.NotificationLike, ContentID: note.ID, ContentType: "note"})
+	must(t, err)
+	if exists {
+		t.Fatalf("ExistsUnread başka kişinin bildirimini eşleştirmemeliydi")
+	}
+
+	count, err := repos.Notifications.CountUnread(1)
+	must(t, err)
+	if count != 2 {
+		t.Fatalf("CountUnread 2 döndürmeliydi, %d döndü", count)
+	}
+
+	must(t, repos.Notifications.MarkRead(like.ID))
+	unread, err := repos.Notifications.FindByUserID(1, true, 10, 0)
+	must(t, err)
+	if len(unread) != 1 || unread[0].ID != comment.ID {
+		t.Fatalf("MarkRead sonrası yalnızca okunmamış bildirim dönmeliydi: %+v", unread)
+	}
+	exists, err = repos.Notifications.ExistsUnread(like)
+	must(t, err)
+	if exists {
+		t.Fatalf("okunan bildirim ExistsUnread tarafından eşleştirilmemeliydi")
+	}
+
+	must(t, repos.Notifications.MarkAllRead(1))
+	count, err = repos.Notifications.CountUnread(1)
+	must(t, err)
+	if count != 0 {
+		t.Fatalf("MarkAllRead sonrası okunmamış bildirim kalmamalıydı, %d", count)
+	}
+	count, err = repos.Notifications.CountUnread(2)
+	must(t, err)
+	if count != 1 {
+		t.Fatalf("MarkAllRead başka kullanıcının bildirimlerini değiştirmemeliydi")
+	}
+
+	// İçerik silindiğinde bildirimleri de silinmeli
+	must(t, repos.Notes.Delete(note.ID))
+	list, err = repos.Notifications.FindByUserID(2, false, 10, 0)
+	must(t, err)
+	if len(list) != 0 {
+		t.Fatalf("silinen notun bildirimleri temizlenmedi")
+	}
+	list, err = repos.Notifications.FindByUserID(1, false, 10, 0)
+	must(t, err)
+	if len(list) != 1 || list[0].ID != comment.ID {
+		t.Fatalf("başka içeriğin bildirimleri silinmemeliydi: %+v", list)
+	}
+
+	must(t, repos.Notifications.DeleteByContentID(pdf.ID, "pdf"))
+	list, err = repos.Notifications.FindByUserID(1, false, 10, 0)
+	must(t, err)
+	if len(list) != 0 {
+		t.Fatalf("DeleteByContentID bildirimleri silmedi")
+	}
+}
+
+func testNotificationPreferenceRepository(t *testing.T, repos *Repositories) {
+	missing, err := repos.NotificationPreferences.FindByUserID(1)
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("tercih kaydı olmayan kullanıcı için nil beklenirken %+v döndü", missing)
+	}
+
+	preferences := &domain.NotificationPreferences{UserID: 1, Likes: false, Comments: true, Mentions: false, Invites: true}
+	must(t, repos.NotificationPreferences.Save(preferences))
+	found, err := repos.NotificationPreferences.FindByUserID(1)
+	must(t, err)
+	if found == nil || found.Likes || !found.Comments || found.Mentions || !found.Invites {
+		t.Fatalf("Save tercihleri olduğu gibi saklamadı: %+v", found)
+	}
+
+	// İkinci kayıt mevcut tercihleri güncellemeli
+	must(t, repos.NotificationPreferences.Save(&domain.NotificationPreferences{UserID: 1, Likes: true, Comments: false, Mentions: true, Invites: false}))
+	found, err = repos.NotificationPreferences.FindByUserID(1)
+	must(t, err)
+	if !found.Likes || found.Comments || !found.Mentions || found.Invites {
+		t.Fatalf("Save mevcut tercihleri güncellemedi: %+v", found)
+	}
+
+	other, err := repos.NotificationPreferences.FindByUserID(2)
+	must(t, err)
+	if other != nil {
+		t.Fatalf("başka kullanıcının tercihleri etkilenmemeliydi")
+	}
+}
@@ -0,0 +1,190 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+func testCatalogRepository(t *testing.T, repos *Repositories) {
+	itu := &domain.University{
+		Name:      "İstanbul Teknik Üniversitesi",
+		ShortName: "İTÜ",
+		Aliases:   []string{"Istanbul Technical University"},
+	}
+	must(t, repos.Catalog.CreateUniversity(itu))
+	if itu.ID == 0 || itu.CreatedAt.IsZero() {
+		t.Fatalf("CreateUniversity ID ve zaman atamadı: %+v", itu)
+	}
+	odtu := &domain.University{Name: "Orta Doğu Teknik Üniversitesi", ShortName: "ODTÜ"}
+	must(t, repos.Catalog.CreateUniversity(odtu))
+
+	// Farklı yazımlar aynı üniversiteye çözülmeli
+	for _, name := range []string{"İTÜ", "itu", "I.T.U.", "istanbul technical university", "ISTANBUL TEKNIK UNIVERSITESI"} {
+		found, err := repos.Catalog.FindUniversityByName(name)
+		must(t, err)
+		if found == nil || found.ID != itu.ID {
+			t.Fatalf("FindUniversityByName(%q) İTÜ'yü döndürmedi: %+v", name, found)
+		}
+	}
+	found, err := repos.Catalog.FindUniversityByID(itu.ID)
+	must(t, err)
+	if found == nil || len(found.Aliases) != 1 || found.Aliases[0] != "Istanbul Technical University" {
+		t.Fatalf("FindUniversityByID diğer adları döndürmedi: %+v", found)
+	}
+
+	err = repos.Catalog.CreateUniversity(&domain.University{Name: "ITU"})
+	if !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("başka üniversitenin adı kullanılınca ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+
+	universities, err := repos.Catalog.FindUniversities("teknik", 10, 0)
+	must(t, err)
+	if len(universities) != 2 || universities[0].ID != itu.ID {
+		t.Fatalf("FindUniversities iki üniversiteyi ada göre sıralı döndürmeliydi: %+v", universities)
+	}
+	universities, err = repos.Catalog.FindUniversities("technical", 10, 0)
+	must(t, err)
+	if len(universities) != 1 || universities[0].ID != itu.ID {
+		t.Fatalf("FindUniversities diğer adlarda arama yapmadı: %+v", universities)
+	}
+
+	odtu.Aliases = []string{"Middle East Technical University", "METU"}
+	must(t, repos.Catalog.UpdateUniversity(odtu))
+	found, err = repos.Catalog.FindUniversityByName("metu")
+	must(t, err)
+	if found == nil || found.ID != odtu.ID || len(found.Aliases) != 2 {
+		t.Fatalf("UpdateUniversity diğer adları güncellemedi: %+v", found)
+	}
+	odtu.Aliases = []string{"Istanbul Technical University"}
+	if err := repos.Catalog.UpdateUniversity(odtu); !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("başka üniversitenin adıyla güncellemede ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+	if found, _ := repos.Catalog.FindUniversityByName("metu"); found == nil || found.ID != odtu.ID {
+		t.Fatalf("başarısız güncelleme mevcut adları silmemeliydi")
+	}
+
+	// Bölümler ve dersler
+	department := &domain.Department{UniversityID: itu.ID, Name: "Bilgisayar Mühendisliği"}
+	must(t, repos.Catalog.CreateDepartment(department))
+	must(t, repos.Catalog.CreateDepartment(&domain.Department{UniversityID: odtu.ID, Name: "Bilgisayar Mühendisliği"}))
+	err = repos.Catalog.CreateDepartment(&domain.Department{UniversityID: itu.ID, Name: "bilgisayar muhendisligi"})
+	if !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı bölüm ikinci kez eklenince ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+	foundDepartment, err := repos.Catalog.FindDepartmentByName(itu.ID, "BİLGİSAYAR MÜHENDİSLİĞİ")
+	must(t, err)
+	if foundDepartment == nil || foundDepartment.ID != department.ID {
+		t.Fatalf("FindDepartmentByName bölümü bulmadı: %+v", foundDepartment)
+	}
+	departments, err := repos.Catalog.FindDepartments(itu.ID, 10, 0)
+	must(t, err)
+	if len(departments) != 1 {
+		t.Fatalf("FindDepartments 1 bölüm döndürmeliydi, %d döndü", len(departments))
+	}
+
+	course := &domain.Course{DepartmentID: department.ID, Code: "BLG 101E", Name: "Bilgisayar Bilimine Giriş"}
+	must(t, repos.Catalog.CreateCourse(course))
+	must(t, repos.Catalog.CreateCourse(&domain.Course{DepartmentID: department.ID, Code: "BLG 102E", Name: "Programlama"}))
+	err = repos.Catalog.CreateCourse(&domain.Course{DepartmentID: department.ID, Code: "blg101e", Name: "Başka"})
+	if !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı ders kodu ikinci kez eklenince ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+	foundCourse, err := repos.Catalog.FindCourseByCode(department.ID, "blg-101e")
+	must(t, err)
+	if foundCourse == nil || foundCourse.ID != course.ID {
+		t.Fatalf("FindCourseByCode dersi bulmadı: %+v", foundCourse)
+	}
+	courses, err := repos.Catalog.FindCourses(department.ID, 10, 0)
+	must(t, err)
+	if len(courses) != 2 || courses[0].ID != course.ID {
+		t.Fatalf("FindCourses dersleri koda göre sıralı döndürmeliydi: %+v", courses)
+	}
+
+	// Dönemler
+	fall := &domain.Term{Year: 2024, Season: domain.TermSeasonFall, Name: "2024-2025 Güz"}
+	must(t, repos.Catalog.CreateTerm(fall))
+	spring := &domain.Term{Year: 2024, Season: domain.TermSeasonSpring, Name: "2024-2025 Bahar"}
+	must(t, repos.Catalog.CreateTerm(spring))
+	must(t, repos.Catalog.CreateTerm(&domain.Term{Year: 2023, Season: domain.TermSeasonSpring, Name: "2023-2024 Bahar"}))
+	if err := repos.Catalog.CreateTerm(&domain.Term{Year: 2024, Season: domain.TermSeasonFall, Name: "x"}); !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı dönem ikinci kez eklenince ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+	terms, err := repos.Catalog.FindTerms(10, 0)
+	must(t, err)
+	if len(terms) != 3 || terms[0].ID != spring.ID || terms[1].ID != fall.ID {
+		t.Fatalf("FindTerms dönemleri en yeniden başlayarak döndürmeliydi: %+v", terms)
+	}
+	foundTerm, err := repos.Catalog.FindTerm(2024, domain.TermSeasonFall)
+	must(t, err)
+	if foundTerm == nil || foundTerm.ID != fall.ID {
+		t.Fatalf("FindTerm dönemi bulmadı: %+v", foundTerm)
+	}
+
+	// Ders bağlantıları
+	week3 := createNote(t, repos, &domain.Note{Title: "Döngüler", UserID: 1, IsPublic: true})
+	week1 := createNote(t, repos, &domain.Note{Title: "Değişkenler", UserID: 1, IsPublic: true})
+	private := createNote(t, repos, &domain.Note{Title: "Özel", UserID: 2})
+	pdf := createPDF(t, repos, &domain.PDF{Title: "Slaytlar", UserID: 2, IsPublic: true})
+
+	content := &domain.CourseContent{CourseID: course.ID, TermID: &fall.ID, ContentID: week3.ID, ContentType: "note", Week: 3, Topic: "Döngüler"}
+	must(t, repos.Catalog.SaveCourseContent(content))
+	if content.ID == 0 || content.CreatedAt.IsZero() {
+		t.Fatalf("SaveCourseContent ID ve zaman atamadı: %+v", content)
+	}
+	must(t, repos.Catalog.SaveCourseContent(&domain.CourseContent{CourseID: courses[1].ID, ContentID: week1.ID, ContentType: "note", Week: 1}))
+	must(t, repos.Catalog.SaveCourseContent(&domain.CourseContent{CourseID: course.ID, ContentID: private.ID, ContentType: "note", Week: 2}))
+	must(t, repos.Catalog.SaveCourseContent(&domain.CourseContent{CourseID: course.ID, TermID: &spring.ID, ContentID: pdf.ID, ContentType: "pdf", Week: 1}))
+
+	// Aynı içerik için kaydetme bağlantıyı değiştirmeli
+	moved := &domain.CourseContent{CourseID: course.ID, TermID: &fall.ID, ContentID: week1.ID, ContentType: "note", Week: 1, Topic: "Değişkenler"}
+	must(t, repos.Catalog.SaveCourseContent(moved))
+	link, err := repos.Catalog.FindCourseContent(week1.ID, "note")
+	must(t, err)
+	if link == nil || link.ID != moved.ID || link.CourseID != course.ID || link.Topic != "Değişkenler" || link.TermID == nil || *link.TermID != fall.ID {
+		t.Fatalf("SaveCourseContent bağlantıyı değiştirmedi: %+v", link)
+	}
+
+	notes, err := repos.Catalog.FindCourseNotes(&domain.CourseContentFilter{CourseID: course.ID, Limit: 10})
+	must(t, err)
+	if len(notes) != 2 || notes[0].ID != week1.ID || notes[1].ID != week3.ID {
+		t.Fatalf("FindCourseNotes herkese açık notları haftaya göre sıralı döndürmeliydi: %+v", notes)
+	}
+	notes, err = repos.Catalog.FindCourseNotes(&domain.CourseContentFilter{CourseID: course.ID, ViewerID: 2, Limit: 10})
+	must(t, err)
+	if len(notes) != 3 || notes[1].ID != private.ID {
+		t.Fatalf("FindCourseNotes görüntüleyenin özel notunu döndürmeliydi: %+v", notes)
+	}
+	notes, err = repos.Catalog.FindCourseNotes(&domain.CourseContentFilter{CourseID: course.ID, Week: 3, Limit: 10})
+	must(t, err)
+	if len(notes) != 1 || notes[0].ID != week3.ID {
+		t.Fatalf("FindCourseNotes haftaya göre filtrelemedi: %+v", notes)
+	}
+	pdfs, err := repos.Catalog.FindCoursePDFs(&domain.CourseContentFilter{CourseID: course.ID, TermID: spring.ID, Limit: 10})
+	must(t, err)
+	if len(pdfs) != 1 || pdfs[0].ID != pdf.ID {
+		t.Fatalf("FindCoursePDFs döneme göre filtrelemedi: %+v", pdfs)
+	}
+	pdfs, err = repos.Catalog.FindCoursePDFs(&domain.CourseContentFilter{CourseID: course.ID, TermID: fall.ID, Limit: 10})
+	must(t, err)
+	if len(pdfs) != 0 {
+		t.Fatalf("FindCoursePDFs başka dönemin PDF'ini döndürmemeliydi: %+v", pdfs)
+	}
+
+	// Bağlantı silinince ve not silinince içerik dersten çıkmalı
+	must(t, repos.Catalog.DeleteCourseContent(week1.ID, "note"))
+	must(t, repos.Notes.Delete(week3.ID))
+	notes, err = repos.Catalog.FindCourseNotes(&domain.CourseContentFilter{CourseID: course.ID, Limit: 10})
+	must(t, err)
+	if len(notes) != 0 {
+		t.Fatalf("silinen bağlantılar dersten çıkarılmadı: %+v", notes)
+	}
+	if link, _ := repos.Catalog.FindCourseContent(week3.ID, "note"); link != nil {
+		t.Fatalf("silinen notun ders bağlantısı temizlenmedi")
+	}
+	must(t, repos.PDFs.Delete(pdf.ID))
+	if link, _ := repos.Catalog.FindCourseContent(pdf.ID, "pdf"); link != nil {
+		t.Fatalf("silinen PDF'in ders bağlantısı temizlenmedi")
+	}
+}
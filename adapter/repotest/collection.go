@@ -0,0 +1,178 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// itemIDs, öğelerin ID'lerini sırasıyla döndürür
+func itemIDs(items []*domain.CollectionItem) []uint {
+	ids := make([]uint, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func testCollectionRepository(t *testing.T, repos *Repositories) {
+	collection := &domain.Collection{UserID: 1, Name: "Vize", Description: "Vize hazırlığı"}
+	must(t, repos.Collections.Create(collection))
+	if collection.ID == 0 || collection.CreatedAt.IsZero() {
+		t.Fatalf("Create ID ve zaman atamadı: %+v", collection)
+	}
+	other := &domain.Collection{UserID: 1, Name: "Final", IsPublic: true}
+	must(t, repos.Collections.Create(other))
+	must(t, repos.Collections.Create(&domain.Collection{UserID: 2, Name: "Başkası"}))
+
+	collection.Name = "Vize Haftası"
+	collection.IsPublic = true
+	must(t, repos.Collections.Update(collection))
+	found, err := repos.Collections.FindByID(collection.ID)
+	must(t, err)
+	if found == nil || found.Name != "Vize Haftası" || !found.IsPublic || found.Description != "Vize hazırlığı" {
+		t.Fatalf("Update koleksiyonu güncellemedi: %+v", found)
+	}
+
+	mine, err := repos.Collections.FindByUserID(1, 10, 0)
+	must(t, err)
+	if len(mine) != 2 || mine[0].ID != collection.ID {
+		t.Fatalf("FindByUserID son güncellenen koleksiyonu önce döndürmeliydi: %+v", mine)
+	}
+
+	// Klasörler: kökte iki klasör, birinin altında bir alt klasör
+	lectures := &domain.CollectionFolder{CollectionID: collection.ID, Name: "Dersler", Position: 0}
+	must(t, repos.Collections.CreateFolder(lectures))
+	exams := &domain.CollectionFolder{CollectionID: collection.ID, Name: "Sınavlar", Position: 1}
+	must(t, repos.Collections.CreateFolder(exams))
+	week1 := &domain.CollectionFolder{CollectionID: collection.ID, ParentID: &lectures.ID, Name: "1. Hafta"}
+	must(t, repos.Collections.CreateFolder(week1))
+	if week1.ID == 0 {
+		t.Fatalf("CreateFolder ID atamadı")
+	}
+
+	folder, err := repos.Collections.FindFolderByID(week1.ID)
+	must(t, err)
+	if folder == nil || folder.ParentID == nil || *folder.ParentID != lectures.ID {
+		t.Fatalf("FindFolderByID üst klasörü döndürmedi: %+v", folder)
+	}
+
+	// Sınavları Derslerin önüne al
+	must(t, repos.Collections.ArrangeFolders(collection.ID, nil, []uint{exams.ID, lectures.ID}))
+	folders, err := repos.Collections.FindFolders(collection.ID)
+	must(t, err)
+	if len(folders) != 3 || folders[0].ID != exams.ID || folders[0].Position != 0 {
+		t.Fatalf("ArrangeFolders sırayı güncellemedi: %+v", folders)
+	}
+
+	week1.Name = "Hafta 1"
+	week1.ParentID = nil
+	week1.Position = 2
+	must(t, repos.Collections.UpdateFolder(week1))
+	folder, err = repos.Collections.FindFolderByID(week1.ID)
+	must(t, err)
+	if folder.Name != "Hafta 1" || folder.ParentID != nil || folder.Position != 2 {
+		t.Fatalf("UpdateFolder klasörü güncellemedi: %+v", folder)
+	}
+
+	// Öğeler
+	note := createNote(t, repos, &domain.Note{Title: "Türev", UserID: 1})
+	pdf := createPDF(t, repos, &domain.PDF{Title: "Slaytlar", UserID: 2, IsPublic: true})
+
+	noteItem := &domain.CollectionItem{CollectionID: collection.ID, ContentID: note.ID, ContentType: "note"}
+	must(t, repos.Collections.CreateItem(noteItem))
+	if noteItem.ID == 0 || noteItem.CreatedAt.IsZero() {
+		t.Fatalf("CreateItem ID ve zaman atamadı: %+v", noteItem)
+	}
+	pdfItem := &domain.CollectionItem{CollectionID: collection.ID, FolderID: &lectures.ID, ContentID: pdf.ID, ContentType: "pdf"}
+	must(t, repos.Collections.CreateItem(pdfItem))
+	must(t, repos.Collections.CreateItem(&domain.CollectionItem{CollectionID: other.ID, ContentID: note.ID, ContentType: "note"}))
+
+	err = repos.Collections.CreateItem(&domain.CollectionItem{CollectionID: collection.ID, ContentID: note.ID, ContentType: "note", Position: 5})
+	if !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı içerik ikinci kez eklenince ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+	must(t, repos.Collections.CreateItem(&domain.CollectionItem{CollectionID: collection.ID, ContentID: 999, ContentType: "pdf", Position: 1}))
+
+	item, err := repos.Collections.FindItemByID(pdfItem.ID)
+	must(t, err)
+	if item == nil || item.FolderID == nil || *item.FolderID != lectures.ID || item.ContentType != "pdf" {
+		t.Fatalf("FindItemByID klasörü döndürmedi: %+v", item)
+	}
+
+	// Not öğesini Dersler klasörüne, PDF'in önüne taşı
+	must(t, repos.Collections.ArrangeItems(collection.ID, &lectures.ID, []uint{noteItem.ID, pdfItem.ID}))
+	items, err := repos.Collections.FindItems(collection.ID)
+	must(t, err)
+	if len(items) != 3 {
+		t.Fatalf("FindItems 3 öğe döndürmeliydi, %d döndü", len(items))
+	}
+	moved, err := repos.Collections.FindItemByID(noteItem.ID)
+	must(t, err)
+	if moved.FolderID == nil || *moved.FolderID != lectures.ID || moved.Position != 0 {
+		t.Fatalf("ArrangeItems öğeyi taşımadı: %+v", moved)
+	}
+	if ids := itemIDs(items); ids[0] != noteItem.ID {
+		t.Fatalf("FindItems öğeleri konuma göre sıralamadı: %v", ids)
+	}
+
+	// Başka koleksiyonun öğeleri taşınmamalı
+	otherItems, err := repos.Collections.FindItems(other.ID)
+	must(t, err)
+	must(t, repos.Collections.ArrangeItems(collection.ID, nil, itemIDs(otherItems)))
+	otherItems, err = repos.Collections.FindItems(other.ID)
+	must(t, err)
+	if len(otherItems) != 1 || otherItems[0].CollectionID != other.ID {
+		t.Fatalf("ArrangeItems başka koleksiyonun öğesini değiştirmemeliydi: %+v", otherItems)
+	}
+
+	// Not silindiğinde tüm koleksiyonlardan çıkarılmalı
+	must(t, repos.Notes.Delete(note.ID))
+	items, err = repos.Collections.FindItems(collection.ID)
+	must(t, err)
+	if len(items) != 2 {
+		t.Fatalf("silinen notun öğesi koleksiyondan çıkarılmadı: %+v", items)
+	}
+	otherItems, err = repos.Collections.FindItems(other.ID)
+	must(t, err)
+	if len(otherItems) != 0 {
+		t.Fatalf("silinen not diğer koleksiyondan çıkarılmadı")
+	}
+
+	// Klasör silindiğinde içindeki öğeler de silinmeli
+	must(t, repos.Collections.DeleteFolders(collection.ID, []uint{lectures.ID}))
+	folders, err = repos.Collections.FindFolders(collection.ID)
+	must(t, err)
+	if len(folders) != 2 {
+		t.Fatalf("DeleteFolders klasörü silmedi: %+v", folders)
+	}
+	items, err = repos.Collections.FindItems(collection.ID)
+	must(t, err)
+	if len(items) != 1 || items[0].FolderID != nil {
+		t.Fatalf("DeleteFolders klasördeki öğeleri silmedi: %+v", items)
+	}
+
+	must(t, repos.Collections.DeleteItem(items[0].ID))
+	deletedItem, err := repos.Collections.FindItemByID(items[0].ID)
+	must(t, err)
+	if deletedItem != nil {
+		t.Fatalf("silinen öğe hala bulunuyor")
+	}
+
+	// Koleksiyon silindiğinde klasörleri de silinmeli
+	must(t, repos.Collections.Delete(collection.ID))
+	deleted, err := repos.Collections.FindByID(collection.ID)
+	must(t, err)
+	if deleted != nil {
+		t.Fatalf("silinen koleksiyon hala bulunuyor")
+	}
+	folders, err = repos.Collections.FindFolders(collection.ID)
+	must(t, err)
+	if len(folders) != 0 {
+		t.Fatalf("silinen koleksiyonun klasörleri temizlenmedi")
+	}
+	if remaining, _ := repos.Collections.FindByID(other.ID); remaining == nil {
+		t.Fatalf("başka koleksiyon silinmemeliydi")
+	}
+}
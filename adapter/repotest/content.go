@@ -0,0 +1,789 @@
+package repotest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+func testUserRepository(t *testing.T, repos *Repositories) {
+	user := &domain.User{Username: "ayse", Email: "ayse@example.com", Password: "hash", University: "İTÜ"}
+	must(t, repos.Users.Create(user))
+	if user.ID == 0 {
+		t.Fatalf("Create sonrası kullanıcı ID'si atanmadı")
+	}
+
+	for name, find := range map[string]func() (*domain.User, error){
+		"FindByID":       func() (*domain.User, error) { return repos.Users.FindByID(user.ID) },
+		"FindByEmail":    func() (*domain.User, error) { return repos.Users.FindByEmail("ayse@example.com") },
+		"FindByUsername": func() (*domain.User, error) { return repos.Users.FindByUsername("ayse") },
+	} {
+		found, err := find()
+		must(t, err)
+		if found == nil || found.ID != user.ID || found.University != "İTÜ" {
+			t.Fatalf("%s beklenen kullanıcıyı döndürmedi: %+v", name, found)
+		}
+	}
+
+	missing, err := repos.Users.FindByEmail("yok@example.com")
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("olmayan kullanıcı için nil beklenirken %+v döndü", missing)
+	}
+
+	if err := repos.Users.Create(&domain.User{Username: "baska", Email: "ayse@example.com", Password: "x"}); err == nil {
+		t.Fatalf("aynı e-posta ile ikinci kullanıcı oluşturulabildi")
+	}
+
+	user.Department = "Bilgisayar"
+	must(t, repos.Users.Update(user))
+	updated, err := repos.Users.FindByID(user.ID)
+	must(t, err)
+	if updated.Department != "Bilgisayar" {
+		t.Fatalf("Update bölüm bilgisini kaydetmedi: %q", updated.Department)
+	}
+
+	must(t, repos.Users.Create(&domain.User{Username: "mehmet", Email: "mehmet@example.com", Password: "hash"}))
+	users, err := repos.Users.List(1, 1)
+	must(t, err)
+	if len(users) != 1 {
+		t.Fatalf("List(1, 1) bir kullanıcı döndürmeliydi, %d döndü", len(users))
+	}
+
+	must(t, repos.Users.Delete(user.ID))
+	deleted, err := repos.Users.FindByID(user.ID)
+	must(t, err)
+	if deleted != nil {
+		t.Fatalf("silinen kullanıcı hala bulunuyor")
+	}
+}
+
+func testNoteRepository(t *testing.T, repos *Repositories) {
+	public := createNote(t, repos, &domain.Note{Title: "Lineer Cebir", Content: "Matris çarpımı", UserID: 1, Tags: []string{"matematik", "vize"}, IsPublic: true})
+	private := createNote(t, repos, &domain.Note{Title: "Fizik", Content: "Newton yasaları", UserID: 1, Tags: []string{"fizik"}})
+	other := createNote(t, repos, &domain.Note{Title: "Kimya", Content: "MATRİS değil mol", UserID: 2, IsPublic: true})
+
+	found := reloadNote(t, repos, public.ID)
+	if found.Title != "Lineer Cebir" || !sameTags(found.Tags, []string{"matematik", "vize"}) {
+		t.Fatalf("FindByID beklenen notu döndürmedi: %+v", found)
+	}
+
+	missing, err := repos.Notes.FindByID(other.ID + 1000)
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("olmayan not için nil beklenirken %+v döndü", missing)
+	}
+
+	byUser, err := repos.Notes.FindByUserID(1, 10, 0)
+	must(t, err)
+	if len(byUser) != 2 {
+		t.Fatalf("FindByUserID 2 not döndürmeliydi, %d döndü", len(byUser))
+	}
+
+	paged, err := repos.Notes.FindByUserID(1, 1, 1)
+	must(t, err)
+	if len(paged) != 1 {
+		t.Fatalf("FindByUserID(limit=1, offset=1) 1 not döndürmeliydi, %d döndü", len(paged))
+	}
+
+	publicNotes, err := repos.Notes.FindPublic(10, 0)
+	must(t, err)
+	if len(publicNotes) != 2 {
+		t.Fatalf("FindPublic 2 not döndürmeliydi, %d döndü", len(publicNotes))
+	}
+	for _, n := range publicNotes {
+		if n.ID == private.ID {
+			t.Fatalf("FindPublic özel notu döndürdü")
+		}
+	}
+
+	tagged, err := repos.Notes.FindByTag("matematik", 10, 0)
+	must(t, err)
+	if len(tagged) != 1 || tagged[0].ID != public.ID {
+		t.Fatalf("FindByTag beklenen notu döndürmedi: %+v", tagged)
+	}
+
+	results, err := repos.Notes.Search(searchQuery(1, domain.SearchTerm{Words: []string{"NEWTON"}}))
+	must(t, err)
+	if len(results) != 1 || results[0].Note.ID != private.ID {
+		t.Fatalf("Search büyük/küçük harf duyarsız içerik araması yapmadı: %+v", results)
+	}
+	results, err = repos.Notes.Search(searchQuery(2, domain.SearchTerm{Words: []string{"newton"}}))
+	must(t, err)
+	if len(results) != 0 {
+		t.Fatalf("Search başka kullanıcının özel notunu döndürdü: %+v", results)
+	}
+
+	must(t, repos.Notes.IncrementViewCount(public.ID))
+	must(t, repos.Notes.IncrementLikeCount(public.ID))
+	must(t, repos.Notes.IncrementLikeCount(public.ID))
+	must(t, repos.Notes.DecrementLikeCount(public.ID))
+
+	found = reloadNote(t, repos, public.ID)
+	if found.ViewCount != 1 || found.LikeCount != 1 {
+		t.Fatalf("sayaçlar beklenen değerde değil: görüntülenme=%d beğeni=%d", found.ViewCount, found.LikeCount)
+	}
+
+	found.Title = "Lineer Cebir 2"
+	found.Tags = []string{"final"}
+	found.IsPublic = false
+	found.LikeCount = 99
+	must(t, repos.Notes.Update(found))
+
+	updated := reloadNote(t, repos, public.ID)
+	if updated.Title != "Lineer Cebir 2" || updated.IsPublic || !sameTags(updated.Tags, []string{"final"}) {
+		t.Fatalf("Update düzenlenebilir alanları kaydetmedi: %+v", updated)
+	}
+	if updated.LikeCount != 1 || updated.ViewCount != 1 {
+		t.Fatalf("Update sayaçları değiştirmemeliydi: %+v", updated)
+	}
+	if updated.Version != 2 || found.Version != 2 {
+		t.Fatalf("Update sürümü 2'ye yükseltmeliydi: saklanan=%d, dönen=%d", updated.Version, found.Version)
+	}
+
+	// Eski sürümle yapılan güncelleme reddedilmeli ve kaydı değiştirmemeli
+	stale := *updated
+	stale.Version = 1
+	stale.Title = "Eski sekme"
+	if err := repos.Notes.Update(&stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("eski sürümle Update ErrVersionConflict döndürmeliydi, %v döndü", err)
+	}
+	if got := reloadNote(t, repos, public.ID); got.Title != "Lineer Cebir 2" || got.Version != 2 {
+		t.Fatalf("reddedilen Update notu değiştirdi: %+v", got)
+	}
+
+	oldTag, err := repos.Notes.FindByTag("matematik", 10, 0)
+	must(t, err)
+	if len(oldTag) != 0 {
+		t.Fatalf("Update sonrası eski etiket hala nota bağlı")
+	}
+
+	// Silme, ilişkili yorum ve beğenileri de temizlemeli
+	must(t, repos.Comments.Create(&domain.Comment{NoteID: private.ID, UserID: 2, Content: "güzel"}))
+	must(t, repos.Likes.Create(&domain.Like{UserID: 2, ContentID: private.ID, Type: "note"}))
+	must(t, repos.Notes.Delete(private.ID))
+
+	deleted, err := repos.Notes.FindByID(private.ID)
+	must(t, err)
+	if deleted != nil {
+		t.Fatalf("silinen not hala bulunuyor")
+	}
+	comments, err := repos.Comments.FindByNoteID(private.ID, 10, 0)
+	must(t, err)
+	if len(comments) != 0 {
+		t.Fatalf("silinen notun yorumları temizlenmedi")
+	}
+	like, err := repos.Likes.FindByUserIDAndContent(2, private.ID, "note")
+	must(t, err)
+	if like != nil {
+		t.Fatalf("silinen notun beğenileri temizlenmedi")
+	}
+}
+
+func testCommentRepository(t *testing.T, repos *Repositories) {
+	note := createNote(t, repos, &domain.Note{Title: "Not", UserID: 1, IsPublic: true})
+
+	first := &domain.Comment{NoteID: note.ID, UserID: 2, Content: "ilk"}
+	must(t, repos.Comments.Create(first))
+	if first.ID == 0 {
+		t.Fatalf("Create sonrası yorum ID'si atanmadı")
+	}
+	must(t, repos.Comments.Create(&domain.Comment{NoteID: note.ID, UserID: 3, Content: "ikinci"}))
+
+	if got := reloadNote(t, repos, note.ID).CommentCount; got != 2 {
+		t.Fatalf("yorum sayısı 2 olmalıydı, %d", got)
+	}
+
+	comments, err := repos.Comments.FindByNoteID(note.ID, 10, 0)
+	must(t, err)
+	if len(comments) != 2 {
+		t.Fatalf("FindByNoteID 2 yorum döndürmeliydi, %d döndü", len(comments))
+	}
+
+	first.Content = "düzenlendi"
+	must(t, repos.Comments.Update(first))
+	comments, err = repos.Comments.FindByNoteID(note.ID, 1, 0)
+	must(t, err)
+	if len(comments) != 1 {
+		t.Fatalf("FindByNoteID(limit=1) 1 yorum döndürmeliydi, %d döndü", len(comments))
+	}
+
+	must(t, repos.Comments.Delete(first.ID))
+	if got := reloadNote(t, repos, note.ID).CommentCount; got != 1 {
+		t.Fatalf("silme sonrası yorum sayısı 1 olmalıydı, %d", got)
+	}
+
+	if err := repos.Comments.Delete(first.ID); err == nil {
+		t.Fatalf("olmayan yorumu silmek hata döndürmeliydi")
+	}
+}
+
+func testNoteRevisionRepository(t *testing.T, repos *Repositories) {
+	note := createNote(t, repos, &domain.Note{Title: "Not", UserID: 1})
+	other := createNote(t, repos, &domain.Note{Title: "Diğer", UserID: 1})
+
+	latest, err := repos.NoteRevisions.FindLatest(note.ID)
+	must(t, err)
+	if latest != nil {
+		t.Fatalf("revizyonu olmayan not için nil beklenirken %+v döndü", latest)
+	}
+
+	first := &domain.NoteRevision{NoteID: note.ID, AuthorID: 1, Title: "v1", Content: "a\nb", Tags: []string{"x", "y"}}
+	must(t, repos.NoteRevisions.Create(first))
+	if first.ID == 0 || first.Number != 1 || first.CreatedAt.IsZero() {
+		t.Fatalf("Create ID, numara ve zaman atamadı: %+v", first)
+	}
+	must(t, repos.NoteRevisions.Create(&domain.NoteRevision{NoteID: other.ID, AuthorID: 1, Title: "diğer"}))
+
+	second := &domain.NoteRevision{NoteID: note.ID, AuthorID: 2, Title: "v2", Content: "a\nc", RestoredFrom: 1}
+	must(t, repos.NoteRevisions.Create(second))
+	if second.Number != 2 {
+		t.Fatalf("numaralar not bazında artmalıydı, %d", second.Number)
+	}
+
+	found, err := repos.NoteRevisions.FindByNumber(note.ID, 1)
+	must(t, err)
+	if found == nil || found.Title != "v1" || found.Content != "a\nb" || !sameTags(found.Tags, []string{"x", "y"}) {
+		t.Fatalf("FindByNumber beklenen revizyonu döndürmedi: %+v", found)
+	}
+
+	missing, err := repos.NoteRevisions.FindByNumber(note.ID, 5)
+	must(t, err)
+	if missing != nil {
+		t.Fatalf("olmayan revizyon için nil beklenirken %+v döndü", missing)
+	}
+
+	latest, err = repos.NoteRevisions.FindLatest(note.ID)
+	must(t, err)
+	if latest == nil || latest.Number != 2 || latest.AuthorID != 2 || latest.RestoredFrom != 1 {
+		t.Fatalf("FindLatest son revizyonu döndürmedi: %+v", latest)
+	}
+
+	revisions, err := repos.NoteRevisions.FindByNoteID(note.ID, 10, 0)
+	must(t, err)
+	if len(revisions) != 2 || revisions[0].Number != 2 || revisions[1].Number != 1 {
+		t.Fatalf("FindByNoteID revizyonları yeniden eskiye döndürmedi: %+v", revisions)
+	}
+	if len(revisions[0].Tags) != 0 {
+		t.Fatalf("etiketsiz revizyon boş etiket listesi döndürmeliydi: %+v", revisions[0].Tags)
+	}
+
+	paged, err := repos.NoteRevisions.FindByNoteID(note.ID, 1, 1)
+	must(t, err)
+	if len(paged) != 1 || paged[0].Number != 1 {
+		t.Fatalf("FindByNoteID(limit=1, offset=1) ilk revizyonu döndürmeliydi: %+v", paged)
+	}
+
+	// Not silindiğinde revizyonları da silinmeli
+	must(t, repos.Notes.Delete(note.ID))
+	revisions, err = repos.NoteRevisions.FindByNoteID(note.ID, 10, 0)
+	must(t, err)
+	if len(revisions) != 0 {
+		t.Fatalf("silinen notun revizyonları temizlenmedi")
+	}
+	revisions, err = repos.NoteRevisions.FindByNoteID(other.ID, 10, 0)
+	must(t, err)
+	if len(revisions) != 1 {
+		t.Fatalf("başka notun revizyonları silinmemeliydi")
+	}
+}
+
+func testPDFRepository(t *testing.T, repos *Repositories) {
+	public := createPDF(t, repos, &domain.PDF{Title: "Ders Notları", Description: "Hafta 1 özet", FileSize: 42, ContentHash: "abc123", PDFVersion: "1.7", UserID: 1, Tags: []string{"vize"}, IsPublic: true})
+	private := createPDF(t, repos, &domain.PDF{Title: "Ödev", Description: "Çözümler", ActiveContent: []string{domain.PDFActiveEmbeddedFile, domain.PDFActiveJavaScript}, UserID: 1})
+
+	found := reloadPDF(t, repos, public.ID)
+	if found.FilePath != "test.pdf" || found.FileSize != 42 || found.ContentHash != "abc123" || found.PDFVersion != "1.7" || !sameTags(found.Tags, []string{"vize"}) {
+		t.Fatalf("FindByID beklenen PDF'i döndürmedi: %+v", found)
+	}
+	if len(found.ActiveContent) != 0 {
+		t.Fatalf("etkin içeriği olmayan PDF boş liste döndürmeliydi: %+v", found.ActiveContent)
+	}
+	if got := reloadPDF(t, repos, private.ID).ActiveContent; !sameTags(got, []string{domain.PDFActiveEmbeddedFile, domain.PDFActiveJavaScript}) {
+		t.Fatalf("etkin içerik türleri saklanmadı: %+v", got)
+	}
+
+	publicPDFs, err := repos.PDFs.FindPublic(10, 0)
+	must(t, err)
+	if len(publicPDFs) != 1 || publicPDFs[0].ID != public.ID {
+		t.Fatalf("FindPublic yalnızca herkese açık PDF'i döndürmeliydi: %+v", publicPDFs)
+	}
+
+	results, err := repos.PDFs.Search(searchQuery(0, domain.SearchTerm{Words: []string{"özet"}}))
+	must(t, err)
+	if len(results) != 1 || results[0].PDF.ID != public.ID || results[0].ContentType != "pdf" {
+		t.Fatalf("Search açıklama içinde arama yapmadı: %+v", results)
+	}
+	results, err = repos.PDFs.Search(searchQuery(0, domain.SearchTerm{Words: []string{"çözümler"}}))
+	must(t, err)
+	if len(results) != 0 {
+		t.Fatalf("Search özel PDF'i herkese döndürdü: %+v", results)
+	}
+
+	tagged, err := repos.PDFs.FindByTag("vize", 10, 0)
+	must(t, err)
+	if len(tagged) != 1 {
+		t.Fatalf("FindByTag 1 PDF döndürmeliydi, %d döndü", len(tagged))
+	}
+
+	found.Title = "Ders Notları (güncel)"
+	found.FilePath = "baska.pdf"
+	must(t, repos.PDFs.Update(found))
+	updated := reloadPDF(t, repos, public.ID)
+	if updated.Title != "Ders Notları (güncel)" || updated.FilePath != "test.pdf" {
+		t.Fatalf("Update başlığı güncellemeli, dosya yolunu korumalıydı: %+v", updated)
+	}
+	if updated.Version != 2 || found.Version != 2 {
+		t.Fatalf("Update sürümü 2'ye yükseltmeliydi: saklanan=%d, dönen=%d", updated.Version, found.Version)
+	}
+
+	stale := *updated
+	stale.Version = 1
+	stale.Title = "Eski sekme"
+	if err := repos.PDFs.Update(&stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("eski sürümle Update ErrVersionConflict döndürmeliydi, %v döndü", err)
+	}
+	if got := reloadPDF(t, repos, public.ID); got.Title != "Ders Notları (güncel)" || got.Version != 2 {
+		t.Fatalf("reddedilen Update PDF'i değiştirdi: %+v", got)
+	}
+
+	must(t, repos.PDFs.IncrementViewCount(private.ID))
+	must(t, repos.PDFs.IncrementLikeCount(private.ID))
+	if got := reloadPDF(t, repos, private.ID); got.ViewCount != 1 || got.LikeCount != 1 {
+		t.Fatalf("PDF sayaçları beklenen değerde değil: %+v", got)
+	}
+
+	must(t, repos.PDFs.UpdatePageCount(private.ID, 12))
+	if got := reloadPDF(t, repos, private.ID); got.PageCount != 12 || got.Version != 1 {
+		t.Fatalf("UpdatePageCount sayfa sayısını sürümü değiştirmeden kaydetmeliydi: %+v", got)
+	}
+	must(t, repos.PDFs.UpdatePreviews(private.ID, true, 3))
+	if got := reloadPDF(t, repos, private.ID); !got.HasThumbnail || got.PreviewPages != 3 || got.Version != 1 {
+		t.Fatalf("UpdatePreviews görsel bilgilerini sürümü değiştirmeden kaydetmeliydi: %+v", got)
+	}
+	must(t, repos.PDFs.UpdateFilePath(private.ID, "pdfs/odev.pdf"))
+	if got := reloadPDF(t, repos, private.ID); got.FilePath != "pdfs/odev.pdf" || got.Version != 1 {
+		t.Fatalf("UpdateFilePath dosya yolunu sürümü değiştirmeden kaydetmeliydi: %+v", got)
+	}
+
+	all, err := repos.PDFs.FindAll(-1, 0)
+	must(t, err)
+	if len(all) != 2 || all[0].ID != public.ID || all[1].ID != private.ID {
+		t.Fatalf("FindAll özel ve herkese açık tüm PDF'leri ID sırasıyla döndürmeliydi: %+v", all)
+	}
+	paged, err := repos.PDFs.FindAll(1, 1)
+	must(t, err)
+	if len(paged) != 1 || paged[0].ID != private.ID {
+		t.Fatalf("FindAll sayfalaması hatalı: %+v", paged)
+	}
+
+	must(t, repos.PDFComments.Create(&domain.PDFComment{PDFID: private.ID, UserID: 2, Content: "soru", PageNumber: 1}))
+	must(t, repos.PDFAnnotations.Create(&domain.PDFAnnotation{PDFID: private.ID, UserID: 2, PageNumber: 1, Type: "highlight", Color: "#ffff00"}))
+	must(t, repos.PDFPages.Replace(private.ID, []*domain.PDFPage{{PageNumber: 1, Text: "Birinci soru"}}))
+	must(t, repos.PDFs.Delete(private.ID))
+
+	deleted, err := repos.PDFs.FindByID(private.ID)
+	must(t, err)
+	if deleted != nil {
+		t.Fatalf("silinen PDF hala bulunuyor")
+	}
+	comments, err := repos.PDFComments.FindByPDFID(private.ID, 10, 0)
+	must(t, err)
+	annotations, err := repos.PDFAnnotations.FindByPDFID(private.ID, 10, 0)
+	must(t, err)
+	pages, err := repos.PDFPages.FindByPDFID(private.ID, 10, 0)
+	must(t, err)
+	if len(comments) != 0 || len(annotations) != 0 || len(pages) != 0 {
+		t.Fatalf("silinen PDF'in yorum, işaretleme ve sayfa metinleri temizlenmedi")
+	}
+
+	// Aynı içerikli PDF'ler dosyayı paylaşır; referans sayısı silinen PDF'leri saymamalı
+	if refs, err := repos.PDFs.CountByFilePath("pdfs/odev.pdf"); err != nil || refs != 0 {
+		t.Fatalf("silinen PDF'in dosyası referans sayılmamalıydı: refs=%d, err=%v", refs, err)
+	}
+	privateCopy := createPDF(t, repos, &domain.PDF{Title: "Ders Notları kopyası", ContentHash: "abc123", UserID: 2})
+	publicCopy := createPDF(t, repos, &domain.PDF{Title: "Ders Notları (ikinci)", ContentHash: "abc123", UserID: 3, IsPublic: true})
+	if refs, err := repos.PDFs.CountByFilePath("test.pdf"); err != nil || refs != 3 {
+		t.Fatalf("CountByFilePath dosyayı paylaşan 3 PDF saymalıydı: refs=%d, err=%v", refs, err)
+	}
+	if refs, err := repos.PDFs.CountByContentHash("abc123"); err != nil || refs != 3 {
+		t.Fatalf("CountByContentHash içeriği aynı 3 PDF saymalıydı: refs=%d, err=%v", refs, err)
+	}
+
+	duplicates, err := repos.PDFs.FindPublicByContentHash("abc123", 10)
+	must(t, err)
+	if len(duplicates) != 2 || duplicates[0].ID != public.ID || duplicates[1].ID != publicCopy.ID {
+		t.Fatalf("FindPublicByContentHash yalnızca herkese açık kopyaları ID sırasıyla döndürmeliydi: %+v", duplicates)
+	}
+	duplicates, err = repos.PDFs.FindPublicByContentHash("abc123", 1)
+	must(t, err)
+	if len(duplicates) != 1 || duplicates[0].ID != public.ID {
+		t.Fatalf("FindPublicByContentHash sınırı uygulamadı: %+v", duplicates)
+	}
+	if reloadPDF(t, repos, privateCopy.ID).IsPublic {
+		t.Fatalf("özel kopya herkese açık kaydedildi")
+	}
+}
+
+func testPDFContentHashBackfill(t *testing.T, repos *Repositories) {
+	legacy := createPDF(t, repos, &domain.PDF{Title: "Eski PDF", FilePath: "uploads/eski.pdf", UserID: 1, IsPublic: true})
+	hashed := createPDF(t, repos, &domain.PDF{Title: "Yeni PDF", ContentHash: "abc123", UserID: 1, IsPublic: true})
+	other := createPDF(t, repos, &domain.PDF{Title: "Başka eski PDF", FilePath: "uploads/baska.pdf", UserID: 2})
+
+	pdfs, err := repos.PDFs.FindWithoutContentHash(0, 10)
+	must(t, err)
+	if len(pdfs) != 2 || pdfs[0].ID != legacy.ID || pdfs[1].ID != other.ID {
+		t.Fatalf("FindWithoutContentHash yalnızca özeti olmayan PDF'leri ID sırasıyla döndürmeliydi: %+v", pdfs)
+	}
+	pdfs, err = repos.PDFs.FindWithoutContentHash(legacy.ID, 1)
+	must(t, err)
+	if len(pdfs) != 1 || pdfs[0].ID != other.ID {
+		t.Fatalf("FindWithoutContentHash afterID'den sonrasını döndürmeliydi: %+v", pdfs)
+	}
+
+	must(t, repos.PDFs.UpdateContentHash(legacy.ID, "abc123.pdf", "abc123"))
+	found := reloadPDF(t, repos, legacy.ID)
+	if found.FilePath != "abc123.pdf" || found.ContentHash != "abc123" || found.Version != 1 || found.Title != "Eski PDF" {
+		t.Fatalf("UpdateContentHash özeti ve dosya yolunu sürümü değiştirmeden kaydetmeliydi: %+v", found)
+	}
+	duplicates, err := repos.PDFs.FindPublicByContentHash("abc123", 10)
+	must(t, err)
+	if len(duplicates) != 2 || duplicates[0].ID != legacy.ID || duplicates[1].ID != hashed.ID {
+		t.Fatalf("özeti hesaplanan eski PDF kopya olarak bulunmalıydı: %+v", duplicates)
+	}
+	pdfs, err = repos.PDFs.FindWithoutContentHash(0, 10)
+	must(t, err)
+	if len(pdfs) != 1 || pdfs[0].ID != other.ID {
+		t.Fatalf("özeti hesaplanan PDF artık listelenmemeliydi: %+v", pdfs)
+	}
+}
+
+func testPDFCommentRepository(t *testing.T, repos *Repositories) {
+	pdf := createPDF(t, repos, &domain.PDF{Title: "PDF", UserID: 1, IsPublic: true})
+
+	comment := &domain.PDFComment{PDFID: pdf.ID, UserID: 2, Content: "sayfa 3 hatalı", PageNumber: 3}
+	must(t, repos.PDFComments.Create(comment))
+	if got := reloadPDF(t, repos, pdf.ID).CommentCount; got != 1 {
+		t.Fatalf("PDF yorum sayısı 1 olmalıydı, %d", got)
+	}
+
+	comment.PageNumber = 4
+	comment.Quote = &domain.TextQuoteSelector{Exact: "türev", Prefix: "fonksiyonun ", Suffix: " sıfırdır"}
+	must(t, repos.PDFComments.Update(comment))
+	comments, err := repos.PDFComments.FindByPDFID(pdf.ID, 10, 0)
+	must(t, err)
+	if len(comments) != 1 || comments[0].PageNumber != 4 {
+		t.Fatalf("Update sayfa numarasını güncellemedi: %+v", comments)
+	}
+	if q := comments[0].Quote; q == nil || *q != *comment.Quote {
+		t.Fatalf("Update alıntıyı güncellemedi: %+v", q)
+	}
+
+	comment.Quote = nil
+	must(t, repos.PDFComments.Update(comment))
+	comments, err = repos.PDFComments.FindByPDFID(pdf.ID, 10, 0)
+	must(t, err)
+	if comments[0].Quote != nil {
+		t.Fatalf("Update alıntıyı kaldırmadı: %+v", comments[0].Quote)
+	}
+
+	must(t, repos.PDFComments.Delete(comment.ID))
+	if got := reloadPDF(t, repos, pdf.ID).CommentCount; got != 0 {
+		t.Fatalf("silme sonrası PDF yorum sayısı 0 olmalıydı, %d", got)
+	}
+}
+
+func testPDFAnnotationRepository(t *testing.T, repos *Repositories) {
+	pdf := createPDF(t, repos, &domain.PDF{Title: "PDF", UserID: 1})
+
+	mine := &domain.PDFAnnotation{
+		PDFID: pdf.ID, UserID: 1, PageNumber: 1,
+		X: 0.1, Y: 0.2, Width: 0.5, Height: 0.04,
+		Rects: []domain.PDFRect{
+			{X: 0.3, Y: 0.2, Width: 0.3, Height: 0.02},
+			{X: 0.1, Y: 0.22, Width: 0.2, Height: 0.02},
+		},
+		Quote: &domain.TextQuoteSelector{Exact: "limit tanımı", Prefix: "ve ", Suffix: " şöyledir"},
+		Type:  "highlight", Color: "#ffff00",
+	}
+	must(t, repos.PDFAnnotations.Create(mine))
+	mine.Rects[0].X = 0.9 // Kaydedilen işaretleme çağıranın dilimini paylaşmamalı
+
+	found, err := repos.PDFAnnotations.FindByID(mine.ID)
+	must(t, err)
+	if found == nil || found.PDFID != pdf.ID || found.Visibility != domain.AnnotationVisibilityPrivate {
+		t.Fatalf("FindByID görünürlüğü belirtilmemiş işaretlemeyi özel olarak döndürmeliydi: %+v", found)
+	}
+	must(t, repos.PDFAnnotations.Create(&domain.PDFAnnotation{PDFID: pdf.ID, UserID: 2, PageNumber: 2, Type: "note", Color: "#00ff00"}))
+
+	all, err := repos.PDFAnnotations.FindByPDFID(pdf.ID, 10, 0)
+	must(t, err)
+	if len(all) != 2 {
+		t.Fatalf("FindByPDFID 2 işaretleme döndürmeliydi, %d döndü", len(all))
+	}
+
+	own, err := repos.PDFAnnotations.FindByPDFIDAndUserID(pdf.ID, 1)
+	must(t, err)
+	if len(own) != 1 || own[0].ID != mine.ID || own[0].Width != 0.5 {
+		t.Fatalf("FindByPDFIDAndUserID beklenen işaretlemeyi döndürmedi: %+v", own)
+	}
+	if len(own[0].Rects) != 2 || own[0].Rects[0].X != 0.3 || own[0].Rects[1].Y != 0.22 {
+		t.Fatalf("işaretleme dikdörtgenleri saklanmadı: %+v", own[0].Rects)
+	}
+	if q := own[0].Quote; q == nil || q.Exact != "limit tanımı" || q.Prefix != "ve " || q.Suffix != " şöyledir" {
+		t.Fatalf("işaretlemenin alıntısı saklanmadı: %+v", q)
+	}
+	if all[1].Rects != nil || all[1].Quote != nil {
+		t.Fatalf("dikdörtgensiz ve alıntısız işaretleme boş dönmeliydi: %+v", all[1])
+	}
+
+	mine.Color = "#ff0000"
+	mine.X = 0.15
+	mine.Rects = []domain.PDFRect{{X: 0.15, Y: 0.2, Width: 0.45, Height: 0.02}}
+	mine.Quote = nil
+	must(t, repos.PDFAnnotations.Update(mine))
+	own, err = repos.PDFAnnotations.FindByPDFIDAndUserID(pdf.ID, 1)
+	must(t, err)
+	if own[0].Color != "#ff0000" || own[0].X != 0.15 {
+		t.Fatalf("Update işaretlemeyi güncellemedi: %+v", own[0])
+	}
+	if len(own[0].Rects) != 1 || own[0].Rects[0].Width != 0.45 || own[0].Quote != nil {
+		t.Fatalf("Update dikdörtgenleri ve alıntıyı güncellemedi: %+v", own[0])
+	}
+
+	must(t, repos.PDFAnnotations.Delete(mine.ID))
+	own, err = repos.PDFAnnotations.FindByPDFIDAndUserID(pdf.ID, 1)
+	must(t, err)
+	if len(own) != 0 {
+		t.Fatalf("silinen işaretleme hala bulunuyor")
+	}
+	if found, err := repos.PDFAnnotations.FindByID(mine.ID); err != nil || found != nil {
+		t.Fatalf("silinen işaretleme FindByID ile bulundu: %+v, %v", found, err)
+	}
+}
+
+func testPDFAnnotationVisibility(t *testing.T, repos *Repositories) {
+	pdf := createPDF(t, repos, &domain.PDF{Title: "PDF", UserID: 1, IsPublic: true})
+	other := createPDF(t, repos, &domain.PDF{Title: "Başka", UserID: 1, IsPublic: true})
+
+	create := func(pdfID, userID uint, visibility string) *domain.PDFAnnotation {
+		annotation := &domain.PDFAnnotation{PDFID: pdfID, UserID: userID, PageNumber: 1, Type: "highlight", Color: "#ffff00", Visibility: visibility}
+		must(t, repos.PDFAnnotations.Create(annotation))
+		return annotation
+	}
+	publicB := create(pdf.ID, 3, domain.AnnotationVisibilityPublic)
+	sharedA := create(pdf.ID, 2, domain.AnnotationVisibilityShared)
+	publicA := create(pdf.ID, 2, domain.AnnotationVisibilityPublic)
+	create(pdf.ID, 2, domain.AnnotationVisibilityPrivate)
+	create(pdf.ID, 4, domain.AnnotationVisibilityPublic) // Çağıranın kendi işaretlemesi
+	create(other.ID, 2, domain.AnnotationVisibilityPublic)
+
+	visible, err := repos.PDFAnnotations.FindVisibleByPDFID(pdf.ID, []string{domain.AnnotationVisibilityPublic}, 4, 100)
+	must(t, err)
+	if len(visible) != 2 || visible[0].ID != publicA.ID || visible[1].ID != publicB.ID {
+		t.Fatalf("FindVisibleByPDFID kullanıcıya göre sıralı herkese açık işaretlemeleri döndürmeliydi: %+v", visible)
+	}
+
+	visible, err = repos.PDFAnnotations.FindVisibleByPDFID(pdf.ID, []string{domain.AnnotationVisibilityPublic, domain.AnnotationVisibilityShared}, 4, 100)
+	must(t, err)
+	if len(visible) != 3 || visible[0].ID != sharedA.ID || visible[1].ID != publicA.ID || visible[2].ID != publicB.ID {
+		t.Fatalf("FindVisibleByPDFID paylaşılan işaretlemeleri de döndürmeliydi: %+v", visible)
+	}
+
+	visible, err = repos.PDFAnnotations.FindVisibleByPDFID(pdf.ID, []string{domain.AnnotationVisibilityPublic, domain.AnnotationVisibilityShared}, 4, 2)
+	must(t, err)
+	if len(visible) != 2 {
+		t.Fatalf("FindVisibleByPDFID sınırı uygulamadı: %d işaretleme döndü", len(visible))
+	}
+
+	publicB.Visibility = domain.AnnotationVisibilityPrivate
+	must(t, repos.PDFAnnotations.Update(publicB))
+	visible, err = repos.PDFAnnotations.FindVisibleByPDFID(pdf.ID, []string{domain.AnnotationVisibilityPublic}, 4, 100)
+	must(t, err)
+	if len(visible) != 1 || visible[0].ID != publicA.ID {
+		t.Fatalf("Update görünürlüğü güncellemedi: %+v", visible)
+	}
+}
+
+func testPDFPageRepository(t *testing.T, repos *Repositories) {
+	pdf := createPDF(t, repos, &domain.PDF{Title: "Slaytlar", UserID: 1, IsPublic: true})
+	other := createPDF(t, repos, &domain.PDF{Title: "Başka", UserID: 1, IsPublic: true})
+
+	must(t, repos.PDFPages.Replace(pdf.ID, []*domain.PDFPage{
+		{PageNumber: 3, Text: "Üçüncü sayfa"},
+		{PageNumber: 1, Text: "Birinci sayfa"},
+		{PageNumber: 2, Text: "İkinci sayfa"},
+	}))
+	must(t, repos.PDFPages.Replace(other.ID, []*domain.PDFPage{{PageNumber: 1, Text: "Başka belge"}}))
+
+	pages, err := repos.PDFPages.FindByPDFID(pdf.ID, -1, 0)
+	must(t, err)
+	if len(pages) != 3 {
+		t.Fatalf("FindByPDFID 3 sayfa döndürmeliydi, %d döndü", len(pages))
+	}
+	for i, page := range pages {
+		if page.PageNumber != i+1 || page.PDFID != pdf.ID {
+			t.Fatalf("sayfalar numaraya göre sıralı değil veya PDF ID'si hatalı: %+v", page)
+		}
+	}
+	if pages[0].Text != "Birinci sayfa" {
+		t.Fatalf("sayfa metni saklanmadı: %q", pages[0].Text)
+	}
+
+	paged, err := repos.PDFPages.FindByPDFID(pdf.ID, 1, 1)
+	must(t, err)
+	if len(paged) != 1 || paged[0].PageNumber != 2 {
+		t.Fatalf("FindByPDFID sayfalaması hatalı: %+v", paged)
+	}
+
+	// Yeniden çıkarma eski sayfaların tamamının yerine geçer
+	must(t, repos.PDFPages.Replace(pdf.ID, []*domain.PDFPage{{PageNumber: 2, Text: "Yeni metin"}}))
+	pages, err = repos.PDFPages.FindByPDFID(pdf.ID, 10, 0)
+	must(t, err)
+	if len(pages) != 1 || pages[0].PageNumber != 2 || pages[0].Text != "Yeni metin" {
+		t.Fatalf("Replace eski sayfaları değiştirmedi: %+v", pages)
+	}
+
+	must(t, repos.PDFPages.Replace(pdf.ID, nil))
+	pages, err = repos.PDFPages.FindByPDFID(pdf.ID, 10, 0)
+	must(t, err)
+	if len(pages) != 0 {
+		t.Fatalf("boş Replace sayfaları silmedi: %+v", pages)
+	}
+
+	pages, err = repos.PDFPages.FindByPDFID(other.ID, 10, 0)
+	must(t, err)
+	if len(pages) != 1 {
+		t.Fatalf("Replace başka PDF'in sayfalarını etkiledi: %+v", pages)
+	}
+}
+
+func testSearch(t *testing.T, repos *Repositories) {
+	titled := createNote(t, repos, &domain.Note{Title: "Matris Teorisi", Content: "Giriş ve tanımlar", UserID: 1, IsPublic: true})
+	body := createNote(t, repos, &domain.Note{Title: "Lineer Cebir", Content: "Matris çarpımı ve <b>determinant</b>", UserID: 1, IsPublic: true})
+	excluded := createNote(t, repos, &domain.Note{Title: "Kimya", Content: "Matris değil mol hesabı", UserID: 2, IsPublic: true})
+	pdf := createPDF(t, repos, &domain.PDF{Title: "Vize Soruları", Description: "Matris ve determinant soruları", UserID: 2, IsPublic: true})
+
+	matris := domain.SearchTerm{Words: []string{"matris"}}
+	results, err := repos.Notes.Search(searchQuery(0, matris))
+	must(t, err)
+	if len(results) != 3 {
+		t.Fatalf("Search 3 not döndürmeliydi, %d döndü", len(results))
+	}
+	if results[0].Note.ID != titled.ID {
+		t.Fatalf("başlıkta eşleşen not ilk sırada olmalıydı: %+v", results[0].Note)
+	}
+	for i := 1; i < len(results); i++ {
+		if results[i].Rank > results[i-1].Rank {
+			t.Fatalf("sonuçlar puana göre sıralı değil")
+		}
+	}
+	if !strings.Contains(results[0].Headline, "<mark>Matris</mark>") {
+		t.Fatalf("başlıktaki eşleşme vurgulanmadı: %q", results[0].Headline)
+	}
+
+	paged, err := repos.Notes.Search(&domain.SearchQuery{Terms: []domain.SearchTerm{matris}, Language: domain.SearchLanguageTurkish, Limit: 1, Offset: 1})
+	must(t, err)
+	if len(paged) != 1 || paged[0].Note.ID != results[1].Note.ID {
+		t.Fatalf("Search sayfalaması hatalı: %+v", paged)
+	}
+
+	results, err = repos.Notes.Search(searchQuery(0, domain.SearchTerm{Words: []string{"determinant"}}))
+	must(t, err)
+	if len(results) != 1 || results[0].Note.ID != body.ID {
+		t.Fatalf("Search içerikte arama yapmadı: %+v", results)
+	}
+	if !strings.Contains(results[0].Snippet, "<mark>determinant</mark>") || strings.Contains(results[0].Snippet, "<b>") {
+		t.Fatalf("özet vurgulanmalı ve içerikteki HTML kaçırılmalıydı: %q", results[0].Snippet)
+	}
+
+	results, err = repos.Notes.Search(searchQuery(0, matris, domain.SearchTerm{Words: []string{"mol"}, Exclude: true}))
+	must(t, err)
+	for _, hit := range results {
+		if hit.Note.ID == excluded.ID {
+			t.Fatalf("hariç tutulan terimi içeren not döndü")
+		}
+	}
+	if len(results) != 2 {
+		t.Fatalf("hariç tutmalı arama 2 not döndürmeliydi, %d döndü", len(results))
+	}
+
+	results, err = repos.Notes.Search(searchQuery(0, domain.SearchTerm{Words: []string{"matris", "çarpımı"}}))
+	must(t, err)
+	if len(results) != 1 || results[0].Note.ID != body.ID {
+		t.Fatalf("ifade araması beklenen notu döndürmedi: %+v", results)
+	}
+	results, err = repos.Notes.Search(searchQuery(0, domain.SearchTerm{Words: []string{"çarpımı", "matris"}}))
+	must(t, err)
+	if len(results) != 0 {
+		t.Fatalf("ifade araması kelime sırasını dikkate almadı: %+v", results)
+	}
+
+	results, err = repos.Notes.Search(searchQuery(0, domain.SearchTerm{Words: []string{"tanım"}, Prefix: true}))
+	must(t, err)
+	if len(results) != 1 || results[0].Note.ID != titled.ID {
+		t.Fatalf("önek araması beklenen notu döndürmedi: %+v", results)
+	}
+
+	// Sorgu dili operatörleri kelime ayıracı sayılır; sözdizimi hatasına yol açmaz
+	results, err = repos.Notes.Search(searchQuery(0,
+		domain.SearchTerm{Words: []string{"matris:*"}},
+		domain.SearchTerm{Words: []string{"!mol)"}, Exclude: true},
+		domain.SearchTerm{Words: []string{"&|!", "(", `'\`}},
+	))
+	must(t, err)
+	if len(results) != 2 {
+		t.Fatalf("operatör içeren arama 2 not döndürmeliydi, %d döndü", len(results))
+	}
+	results, err = repos.Notes.Search(searchQuery(0, domain.SearchTerm{Words: []string{"):*"}, Prefix: true}))
+	must(t, err)
+	if len(results) != 0 {
+		t.Fatalf("yalnızca operatörlerden oluşan arama sonuç döndürmemeliydi: %+v", results)
+	}
+	pdfResults, err := repos.PDFs.Search(searchQuery(0, domain.SearchTerm{Words: []string{"(determinant|"}}, domain.SearchTerm{Words: []string{"'"}, Prefix: true}))
+	must(t, err)
+	if len(pdfResults) != 1 || pdfResults[0].PDF.ID != pdf.ID {
+		t.Fatalf("operatör içeren PDF araması beklenen PDF'i döndürmedi: %+v", pdfResults)
+	}
+
+	pdfResults, err = repos.PDFs.Search(searchQuery(0, domain.SearchTerm{Words: []string{"determinant"}}))
+	must(t, err)
+	if len(pdfResults) != 1 || pdfResults[0].PDF.ID != pdf.ID || pdfResults[0].Note != nil {
+		t.Fatalf("PDF araması beklenen PDF'i döndürmedi: %+v", pdfResults)
+	}
+
+	// Sayfa metinlerinde eşleşen PDF, en güçlü eşleşen sayfayı ve o sayfadan bir özeti gösterir
+	lecture := createPDF(t, repos, &domain.PDF{Title: "Hafta 5", Description: "Ders slaytları", UserID: 2, IsPublic: true})
+	must(t, repos.PDFPages.Replace(lecture.ID, []*domain.PDFPage{
+		{PageNumber: 1, Text: "Giriş"},
+		{PageNumber: 2, Text: "Özdeğer tanımı"},
+		{PageNumber: 4, Text: "Özdeğer ve özvektör: her özdeğer için bir özvektör bulunur"},
+	}))
+	pdfResults, err = repos.PDFs.Search(searchQuery(0, domain.SearchTerm{Words: []string{"özdeğer"}}))
+	must(t, err)
+	if len(pdfResults) != 1 || pdfResults[0].PDF.ID != lecture.ID || pdfResults[0].PageNumber != 4 {
+		t.Fatalf("sayfa metni araması en güçlü eşleşen sayfayı döndürmedi: %+v", pdfResults)
+	}
+	if !strings.Contains(pdfResults[0].Snippet, "<mark>Özdeğer</mark>") {
+		t.Fatalf("özet eşleşen sayfadan oluşturulmadı: %q", pdfResults[0].Snippet)
+	}
+	pdfResults, err = repos.PDFs.Search(searchQuery(0, domain.SearchTerm{Words: []string{"slaytları"}}))
+	must(t, err)
+	if len(pdfResults) != 1 || pdfResults[0].PageNumber != 0 {
+		t.Fatalf("açıklamada eşleşen PDF sayfa numarası içermemeliydi: %+v", pdfResults)
+	}
+	pdfResults, err = repos.PDFs.Search(searchQuery(0, domain.SearchTerm{Words: []string{"determinant"}}))
+	must(t, err)
+	if len(pdfResults) != 1 || pdfResults[0].PDF.ID != pdf.ID || pdfResults[0].PageNumber != 0 {
+		t.Fatalf("sayfası olmayan PDF'in araması değişti: %+v", pdfResults)
+	}
+
+	must(t, repos.Notes.Delete(titled.ID))
+	results, err = repos.Notes.Search(searchQuery(0, matris))
+	must(t, err)
+	if len(results) != 2 {
+		t.Fatalf("silinen not aramada döndü: %d sonuç", len(results))
+	}
+}
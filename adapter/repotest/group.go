@@ -0,0 +1,197 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+func testGroupRepository(t *testing.T, repos *Repositories) {
+	group := &domain.Group{OwnerID: 1, Name: "Algoritmalar", Description: "Vize çalışması"}
+	must(t, repos.Groups.Create(group))
+	if group.ID == 0 || group.CreatedAt.IsZero() {
+		t.Fatalf("Create ID ve zaman atamadı: %+v", group)
+	}
+	other := &domain.Group{OwnerID: 2, Name: "Fizik"}
+	must(t, repos.Groups.Create(other))
+
+	group.Name = "Algoritmalar 2"
+	must(t, repos.Groups.Update(group))
+	found, err := repos.Groups.FindByID(group.ID)
+	must(t, err)
+	if found == nil || found.Name != "Algoritmalar 2" || found.Description != "Vize çalışması" {
+		t.Fatalf("Update grubu güncellemedi: %+v", found)
+	}
+
+	// Üyelikler
+	owner := &domain.GroupMember{GroupID: group.ID, UserID: 1, Role: domain.GroupRoleOwner}
+	must(t, repos.Groups.CreateMember(owner))
+	if owner.ID == 0 || owner.CreatedAt.IsZero() {
+		t.Fatalf("CreateMember ID ve zaman atamadı: %+v", owner)
+	}
+	member := &domain.GroupMember{GroupID: group.ID, UserID: 2, Role: domain.GroupRoleMember}
+	must(t, repos.Groups.CreateMember(member))
+	must(t, repos.Groups.CreateMember(&domain.GroupMember{GroupID: other.ID, UserID: 2, Role: domain.GroupRoleOwner}))
+	err = repos.Groups.CreateMember(&domain.GroupMember{GroupID: group.ID, UserID: 2, Role: domain.GroupRoleAdmin})
+	if !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı kullanıcı ikinci kez eklenince ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+
+	member.Role = domain.GroupRoleAdmin
+	must(t, repos.Groups.UpdateMember(member))
+	foundMember, err := repos.Groups.FindMember(group.ID, 2)
+	must(t, err)
+	if foundMember == nil || foundMember.Role != domain.GroupRoleAdmin {
+		t.Fatalf("UpdateMember rolü güncellemedi: %+v", foundMember)
+	}
+	if foundMember, _ := repos.Groups.FindMember(group.ID, 3); foundMember != nil {
+		t.Fatalf("üye olmayan kullanıcı için nil beklenirdi: %+v", foundMember)
+	}
+	members, err := repos.Groups.FindMembers(group.ID, 10, 0)
+	must(t, err)
+	if len(members) != 2 || members[0].UserID != 1 || members[1].UserID != 2 {
+		t.Fatalf("FindMembers üyeleri katılma sırasına göre döndürmeliydi: %+v", members)
+	}
+	count, err := repos.Groups.CountMembers(group.ID)
+	must(t, err)
+	if count != 2 {
+		t.Fatalf("CountMembers 2 döndürmeliydi, %d döndü", count)
+	}
+	groups, err := repos.Groups.FindByUserID(2, 10, 0)
+	must(t, err)
+	if len(groups) != 2 || groups[0].ID != group.ID {
+		t.Fatalf("FindByUserID son güncellenen grubu önce döndürmeliydi: %+v", groups)
+	}
+
+	// Üyelik istekleri
+	request := &domain.GroupJoinRequest{GroupID: group.ID, UserID: 3, Message: "Katılabilir miyim?", Status: domain.GroupJoinRequestPending}
+	must(t, repos.Groups.CreateJoinRequest(request))
+	must(t, repos.Groups.CreateJoinRequest(&domain.GroupJoinRequest{GroupID: group.ID, UserID: 4, Status: domain.GroupJoinRequestPending}))
+	pending, err := repos.Groups.FindPendingJoinRequest(group.ID, 3)
+	must(t, err)
+	if pending == nil || pending.ID != request.ID || pending.Message != "Katılabilir miyim?" {
+		t.Fatalf("FindPendingJoinRequest isteği bulmadı: %+v", pending)
+	}
+	reviewer := uint(1)
+	request.Status = domain.GroupJoinRequestRejected
+	request.ReviewedBy = &reviewer
+	must(t, repos.Groups.UpdateJoinRequest(request))
+	if pending, _ := repos.Groups.FindPendingJoinRequest(group.ID, 3); pending != nil {
+		t.Fatalf("reddedilen istek bekleyen olarak dönmemeliydi")
+	}
+	reviewed, err := repos.Groups.FindJoinRequestByID(request.ID)
+	must(t, err)
+	if reviewed == nil || reviewed.Status != domain.GroupJoinRequestRejected || reviewed.ReviewedBy == nil || *reviewed.ReviewedBy != 1 {
+		t.Fatalf("UpdateJoinRequest isteği güncellemedi: %+v", reviewed)
+	}
+	requests, err := repos.Groups.FindJoinRequests(group.ID, domain.GroupJoinRequestPending, 10, 0)
+	must(t, err)
+	if len(requests) != 1 || requests[0].UserID != 4 {
+		t.Fatalf("FindJoinRequests yalnızca bekleyen isteği döndürmeliydi: %+v", requests)
+	}
+
+	// Grup içerikleri ve görünürlük
+	ownerNote := createNote(t, repos, &domain.Note{Title: "Sahibin notu", UserID: 1})
+	memberNote := createNote(t, repos, &domain.Note{Title: "Üyenin notu", UserID: 2})
+	pdf := createPDF(t, repos, &domain.PDF{Title: "Slaytlar", UserID: 1})
+
+	content := &domain.GroupContent{GroupID: group.ID, ContentID: ownerNote.ID, ContentType: "note", UserID: 1}
+	must(t, repos.Groups.CreateContent(content))
+	if content.ID == 0 || content.CreatedAt.IsZero() {
+		t.Fatalf("CreateContent ID ve zaman atamadı: %+v", content)
+	}
+	must(t, repos.Groups.CreateContent(&domain.GroupContent{GroupID: group.ID, ContentID: memberNote.ID, ContentType: "note", UserID: 2}))
+	must(t, repos.Groups.CreateContent(&domain.GroupContent{GroupID: group.ID, ContentID: pdf.ID, ContentType: "pdf", UserID: 1}))
+	err = repos.Groups.CreateContent(&domain.GroupContent{GroupID: group.ID, ContentID: ownerNote.ID, ContentType: "note", UserID: 2})
+	if !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı içerik ikinci kez paylaşılınca ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+
+	contents, err := repos.Groups.FindContents(group.ID, 10, 0)
+	must(t, err)
+	if len(contents) != 3 || contents[0].ContentType != "pdf" || contents[2].ContentID != ownerNote.ID {
+		t.Fatalf("FindContents içerikleri son paylaşılandan başlayarak döndürmeliydi: %+v", contents)
+	}
+	foundContent, err := repos.Groups.FindContent(group.ID, pdf.ID, "pdf")
+	must(t, err)
+	if foundContent == nil || foundContent.UserID != 1 {
+		t.Fatalf("FindContent paylaşımı bulmadı: %+v", foundContent)
+	}
+	if foundContent, _ := repos.Groups.FindContent(group.ID, memberNote.ID, "pdf"); foundContent != nil {
+		t.Fatalf("farklı içerik türü için nil beklenirdi: %+v", foundContent)
+	}
+
+	shared, err := repos.Groups.IsContentShared(ownerNote.ID, "note", 2)
+	must(t, err)
+	if !shared {
+		t.Fatalf("IsContentShared grup üyesi için true döndürmeliydi")
+	}
+	if shared, _ := repos.Groups.IsContentShared(ownerNote.ID, "note", 3); shared {
+		t.Fatalf("IsContentShared üye olmayan için false döndürmeliydi")
+	}
+	if shared, _ := repos.Groups.IsContentShared(memberNote.ID, "pdf", 2); shared {
+		t.Fatalf("IsContentShared içerik türünü dikkate almalıydı")
+	}
+
+	must(t, repos.Groups.DeleteContent(group.ID, pdf.ID, "pdf"))
+	if foundContent, _ := repos.Groups.FindContent(group.ID, pdf.ID, "pdf"); foundContent != nil {
+		t.Fatalf("DeleteContent paylaşımı kaldırmadı")
+	}
+
+	// Üye çıkarılınca paylaştığı içerikler gruptan kalkmalı
+	must(t, repos.Groups.DeleteMember(group.ID, 2))
+	if foundMember, _ := repos.Groups.FindMember(group.ID, 2); foundMember != nil {
+		t.Fatalf("DeleteMember üyeliği silmedi")
+	}
+	if foundContent, _ := repos.Groups.FindContent(group.ID, memberNote.ID, "note"); foundContent != nil {
+		t.Fatalf("DeleteMember üyenin paylaştığı içeriği kaldırmadı")
+	}
+	if shared, _ := repos.Groups.IsContentShared(ownerNote.ID, "note", 2); shared {
+		t.Fatalf("ayrılan üye grup içeriğini görmemeliydi")
+	}
+
+	// Not silinince grup alanından çıkmalı
+	must(t, repos.Notes.Delete(ownerNote.ID))
+	if foundContent, _ := repos.Groups.FindContent(group.ID, ownerNote.ID, "note"); foundContent != nil {
+		t.Fatalf("silinen not grup alanından çıkarılmadı")
+	}
+
+	// Etkinlikler
+	must(t, repos.Groups.CreateActivity(&domain.GroupActivity{GroupID: group.ID, UserID: 1, Type: domain.GroupActivityCreated}))
+	posted := &domain.GroupActivity{GroupID: group.ID, UserID: 1, Type: domain.GroupActivityContentPosted, ContentID: pdf.ID, ContentType: "pdf", Title: "Slaytlar"}
+	must(t, repos.Groups.CreateActivity(posted))
+	if posted.ID == 0 || posted.CreatedAt.IsZero() {
+		t.Fatalf("CreateActivity ID ve zaman atamadı: %+v", posted)
+	}
+	must(t, repos.Groups.CreateActivity(&domain.GroupActivity{GroupID: other.ID, UserID: 2, Type: domain.GroupActivityCreated}))
+	activities, err := repos.Groups.FindActivities(group.ID, 10, 0)
+	must(t, err)
+	if len(activities) != 2 || activities[0].ID != posted.ID || activities[0].Title != "Slaytlar" || activities[0].ContentType != "pdf" {
+		t.Fatalf("FindActivities etkinlikleri en yeniden başlayarak döndürmeliydi: %+v", activities)
+	}
+
+	// Grup silinince ilişkili kayıtlar ve davet bağlantıları silinmeli
+	invite := &domain.Invite{ContentID: group.ID, Type: "group", Token: "grup-davet", CreatedBy: 1, ExpiresAt: domain.Now().Add(time.Hour), IsActive: true}
+	must(t, repos.Invites.Create(invite))
+	must(t, repos.Groups.Delete(group.ID))
+	if found, _ := repos.Groups.FindByID(group.ID); found != nil {
+		t.Fatalf("Delete grubu silmedi")
+	}
+	if foundMember, _ := repos.Groups.FindMember(group.ID, 1); foundMember != nil {
+		t.Fatalf("Delete üyelikleri silmedi")
+	}
+	if activities, _ := repos.Groups.FindActivities(group.ID, 10, 0); len(activities) != 0 {
+		t.Fatalf("Delete etkinlikleri silmedi: %+v", activities)
+	}
+	if requests, _ := repos.Groups.FindJoinRequests(group.ID, domain.GroupJoinRequestPending, 10, 0); len(requests) != 0 {
+		t.Fatalf("Delete üyelik isteklerini silmedi: %+v", requests)
+	}
+	if found, _ := repos.Invites.FindByToken("grup-davet"); found != nil {
+		t.Fatalf("Delete grubun davet bağlantısını silmedi")
+	}
+	if found, _ := repos.Groups.FindByID(other.ID); found == nil {
+		t.Fatalf("Delete başka grubu silmemeliydi")
+	}
+}
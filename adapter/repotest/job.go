@@ -0,0 +1,136 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// reloadJob, işi veri deposundan yeniden okur
+func reloadJob(t *testing.T, repos *Repositories, id uint) *domain.Job {
+	t.Helper()
+	job, err := repos.Jobs.FindByID(id)
+	must(t, err)
+	if job == nil {
+		t.Fatalf("iş %d bulunamadı", id)
+	}
+	return job
+}
+
+func testJobRepository(t *testing.T, repos *Repositories) {
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	first := &domain.Job{Type: domain.JobExtractPDFText, Payload: `{"pdfId":1}`, MaxAttempts: 3, RunAt: base}
+	later := &domain.Job{Type: domain.JobExtractPDFText, Payload: `{"pdfId":2}`, MaxAttempts: 3, RunAt: base.Add(time.Minute)}
+	oldest := &domain.Job{Type: domain.JobCountPDFPages, Payload: `{"pdfId":1}`, MaxAttempts: 3, RunAt: base.Add(-time.Minute)}
+	for _, job := range []*domain.Job{first, later, oldest} {
+		must(t, repos.Jobs.Create(job))
+		if job.ID == 0 || job.Status != domain.JobStatusPending {
+			t.Fatalf("Create ID atamalı ve işi beklemeye almalıydı: %+v", job)
+		}
+	}
+	if got := reloadJob(t, repos, first.ID); got.Payload != `{"pdfId":1}` || got.MaxAttempts != 3 || got.Attempts != 0 {
+		t.Fatalf("iş alanları saklanmadı: %+v", got)
+	}
+
+	// Çalıştırma zamanı gelmiş işler en eskiden başlayarak kiralanır
+	until := base.Add(5 * time.Minute)
+	leased, err := repos.Jobs.Lease("w1", base, until)
+	must(t, err)
+	if leased == nil || leased.ID != oldest.ID {
+		t.Fatalf("Lease çalıştırma zamanı en eski işi döndürmeliydi: %+v", leased)
+	}
+	if leased.Status != domain.JobStatusRunning || leased.Attempts != 1 || leased.LockedBy != "w1" ||
+		leased.LockedUntil == nil || !leased.LockedUntil.Equal(until) {
+		t.Fatalf("kiralanan iş bilgileri hatalı: %+v", leased)
+	}
+	leased, err = repos.Jobs.Lease("w1", base, until)
+	must(t, err)
+	if leased == nil || leased.ID != first.ID {
+		t.Fatalf("ikinci Lease sıradaki işi döndürmeliydi: %+v", leased)
+	}
+	leased, err = repos.Jobs.Lease("w1", base, until)
+	must(t, err)
+	if leased != nil {
+		t.Fatalf("zamanı gelmemiş iş kiralandı: %+v", leased)
+	}
+
+	// Sonucu yalnızca işi kiralayan worker yazabilir
+	if err := repos.Jobs.Complete(oldest.ID, "w2", base); !errors.Is(err, domain.ErrJobLeaseLost) {
+		t.Fatalf("başka worker'ın Complete çağrısı ErrJobLeaseLost döndürmeliydi, %v döndü", err)
+	}
+	must(t, repos.Jobs.Complete(oldest.ID, "w1", base))
+	if got := reloadJob(t, repos, oldest.ID); got.Status != domain.JobStatusSucceeded || got.FinishedAt == nil ||
+		got.LockedBy != "" || got.LockedUntil != nil {
+		t.Fatalf("Complete işi başarılı olarak işaretlemedi: %+v", got)
+	}
+	if err := repos.Jobs.Complete(oldest.ID, "w1", base); !errors.Is(err, domain.ErrJobLeaseLost) {
+		t.Fatalf("tamamlanmış işte Complete ErrJobLeaseLost döndürmeliydi, %v döndü", err)
+	}
+
+	// Yeniden denenecek iş bekleme süresi dolana kadar kiralanmaz
+	retryAt := base.Add(2 * time.Minute)
+	must(t, repos.Jobs.Fail(first.ID, "w1", "geçici hata", &retryAt, base))
+	if got := reloadJob(t, repos, first.ID); got.Status != domain.JobStatusPending || got.LastError != "geçici hata" ||
+		!got.RunAt.Equal(retryAt) || got.Attempts != 1 || got.LockedUntil != nil {
+		t.Fatalf("Fail işi yeniden denemeye almadı: %+v", got)
+	}
+	now := base.Add(90 * time.Second)
+	leased, err = repos.Jobs.Lease("w1", now, now.Add(10*time.Second))
+	must(t, err)
+	if leased == nil || leased.ID != later.ID {
+		t.Fatalf("Lease bekleme süresindeki işi atlayıp sıradakini döndürmeliydi: %+v", leased)
+	}
+
+	// Kirası dolan iş başka bir worker tarafından yeniden kiralanır; eski worker sonucu yazamaz
+	now = now.Add(20 * time.Second)
+	leased, err = repos.Jobs.Lease("w2", now, now.Add(5*time.Minute))
+	must(t, err)
+	if leased == nil || leased.ID != later.ID || leased.Attempts != 2 || leased.LockedBy != "w2" {
+		t.Fatalf("kirası dolan iş yeniden kiralanmadı: %+v", leased)
+	}
+	if err := repos.Jobs.Complete(later.ID, "w1", now); !errors.Is(err, domain.ErrJobLeaseLost) {
+		t.Fatalf("kirası kaybedilen işte Complete ErrJobLeaseLost döndürmeliydi, %v döndü", err)
+	}
+	must(t, repos.Jobs.Fail(later.ID, "w2", "kalıcı hata", nil, now))
+	if got := reloadJob(t, repos, later.ID); got.Status != domain.JobStatusDead || got.FinishedAt == nil || got.LastError != "kalıcı hata" {
+		t.Fatalf("retryAt olmadan Fail işi ölü olarak işaretlemeliydi: %+v", got)
+	}
+
+	// Yalnızca ölü işler elle yeniden denenebilir
+	if err := repos.Jobs.Retry(first.ID, now); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("bekleyen işte Retry ErrInvalidInput döndürmeliydi, %v döndü", err)
+	}
+	if err := repos.Jobs.Retry(9999, now); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("olmayan işte Retry ErrNotFound döndürmeliydi, %v döndü", err)
+	}
+	must(t, repos.Jobs.Retry(later.ID, now))
+	if got := reloadJob(t, repos, later.ID); got.Status != domain.JobStatusPending || got.Attempts != 0 ||
+		got.FinishedAt != nil || !got.RunAt.Equal(now) {
+		t.Fatalf("Retry işi sıfırlayıp beklemeye almadı: %+v", got)
+	}
+
+	pending, err := repos.Jobs.Find(domain.JobFilter{Status: domain.JobStatusPending}, -1, 0)
+	must(t, err)
+	if len(pending) != 2 || pending[0].ID != later.ID || pending[1].ID != first.ID {
+		t.Fatalf("Find durum filtresiyle en yeni işi önce döndürmeliydi: %+v", pending)
+	}
+	counted, err := repos.Jobs.Find(domain.JobFilter{Type: domain.JobCountPDFPages}, 10, 0)
+	must(t, err)
+	if len(counted) != 1 || counted[0].ID != oldest.ID {
+		t.Fatalf("Find tür filtresi hatalı: %+v", counted)
+	}
+	paged, err := repos.Jobs.Find(domain.JobFilter{}, 1, 1)
+	must(t, err)
+	if len(paged) != 1 || paged[0].ID != later.ID {
+		t.Fatalf("Find sayfalaması hatalı: %+v", paged)
+	}
+
+	counts, err := repos.Jobs.CountByStatus()
+	must(t, err)
+	if counts[domain.JobStatusPending] != 2 || counts[domain.JobStatusSucceeded] != 1 || counts[domain.JobStatusDead] != 0 {
+		t.Fatalf("CountByStatus hatalı: %+v", counts)
+	}
+}
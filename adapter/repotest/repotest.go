@@ -0,0 +1,159 @@
+// Package repotest, domain repository arayüzleri için ortak sözleşme testlerini içerir.
+//
+// Her adaptör (memory, postgres, ...) kendi test dosyasından Run fonksiyonunu
+// çağırarak aynı davranışı sağladığını doğrular:
+//
+//	func TestRepositories(t *testing.T) {
+//		repotest.Run(t, func(t *testing.T) *repotest.Repositories {
+//			store := memory.NewStore()
+//			return &repotest.Repositories{Notes: memory.NewNoteRepository(store), ...}
+//		})
+//	}
+//
+// Factory her çağrıldığında boş bir veri deposu üzerinde çalışan repository'ler
+// döndürmelidir; testler kayıt sayılarını bu varsayımla kontrol eder.
+package repotest
+
+import (
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// Repositories, sözleşme testlerinin üzerinde çalıştığı repository kümesidir.
+// Tüm repository'ler aynı veri deposunu paylaşmalıdır (ör. sayaç yan etkileri için).
+type Repositories struct {
+	Users                   domain.UserRepository
+	Notes                   domain.NoteRepository
+	Comments                domain.CommentRepository
+	PDFs                    domain.PDFRepository
+	PDFComments             domain.PDFCommentRepository
+	PDFAnnotations          domain.PDFAnnotationRepository
+	PDFPages                domain.PDFPageRepository
+	Likes                   domain.LikeRepository
+	Views                   domain.ViewRepository
+	Invites                 domain.InviteRepository
+	Tokens                  domain.TokenRepository
+	LoginAttempts           domain.LoginAttemptRepository
+	NoteRevisions           domain.NoteRevisionRepository
+	Notifications           domain.NotificationRepository
+	NotificationPreferences domain.NotificationPreferenceRepository
+	Jobs                    domain.JobRepository
+	Collections             domain.CollectionRepository
+	Catalog                 domain.CatalogRepository
+	Groups                  domain.GroupRepository
+	Shares                  domain.ShareRepository
+}
+
+// Factory, her alt test için boş bir veri deposu üzerinde yeni repository'ler üretir
+type Factory func(t *testing.T) *Repositories
+
+// Run, tüm repository sözleşme testlerini çalıştırır
+func Run(t *testing.T, newRepos Factory) {
+	t.Run("UserRepository", func(t *testing.T) { testUserRepository(t, newRepos(t)) })
+	t.Run("NoteRepository", func(t *testing.T) { testNoteRepository(t, newRepos(t)) })
+	t.Run("CommentRepository", func(t *testing.T) { testCommentRepository(t, newRepos(t)) })
+	t.Run("NoteRevisionRepository", func(t *testing.T) { testNoteRevisionRepository(t, newRepos(t)) })
+	t.Run("PDFRepository", func(t *testing.T) { testPDFRepository(t, newRepos(t)) })
+	t.Run("PDFContentHashBackfill", func(t *testing.T) { testPDFContentHashBackfill(t, newRepos(t)) })
+	t.Run("PDFCommentRepository", func(t *testing.T) { testPDFCommentRepository(t, newRepos(t)) })
+	t.Run("PDFAnnotationRepository", func(t *testing.T) { testPDFAnnotationRepository(t, newRepos(t)) })
+	t.Run("PDFAnnotationVisibility", func(t *testing.T) { testPDFAnnotationVisibility(t, newRepos(t)) })
+	t.Run("PDFPageRepository", func(t *testing.T) { testPDFPageRepository(t, newRepos(t)) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newRepos(t)) })
+	t.Run("LikeRepository", func(t *testing.T) { testLikeRepository(t, newRepos(t)) })
+	t.Run("ViewRepository", func(t *testing.T) { testViewRepository(t, newRepos(t)) })
+	t.Run("InviteRepository", func(t *testing.T) { testInviteRepository(t, newRepos(t)) })
+	t.Run("InviteLimits", func(t *testing.T) { testInviteLimits(t, newRepos(t)) })
+	t.Run("TokenRepository", func(t *testing.T) { testTokenRepository(t, newRepos(t)) })
+	t.Run("LoginAttemptRepository", func(t *testing.T) { testLoginAttemptRepository(t, newRepos(t)) })
+	t.Run("NotificationRepository", func(t *testing.T) { testNotificationRepository(t, newRepos(t)) })
+	t.Run("NotificationPreferenceRepository", func(t *testing.T) { testNotificationPreferenceRepository(t, newRepos(t)) })
+	t.Run("JobRepository", func(t *testing.T) { testJobRepository(t, newRepos(t)) })
+	t.Run("CollectionRepository", func(t *testing.T) { testCollectionRepository(t, newRepos(t)) })
+	t.Run("CatalogRepository", func(t *testing.T) { testCatalogRepository(t, newRepos(t)) })
+	t.Run("GroupRepository", func(t *testing.T) { testGroupRepository(t, newRepos(t)) })
+	t.Run("ShareRepository", func(t *testing.T) { testShareRepository(t, newRepos(t)) })
+}
+
+// must, beklenmeyen bir hata durumunda testi sonlandırır
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("beklenmeyen hata: %v", err)
+	}
+}
+
+// searchQuery, kullanıcı adına Türkçe arama yapan ve ilk 10 sonucu isteyen bir sorgu oluşturur
+func searchQuery(userID uint, terms ...domain.SearchTerm) *domain.SearchQuery {
+	return &domain.SearchQuery{Terms: terms, Language: domain.SearchLanguageTurkish, UserID: userID, Limit: 10}
+}
+
+// createNote, test için bir not oluşturur
+func createNote(t *testing.T, repos *Repositories, note *domain.Note) *domain.Note {
+	t.Helper()
+	must(t, repos.Notes.Create(note))
+	if note.ID == 0 {
+		t.Fatalf("Create sonrası not ID'si atanmadı")
+	}
+	if note.Version != 1 {
+		t.Fatalf("Create sonrası not sürümü 1 olmalıydı, %d oldu", note.Version)
+	}
+	return note
+}
+
+// createPDF, test için bir PDF oluşturur
+func createPDF(t *testing.T, repos *Repositories, pdf *domain.PDF) *domain.PDF {
+	t.Helper()
+	if pdf.FilePath == "" {
+		pdf.FilePath = "test.pdf"
+	}
+	must(t, repos.PDFs.Create(pdf))
+	if pdf.ID == 0 {
+		t.Fatalf("Create sonrası PDF ID'si atanmadı")
+	}
+	if pdf.Version != 1 {
+		t.Fatalf("Create sonrası PDF sürümü 1 olmalıydı, %d oldu", pdf.Version)
+	}
+	return pdf
+}
+
+// reloadNote, notu depodan yeniden okur
+func reloadNote(t *testing.T, repos *Repositories, id uint) *domain.Note {
+	t.Helper()
+	note, err := repos.Notes.FindByID(id)
+	must(t, err)
+	if note == nil {
+		t.Fatalf("not %d bulunamadı", id)
+	}
+	return note
+}
+
+// reloadPDF, PDF'i depodan yeniden okur
+func reloadPDF(t *testing.T, repos *Repositories, id uint) *domain.PDF {
+	t.Helper()
+	pdf, err := repos.PDFs.FindByID(id)
+	must(t, err)
+	if pdf == nil {
+		t.Fatalf("PDF %d bulunamadı", id)
+	}
+	return pdf
+}
+
+// sameTags, iki etiket listesinin sıradan bağımsız olarak eşit olup olmadığını kontrol eder
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int)
+	for _, tag := range a {
+		seen[tag]++
+	}
+	for _, tag := range b {
+		seen[tag]--
+		if seen[tag] < 0 {
+			return false
+		}
+	}
+	return true
+}
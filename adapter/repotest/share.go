@@ -0,0 +1,79 @@
+package repotest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+func testShareRepository(t *testing.T, repos *Repositories) {
+	note := createNote(t, repos, &domain.Note{Title: "Özel not", UserID: 1})
+	pdf := createPDF(t, repos, &domain.PDF{Title: "Özel PDF", UserID: 1})
+
+	viewer := &domain.ContentShare{ContentID: note.ID, ContentType: "note", OwnerID: 1, UserID: 2, Role: domain.ShareRoleViewer}
+	must(t, repos.Shares.Create(viewer))
+	if viewer.ID == 0 || viewer.CreatedAt.IsZero() {
+		t.Fatalf("Create ID ve zaman atamadı: %+v", viewer)
+	}
+	editor := &domain.ContentShare{ContentID: note.ID, ContentType: "note", OwnerID: 1, UserID: 3, Role: domain.ShareRoleEditor}
+	must(t, repos.Shares.Create(editor))
+	pdfShare := &domain.ContentShare{ContentID: pdf.ID, ContentType: "pdf", OwnerID: 1, UserID: 2, Role: domain.ShareRoleCommenter}
+	must(t, repos.Shares.Create(pdfShare))
+	err := repos.Shares.Create(&domain.ContentShare{ContentID: note.ID, ContentType: "note", OwnerID: 1, UserID: 2, Role: domain.ShareRoleEditor})
+	if !errors.Is(err, domain.ErrDuplicateEntry) {
+		t.Fatalf("aynı kullanıcıyla ikinci paylaşımda ErrDuplicateEntry beklenirken %v döndü", err)
+	}
+
+	found, err := repos.Shares.Find(note.ID, "note", 2)
+	must(t, err)
+	if found == nil || found.ID != viewer.ID || found.Role != domain.ShareRoleViewer || found.OwnerID != 1 {
+		t.Fatalf("Find paylaşımı bulmadı: %+v", found)
+	}
+	if found, _ := repos.Shares.Find(pdf.ID, "pdf", 3); found != nil {
+		t.Fatalf("paylaşılmamış içerik için nil beklenirdi: %+v", found)
+	}
+	if found, _ := repos.Shares.Find(note.ID, "pdf", 3); found != nil {
+		t.Fatalf("Find içerik türünü dikkate almalıydı: %+v", found)
+	}
+
+	viewer.Role = domain.ShareRoleCommenter
+	must(t, repos.Shares.Update(viewer))
+	found, err = repos.Shares.FindByID(viewer.ID)
+	must(t, err)
+	if found == nil || found.Role != domain.ShareRoleCommenter {
+		t.Fatalf("Update rolü güncellemedi: %+v", found)
+	}
+
+	shares, err := repos.Shares.FindByContent(note.ID, "note", 10, 0)
+	must(t, err)
+	if len(shares) != 2 || shares[0].UserID != 2 || shares[1].UserID != 3 {
+		t.Fatalf("FindByContent paylaşımları eskiden yeniye döndürmeliydi: %+v", shares)
+	}
+
+	shares, err = repos.Shares.FindByUserID(2, "", 10, 0)
+	must(t, err)
+	if len(shares) != 2 || shares[0].ContentType != "pdf" || shares[1].ContentType != "note" {
+		t.Fatalf("FindByUserID paylaşımları son paylaşılandan başlayarak döndürmeliydi: %+v", shares)
+	}
+	shares, err = repos.Shares.FindByUserID(2, "note", 10, 0)
+	must(t, err)
+	if len(shares) != 1 || shares[0].ContentID != note.ID {
+		t.Fatalf("FindByUserID türe göre filtrelemeliydi: %+v", shares)
+	}
+
+	must(t, repos.Shares.Delete(editor.ID))
+	if found, _ := repos.Shares.FindByID(editor.ID); found != nil {
+		t.Fatalf("Delete paylaşımı kaldırmadı")
+	}
+
+	// İçerik silinince paylaşımları da silinmeli
+	must(t, repos.Notes.Delete(note.ID))
+	if found, _ := repos.Shares.Find(note.ID, "note", 2); found != nil {
+		t.Fatalf("silinen notun paylaşımı kaldırılmadı")
+	}
+	must(t, repos.PDFs.Delete(pdf.ID))
+	if found, _ := repos.Shares.Find(pdf.ID, "pdf", 2); found != nil {
+		t.Fatalf("silinen PDF'in paylaşımı kaldırılmadı")
+	}
+}
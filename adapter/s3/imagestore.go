@@ -0,0 +1,87 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/minio/minio-go/v7"
+)
+
+// ImageStorage, domain.PDFImageStorage arayüzünün S3 uyumlu nesne deposu implementasyonu.
+// Görseller "<önek><anahtar>" nesnelerinde saklanır.
+type ImageStorage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewImageStorage, yeni bir ImageStorage örneği oluşturur. Kova yoksa oluşturulur.
+func NewImageStorage(config *Config) (*ImageStorage, error) {
+	client, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &ImageStorage{client: client, bucket: config.Bucket, prefix: normalizePrefix(config.Prefix)}, nil
+}
+
+// Save, görseli nesne deposuna yükler; yükleme tamamlanana kadar mevcut nesne değişmez
+func (s *ImageStorage) Save(key string, content []byte) error {
+	if _, err := s.client.PutObject(context.Background(), s.bucket, s.prefix+key, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{
+		ContentType: contentTypeOf(key),
+	}); err != nil {
+		return fmt.Errorf("görsel kaydedilemedi: %w", err)
+	}
+	return nil
+}
+
+// Open, görsel nesnesini okumak için açar
+func (s *ImageStorage) Open(key string) (io.ReadSeekCloser, error) {
+	object, err := s.client.GetObject(context.Background(), s.bucket, s.prefix+key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("görsel açılamadı: %w", err)
+	}
+
+	// GetObject isteği ilk okumaya kadar göndermez; nesne yoksa hatayı burada döndür
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, fmt.Errorf("%w: %s", domain.ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("görsel açılamadı: %w", err)
+	}
+	return object, nil
+}
+
+// DeleteAll, öneki "<prefix>/" olan tüm görsel nesnelerini siler
+func (s *ImageStorage) DeleteAll(prefix string) error {
+	ctx := context.Background()
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    s.prefix + strings.TrimSuffix(prefix, "/") + "/",
+		Recursive: true,
+	})
+	for result := range s.client.RemoveObjects(ctx, s.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if result.Err != nil {
+			return fmt.Errorf("görseller silinemedi: %w", result.Err)
+		}
+	}
+	return nil
+}
+
+// contentTypeOf, anahtarın uzantısına göre nesnenin içerik türünü döndürür
+func contentTypeOf(key string) string {
+	switch {
+	case strings.HasSuffix(key, ".jpg"), strings.HasSuffix(key, ".jpeg"):
+		return "image/jpeg"
+	case strings.HasSuffix(key, ".png"):
+		return "image/png"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Ensure ImageStorage implements domain.PDFImageStorage
+var _ domain.PDFImageStorage = (*ImageStorage)(nil)
@@ -0,0 +1,181 @@
+// Package s3, PDF dosyalarını ve PDF'lerden üretilen görselleri S3 uyumlu bir nesne deposunda
+// (AWS S3, MinIO vb.) saklayan domain.PDFStorage ve domain.PDFImageStorage implementasyonlarını içerir.
+package s3
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// uploadPartSize, boyutu bilinmeyen akışlar parça parça yüklenirken her parça için ayrılan tampon boyutu.
+// Kütüphanenin varsayılanı en büyük nesne boyutuna göre seçildiği için yükleme başına yüzlerce MB bellek ayırır.
+const uploadPartSize = 16 << 20
+
+// uploadPrefix, içerik özeti hesaplanana kadar yüklemelerin yazıldığı geçici anahtarların öneki
+const uploadPrefix = ".uploads/"
+
+// Config, S3 uyumlu depo bağlantı ayarlarını içerir
+type Config struct {
+	Endpoint  string // Sunucu adresi, şema olmadan (ör. "s3.amazonaws.com" veya "localhost:9000")
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	Prefix    string // Nesne anahtarlarının başına eklenen önek (ör. "pdfs/")
+}
+
+// PDFStorage, domain.PDFStorage arayüzünün S3 uyumlu nesne deposu implementasyonu.
+// Saklanan dosya yolu, kovadaki nesne anahtarıdır.
+type PDFStorage struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewPDFStorage, yeni bir PDFStorage örneği oluşturur. Kova yoksa oluşturulur.
+func NewPDFStorage(config *Config) (*PDFStorage, error) {
+	client, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &PDFStorage{client: client, bucket: config.Bucket, prefix: normalizePrefix(config.Prefix)}, nil
+}
+
+// newClient, yapılandırmadaki depoya bağlanan bir istemci oluşturur. Kova yoksa oluşturulur.
+func newClient(config *Config) (*minio.Client, error) {
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("S3 adresi ve kova adı gerekli")
+	}
+
+	client, err := minio.New(config.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.AccessKey, config.SecretKey, ""),
+		Secure: config.UseSSL,
+		Region: config.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("S3 istemcisi oluşturulamadı: %w", err)
+	}
+
+	ctx := context.Background()
+	exists, err := client.BucketExists(ctx, config.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("S3 kovası kontrol edilemedi: %w", err)
+	}
+	if !exists {
+		if err := client.MakeBucket(ctx, config.Bucket, minio.MakeBucketOptions{Region: config.Region}); err != nil {
+			return nil, fmt.Errorf("S3 kovası oluşturulamadı: %w", err)
+		}
+	}
+	return client, nil
+}
+
+// normalizePrefix, öneki boş değilse tek bir "/" ile biten biçime getirir
+func normalizePrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// Save, PDF içeriğini nesne deposuna akış halinde yükler ve SHA-256 özetini yüklerken hesaplar.
+// Özet yükleme bitmeden bilinmediğinden içerik önce geçici bir anahtara yüklenir, ardından depo
+// içinde "<önek><sha256>.pdf" anahtarına kopyalanır ve geçici nesne silinir. Aynı içerik zaten
+// saklanıyorsa kopyalama yapılmaz. Yarıda kalan yükleme mevcut nesneleri değiştirmez.
+func (s *PDFStorage) Save(content io.Reader) (*domain.StoredFile, error) {
+	ctx := context.Background()
+
+	var suffix [16]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+	tmpKey := s.prefix + uploadPrefix + hex.EncodeToString(suffix[:])
+
+	hash := sha256.New()
+	info, err := s.client.PutObject(ctx, s.bucket, tmpKey, io.TeeReader(content, hash), -1, minio.PutObjectOptions{
+		ContentType: "application/pdf",
+		PartSize:    uploadPartSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+	defer s.client.RemoveObject(ctx, s.bucket, tmpKey, minio.RemoveObjectOptions{})
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	stored := &domain.StoredFile{
+		Path:   s.prefix + sum + ".pdf",
+		Size:   info.Size,
+		SHA256: sum,
+	}
+
+	// Aynı içerik zaten saklanıyorsa yeniden kopyalama
+	if _, err := s.client.StatObject(ctx, s.bucket, stored.Path, minio.StatObjectOptions{}); err == nil {
+		return stored, nil
+	} else if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+
+	if _, err := s.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: s.bucket, Object: stored.Path},
+		minio.CopySrcOptions{Bucket: s.bucket, Object: tmpKey},
+	); err != nil {
+		return nil, fmt.Errorf("PDF dosyası kaydedilemedi: %w", err)
+	}
+	return stored, nil
+}
+
+// Open, PDF nesnesini okumak için açar. Dönen nesne konumlandırıldığında yalnızca istenen
+// aralık depodan indirilir.
+func (s *PDFStorage) Open(filePath string) (io.ReadSeekCloser, error) {
+	object, err := s.client.GetObject(context.Background(), s.bucket, filePath, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("PDF dosyası açılamadı: %w", err)
+	}
+
+	// GetObject isteği ilk okumaya kadar göndermez; nesne yoksa hatayı burada döndür
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, fmt.Errorf("PDF dosyası açılamadı: %w", err)
+	}
+	return object, nil
+}
+
+// Delete, PDF nesnesini depodan siler
+func (s *PDFStorage) Delete(filePath string) error {
+	if err := s.client.RemoveObject(context.Background(), s.bucket, filePath, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("PDF dosyası silinemedi: %w", err)
+	}
+	return nil
+}
+
+// PresignedURL, nesnenin kimlik doğrulaması gerektirmeden expiry süresince indirilebileceği bir bağlantı üretir.
+// İndirilen dosya tarayıcıda fileName adıyla gösterilir.
+func (s *PDFStorage) PresignedURL(filePath, fileName string, expiry time.Duration) (string, error) {
+	params := url.Values{}
+	params.Set("response-content-type", "application/pdf")
+	params.Set("response-content-disposition", fmt.Sprintf("inline; filename=%q", fileName))
+
+	u, err := s.client.PresignedGetObject(context.Background(), s.bucket, filePath, expiry, params)
+	if err != nil {
+		return "", fmt.Errorf("PDF indirme bağlantısı oluşturulamadı: %w", err)
+	}
+	return u.String(), nil
+}
+
+// Ensure PDFStorage implements domain.PDFStorage
+var _ domain.PDFStorage = (*PDFStorage)(nil)
+
+// Ensure PDFStorage implements domain.PDFURLSigner
+var _ domain.PDFURLSigner = (*PDFStorage)(nil)
@@ -0,0 +1,85 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	gosqlite "github.com/glebarez/go-sqlite"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// MemoryPath, bellek içi (geçici) SQLite veritabanı için kullanılan yoldur
+const MemoryPath = ":memory:"
+
+// Config, SQLite bağlantı ayarlarını içerir
+type Config struct {
+	// Path, veritabanı dosyasının yolu (MemoryPath ile bellek içi veritabanı açılır)
+	Path string
+}
+
+func init() {
+	// SQLite'ın yerleşik LOWER fonksiyonu yalnızca ASCII karakterleri dönüştürür.
+	// Türkçe karakterlerle büyük/küçük harf duyarsız aramanın PostgreSQL ILIKE ile
+	// aynı sonucu vermesi için Unicode destekli bir sürümle değiştirilir.
+	gosqlite.MustRegisterDeterministicScalarFunction("lower", 1, func(ctx *gosqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch v := args[0].(type) {
+		case string:
+			return strings.ToLower(v), nil
+		case []byte:
+			return strings.ToLower(string(v)), nil
+		default:
+			return v, nil
+		}
+	})
+}
+
+// IsMemory, yolun bellek içi bir veritabanını gösterip göstermediğini kontrol eder
+// (":memory:", "file::memory:" veya "mode=memory" parametresi içeren URI)
+func IsMemory(path string) bool {
+	return path == MemoryPath || strings.HasPrefix(path, "file::memory:") || strings.Contains(path, "mode=memory")
+}
+
+// NewConnection, SQLite veritabanına yeni bir bağlantı oluşturur
+func NewConnection(config *Config) (*gorm.DB, error) {
+	memory := IsMemory(config.Path)
+	if !memory {
+		// Veritabanı dizinini oluştur (yoksa)
+		if err := os.MkdirAll(filepath.Dir(config.Path), 0755); err != nil {
+			return nil, fmt.Errorf("veritabanı dizini oluşturulamadı: %w", err)
+		}
+	}
+
+	separator := "?"
+	if strings.Contains(config.Path, "?") {
+		separator = "&"
+	}
+	dsn := config.Path + separator + "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+	if !memory {
+		dsn += "&_pragma=journal_mode(WAL)"
+	}
+
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("veritabani bağlantisi kurulamadi: %w", err)
+	}
+
+	// SQLite aynı anda tek bir yazıcıya izin verir; tek bağlantı kilitlenme hatalarını
+	// önler ve bellek içi veritabanının tüm sorgular arasında paylaşılmasını sağlar
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("veritabani bağlantisi alinamadi: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	log.Printf("SQLite veritabanına başarıyla bağlanıldı: %s", config.Path)
+	return db, nil
+}
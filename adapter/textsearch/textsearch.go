@@ -0,0 +1,319 @@
+// Package textsearch, veritabanı kullanmayan bellek içi adaptörün uygulama içi eşleştirme, sıralama
+// ve vurgulama yardımcılarını içerir. SQLite'ın FTS5 araması da aynı alan ağırlıklarını kullanır.
+//
+// Kök bulma (stemming) yapılmaz; bunun yerine her kelime önek olarak eşleşir ("not" sorgusu
+// "notlar" ile eşleşir). Sıralama PostgreSQL'in ts_rank ağırlıklarını (A=1.0, B=0.4, C=0.2) izler.
+package textsearch
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// Alan ağırlıkları (PostgreSQL'in varsayılan A, B ve C ağırlıkları)
+const (
+	TitleWeight = 1.0
+	BodyWeight  = 0.4
+	PageWeight  = 0.2 // PDF sayfalarından çıkarılan metin
+)
+
+// Vurgulanacak bölümün başlangıç ve bitiş işaretleri. PostgreSQL ts_headline çıktısında da
+// bu işaretler kullanılır; metin HTML olarak kaçırıldıktan sonra <mark> etiketine çevrilir.
+const (
+	StartMarker = "\x02"
+	StopMarker  = "\x03"
+)
+
+// snippetWords, özet parçasında gösterilecek en fazla kelime sayısı
+const snippetWords = 30
+
+// Field, aranan bir metin alanı ve ağırlığıdır
+type Field struct {
+	Text   string
+	Weight float64
+}
+
+// token, metindeki bir kelimenin konumu ve normalleştirilmiş halidir
+type token struct {
+	start, end int
+	word       string
+}
+
+// Match, içeriğin sorgudaki tüm terimleri içerip içermediğini ve içeriyorsa sıralama puanını döndürür
+func Match(terms []domain.SearchTerm, fields ...Field) (float64, bool) {
+	rank := 0.0
+	for _, term := range terms {
+		occurrences := 0.0
+		for _, field := range fields {
+			count := len(termMatches(tokenize(field.Text), term))
+			occurrences += float64(count) * field.Weight
+		}
+
+		if term.Exclude {
+			if occurrences > 0 {
+				return 0, false
+			}
+			continue
+		}
+		if occurrences == 0 {
+			return 0, false
+		}
+		rank += occurrences
+	}
+	return rank, true
+}
+
+// NoteHit, not sorguya uyuyorsa başlığı ve içeriği vurgulanmış arama sonucunu döndürür
+func NoteHit(note *domain.Note, terms []domain.SearchTerm) (*domain.SearchHit, bool) {
+	rank, ok := Match(terms, Field{Text: note.Title, Weight: TitleWeight}, Field{Text: note.Content, Weight: BodyWeight})
+	if !ok {
+		return nil, false
+	}
+	return &domain.SearchHit{
+		ContentType: "note",
+		Rank:        rank,
+		Headline:    Highlight(note.Title, terms),
+		Snippet:     Snippet(note.Content, terms),
+		Note:        note,
+	}, true
+}
+
+// PDFHit, PDF'in başlığı ve açıklaması ya da sayfalarından biri sorguya uyuyorsa arama sonucunu döndürür.
+// Sayfa eşleşmesi varsa sonuç en yüksek puanlı sayfayı gösterir ve özet o sayfanın metninden oluşturulur.
+func PDFHit(pdf *domain.PDF, pages []*domain.PDFPage, terms []domain.SearchTerm) (*domain.SearchHit, bool) {
+	rank, ok := Match(terms, Field{Text: pdf.Title, Weight: TitleWeight}, Field{Text: pdf.Description, Weight: BodyWeight})
+
+	var best *domain.PDFPage
+	bestRank := 0.0
+	for _, page := range pages {
+		pageRank, pageOK := Match(terms, Field{Text: page.Text, Weight: PageWeight})
+		if pageOK && (best == nil || pageRank > bestRank || (pageRank == bestRank && page.PageNumber < best.PageNumber)) {
+			best, bestRank = page, pageRank
+		}
+	}
+	if !ok && best == nil {
+		return nil, false
+	}
+
+	hit := &domain.SearchHit{
+		ContentType: "pdf",
+		Rank:        rank + bestRank,
+		Headline:    Highlight(pdf.Title, terms),
+		Snippet:     Snippet(pdf.Description, terms),
+		PDF:         pdf,
+	}
+	if best != nil {
+		hit.PageNumber = best.PageNumber
+		hit.Snippet = Snippet(best.Text, terms)
+	}
+	return hit, true
+}
+
+// Highlight, metnin tamamını HTML olarak kaçırır ve eşleşen kelimeleri <mark> ile işaretler
+func Highlight(text string, terms []domain.SearchTerm) string {
+	tokens := tokenize(text)
+	return render(text, tokens, markedTokens(tokens, terms), 0, len(tokens))
+}
+
+// Snippet, metnin ilk eşleşme etrafındaki bölümünü vurgulanmış olarak döndürür.
+// Metinde eşleşme yoksa metnin başı döndürülür.
+func Snippet(text string, terms []domain.SearchTerm) string {
+	tokens := tokenize(text)
+	if len(tokens) == 0 {
+		return ""
+	}
+	marked := markedTokens(tokens, terms)
+
+	first := 0
+	for i := range tokens {
+		if marked[i] {
+			first = i
+			break
+		}
+	}
+
+	from := first - snippetWords/3
+	if from < 0 {
+		from = 0
+	}
+	to := from + snippetWords
+	if to > len(tokens) {
+		to = len(tokens)
+	}
+
+	snippet := render(text, tokens, marked, from, to)
+	if from > 0 {
+		snippet = "… " + snippet
+	}
+	if to < len(tokens) {
+		snippet += " …"
+	}
+	return snippet
+}
+
+// FromMarkers, StartMarker/StopMarker ile işaretlenmiş metni HTML olarak kaçırır ve işaretleri <mark> etiketine çevirir
+func FromMarkers(text string) string {
+	text = html.EscapeString(text)
+	text = strings.ReplaceAll(text, StartMarker, "<mark>")
+	return strings.ReplaceAll(text, StopMarker, "</mark>")
+}
+
+// SortHits, sonuçları puana göre azalan, eşit puanlarda en yeni içerik önce olacak şekilde sıralar
+func SortHits(hits []*domain.SearchHit) {
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hitID(hits[i]) > hitID(hits[j])
+	})
+}
+
+// hitID, sonucun içerik ID'sini döndürür
+func hitID(hit *domain.SearchHit) uint {
+	if hit.Note != nil {
+		return hit.Note.ID
+	}
+	if hit.PDF != nil {
+		return hit.PDF.ID
+	}
+	return 0
+}
+
+// Paginate, sıralanmış sonuçların istenen sayfasını döndürür
+func Paginate(hits []*domain.SearchHit, limit, offset int) []*domain.SearchHit {
+	if offset >= len(hits) {
+		return []*domain.SearchHit{}
+	}
+	hits = hits[offset:]
+	if limit > 0 && limit < len(hits) {
+		hits = hits[:limit]
+	}
+	return hits
+}
+
+// Normalize, kelimeyi karşılaştırma için küçük harfe çevirir. Türkçe büyük "İ" harfinin
+// küçültülmesiyle oluşan birleşik nokta işareti atılır ("MATRİS" ile "matris" eşleşir).
+func Normalize(word string) string {
+	return strings.ReplaceAll(strings.ToLower(word), "\u0307", "")
+}
+
+// NormalizeTerms, terimlerin kelimelerini harf ve rakam dışındaki karakterlerden bölerek normalleştirir.
+// Böylece sorgu dili operatörleri (& | ! : * ( ) ve tırnaklar) kelimelere karışmaz. Kelimesi kalmayan
+// terimler atılır; hariç tutulmayan terim kalmazsa sorgu hiçbir içerikle eşleşmeyeceğinden nil döner.
+func NormalizeTerms(terms []domain.SearchTerm) []domain.SearchTerm {
+	var normalized []domain.SearchTerm
+	positive := false
+	for _, term := range terms {
+		var words []string
+		for _, word := range term.Words {
+			words = append(words, strings.FieldsFunc(Normalize(word), func(r rune) bool {
+				return !isWordRune(r)
+			})...)
+		}
+		if len(words) == 0 {
+			continue
+		}
+		normalized = append(normalized, domain.SearchTerm{Words: words, Prefix: term.Prefix, Exclude: term.Exclude})
+		positive = positive || !term.Exclude
+	}
+	if !positive {
+		return nil
+	}
+	return normalized
+}
+
+// isWordRune, karakterin bir kelimenin parçası olup olmadığını kontrol eder
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// tokenize, metni harf ve rakamlardan oluşan kelimelere ayırır
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	for i, r := range text {
+		isWord := isWordRune(r)
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			tokens = append(tokens, token{start: start, end: i, word: Normalize(text[start:i])})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, token{start: start, end: len(text), word: Normalize(text[start:])})
+	}
+	return tokens
+}
+
+// termMatches, terimin metinde geçtiği konumların ilk kelime indekslerini döndürür
+func termMatches(tokens []token, term domain.SearchTerm) []int {
+	if len(term.Words) == 0 {
+		return nil
+	}
+
+	var matches []int
+	for i := 0; i+len(term.Words) <= len(tokens); i++ {
+		matched := true
+		for k, word := range term.Words {
+			if !strings.HasPrefix(tokens[i+k].word, Normalize(word)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// markedTokens, hariç tutulmayan terimlerle eşleşen kelimelerin indekslerini döndürür
+func markedTokens(tokens []token, terms []domain.SearchTerm) map[int]bool {
+	marked := make(map[int]bool)
+	for _, term := range terms {
+		if term.Exclude {
+			continue
+		}
+		for _, i := range termMatches(tokens, term) {
+			for k := range term.Words {
+				marked[i+k] = true
+			}
+		}
+	}
+	return marked
+}
+
+// render, [from, to) aralığındaki kelimeleri kapsayan metni HTML olarak kaçırarak ve işaretli kelimeleri vurgulayarak yazar
+func render(text string, tokens []token, marked map[int]bool, from, to int) string {
+	if from >= to {
+		return html.EscapeString(text)
+	}
+
+	var b strings.Builder
+	pos := tokens[from].start
+	if from == 0 {
+		pos = 0
+	}
+	end := tokens[to-1].end
+	if to == len(tokens) {
+		end = len(text)
+	}
+
+	for i := from; i < to; i++ {
+		b.WriteString(html.EscapeString(text[pos:tokens[i].start]))
+		word := html.EscapeString(text[tokens[i].start:tokens[i].end])
+		if marked[i] {
+			b.WriteString("<mark>" + word + "</mark>")
+		} else {
+			b.WriteString(word)
+		}
+		pos = tokens[i].end
+	}
+	b.WriteString(html.EscapeString(text[pos:end]))
+	return strings.TrimSpace(b.String())
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -9,8 +10,13 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/OmerFErdogan/uninote/adapter/localfs"
+	"github.com/OmerFErdogan/uninote/adapter/export"
+	"github.com/OmerFErdogan/uninote/adapter/markdown"
+	"github.com/OmerFErdogan/uninote/adapter/pdftext"
 	"github.com/OmerFErdogan/uninote/adapter/postgres"
+	"github.com/OmerFErdogan/uninote/adapter/qrcode"
+	"github.com/OmerFErdogan/uninote/adapter/sqlite"
+	"github.com/OmerFErdogan/uninote/domain"
 	"github.com/OmerFErdogan/uninote/infrastructure/env"
 	apphttp "github.com/OmerFErdogan/uninote/infrastructure/http"
 	"github.com/OmerFErdogan/uninote/infrastructure/http/handler"
@@ -18,6 +24,7 @@ import (
 	"github.com/OmerFErdogan/uninote/infrastructure/logger"
 	"github.com/OmerFErdogan/uninote/usecase"
 	"github.com/go-chi/chi/v5"
+	"gorm.io/gorm"
 )
 
 func main() {
@@ -33,42 +40,39 @@ func main() {
 	}
 
 	// Veritabanı bağlantısını oluştur
-	dbConfig := &postgres.Config{
-		Host:     config.DBHost,
-		Port:     config.DBPort,
-		User:     config.DBUser,
-		Password: config.DBPassword,
-		DBName:   config.DBName,
-		SSLMode:  config.DBSSLMode,
-	}
-
-	logger.Info("Veritabanına bağlanılıyor: %s:%s/%s", config.DBHost, config.DBPort, config.DBName)
-	db, err := postgres.NewConnection(dbConfig)
+	db, err := openDatabase(config)
 	if err != nil {
 		logger.Error("Veritabanı bağlantısı kurulamadı: %v", err)
 		log.Fatalf("Veritabanı bağlantısı kurulamadı: %v", err)
 	}
 
-	// Veritabanı modellerini migrate et
-	logger.Info("Veritabanı modelleri migrate ediliyor...")
-	err = postgres.Migrate(db,
-		&postgres.UserModel{},
-		&postgres.NoteModel{},
-		&postgres.CommentModel{},
-		&postgres.PDFModel{},
-		&postgres.PDFCommentModel{},
-		&postgres.PDFAnnotationModel{},
-		&postgres.ContentLikeModel{},
-		&postgres.InviteModel{},
-		&postgres.RevokedTokenModel{},
-		&postgres.LoginAttemptModel{},
-		&postgres.ViewModel{},
-	)
-	if err != nil {
-		logger.Error("Veritabanı migrasyonu başarısız: %v", err)
-		log.Fatalf("Veritabanı migrasyonu başarısız: %v", err)
+	// "migrate" alt komutu: şema migrasyonlarını çalıştır ve çık
+	migrator := postgres.NewMigrator(db)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(migrator, os.Args[2:]); err != nil {
+			logger.Error("Migrasyon komutu başarısız: %v", err)
+			log.Fatalf("Migrasyon komutu başarısız: %v", err)
+		}
+		return
+	}
+
+	// Bellek içi SQLite veritabanı yalnızca bu süreçte yaşadığından ayrı bir "migrate up" komutuyla
+	// hazırlanamaz; migrasyonlar sunucu açılırken uygulanır
+	if config.DBDriver == "sqlite" && sqlite.IsMemory(config.DBPath) {
+		logger.Info("Bellek içi SQLite veritabanına migrasyonlar uygulanıyor...")
+		if err := migrator.Up(); err != nil {
+			logger.Error("Migrasyonlar uygulanamadı: %v", err)
+			log.Fatalf("Migrasyonlar uygulanamadı: %v", err)
+		}
+	}
+
+	// Şema güncel değilse sunucuyu başlatma
+	logger.Info("Veritabanı şema sürümü kontrol ediliyor...")
+	if err := migrator.CheckSchema(); err != nil {
+		logger.Error("Veritabanı şeması kontrolü başarısız: %v", err)
+		log.Fatalf("Veritabanı şeması kontrolü başarısız: %v (önce \"migrate up\" komutunu çalıştırın)", err)
 	}
-	logger.Info("Veritabanı migrasyonu başarılı")
+	logger.Info("Veritabanı şeması güncel")
 
 	// Repository'leri oluştur
 	userRepo := postgres.NewUserRepository(db)
@@ -77,17 +81,38 @@ func main() {
 	pdfRepo := postgres.NewPDFRepository(db)
 	pdfCommentRepo := postgres.NewPDFCommentRepository(db)
 	pdfAnnotationRepo := postgres.NewPDFAnnotationRepository(db)
+	pdfPageRepo := postgres.NewPDFPageRepository(db)
 	likeRepo := postgres.NewLikeRepository(db)
 	inviteRepo := postgres.NewInviteRepository(db)
 	tokenRepo := postgres.NewTokenRepository(db)
 	loginAttemptRepo := postgres.NewLoginAttemptRepository(db)
 	viewRepo := postgres.NewViewRepository(db)
+	revisionRepo := postgres.NewNoteRevisionRepository(db)
+	notificationRepo := postgres.NewNotificationRepository(db)
+	notificationPreferenceRepo := postgres.NewNotificationPreferenceRepository(db)
+	jobRepo := postgres.NewJobRepository(db)
+	collectionRepo := postgres.NewCollectionRepository(db)
+	catalogRepo := postgres.NewCatalogRepository(db)
+	groupRepo := postgres.NewGroupRepository(db)
+	shareRepo := postgres.NewShareRepository(db)
 
 	// PDF depolama servisini oluştur
-	pdfStorage, err := localfs.NewPDFStorage(config.PDFStoragePath)
+	pdfStorage, err := openPDFStorage(config)
 	if err != nil {
 		log.Fatalf("PDF depolama servisi oluşturulamadı: %v", err)
 	}
+	contentURLTTL, err := pdfContentURLTTL(config, pdfStorage)
+	if err != nil {
+		log.Fatalf("PDF içerik sunma yöntemi geçersiz: %v", err)
+	}
+	rejectActive, err := rejectActiveContent(config)
+	if err != nil {
+		log.Fatalf("PDF etkin içerik politikası geçersiz: %v", err)
+	}
+	previewOptions, err := openPreviewOptions(config)
+	if err != nil {
+		log.Fatalf("PDF görsel ayarları geçersiz: %v", err)
+	}
 
 	// Servisleri oluştur
 	authService := usecase.NewAuthService(
@@ -99,23 +124,67 @@ func main() {
 		config.MaxLoginAttempts,
 		config.LoginWindowMins,
 	)
-	noteService := usecase.NewNoteService(noteRepo, commentRepo)
-	pdfService := usecase.NewPDFService(pdfRepo, pdfCommentRepo, pdfAnnotationRepo, pdfStorage)
-	likeService := usecase.NewLikeService(likeRepo, noteRepo, pdfRepo)
-	commentService := usecase.NewCommentService(noteRepo, commentRepo, pdfRepo, pdfCommentRepo, userRepo)
-	inviteService := usecase.NewInviteService(inviteRepo, noteRepo, pdfRepo)
+	contentAccess := usecase.NewContentAccess(groupRepo, shareRepo)
+	eventHub := usecase.NewEventHub(noteRepo, pdfRepo, contentAccess)
+	notificationService := usecase.NewNotificationService(notificationRepo, notificationPreferenceRepo, userRepo, noteRepo, pdfRepo, collectionRepo, eventHub)
+	noteService := usecase.NewNoteService(noteRepo, commentRepo, revisionRepo, markdown.NewHTMLRenderer(), config.NoteRenderCacheSize, contentAccess, notificationService, eventHub)
+	jobService := usecase.NewJobService(jobRepo, config.JobWorkers, config.JobMaxAttempts)
+	pdfService := usecase.NewPDFService(pdfRepo, pdfCommentRepo, pdfAnnotationRepo, pdfPageRepo, userRepo, pdfStorage, int64(config.PDFMaxUploadMB)<<20, contentURLTTL, pdftext.NewExtractor(), pdftext.NewInspector(), rejectActive, previewOptions, contentAccess, jobService, notificationService, eventHub)
+	likeService := usecase.NewLikeService(likeRepo, noteRepo, pdfRepo, notificationService, eventHub)
+	searchService := usecase.NewSearchService(noteRepo, pdfRepo, config.SearchLanguage)
+	commentService := usecase.NewCommentService(noteRepo, commentRepo, pdfRepo, pdfCommentRepo, userRepo, contentAccess)
 	viewService := usecase.NewViewService(viewRepo, userRepo, noteRepo, pdfRepo, logger.NewLogger())
+	inviteService := usecase.NewInviteService(inviteRepo, noteRepo, pdfRepo, collectionRepo, groupRepo, userRepo, qrcode.NewEncoder(), config.InviteBaseURL, viewService, notificationService)
+	collectionService := usecase.NewCollectionService(collectionRepo, noteRepo, pdfRepo)
+	catalogService := usecase.NewCatalogService(catalogRepo, noteRepo, pdfRepo)
+	groupService := usecase.NewGroupService(groupRepo, noteRepo, pdfRepo, userRepo, inviteService)
+	shareService := usecase.NewShareService(shareRepo, noteRepo, pdfRepo, userRepo)
+	exportService := usecase.NewExportService(noteRepo, userRepo, contentAccess, export.Exporters()...)
+	liveService := usecase.NewLiveService(noteService, userRepo, time.Duration(config.LiveSaveIntervalSecs)*time.Second)
+
+	// "migrate-storage" alt komutu: yerel PDF dosyalarını S3'e taşı ve çık
+	if len(os.Args) > 1 && os.Args[1] == "migrate-storage" {
+		if err := runMigrateStorage(config, pdfService, os.Args[2:]); err != nil {
+			logger.Error("Depo taşıma komutu başarısız: %v", err)
+			log.Fatalf("Depo taşıma komutu başarısız: %v", err)
+		}
+		return
+	}
+
+	// Arka plan işlerini kaydet ve worker havuzunu başlat
+	jobService.Register(domain.JobExtractPDFText, pdfService.RunExtractTextJob)
+	jobService.Register(domain.JobCountPDFPages, pdfService.RunCountPagesJob)
+	jobService.Register(domain.JobRenderPDFPages, pdfService.RunRenderPagesJob)
+	jobService.Register(domain.JobHashPDFContent, pdfService.RunHashContentJob)
+	jobService.Start()
+
+	// İçerik özeti olmayan eski PDF'lerin özetleri arka planda hesaplanır
+	if count, err := pdfService.EnqueueContentHashBackfill(); err != nil {
+		logger.Error("Eski PDF'ler için içerik özeti işleri kuyruğa alınamadı: %v", err)
+	} else if count > 0 {
+		logger.Info("%d eski PDF için içerik özeti işi kuyruğa alındı", count)
+	}
 
 	// Middleware'leri oluştur
 	authMiddleware := middleware.NewAuthMiddleware(authService)
 
 	// Handler'ları oluştur
 	authHandler := handler.NewAuthHandler(authService)
-	noteHandler := handler.NewNoteHandler(noteService, likeService, commentService)
-	pdfHandler := handler.NewPDFHandler(pdfService, likeService, commentService)
+	noteHandler := handler.NewNoteHandler(noteService, likeService, commentService, searchService, inviteService)
+	pdfHandler := handler.NewPDFHandler(pdfService, likeService, commentService, searchService, inviteService)
 	likeHandler := handler.NewLikeHandler(likeService)
-	inviteHandler := handler.NewInviteHandler(inviteService, noteService, pdfService)
+	inviteHandler := handler.NewInviteHandler(inviteService, noteService, pdfService, collectionService, groupService)
 	viewHandler := handler.NewViewHandler(viewService, noteService, pdfService, logger.NewLogger())
+	liveHandler := handler.NewLiveHandler(liveService, authService, inviteService)
+	exportHandler := handler.NewExportHandler(exportService, inviteService)
+	notificationHandler := handler.NewNotificationHandler(notificationService)
+	eventHandler := handler.NewEventHandler(eventHub, authService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	jobHandler := handler.NewJobHandler(jobService)
+	collectionHandler := handler.NewCollectionHandler(collectionService, inviteService)
+	catalogHandler := handler.NewCatalogHandler(catalogService)
+	groupHandler := handler.NewGroupHandler(groupService)
+	shareHandler := handler.NewShareHandler(shareService)
 
 	// Router'ı oluştur
 	router := apphttp.NewRouter()
@@ -168,6 +237,36 @@ func main() {
 
 		// Görüntüleme takip endpoint'leri
 		viewHandler.RegisterRoutes(r, authMiddleware)
+
+		// Canlı düzenleme endpoint'leri
+		liveHandler.RegisterRoutes(r, authMiddleware)
+
+		// Not dışa aktarma endpoint'i
+		exportHandler.RegisterRoutes(r, authMiddleware)
+
+		// Bildirim endpoint'leri
+		notificationHandler.RegisterRoutes(r, authMiddleware)
+
+		// Anlık olay akışı (SSE) endpoint'i
+		eventHandler.RegisterRoutes(r, authMiddleware)
+
+		// Birleşik arama endpoint'i
+		searchHandler.RegisterRoutes(r, authMiddleware)
+
+		// Koleksiyon endpoint'leri
+		collectionHandler.RegisterRoutes(r, authMiddleware)
+
+		// Ders kataloğu endpoint'leri
+		catalogHandler.RegisterRoutes(r, authMiddleware, middleware.RequireAdmin(config.AdminUserIDs))
+
+		// Çalışma grubu endpoint'leri
+		groupHandler.RegisterRoutes(r, authMiddleware)
+
+		// Kullanıcı paylaşımı endpoint'leri
+		shareHandler.RegisterRoutes(r, authMiddleware)
+
+		// Yönetici iş kuyruğu endpoint'leri
+		jobHandler.RegisterRoutes(r, authMiddleware, middleware.RequireAdmin(config.AdminUserIDs))
 	})
 
 	// Statik dosyaları web klasöründen sun (isteğe bağlı)
@@ -218,6 +317,12 @@ func main() {
 	logger.Info("Sunucu kapatılıyor...")
 	log.Println("Sunucu kapatılıyor...")
 
+	// Canlı düzenleme oturumlarını kaydet ve WebSocket bağlantılarını kapat
+	liveService.Close()
+
+	// Açık olay akışı bağlantılarını sonlandır
+	eventHub.Close()
+
 	// Graceful shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -227,6 +332,9 @@ func main() {
 		log.Fatalf("Sunucu kapatma hatası: %v", err)
 	}
 
+	// Çalışmakta olan arka plan işlerinin bitmesini bekle
+	jobService.Close()
+
 	// Veritabanı bağlantısını kapat
 	sqlDB, err := db.DB()
 	if err != nil {
@@ -241,3 +349,24 @@ func main() {
 	logger.Info("Sunucu başarıyla kapatıldı")
 	log.Println("Sunucu başarıyla kapatıldı")
 }
+
+// openDatabase, yapılandırmadaki DB_DRIVER değerine göre veritabanı bağlantısını açar
+func openDatabase(config *env.Config) (*gorm.DB, error) {
+	switch config.DBDriver {
+	case "postgres":
+		logger.Info("Veritabanına bağlanılıyor: %s:%s/%s", config.DBHost, config.DBPort, config.DBName)
+		return postgres.NewConnection(&postgres.Config{
+			Host:     config.DBHost,
+			Port:     config.DBPort,
+			User:     config.DBUser,
+			Password: config.DBPassword,
+			DBName:   config.DBName,
+			SSLMode:  config.DBSSLMode,
+		})
+	case "sqlite":
+		logger.Info("SQLite veritabanı açılıyor: %s", config.DBPath)
+		return sqlite.NewConnection(&sqlite.Config{Path: config.DBPath})
+	default:
+		return nil, fmt.Errorf("desteklenmeyen veritabanı sürücüsü: %s", config.DBDriver)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/adapter/postgres"
+)
+
+const migrateUsage = `kullanım: server migrate <komut>
+
+komutlar:
+  up            bekleyen tüm migrasyonları uygular
+  down          son uygulanan migrasyonu geri alır
+  status        migrasyonların durumunu listeler
+  to <sürüm>    şemayı verilen sürüme getirir (gerekirse geri alır)`
+
+// runMigrate, "migrate" alt komutunu çalıştırır
+func runMigrate(migrator *postgres.Migrator, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("migrasyon komutu belirtilmedi\n%s", migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	case "down":
+		if err := migrator.Down(); err != nil {
+			return err
+		}
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("hedef sürüm belirtilmedi\n%s", migrateUsage)
+		}
+		version, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return fmt.Errorf("geçersiz sürüm: %s", args[1])
+		}
+		if err := migrator.To(uint(version)); err != nil {
+			return err
+		}
+	case "status":
+		return printMigrationStatus(migrator)
+	default:
+		return fmt.Errorf("bilinmeyen migrasyon komutu: %s\n%s", args[0], migrateUsage)
+	}
+
+	current, err := migrator.CurrentVersion()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Veritabanı şema sürümü: %d (en son: %d)\n", current, migrator.LatestVersion())
+	return nil
+}
+
+// printMigrationStatus, tüm migrasyonların uygulanma durumunu yazdırır
+func printMigrationStatus(migrator *postgres.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	for _, status := range statuses {
+		state := "bekliyor"
+		if status.Applied {
+			state = "uygulandı " + status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("%4d  %-30s %s\n", status.Version, status.Name, state)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/adapter/localfs"
+	"github.com/OmerFErdogan/uninote/adapter/pdfrender"
+	"github.com/OmerFErdogan/uninote/adapter/s3"
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/env"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+	"github.com/OmerFErdogan/uninote/usecase"
+)
+
+const migrateStorageUsage = `kullanım: server migrate-storage [-delete-local]
+
+Yerel depodaki (PDF_STORAGE_PATH) PDF dosyalarını yapılandırılmış S3 kovasına kopyalar ve
+veritabanındaki dosya yollarını günceller. PDF_STORAGE_DRIVER=s3 olmalıdır. Yerelde dosyası
+bulunmayan PDF'ler atlandığı için komut yarıda kalırsa yeniden çalıştırılabilir.
+
+seçenekler:
+  -delete-local   kopyalanan dosyaları yerel depodan siler`
+
+// openPDFStorage, yapılandırmadaki PDF_STORAGE_DRIVER değerine göre PDF deposunu oluşturur
+func openPDFStorage(config *env.Config) (domain.PDFStorage, error) {
+	switch config.PDFStorageDriver {
+	case "local":
+		logger.Info("PDF'ler yerel dizinde saklanıyor: %s", config.PDFStoragePath)
+		return localfs.NewPDFStorage(config.PDFStoragePath)
+	case "s3":
+		logger.Info("PDF'ler S3 kovasında saklanıyor: %s/%s", config.S3Endpoint, config.S3Bucket)
+		return s3.NewPDFStorage(s3Config(config, config.S3Prefix))
+	default:
+		return nil, fmt.Errorf("desteklenmeyen PDF depolama sürücüsü: %s", config.PDFStorageDriver)
+	}
+}
+
+// s3Config, yapılandırmadaki S3 bağlantı ayarlarını verilen nesne anahtarı önekiyle döndürür
+func s3Config(config *env.Config, prefix string) *s3.Config {
+	return &s3.Config{
+		Endpoint:  config.S3Endpoint,
+		Region:    config.S3Region,
+		Bucket:    config.S3Bucket,
+		AccessKey: config.S3AccessKey,
+		SecretKey: config.S3SecretKey,
+		UseSSL:    config.S3UseSSL,
+		Prefix:    prefix,
+	}
+}
+
+// openPreviewOptions, PDF görsellerinin deposunu PDF_STORAGE_DRIVER değerine göre oluşturur ve
+// PDF_RENDER_COMMAND tanımlıysa görselleştiriciyi hazırlar. Komut bulunamazsa sunucu görsel
+// üretmeden çalışmaya devam eder; önceden üretilmiş görseller sunulmaya devam eder.
+func openPreviewOptions(config *env.Config) (usecase.PDFPreviewOptions, error) {
+	options := usecase.PDFPreviewOptions{
+		CoverWidth:   config.PDFThumbnailWidth,
+		PreviewWidth: config.PDFPreviewWidth,
+		PreviewPages: config.PDFPreviewPages,
+	}
+	if options.CoverWidth <= 0 || options.PreviewWidth <= 0 || options.PreviewPages < 0 {
+		return options, fmt.Errorf("geçersiz görsel boyutu veya önizleme sayfa sayısı")
+	}
+
+	var err error
+	switch config.PDFStorageDriver {
+	case "local":
+		options.Images, err = localfs.NewImageStorage(config.PDFImageStoragePath)
+	case "s3":
+		options.Images, err = s3.NewImageStorage(s3Config(config, config.S3ImagePrefix))
+	default:
+		err = fmt.Errorf("desteklenmeyen PDF depolama sürücüsü: %s", config.PDFStorageDriver)
+	}
+	if err != nil {
+		return options, err
+	}
+
+	if config.PDFRenderCommand == "none" {
+		logger.Info("PDF görsel üretimi kapalı")
+		return options, nil
+	}
+	renderer, err := pdfrender.NewRenderer(config.PDFRenderCommand, time.Duration(config.PDFRenderTimeoutSecs)*time.Second)
+	if err != nil {
+		logger.Error("PDF görsel üretimi devre dışı: %v", err)
+		return options, nil
+	}
+	options.Renderer = renderer
+	return options, nil
+}
+
+// pdfContentURLTTL, PDF_CONTENT_DELIVERY değerine göre içerik bağlantılarının geçerlilik süresini döndürür.
+// 0, içeriğin sunucu üzerinden akış halinde sunulacağı anlamına gelir.
+func pdfContentURLTTL(config *env.Config, storage domain.PDFStorage) (time.Duration, error) {
+	switch config.PDFContentDelivery {
+	case "stream":
+		return 0, nil
+	case "presigned":
+		if _, ok := storage.(domain.PDFURLSigner); !ok {
+			return 0, fmt.Errorf("%s deposu süreli indirme bağlantısı desteklemiyor", config.PDFStorageDriver)
+		}
+		if config.PDFPresignExpiryMins <= 0 {
+			return 0, fmt.Errorf("geçersiz bağlantı süresi: %d dakika", config.PDFPresignExpiryMins)
+		}
+		return time.Duration(config.PDFPresignExpiryMins) * time.Minute, nil
+	default:
+		return 0, fmt.Errorf("desteklenmeyen PDF içerik sunma yöntemi: %s", config.PDFContentDelivery)
+	}
+}
+
+// rejectActiveContent, PDF_ACTIVE_CONTENT değerine göre etkin içerik bulunan yüklemelerin reddedilip
+// reddedilmeyeceğini döndürür
+func rejectActiveContent(config *env.Config) (bool, error) {
+	switch config.PDFActiveContent {
+	case "flag":
+		return false, nil
+	case "reject":
+		return true, nil
+	default:
+		return false, fmt.Errorf("desteklenmeyen PDF etkin içerik politikası: %s", config.PDFActiveContent)
+	}
+}
+
+// runMigrateStorage, "migrate-storage" alt komutunu çalıştırır
+func runMigrateStorage(config *env.Config, pdfService *usecase.PDFService, args []string) error {
+	flags := flag.NewFlagSet("migrate-storage", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	deleteLocal := flags.Bool("delete-local", false, "")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%v\n%s", err, migrateStorageUsage)
+	}
+
+	if config.PDFStorageDriver != "s3" {
+		return fmt.Errorf("hedef depo S3 olmalı (PDF_STORAGE_DRIVER=s3)\n%s", migrateStorageUsage)
+	}
+
+	source, err := localfs.NewPDFStorage(config.PDFStoragePath)
+	if err != nil {
+		return fmt.Errorf("yerel depo açılamadı: %w", err)
+	}
+
+	result, err := pdfService.MigrateStorage(source, *deleteLocal)
+	if result != nil {
+		fmt.Printf("Kopyalanan: %d, atlanan: %d, başarısız: %d\n", result.Copied, result.Skipped, result.Failed)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d PDF taşınamadı; ayrıntılar için günlüklere bakın", result.Failed)
+	}
+	return nil
+}
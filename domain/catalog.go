@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Dönem türleri
+const (
+	TermSeasonFall   = "fall"
+	TermSeasonSpring = "spring"
+	TermSeasonSummer = "summer"
+)
+
+// IsTermSeason, verilen değerin geçerli bir dönem türü olup olmadığını kontrol eder
+func IsTermSeason(season string) bool {
+	return season == TermSeasonFall || season == TermSeasonSpring || season == TermSeasonSummer
+}
+
+// catalogFold, Türkçe ve diğer aksanlı harfleri karşılaştırma için sadeleştirir
+var catalogFold = strings.NewReplacer(
+	"ı", "i", "ğ", "g", "ü", "u", "ş", "s", "ö", "o", "ç", "c",
+	"â", "a", "î", "i", "û", "u", "é", "e", "è", "e", "ä", "a",
+)
+
+// CatalogKey, üniversite, bölüm ve ders adlarından karşılaştırma anahtarı üretir. Harfler Türkçe
+// kurallarıyla küçültülür, aksanlar kaldırılır ve harf ile rakam dışındaki karakterler atılır;
+// böylece "İTÜ", "itü" ve "I.T.U." aynı anahtara, "BLG 101E" ile "blg101e" de aynı anahtara dönüşür.
+func CatalogKey(name string) string {
+	lower := strings.ToLowerSpecial(unicode.TurkishCase, name)
+	// Büyük I Türkçe kurallarıyla ı olur; İngilizce adlar ("Istanbul") için ikisi de i'ye katlanır
+	lower = catalogFold.Replace(lower)
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, lower)
+}
+
+// University, bir üniversiteyi temsil eder. Üniversite; adı, kısa adı ve diğer adlarından
+// (ör. İngilizce adı) herhangi biriyle bulunabilir.
+type University struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	ShortName string    `json:"shortName"`
+	Aliases   []string  `json:"aliases"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Names, üniversitenin arama yapılabilen tüm adlarını döndürür
+func (u *University) Names() []string {
+	names := []string{u.Name}
+	if u.ShortName != "" {
+		names = append(names, u.ShortName)
+	}
+	return append(names, u.Aliases...)
+}
+
+// Department, bir üniversitedeki bölümü temsil eder
+type Department struct {
+	ID           uint      `json:"id"`
+	UniversityID uint      `json:"universityId"`
+	Name         string    `json:"name"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Course, bir bölümdeki dersi temsil eder. Ders kodu bölüm içinde benzersizdir.
+type Course struct {
+	ID           uint      `json:"id"`
+	DepartmentID uint      `json:"departmentId"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// Term, bir akademik dönemi temsil eder. Year, akademik yılın başladığı yıldır
+// (2024-2025 Güz dönemi için 2024).
+type Term struct {
+	ID        uint      `json:"id"`
+	Year      int       `json:"year"`
+	Season    string    `json:"season"` // "fall", "spring" veya "summer"
+	Name      string    `json:"name"`   // ör. "2024-2025 Güz"
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CourseContent, bir not veya PDF'in bağlı olduğu dersi, dönemi, haftayı ve konuyu tutar.
+// Bir içerik en fazla bir derse bağlanabilir.
+type CourseContent struct {
+	ID          uint      `json:"id"`
+	CourseID    uint      `json:"courseId"`
+	TermID      *uint     `json:"termId"`
+	ContentID   uint      `json:"contentId"`
+	ContentType string    `json:"contentType"` // "note" veya "pdf"
+	Week        int       `json:"week"`        // 0 ise hafta belirtilmemiştir
+	Topic       string    `json:"topic"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// CourseContentFilter, bir derse bağlı içerikleri listelerken kullanılan filtredir
+type CourseContentFilter struct {
+	CourseID uint
+	TermID   uint // 0 değilse yalnızca bu döneme bağlı içerikler
+	Week     int  // 0 değilse yalnızca bu haftaya bağlı içerikler
+	ViewerID uint // Herkese açık içeriklerin yanında bu kullanıcının özel içerikleri de döner
+	Limit    int
+	Offset   int
+}
+
+// CourseDetail, dersi bağlı olduğu bölüm ve üniversiteyle birlikte temsil eder
+type CourseDetail struct {
+	Course     *Course     `json:"course"`
+	Department *Department `json:"department"`
+	University *University `json:"university"`
+}
+
+// CourseContentDetail, bir içeriğin ders bağlantısını ders, bölüm, üniversite ve dönem bilgileriyle temsil eder
+type CourseContentDetail struct {
+	*CourseContent
+	Course     *Course     `json:"course"`
+	Department *Department `json:"department"`
+	University *University `json:"university"`
+	Term       *Term       `json:"term,omitempty"`
+}
+
+// CatalogRepository, üniversite, bölüm, ders ve dönem kataloğunun ve içeriklerin ders
+// bağlantılarının saklanması ve alınması için bir arayüz tanımlar
+type CatalogRepository interface {
+	FindUniversityByID(id uint) (*University, error)
+	// FindUniversityByName, adı, kısa adı veya diğer adlarından birinin CatalogKey'i verilen adınkiyle
+	// eşleşen üniversiteyi döndürür
+	FindUniversityByName(name string) (*University, error)
+	// FindUniversities, adlarından birinin anahtarı query'nin anahtarını içeren üniversiteleri ada
+	// göre sıralı döndürür; query boşsa tüm üniversiteler döner
+	FindUniversities(query string, limit, offset int) ([]*University, error)
+	// CreateUniversity ve UpdateUniversity, adlardan biri başka bir üniversiteye aitse ErrDuplicateEntry döndürür
+	CreateUniversity(university *University) error
+	UpdateUniversity(university *University) error
+
+	FindDepartmentByID(id uint) (*Department, error)
+	FindDepartmentByName(universityID uint, name string) (*Department, error)
+	FindDepartments(universityID uint, limit, offset int) ([]*Department, error)
+	// CreateDepartment, üniversitede aynı anahtarlı bir bölüm varsa ErrDuplicateEntry döndürür
+	CreateDepartment(department *Department) error
+
+	FindCourseByID(id uint) (*Course, error)
+	FindCourseByCode(departmentID uint, code string) (*Course, error)
+	FindCourses(departmentID uint, limit, offset int) ([]*Course, error)
+	// CreateCourse, bölümde aynı kodlu bir ders varsa ErrDuplicateEntry döndürür
+	CreateCourse(course *Course) error
+
+	FindTermByID(id uint) (*Term, error)
+	FindTerm(year int, season string) (*Term, error)
+	// FindTerms, dönemleri en yeniden başlayarak döndürür
+	FindTerms(limit, offset int) ([]*Term, error)
+	// CreateTerm, aynı yıl ve türde bir dönem varsa ErrDuplicateEntry döndürür
+	CreateTerm(term *Term) error
+
+	FindCourseContent(contentID uint, contentType string) (*CourseContent, error)
+	// SaveCourseContent, içeriğin ders bağlantısını oluşturur veya mevcut bağlantıyı değiştirir
+	SaveCourseContent(content *CourseContent) error
+	DeleteCourseContent(contentID uint, contentType string) error
+	// FindCourseNotes ve FindCoursePDFs, derse bağlı ve görüntüleyenin erişebildiği içerikleri haftaya
+	// göre sıralı döndürür
+	FindCourseNotes(filter *CourseContentFilter) ([]*Note, error)
+	FindCoursePDFs(filter *CourseContentFilter) ([]*PDF, error)
+}
+
+// CatalogService, ders kataloğu ve içeriklerin ders bağlantılarıyla ilgili iş mantığı için bir arayüz tanımlar
+type CatalogService interface {
+	CreateUniversity(university *University) error
+	UpdateUniversity(university *University) error
+	AddUniversityAlias(id uint, alias string) (*University, error)
+	GetUniversity(id uint) (*University, error)
+	ResolveUniversity(name string) (*University, error)
+	SearchUniversities(query string, limit, offset int) ([]*University, error)
+	CreateDepartment(department *Department) (bool, error)
+	GetDepartment(id uint) (*Department, error)
+	GetDepartments(universityID uint, limit, offset int) ([]*Department, error)
+	CreateCourse(course *Course) (bool, error)
+	GetCourse(id uint) (*CourseDetail, error)
+	GetCourses(departmentID uint, limit, offset int) ([]*Course, error)
+	CreateTerm(term *Term) (bool, error)
+	GetTerms(limit, offset int) ([]*Term, error)
+	AttachContent(content *CourseContent, userID uint) (*CourseContentDetail, error)
+	DetachContent(contentID uint, contentType string, userID uint) error
+	GetContentCourse(contentID uint, contentType string, userID uint) (*CourseContentDetail, error)
+	GetCourseNotes(filter *CourseContentFilter) ([]*Note, error)
+	GetCoursePDFs(filter *CourseContentFilter) ([]*PDF, error)
+}
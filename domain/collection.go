@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"time"
+)
+
+// Collection, kullanıcının notlarını ve PDF'lerini klasörler halinde düzenlediği kütüphane koleksiyonudur
+type Collection struct {
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"userId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	IsPublic    bool      `json:"isPublic"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// CollectionFolder, koleksiyon içindeki bir klasördür. ParentID nil ise klasör koleksiyonun kökündedir.
+type CollectionFolder struct {
+	ID           uint      `json:"id"`
+	CollectionID uint      `json:"collectionId"`
+	ParentID     *uint     `json:"parentId"`
+	Name         string    `json:"name"`
+	Position     int       `json:"position"` // Aynı üst klasördeki klasörler arasındaki sıra (0'dan başlar)
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// CollectionItem, koleksiyona eklenmiş bir not veya PDF'tir. FolderID nil ise öğe koleksiyonun kökündedir.
+// Bir içerik aynı koleksiyona yalnızca bir kez eklenebilir.
+type CollectionItem struct {
+	ID           uint      `json:"id"`
+	CollectionID uint      `json:"collectionId"`
+	FolderID     *uint     `json:"folderId"`
+	ContentID    uint      `json:"contentId"`
+	ContentType  string    `json:"contentType"` // "note" veya "pdf"
+	Position     int       `json:"position"`    // Aynı klasördeki öğeler arasındaki sıra (0'dan başlar)
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// CollectionItemResponse, içerik bilgileriyle zenginleştirilmiş koleksiyon öğesidir
+type CollectionItemResponse struct {
+	CollectionItem
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"` // Sadece PDF'ler için
+	OwnerID     uint      `json:"ownerId"`
+	IsPublic    bool      `json:"isPublic"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// CollectionDetail, koleksiyonu klasörleri ve öğeleriyle birlikte temsil eder.
+// Klasörler ve öğeler düz listeler halinde konuma göre sıralıdır; ağaç parentId ve folderId alanlarından kurulur.
+type CollectionDetail struct {
+	Collection *Collection               `json:"collection"`
+	Folders    []*CollectionFolder       `json:"folders"`
+	Items      []*CollectionItemResponse `json:"items"`
+}
+
+// CollectionRepository, koleksiyonların, klasörlerinin ve öğelerinin saklanması ve alınması için bir arayüz tanımlar
+type CollectionRepository interface {
+	FindByID(id uint) (*Collection, error)
+	// FindByUserID, kullanıcının koleksiyonlarını son güncellenenden başlayarak döndürür
+	FindByUserID(userID uint, limit, offset int) ([]*Collection, error)
+	Create(collection *Collection) error
+	Update(collection *Collection) error
+	// Delete, koleksiyonu klasörleri ve öğeleriyle birlikte siler
+	Delete(id uint) error
+
+	FindFolderByID(id uint) (*CollectionFolder, error)
+	// FindFolders, koleksiyonun tüm klasörlerini konuma göre sıralı döndürür
+	FindFolders(collectionID uint) ([]*CollectionFolder, error)
+	CreateFolder(folder *CollectionFolder) error
+	UpdateFolder(folder *CollectionFolder) error
+	// DeleteFolders, verilen klasörleri ve içlerindeki öğeleri siler
+	DeleteFolders(collectionID uint, folderIDs []uint) error
+	// ArrangeFolders, klasörleri verilen üst klasöre taşır ve verilen sırayla konumlarını 0'dan başlayarak numaralar
+	ArrangeFolders(collectionID uint, parentID *uint, folderIDs []uint) error
+
+	FindItemByID(id uint) (*CollectionItem, error)
+	// FindItems, koleksiyonun tüm öğelerini konuma göre sıralı döndürür
+	FindItems(collectionID uint) ([]*CollectionItem, error)
+	// CreateItem, içerik koleksiyonda zaten varsa ErrDuplicateEntry döndürür
+	CreateItem(item *CollectionItem) error
+	DeleteItem(id uint) error
+	// ArrangeItems, öğeleri verilen klasöre taşır ve verilen sırayla konumlarını 0'dan başlayarak numaralar
+	ArrangeItems(collectionID uint, folderID *uint, itemIDs []uint) error
+}
+
+// CollectionService, koleksiyon ile ilgili iş mantığını içerir
+type CollectionService interface {
+	CreateCollection(collection *Collection) error
+	UpdateCollection(collection *Collection) error
+	DeleteCollection(id, userID uint) error
+	GetCollection(id, userID uint, invite *Invite) (*CollectionDetail, error)
+	GetOwnedCollection(id, userID uint) (*Collection, error)
+	GetUserCollections(userID uint, limit, offset int) ([]*Collection, error)
+	CreateFolder(folder *CollectionFolder, userID uint, position *int) error
+	RenameFolder(collectionID, folderID, userID uint, name string) (*CollectionFolder, error)
+	MoveFolder(collectionID, folderID, userID uint, parentID *uint, position *int) error
+	DeleteFolder(collectionID, folderID, userID uint) error
+	AddItem(item *CollectionItem, userID uint, position *int) error
+	MoveItem(collectionID, itemID, userID uint, folderID *uint, position *int) error
+	RemoveItem(collectionID, itemID, userID uint) error
+}
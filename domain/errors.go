@@ -17,6 +17,9 @@ var (
 	ErrInvalidInput       = errors.New("geçersiz girdi")
 	ErrInternalServer     = errors.New("sunucu hatası")
 	ErrDuplicateEntry     = errors.New("kayıt zaten mevcut")
+	ErrVersionConflict    = errors.New("kayıt başka bir istek tarafından değiştirilmiş")
+	ErrPDFMalformed       = errors.New("dosya geçerli bir PDF değil")
+	ErrPDFEncrypted       = errors.New("şifrelenmiş PDF dosyaları desteklenmiyor")
 )
 
 // Now, şu anki zamanı döndürür (test edilebilirlik için)
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Anlık olay türleri
+const (
+	EventNotification = "notification" // Kullanıcıya yeni bildirim
+	EventLikeCount    = "like_count"   // İçeriğin beğeni sayısı değişti
+	EventComment      = "comment"      // İçeriğe yeni yorum eklendi
+	EventRevision     = "revision"     // Nota yeni revizyon eklendi
+	EventResync       = "resync"       // Kaçırılan olaylar tekrar gönderilemiyor; istemci durumu yeniden yüklemeli
+)
+
+// Event, sunucudan istemcilere anlık olarak iletilen bir olaydır
+type Event struct {
+	ID        uint64      `json:"id"`
+	Type      string      `json:"type"`
+	Topic     string      `json:"topic"`
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// UserTopic, yalnızca verilen kullanıcıya iletilen olayların konusudur
+func UserTopic(userID uint) string {
+	return fmt.Sprintf("user:%d", userID)
+}
+
+// ContentTopic, bir içeriği (not veya PDF) takip eden herkese iletilen olayların konusudur
+func ContentTopic(contentType string, contentID uint) string {
+	return fmt.Sprintf("%s:%d", contentType, contentID)
+}
+
+// LikeCountEvent, beğeni sayısı değişikliği olayının verisidir
+type LikeCountEvent struct {
+	ContentID   uint   `json:"contentId"`
+	ContentType string `json:"contentType"`
+	LikeCount   int    `json:"likeCount"`
+}
+
+// CommentEvent, yeni yorum olayının verisidir
+type CommentEvent struct {
+	ContentID    uint   `json:"contentId"`
+	ContentType  string `json:"contentType"`
+	CommentID    uint   `json:"commentId"`
+	UserID       uint   `json:"userId"`
+	CommentCount int    `json:"commentCount"`
+}
+
+// RevisionEvent, yeni revizyon olayının verisidir
+type RevisionEvent struct {
+	NoteID       uint      `json:"noteId"`
+	Number       int       `json:"number"`
+	AuthorID     uint      `json:"authorId"`
+	RestoredFrom int       `json:"restoredFrom,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
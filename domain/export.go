@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// Notların dışa aktarılabildiği dosya biçimleri
+const (
+	ExportFormatPDF      = "pdf"
+	ExportFormatMarkdown = "md"
+	ExportFormatHTML     = "html"
+	ExportFormatDOCX     = "docx"
+)
+
+// NoteDocument, dışa aktarılan bir notun içeriği ve üst verileridir
+type NoteDocument struct {
+	Title     string
+	Author    string // Notu oluşturan kullanıcının görünen adı
+	Tags      []string
+	Content   string // Markdown
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ExportedFile, dışa aktarma sonucunda üretilen dosyadır
+type ExportedFile struct {
+	FileName    string // Uzantısıyla birlikte önerilen dosya adı
+	ContentType string
+	Content     []byte
+}
+
+// NoteExporter, notları tek bir dosya biçimine dönüştürür
+type NoteExporter interface {
+	Format() string      // Biçim adı (ExportFormat*)
+	ContentType() string // Üretilen dosyanın MIME türü
+	Export(doc *NoteDocument) ([]byte, error)
+}
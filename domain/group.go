@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"time"
+)
+
+// Grup üyelik rolleri
+const (
+	GroupRoleOwner  = "owner"  // Grubu oluşturan; grubu silebilir ve rolleri değiştirebilir
+	GroupRoleAdmin  = "admin"  // Grubu düzenleyebilir, üyelik isteklerini yönetebilir ve üyeleri çıkarabilir
+	GroupRoleMember = "member" // Grup alanını görebilir ve içerik paylaşabilir
+)
+
+// Üyelik isteği durumları
+const (
+	GroupJoinRequestPending  = "pending"
+	GroupJoinRequestApproved = "approved"
+	GroupJoinRequestRejected = "rejected"
+)
+
+// Grup etkinlik türleri
+const (
+	GroupActivityCreated        = "group_created"
+	GroupActivityMemberJoined   = "member_joined"
+	GroupActivityMemberLeft     = "member_left"
+	GroupActivityMemberRemoved  = "member_removed"
+	GroupActivityRoleChanged    = "role_changed"
+	GroupActivityContentPosted  = "content_posted"
+	GroupActivityContentRemoved = "content_removed"
+)
+
+// Group, üyelerinin not ve PDF paylaştığı bir çalışma grubunu temsil eder. Grup alanına paylaşılan
+// özel içerikleri yalnızca grup üyeleri görebilir.
+type Group struct {
+	ID          uint      `json:"id"`
+	OwnerID     uint      `json:"ownerId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// GroupMember, bir kullanıcının gruptaki üyeliğini ve rolünü temsil eder
+type GroupMember struct {
+	ID        uint      `json:"id"`
+	GroupID   uint      `json:"groupId"`
+	UserID    uint      `json:"userId"`
+	Username  string    `json:"username,omitempty"` // Saklanmaz; listelenirken doldurulur
+	Role      string    `json:"role"`               // "owner", "admin" veya "member"
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// GroupJoinRequest, bir kullanıcının gruba katılma isteğidir
+type GroupJoinRequest struct {
+	ID         uint      `json:"id"`
+	GroupID    uint      `json:"groupId"`
+	UserID     uint      `json:"userId"`
+	Username   string    `json:"username,omitempty"` // Saklanmaz; listelenirken doldurulur
+	Message    string    `json:"message"`
+	Status     string    `json:"status"`               // "pending", "approved" veya "rejected"
+	ReviewedBy *uint     `json:"reviewedBy,omitempty"` // İsteği onaylayan veya reddeden yönetici
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// GroupContent, bir üyenin grup alanına paylaştığı not veya PDF'tir. Bir içerik aynı gruba yalnızca
+// bir kez paylaşılabilir.
+type GroupContent struct {
+	ID          uint      `json:"id"`
+	GroupID     uint      `json:"groupId"`
+	ContentID   uint      `json:"contentId"`
+	ContentType string    `json:"contentType"` // "note" veya "pdf"
+	UserID      uint      `json:"userId"`      // İçeriği paylaşan üye
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// GroupContentResponse, içerik bilgileriyle zenginleştirilmiş grup içeriğidir
+type GroupContentResponse struct {
+	GroupContent
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"` // Sadece PDF'ler için
+	IsPublic    bool      `json:"isPublic"`              // false ise içeriği yalnızca grup üyeleri görebilir
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// GroupActivity, grup etkinlik akışındaki bir kayıttır. Title, içerik etkinliklerinde içeriğin o
+// anki başlığıdır; içerik sonradan silinse de akışta görünür.
+type GroupActivity struct {
+	ID           uint      `json:"id"`
+	GroupID      uint      `json:"groupId"`
+	UserID       uint      `json:"userId"` // İşlemi yapan kullanıcı
+	Username     string    `json:"username,omitempty"`
+	Type         string    `json:"type"`
+	TargetUserID uint      `json:"targetUserId,omitempty"` // Üyelik etkinliklerinde etkilenen kullanıcı
+	ContentID    uint      `json:"contentId,omitempty"`
+	ContentType  string    `json:"contentType,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	Role         string    `json:"role,omitempty"` // Rol değişikliklerinde yeni rol
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// GroupDetail, grubu üye sayısı ve görüntüleyen kullanıcının rolüyle birlikte temsil eder
+type GroupDetail struct {
+	*Group
+	MemberCount int    `json:"memberCount"`
+	Role        string `json:"role,omitempty"` // Görüntüleyen üye değilse boştur
+}
+
+// GroupRepository, çalışma gruplarının, üyeliklerin, üyelik isteklerinin, grup içeriklerinin ve
+// etkinliklerin saklanması ve alınması için bir arayüz tanımlar
+type GroupRepository interface {
+	FindByID(id uint) (*Group, error)
+	// FindByUserID, kullanıcının üyesi olduğu grupları son güncellenenden başlayarak döndürür
+	FindByUserID(userID uint, limit, offset int) ([]*Group, error)
+	Create(group *Group) error
+	Update(group *Group) error
+	// Delete, grubu üyelikleri, istekleri, içerik paylaşımları, etkinlikleri ve davet bağlantılarıyla birlikte siler
+	Delete(id uint) error
+
+	FindMember(groupID, userID uint) (*GroupMember, error)
+	// FindMembers, grubun üyelerini katılma sırasına göre döndürür
+	FindMembers(groupID uint, limit, offset int) ([]*GroupMember, error)
+	CountMembers(groupID uint) (int, error)
+	// CreateMember, kullanıcı grubun zaten üyesiyse ErrDuplicateEntry döndürür
+	CreateMember(member *GroupMember) error
+	UpdateMember(member *GroupMember) error
+	// DeleteMember, üyeliği ve üyenin grup alanına paylaştığı içerikleri siler
+	DeleteMember(groupID, userID uint) error
+
+	FindJoinRequestByID(id uint) (*GroupJoinRequest, error)
+	FindPendingJoinRequest(groupID, userID uint) (*GroupJoinRequest, error)
+	// FindJoinRequests, grubun verilen durumdaki isteklerini eskiden yeniye döndürür
+	FindJoinRequests(groupID uint, status string, limit, offset int) ([]*GroupJoinRequest, error)
+	CreateJoinRequest(request *GroupJoinRequest) error
+	UpdateJoinRequest(request *GroupJoinRequest) error
+
+	FindContent(groupID, contentID uint, contentType string) (*GroupContent, error)
+	// FindContents, grup alanındaki içerikleri son paylaşılandan başlayarak döndürür
+	FindContents(groupID uint, limit, offset int) ([]*GroupContent, error)
+	// CreateContent, içerik gruba zaten paylaşılmışsa ErrDuplicateEntry döndürür
+	CreateContent(content *GroupContent) error
+	DeleteContent(groupID, contentID uint, contentType string) error
+	// IsContentShared, içeriğin kullanıcının üyesi olduğu gruplardan birine paylaşılıp paylaşılmadığını döndürür
+	IsContentShared(contentID uint, contentType string, userID uint) (bool, error)
+
+	CreateActivity(activity *GroupActivity) error
+	// FindActivities, grubun etkinliklerini en yeniden başlayarak döndürür
+	FindActivities(groupID uint, limit, offset int) ([]*GroupActivity, error)
+}
+
+// GroupService, çalışma grupları ile ilgili iş mantığını içerir
+type GroupService interface {
+	CreateGroup(group *Group) error
+	UpdateGroup(group *Group, userID uint) error
+	DeleteGroup(id, userID uint) error
+	GetGroup(id, userID uint) (*GroupDetail, error)
+	GetUserGroups(userID uint, limit, offset int) ([]*Group, error)
+	GetMembers(groupID, userID uint, limit, offset int) ([]*GroupMember, error)
+	ChangeMemberRole(groupID, memberID, userID uint, role string) (*GroupMember, error)
+	RemoveMember(groupID, memberID, userID uint) error
+	LeaveGroup(groupID, userID uint) error
+	RequestJoin(groupID, userID uint, message string) (*GroupJoinRequest, error)
+	GetJoinRequests(groupID, userID uint, limit, offset int) ([]*GroupJoinRequest, error)
+	ReviewJoinRequest(groupID, requestID, userID uint, approve bool) (*GroupJoinRequest, error)
+	JoinByInvite(token, password string, userID uint) (*GroupDetail, error)
+	PostContent(content *GroupContent) error
+	RemoveContent(groupID, contentID uint, contentType string, userID uint) error
+	GetContents(groupID, userID uint, limit, offset int) ([]*GroupContentResponse, error)
+	GetActivities(groupID, userID uint, limit, offset int) ([]*GroupActivity, error)
+}
@@ -4,17 +4,52 @@ import (
 	"time"
 )
 
-// Invite, bir içeriğe (not veya PDF) erişim için davet bağlantısını temsil eder
+// Davet bağlantısı yetki düzeyleri. Her düzey bir öncekinin yetkilerini de içerir.
+const (
+	InvitePermissionRead     = "read"     // İçeriği görebilir
+	InvitePermissionComment  = "comment"  // Giriş yapmış kullanıcılar yorum da yapabilir (not ve PDF)
+	InvitePermissionAnnotate = "annotate" // Giriş yapmış kullanıcılar işaretleme de ekleyebilir (yalnızca PDF)
+)
+
+// Invite, bir içeriğe (not, PDF veya koleksiyon) erişim ya da bir çalışma grubuna katılım için davet
+// bağlantısını temsil eder
 type Invite struct {
-	ID        uint      `json:"id"`
-	ContentID uint      `json:"contentId"`
-	Type      string    `json:"type"` // "note" veya "pdf"
-	Token     string    `json:"token"`
-	CreatedBy uint      `json:"createdBy"`
-	ExpiresAt time.Time `json:"expiresAt"`
-	IsActive  bool      `json:"isActive"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID           uint      `json:"id"`
+	ContentID    uint      `json:"contentId"`
+	Type         string    `json:"type"` // "note", "pdf", "collection" veya "group"
+	Token        string    `json:"token"`
+	CreatedBy    uint      `json:"createdBy"`
+	Permission   string    `json:"permission"` // "read", "comment" veya "annotate"
+	MaxUses      int       `json:"maxUses"`    // En fazla kullanım sayısı (0: sınırsız)
+	UseCount     int       `json:"useCount"`
+	PasswordHash string    `json:"-"` // Parola korumalı bağlantılar için bcrypt özeti
+	ExpiresAt    time.Time `json:"expiresAt"`
+	IsActive     bool      `json:"isActive"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// HasPassword, davet bağlantısının parola korumalı olup olmadığını döndürür
+func (i *Invite) HasPassword() bool {
+	return i.PasswordHash != ""
+}
+
+// Exhausted, davet bağlantısının kullanım hakkının dolup dolmadığını döndürür
+func (i *Invite) Exhausted() bool {
+	return i.MaxUses > 0 && i.UseCount >= i.MaxUses
+}
+
+// InviteRedemption, bir davet bağlantısının kullanılmasını temsil eder. Giriş yapmış bir kullanıcı
+// bağlantıyı tekrar açtığında yeni kayıt oluşmaz; mevcut kaydın son kullanım zamanı güncellenir. Giriş
+// yapmamış ziyaretçiler ilk kullanımda aldıkları ziyaretçi anahtarıyla aynı kayda bağlanır.
+type InviteRedemption struct {
+	ID         uint      `json:"id"`
+	InviteID   uint      `json:"inviteId"`
+	UserID     uint      `json:"userId"`             // Giriş yapmamış kullanıcılar için 0
+	VisitorKey string    `json:"-"`                  // Giriş yapmamış ziyaretçinin sonraki isteklerde gönderdiği anahtar
+	Username   string    `json:"username,omitempty"` // Saklanmaz; listelenirken doldurulur
+	CreatedAt  time.Time `json:"createdAt"`          // Bağlantının ilk kullanıldığı zaman
+	LastUsedAt time.Time `json:"lastUsedAt"`
 }
 
 // InviteRepository, davet bağlantısı verilerinin saklanması ve alınması için bir arayüz tanımlar
@@ -24,15 +59,39 @@ type InviteRepository interface {
 	FindByContentID(contentID uint, contentType string) ([]*Invite, error)
 	Create(invite *Invite) error
 	Update(invite *Invite) error
+	// Delete ve DeleteByContentID davetlerin kullanım kayıtlarını da siler
 	Delete(id uint) error
 	DeleteByContentID(contentID uint, contentType string) error
+
+	// FindRedemption, giriş yapmış kullanıcının davet kullanım kaydını bulur; kayıt yoksa nil döndürür
+	FindRedemption(inviteID, userID uint) (*InviteRedemption, error)
+	// FindRedemptionByVisitorKey, giriş yapmamış ziyaretçinin davet kullanım kaydını anahtarıyla bulur;
+	// kayıt yoksa nil döndürür
+	FindRedemptionByVisitorKey(inviteID uint, visitorKey string) (*InviteRedemption, error)
+	// FindRedemptions, davetin kullanım kayıtlarını son kullanılandan başlayarak döndürür
+	FindRedemptions(inviteID uint, limit, offset int) ([]*InviteRedemption, error)
+	// Redeem, kullanım hakkı kalmışsa davetin kullanım sayısını bir artırır ve kullanım kaydını aynı
+	// işlemde ekler. Kullanım hakkı dolmuşsa veya davet yoksa false döndürür. Giriş yapmış kullanıcının
+	// davet için zaten bir kaydı varsa hiçbir değişiklik yapmadan ErrDuplicateEntry döndürür.
+	Redeem(redemption *InviteRedemption) (bool, error)
+	UpdateRedemption(redemption *InviteRedemption) error
 }
 
 // InviteService, davet bağlantısı ile ilgili iş mantığını içerir
 type InviteService interface {
-	CreateInvite(invite *Invite) error
+	CreateInvite(invite *Invite, password string) error
 	GetInvite(token string) (*Invite, error)
 	GetInvitesByContent(contentID uint, contentType string) ([]*Invite, error)
 	DeactivateInvite(id uint, userID uint) error
 	ValidateInvite(token string) (bool, *Invite, error)
+	AuthorizeInvite(token, password string, userID uint, visitorKey string) (*Invite, error)
+	RedeemInvite(invite *Invite, userID uint, visitorKey string) (string, error)
+	GetRedemptions(id, userID uint, limit, offset int) ([]*InviteRedemption, error)
+	GetInviteQRCode(id, userID uint, size int) ([]byte, error)
+}
+
+// QRCodeEncoder, metni kare biçimli bir QR kod görseline dönüştürür
+type QRCodeEncoder interface {
+	// EncodePNG, içeriği kenar uzunluğu size piksel olan PNG görseline dönüştürür
+	EncodePNG(content string, size int) ([]byte, error)
 }
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Arka plan işi durumları
+const (
+	JobStatusPending   = "pending"   // Çalıştırılmayı bekliyor (ilk deneme veya yeniden deneme)
+	JobStatusRunning   = "running"   // Bir worker tarafından kiralanmış
+	JobStatusSucceeded = "succeeded" // Başarıyla tamamlandı
+	JobStatusDead      = "dead"      // Deneme hakkı tükendi veya kalıcı hata; elle yeniden denenebilir
+)
+
+// Arka plan işi türleri
+const (
+	JobExtractPDFText = "pdf.extract_text" // PDF sayfa metinlerini çıkarır ve aramaya ekler
+	JobCountPDFPages  = "pdf.count_pages"  // PDF'in sayfa sayısını kaydeder
+	JobRenderPDFPages = "pdf.render_pages" // PDF'in kapak ve sayfa önizleme görsellerini üretir
+	JobHashPDFContent = "pdf.hash_content" // İçerik özeti olmayan eski PDF'in özetini hesaplar ve dosyasını paylaşılabilir hale getirir
+)
+
+// ErrJobLeaseLost, kirası başka bir worker'a geçmiş bir iş için sonuç yazılmaya çalışıldığında döner
+var ErrJobLeaseLost = errors.New("iş kirası kaybedildi")
+
+// Job, kalıcı iş kuyruğundaki bir arka plan işini temsil eder
+type Job struct {
+	ID          uint       `json:"id"`
+	Type        string     `json:"type"`
+	Payload     string     `json:"payload"` // JSON
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"` // Kiralanma sayısı
+	MaxAttempts int        `json:"maxAttempts"`
+	RunAt       time.Time  `json:"runAt"` // Bu zamandan önce kiralanmaz
+	LockedBy    string     `json:"lockedBy,omitempty"`
+	LockedUntil *time.Time `json:"lockedUntil,omitempty"`
+	LastError   string     `json:"lastError,omitempty"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// PDFJobPayload, PDF işleme işlerinin yüküdür
+type PDFJobPayload struct {
+	PDFID uint `json:"pdfId"`
+}
+
+// JobFilter, iş listeleme filtresidir; boş alanlar filtrelenmez
+type JobFilter struct {
+	Status string
+	Type   string
+}
+
+// JobRepository, arka plan işlerinin saklanması ve kiralanması için bir arayüz tanımlar
+type JobRepository interface {
+	Create(job *Job) error
+	FindByID(id uint) (*Job, error)
+	Find(filter JobFilter, limit, offset int) ([]*Job, error) // En yeni iş önce
+	CountByStatus() (map[string]int, error)
+	// Lease, çalıştırma zamanı gelmiş en eski bekleyen işi ya da kirası dolmuş (worker'ı çökmüş)
+	// çalışan işi workerID adına until zamanına kadar kiralar ve deneme sayısını artırır.
+	// Uygun iş yoksa nil döner.
+	Lease(workerID string, now, until time.Time) (*Job, error)
+	// Complete, workerID'nin kiraladığı işi başarılı olarak işaretler; kira kaybedildiyse ErrJobLeaseLost döner
+	Complete(id uint, workerID string, now time.Time) error
+	// Fail, workerID'nin kiraladığı işin hatasını kaydeder. retryAt verilirse iş o zamanda yeniden
+	// denenmek üzere beklemeye alınır, verilmezse ölü olarak işaretlenir. Kira kaybedildiyse ErrJobLeaseLost döner.
+	Fail(id uint, workerID string, errMsg string, retryAt *time.Time, now time.Time) error
+	// Retry, ölü bir işi deneme sayısını sıfırlayarak hemen çalıştırılmak üzere beklemeye alır.
+	// İş yoksa ErrNotFound, ölü değilse ErrInvalidInput döner.
+	Retry(id uint, now time.Time) error
+}
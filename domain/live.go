@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Canlı düzenleme mesaj türleri
+const (
+	LiveMessageInit   = "init"   // Sunucu -> istemci: belgenin mevcut hali ve katılımcılar
+	LiveMessageOp     = "op"     // İki yönlü: metin düzenlemesi
+	LiveMessageAck    = "ack"    // Sunucu -> istemci: gönderilen düzenleme uygulandı
+	LiveMessageCursor = "cursor" // İki yönlü: imleç / seçim konumu
+	LiveMessageJoin   = "join"   // Sunucu -> istemci: yeni katılımcı
+	LiveMessageLeave  = "leave"  // Sunucu -> istemci: ayrılan katılımcı
+	LiveMessageSaved  = "saved"  // Sunucu -> istemci: belge kaydedildi
+	LiveMessageError  = "error"  // Sunucu -> istemci: hata
+)
+
+// LiveMessage, canlı düzenleme oturumunda istemci ile sunucu arasında gönderilen mesajdır
+type LiveMessage struct {
+	Type         string            `json:"type"`
+	Revision     int               `json:"revision"`
+	ClientID     string            `json:"clientId,omitempty"`
+	Operation    TextOperation     `json:"operation,omitempty"`
+	Content      string            `json:"content,omitempty"`
+	Cursor       *LiveCursor       `json:"cursor,omitempty"`
+	Participant  *LiveParticipant  `json:"participant,omitempty"`
+	Participants []LiveParticipant `json:"participants,omitempty"`
+	Version      int               `json:"version,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// LiveCursor, bir katılımcının imleç konumu ve seçimidir (UTF-16 kod birimi cinsinden)
+type LiveCursor struct {
+	Position     int `json:"position"`
+	SelectionEnd int `json:"selectionEnd"`
+}
+
+// LiveParticipant, bir nottaki canlı düzenleme oturumuna bağlı kullanıcıdır
+type LiveParticipant struct {
+	ClientID string      `json:"clientId"`
+	UserID   uint        `json:"userId,omitempty"`
+	Username string      `json:"username"`
+	CanEdit  bool        `json:"canEdit"`
+	Cursor   *LiveCursor `json:"cursor,omitempty"`
+}
+
+// OperationComponent, bir metin düzenlemesinin tek adımıdır: atla, ekle veya sil.
+// Bir bileşende bu alanlardan yalnızca biri dolu olur.
+type OperationComponent struct {
+	Retain int
+	Insert string
+	Delete int
+}
+
+// TextOperation, belgenin başından sonuna uygulanan düzenleme adımlarıdır.
+// JSON'da ot.js biçimiyle gösterilir: pozitif sayı atla, negatif sayı sil, metin ekle.
+// Örn. [3, "abc", -2]: 3 karakter atla, "abc" ekle, 2 karakter sil.
+type TextOperation []OperationComponent
+
+// MarshalJSON, düzenlemeyi ot.js biçiminde kodlar
+func (op TextOperation) MarshalJSON() ([]byte, error) {
+	items := make([]interface{}, 0, len(op))
+	for _, c := range op {
+		switch {
+		case c.Retain > 0:
+			items = append(items, c.Retain)
+		case c.Delete > 0:
+			items = append(items, -c.Delete)
+		default:
+			items = append(items, c.Insert)
+		}
+	}
+	return json.Marshal(items)
+}
+
+// UnmarshalJSON, ot.js biçimindeki düzenlemeyi çözer
+func (op *TextOperation) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	result := make(TextOperation, 0, len(items))
+	for _, item := range items {
+		var text string
+		if err := json.Unmarshal(item, &text); err == nil {
+			if text == "" {
+				return errors.New("boş ekleme bileşeni")
+			}
+			result = append(result, OperationComponent{Insert: text})
+			continue
+		}
+
+		var n int
+		if err := json.Unmarshal(item, &n); err != nil {
+			return fmt.Errorf("geçersiz düzenleme bileşeni: %s", item)
+		}
+		switch {
+		case n > 0:
+			result = append(result, OperationComponent{Retain: n})
+		case n < 0:
+			result = append(result, OperationComponent{Delete: -n})
+		default:
+			return errors.New("sıfır uzunluklu düzenleme bileşeni")
+		}
+	}
+
+	*op = result
+	return nil
+}
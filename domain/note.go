@@ -8,13 +8,15 @@ import (
 type Note struct {
 	ID           uint      `json:"id"`
 	Title        string    `json:"title"`
-	Content      string    `json:"content"`
+	Content      string    `json:"content"`               // Markdown
+	ContentHTML  string    `json:"contentHtml,omitempty"` // İçeriğin güvenli HTML hali; yalnızca istendiğinde doldurulur, saklanmaz
 	UserID       uint      `json:"userId"`
 	Tags         []string  `json:"tags"`
 	IsPublic     bool      `json:"isPublic"`
 	ViewCount    int       `json:"viewCount"`
 	LikeCount    int       `json:"likeCount"`
 	CommentCount int       `json:"commentCount"`
+	Version      int       `json:"version"`
 	CreatedAt    time.Time `json:"createdAt"`
 	UpdatedAt    time.Time `json:"updatedAt"`
 }
@@ -35,9 +37,9 @@ type NoteRepository interface {
 	FindByUserID(userID uint, limit, offset int) ([]*Note, error)
 	FindPublic(limit, offset int) ([]*Note, error)
 	FindByTag(tag string, limit, offset int) ([]*Note, error)
-	Search(query string, limit, offset int) ([]*Note, error)
+	Search(query *SearchQuery) ([]*SearchHit, error) // Sonuçlar puana göre sıralıdır
 	Create(note *Note) error
-	Update(note *Note) error
+	Update(note *Note) error // Saklanan sürüm Version ile eşleşmezse ErrVersionConflict döner
 	Delete(id uint) error
 	IncrementViewCount(id uint) error
 	IncrementLikeCount(id uint) error
@@ -52,6 +54,12 @@ type CommentRepository interface {
 	Delete(id uint) error
 }
 
+// ContentRenderer, Markdown not içeriklerini kullanıcıya doğrudan gösterilebilecek HTML'e dönüştürür.
+// Çıktıda ham HTML ve betik çalıştırabilen bağlantılar bulunmamalıdır.
+type ContentRenderer interface {
+	RenderHTML(content string) (string, error)
+}
+
 // NoteService, not ile ilgili iş mantığını içerir
 type NoteService interface {
 	CreateNote(note *Note) error
@@ -60,9 +68,14 @@ type NoteService interface {
 	GetNote(id uint) (*Note, error)
 	GetUserNotes(userID uint, limit, offset int) ([]*Note, error)
 	GetPublicNotes(limit, offset int) ([]*Note, error)
-	SearchNotes(query string, limit, offset int) ([]*Note, error)
-	AddComment(comment *Comment) error
+	GetNotesByTag(tag string, limit, offset int) ([]*Note, error)
+	AddComment(comment *Comment, invite *Invite) error
 	GetComments(noteID uint, limit, offset int) ([]*Comment, error)
 	LikeNote(noteID uint, userID uint) error
 	UnlikeNote(noteID uint, userID uint) error
+	GetRevisions(noteID, userID uint, limit, offset int) ([]*NoteRevision, error)
+	GetRevision(noteID, userID uint, number int) (*NoteRevision, error)
+	DiffRevisions(noteID, userID uint, from, to int) (*RevisionDiff, error)
+	RestoreRevision(noteID, userID uint, number int) (*Note, error)
+	RenderNotes(notes ...*Note) error
 }
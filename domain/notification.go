@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+)
+
+// Bildirim türleri
+const (
+	NotificationLike    = "like"    // İçerik beğenildi
+	NotificationComment = "comment" // İçeriğe yorum yapıldı
+	NotificationMention = "mention" // Kullanıcıdan bir yorumda bahsedildi
+	NotificationInvite  = "invite"  // İçeriğin davet bağlantısı kullanıldı
+)
+
+// Notification, bir kullanıcıya içerikleriyle ilgili bir etkileşimi bildiren kayıttır
+type Notification struct {
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"userId"`  // Bildirimi alan kullanıcı
+	ActorID     uint      `json:"actorId"` // Etkileşimi yapan kullanıcı (misafirler için 0)
+	Type        string    `json:"type"`
+	ContentID   uint      `json:"contentId"`
+	ContentType string    `json:"contentType"` // "note", "pdf" veya "collection"
+	Message     string    `json:"message"`
+	IsRead      bool      `json:"isRead"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// NotificationPreferences, bir kullanıcının hangi bildirim türlerini almak istediğini belirtir
+type NotificationPreferences struct {
+	UserID    uint      `json:"userId"`
+	Likes     bool      `json:"likes"`
+	Comments  bool      `json:"comments"`
+	Mentions  bool      `json:"mentions"`
+	Invites   bool      `json:"invites"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DefaultNotificationPreferences, tercih kaydı olmayan kullanıcılar için varsayılan tercihleri döndürür
+func DefaultNotificationPreferences(userID uint) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:   userID,
+		Likes:    true,
+		Comments: true,
+		Mentions: true,
+		Invites:  true,
+	}
+}
+
+// Allows, verilen bildirim türünün tercihlere göre gönderilip gönderilmeyeceğini döndürür
+func (p *NotificationPreferences) Allows(notificationType string) bool {
+	switch notificationType {
+	case NotificationLike:
+		return p.Likes
+	case NotificationComment:
+		return p.Comments
+	case NotificationMention:
+		return p.Mentions
+	case NotificationInvite:
+		return p.Invites
+	default:
+		return false
+	}
+}
+
+// NotificationRepository, bildirim verilerinin saklanması ve alınması için bir arayüz tanımlar
+type NotificationRepository interface {
+	FindByID(id uint) (*Notification, error)
+	FindByUserID(userID uint, unreadOnly bool, limit, offset int) ([]*Notification, error)
+	// ExistsUnread, aynı alıcı, kişi, tür ve içerik için okunmamış bir bildirim olup olmadığını kontrol eder
+	ExistsUnread(notification *Notification) (bool, error)
+	CountUnread(userID uint) (int64, error)
+	Create(notification *Notification) error
+	MarkRead(id uint) error
+	MarkAllRead(userID uint) error
+	DeleteByContentID(contentID uint, contentType string) error
+}
+
+// NotificationPreferenceRepository, bildirim tercihlerinin saklanması ve alınması için bir arayüz tanımlar
+type NotificationPreferenceRepository interface {
+	// FindByUserID, kullanıcının kayıtlı tercihlerini döndürür; kayıt yoksa nil döner
+	FindByUserID(userID uint) (*NotificationPreferences, error)
+	Save(preferences *NotificationPreferences) error
+}
+
+// NotificationService, bildirim ile ilgili iş mantığını içerir
+type NotificationService interface {
+	NotifyLike(actorID, contentID uint, contentType string) error
+	NotifyComment(actorID, contentID uint, contentType, text string) error
+	NotifyInviteUsed(invite *Invite, userID uint) error
+	GetNotifications(userID uint, unreadOnly bool, limit, offset int) ([]*Notification, error)
+	GetUnreadCount(userID uint) (int64, error)
+	MarkAsRead(id, userID uint) error
+	MarkAllAsRead(userID uint) error
+	GetPreferences(userID uint) (*NotificationPreferences, error)
+	UpdatePreferences(preferences *NotificationPreferences) error
+}
@@ -1,52 +1,121 @@
 package domain
 
 import (
+	"io"
 	"time"
 )
 
+// PDF'lerde bulunabilecek, görüntüleyicide kod çalıştırabilen veya dosya açabilen etkin içerik türleri
+const (
+	PDFActiveJavaScript   = "javascript"    // Belge, sayfa, form veya işaretleme JavaScript eylemleri
+	PDFActiveLaunch       = "launch"        // Harici uygulama veya dosya açan Launch eylemleri
+	PDFActiveEmbeddedFile = "embedded-file" // Belgeye gömülü veya eklenmiş dosyalar
+)
+
 // PDF, bir kullanıcının yüklediği PDF dosyasını temsil eder
 type PDF struct {
-	ID           uint      `json:"id"`
-	Title        string    `json:"title"`
-	Description  string    `json:"description"`
-	FilePath     string    `json:"filePath"`
-	FileSize     int64     `json:"fileSize"`
-	UserID       uint      `json:"userId"`
-	Tags         []string  `json:"tags"`
-	IsPublic     bool      `json:"isPublic"`
-	ViewCount    int       `json:"viewCount"`
-	LikeCount    int       `json:"likeCount"`
-	CommentCount int       `json:"commentCount"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID            uint      `json:"id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	FilePath      string    `json:"filePath"`
+	FileSize      int64     `json:"fileSize"`
+	ContentHash   string    `json:"contentHash,omitempty"`   // Dosya içeriğinin onaltılık SHA-256 özeti
+	PageCount     int       `json:"pageCount"`               // Yüklemede okunur; eski PDF'lerde arka planda hesaplanana kadar 0
+	PDFVersion    string    `json:"pdfVersion,omitempty"`    // Dosyanın PDF sürümü (ör. "1.7")
+	ActiveContent []string  `json:"activeContent,omitempty"` // Yüklemede bulunan etkin içerik türleri (PDFActive*)
+	HasThumbnail  bool      `json:"hasThumbnail"`            // Kapak görseli arka planda üretildiğinde true olur
+	PreviewPages  int       `json:"previewPages"`            // Önizleme görseli üretilmiş ilk sayfaların sayısı
+	UserID        uint      `json:"userId"`
+	Tags          []string  `json:"tags"`
+	IsPublic      bool      `json:"isPublic"`
+	ViewCount     int       `json:"viewCount"`
+	LikeCount     int       `json:"likeCount"`
+	CommentCount  int       `json:"commentCount"`
+	Version       int       `json:"version"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
 }
 
 // PDFComment, bir PDF üzerindeki yorumu temsil eder
 type PDFComment struct {
-	ID         uint      `json:"id"`
-	PDFID      uint      `json:"pdfId"`
-	UserID     uint      `json:"userId"`
-	Content    string    `json:"content"`
-	PageNumber int       `json:"pageNumber"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID         uint               `json:"id"`
+	PDFID      uint               `json:"pdfId"`
+	UserID     uint               `json:"userId"`
+	Content    string             `json:"content"`
+	PageNumber int                `json:"pageNumber"`
+	Quote      *TextQuoteSelector `json:"quote,omitempty"` // Yorumun alıntıladığı metin (opsiyonel)
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
 }
 
-// PDFAnnotation, bir PDF üzerindeki işaretlemeyi temsil eder
+// PDFAnnotation, bir PDF üzerindeki işaretlemeyi temsil eder. Konum, görüntüleyicinin yakınlaştırma
+// düzeyinden bağımsız olması için sayfa boyutuna göre normalleştirilmiş koordinatlarla (0-1, sayfanın
+// sol üst köşesi orijin) tutulur; metin seçimine bağlı işaretlemeler ayrıca seçilen metni saklar.
 type PDFAnnotation struct {
-	ID         uint      `json:"id"`
-	PDFID      uint      `json:"pdfId"`
-	UserID     uint      `json:"userId"`
-	PageNumber int       `json:"pageNumber"`
-	Content    string    `json:"content"`
-	X          float64   `json:"x"`
-	Y          float64   `json:"y"`
-	Width      float64   `json:"width"`
-	Height     float64   `json:"height"`
-	Type       string    `json:"type"` // highlight, underline, note, etc.
-	Color      string    `json:"color"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID         uint               `json:"id"`
+	PDFID      uint               `json:"pdfId"`
+	UserID     uint               `json:"userId"`
+	PageNumber int                `json:"pageNumber"`
+	Content    string             `json:"content"`
+	X          float64            `json:"x"`               // Kapsayan dikdörtgenin sol kenarı (sayfa genişliğine oranı)
+	Y          float64            `json:"y"`               // Kapsayan dikdörtgenin üst kenarı (sayfa yüksekliğine oranı)
+	Width      float64            `json:"width"`           // Sayfa genişliğine oranı
+	Height     float64            `json:"height"`          // Sayfa yüksekliğine oranı
+	Rects      []PDFRect          `json:"rects,omitempty"` // Birden fazla satıra yayılan seçimlerde satır başına dikdörtgenler
+	Quote      *TextQuoteSelector `json:"quote,omitempty"` // İşaretlenen metin (opsiyonel)
+	Type       string             `json:"type"`            // highlight, underline, strikeout veya note
+	Color      string             `json:"color"`           // Onaltılık renk (#RGB, #RRGGBB veya alfa kanallı)
+	Visibility string             `json:"visibility"`      // private, shared veya public
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+}
+
+// AnnotationLayer, bir kullanıcının PDF üzerinde başkalarına açtığı işaretlemeleri tek bir katman
+// olarak gruplar; görüntüleyiciler katmanları ayrı ayrı gösterip gizleyebilir
+type AnnotationLayer struct {
+	UserID      uint             `json:"userId"`
+	Username    string           `json:"username"`
+	FullName    string           `json:"fullName"`
+	Annotations []*PDFAnnotation `json:"annotations"`
+}
+
+// PDF işaretleme türleri
+const (
+	AnnotationTypeHighlight = "highlight"
+	AnnotationTypeUnderline = "underline"
+	AnnotationTypeStrikeout = "strikeout"
+	AnnotationTypeNote      = "note"
+)
+
+// PDF işaretleme görünürlükleri
+const (
+	AnnotationVisibilityPrivate = "private" // Yalnızca işaretlemeyi oluşturan görür
+	AnnotationVisibilityShared  = "shared"  // PDF sahibi ve PDF'e davet bağlantısıyla erişenler de görür
+	AnnotationVisibilityPublic  = "public"  // PDF'i görebilen herkes görür
+)
+
+// PDFRect, sayfa boyutuna göre normalleştirilmiş (0-1) bir dikdörtgendir
+type PDFRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// TextQuoteSelector, seçilen metni ve ayırt edilebilmesi için önündeki ve arkasındaki metni tutar.
+// W3C Web Annotation modelindeki TextQuoteSelector ile aynı anlamdadır; sayfa metni yeniden
+// çıkarıldığında veya koordinatlar eşleşmediğinde seçimin yeniden bulunmasını sağlar.
+type TextQuoteSelector struct {
+	Exact  string `json:"exact"`
+	Prefix string `json:"prefix,omitempty"`
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// PDFPage, bir PDF sayfasından çıkarılan metni temsil eder
+type PDFPage struct {
+	PDFID      uint   `json:"pdfId"`
+	PageNumber int    `json:"pageNumber"` // 1'den başlar
+	Text       string `json:"text"`
 }
 
 // PDFRepository, PDF verilerinin saklanması ve alınması için bir arayüz tanımlar
@@ -55,13 +124,22 @@ type PDFRepository interface {
 	FindByUserID(userID uint, limit, offset int) ([]*PDF, error)
 	FindPublic(limit, offset int) ([]*PDF, error)
 	FindByTag(tag string, limit, offset int) ([]*PDF, error)
-	Search(query string, limit, offset int) ([]*PDF, error)
+	Search(query *SearchQuery) ([]*SearchHit, error) // Sonuçlar puana göre sıralıdır
 	Create(pdf *PDF) error
-	Update(pdf *PDF) error
+	Update(pdf *PDF) error // Saklanan sürüm Version ile eşleşmezse ErrVersionConflict döner
 	Delete(id uint) error
 	IncrementViewCount(id uint) error
 	IncrementLikeCount(id uint) error
 	DecrementLikeCount(id uint) error
+	UpdatePageCount(id uint, pageCount int) error
+	FindAll(limit, offset int) ([]*PDF, error)                             // Tüm PDF'ler, ID sırasıyla (bakım işlemleri için)
+	UpdateFilePath(id uint, filePath string) error                         // Dosya başka bir depoya taşındığında kullanılır; sürüm değişmez
+	CountByFilePath(filePath string) (int, error)                          // Aynı dosyayı paylaşan PDF sayısı (dosya referans sayısı)
+	FindPublicByContentHash(contentHash string, limit int) ([]*PDF, error) // İçeriği aynı olan herkese açık PDF'ler, ID sırasıyla
+	CountByContentHash(contentHash string) (int, error)                    // İçeriği aynı olan PDF sayısı (görsel referans sayısı)
+	UpdatePreviews(id uint, hasThumbnail bool, previewPages int) error     // Üretilen görselleri kaydeder; sürüm değişmez
+	FindWithoutContentHash(afterID uint, limit int) ([]*PDF, error)        // İçerik özeti olmayan eski PDF'ler, ID'si afterID'den büyük olanlar ID sırasıyla
+	UpdateContentHash(id uint, filePath, contentHash string) error         // Eski PDF'in içerik özetini ve dosya yolunu kaydeder; sürüm değişmez
 }
 
 // PDFCommentRepository, PDF yorumlarının saklanması ve alınması için bir arayüz tanımlar
@@ -74,34 +152,129 @@ type PDFCommentRepository interface {
 
 // PDFAnnotationRepository, PDF işaretlemelerinin saklanması ve alınması için bir arayüz tanımlar
 type PDFAnnotationRepository interface {
+	FindByID(id uint) (*PDFAnnotation, error)
 	FindByPDFID(pdfID uint, limit, offset int) ([]*PDFAnnotation, error)
 	FindByPDFIDAndUserID(pdfID, userID uint) ([]*PDFAnnotation, error)
+	// FindVisibleByPDFID, görünürlüğü verilenlerden biri olan ve excludeUserID dışındaki kullanıcılara
+	// ait işaretlemeleri kullanıcı ID'sine, ardından işaretleme ID'sine göre sıralı döndürür
+	FindVisibleByPDFID(pdfID uint, visibilities []string, excludeUserID uint, limit int) ([]*PDFAnnotation, error)
 	Create(annotation *PDFAnnotation) error
 	Update(annotation *PDFAnnotation) error
 	Delete(id uint) error
 }
 
+// PDFPageRepository, PDF sayfa metinlerinin saklanması ve alınması için bir arayüz tanımlar
+type PDFPageRepository interface {
+	FindByPDFID(pdfID uint, limit, offset int) ([]*PDFPage, error) // Sayfa numarasına göre sıralıdır
+	Replace(pdfID uint, pages []*PDFPage) error                    // PDF'in saklanan tüm sayfa metinlerini verilenlerle değiştirir
+}
+
 // PDFService, PDF ile ilgili iş mantığını içerir
 type PDFService interface {
-	UploadPDF(pdf *PDF, fileContent []byte) error
+	UploadPDF(pdf *PDF, content io.Reader) error
 	UpdatePDF(pdf *PDF) error
 	DeletePDF(id uint, userID uint) error
 	GetPDF(id uint) (*PDF, error)
-	GetPDFContent(id uint) ([]byte, error)
+	GetPDFContent(id, userID uint) (*PDFContent, error)
 	GetUserPDFs(userID uint, limit, offset int) ([]*PDF, error)
 	GetPublicPDFs(limit, offset int) ([]*PDF, error)
-	SearchPDFs(query string, limit, offset int) ([]*PDF, error)
-	AddComment(comment *PDFComment) error
+	GetPDFsByTag(tag string, limit, offset int) ([]*PDF, error)
+	FindPublicDuplicates(contentHash string, excludeID uint) ([]*PDF, error)
+	AddComment(comment *PDFComment, invite *Invite) error
 	GetComments(pdfID uint, limit, offset int) ([]*PDFComment, error)
-	AddAnnotation(annotation *PDFAnnotation) error
+	AddAnnotation(annotation *PDFAnnotation, invite *Invite) error
+	UpdateAnnotation(annotation *PDFAnnotation, userID uint) error
+	DeleteAnnotation(pdfID, annotationID, userID uint) error
 	GetAnnotations(pdfID uint, userID uint) ([]*PDFAnnotation, error)
+	GetAnnotationLayers(pdfID, userID uint, invite *Invite) ([]*AnnotationLayer, error)
+	ExportAnnotations(pdfID, userID uint, pdfIRI string) (*WebAnnotationCollection, error)
+	ImportAnnotations(pdfID, userID uint, invite *Invite, visibility string, data []byte) ([]*PDFAnnotation, error)
+	ExtractText(pdfID uint, userID uint) ([]*PDFPage, error)
+	GetPages(pdfID uint, userID uint, limit, offset int) ([]*PDFPage, error)
 	LikePDF(pdfID uint, userID uint) error
 	UnlikePDF(pdfID uint, userID uint) error
 }
 
-// PDFStorage, PDF dosyalarının saklanması ve alınması için bir arayüz tanımlar
+// StoredFile, depoya kaydedilen bir dosyanın konumu, boyutu ve içerik özetidir
+type StoredFile struct {
+	Path   string
+	Size   int64
+	SHA256 string // Onaltılık (hex) SHA-256 özeti
+}
+
+// PDFStorage, PDF dosyalarının saklanması ve alınması için bir arayüz tanımlar. Dosyalar içerik
+// adresli saklanır: aynı içerik kaç kez kaydedilirse kaydedilsin depoda tek kopyası bulunur ve
+// birden fazla PDF aynı dosya yolunu paylaşabilir.
 type PDFStorage interface {
-	Save(fileContent []byte, fileName string) (string, error)
-	Get(filePath string) ([]byte, error)
+	// Save, içeriği sonuna kadar okuyarak depoya akış halinde yazar; dosyanın tamamı belleğe alınmaz.
+	// Dosya yolu içeriğin SHA-256 özetinden türetilir; aynı içerik zaten saklanıyorsa mevcut dosya
+	// korunur ve onun yolu döner. Okuma hata verirse kısmen yazılmış dosya bırakılmaz.
+	Save(content io.Reader) (*StoredFile, error)
+	// Open, dosyayı okumak ve içinde konumlanmak için açar; kapatmak çağıranın sorumluluğundadır
+	Open(filePath string) (io.ReadSeekCloser, error)
+	// Delete, dosyayı siler; dosyaya başvuran başka PDF kalmadığından emin olmak çağıranın sorumluluğundadır
 	Delete(filePath string) error
 }
+
+// PDFURLSigner, dosyalar için kimlik doğrulaması gerektirmeyen süreli indirme bağlantısı üretebilen
+// depolar tarafından uygulanır (ör. S3 önceden imzalanmış bağlantıları)
+type PDFURLSigner interface {
+	PresignedURL(filePath, fileName string, expiry time.Duration) (string, error)
+}
+
+// PDFContent, bir PDF'in dosyasına erişim bilgisidir: dosya ya sunucu üzerinden okunmak için
+// açılmıştır (File, çağıran kapatmalıdır) ya da istemci depodan süreli bir bağlantıyla indirir (URL)
+type PDFContent struct {
+	PDF  *PDF
+	File io.ReadSeekCloser
+	URL  string
+}
+
+// PDFInspection, bir PDF dosyasının yükleme sırasında okunan yapısal bilgileridir
+type PDFInspection struct {
+	Version       string // Başlıktaki veya katalogdaki PDF sürümü (ör. "1.7")
+	PageCount     int
+	ActiveContent []string // Bulunan etkin içerik türleri (PDFActive*); yoksa boş
+}
+
+// PDFInspector, yüklenen dosyaların gerçekten PDF olduğunu doğrular ve etkin içerik arar
+type PDFInspector interface {
+	// Inspect, başlığı, çapraz başvuru tablosunu (xref) ve trailer'ı ayrıştırır. Dosya PDF olarak
+	// okunamazsa ErrPDFMalformed, şifrelenmişse ErrPDFEncrypted ile sarmalanmış hata döner.
+	Inspect(file io.ReaderAt, size int64) (*PDFInspection, error)
+}
+
+// PDFImage, bir PDF'in kapak veya sayfa önizleme görselidir. File okunmak üzere açılmıştır ve
+// kapatmak çağıranın sorumluluğundadır.
+type PDFImage struct {
+	PDF         *PDF
+	File        io.ReadSeekCloser
+	ContentType string
+	ETag        string // Görselin içeriğini tanımlayan, tırnaksız değer
+}
+
+// PDFRenderer, PDF sayfalarını görsele dönüştürür
+type PDFRenderer interface {
+	// RenderPages, firstPage ile lastPage (dahil, 1'den başlar) arasındaki sayfaları verilen piksel
+	// genişliğinde JPEG görsellerine dönüştürür ve sayfa sırasıyla döndürür
+	RenderPages(fileContent []byte, firstPage, lastPage, width int) ([][]byte, error)
+}
+
+// PDFImageStorage, PDF'lerden üretilen görsellerin saklanması için bir arayüz tanımlar. Anahtarlar
+// "/" ile ayrılmış göreli yollardır (ör. "<sha256>/cover.jpg").
+type PDFImageStorage interface {
+	// Save, görseli anahtarın altına yazar; mevcut görselin yerine geçer
+	Save(key string, content []byte) error
+	// Open, görseli okumak için açar; görsel yoksa ErrNotFound ile sarmalanmış hata döner
+	Open(key string) (io.ReadSeekCloser, error)
+	// DeleteAll, öneki "<prefix>/" olan tüm görselleri siler
+	DeleteAll(prefix string) error
+}
+
+// PDFTextExtractor, PDF dosyalarından sayfa sayfa metin çıkarır
+type PDFTextExtractor interface {
+	// ExtractPages, her sayfanın metnini sayfa sırasıyla döndürür; metni olmayan sayfalar boş dizedir
+	ExtractPages(fileContent []byte) ([]string, error)
+	// CountPages, metin çıkarmadan yalnızca sayfa sayısını döndürür
+	CountPages(fileContent []byte) (int, error)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"time"
+)
+
+// NoteRevision, bir notun belirli bir andaki değiştirilemez anlık görüntüsünü temsil eder
+type NoteRevision struct {
+	ID           uint      `json:"id"`
+	NoteID       uint      `json:"noteId"`
+	Number       int       `json:"number"` // Not içindeki sıra numarası (1'den başlar)
+	AuthorID     uint      `json:"authorId"`
+	Title        string    `json:"title"`
+	Content      string    `json:"content"`
+	Tags         []string  `json:"tags"`
+	RestoredFrom int       `json:"restoredFrom,omitempty"` // Geri yüklenen revizyonun numarası
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// DiffLine, iki metin arasındaki satır bazlı farkın bir satırını temsil eder
+type DiffLine struct {
+	Type    string `json:"type"` // "equal", "insert" veya "delete"
+	Content string `json:"content"`
+	OldLine int    `json:"oldLine,omitempty"`
+	NewLine int    `json:"newLine,omitempty"`
+}
+
+// RevisionDiff, bir notun iki revizyonu arasındaki farkı temsil eder
+type RevisionDiff struct {
+	NoteID      uint       `json:"noteId"`
+	From        int        `json:"from"`
+	To          int        `json:"to"`
+	OldTitle    string     `json:"oldTitle"`
+	NewTitle    string     `json:"newTitle"`
+	AddedTags   []string   `json:"addedTags"`
+	RemovedTags []string   `json:"removedTags"`
+	Additions   int        `json:"additions"`
+	Deletions   int        `json:"deletions"`
+	Lines       []DiffLine `json:"lines"`
+}
+
+// NoteRevisionRepository, not revizyonlarının saklanması ve alınması için bir arayüz tanımlar
+type NoteRevisionRepository interface {
+	// FindByNoteID, notun revizyonlarını en yeniden eskiye döndürür
+	FindByNoteID(noteID uint, limit, offset int) ([]*NoteRevision, error)
+	FindByNumber(noteID uint, number int) (*NoteRevision, error)
+	FindLatest(noteID uint) (*NoteRevision, error)
+	// Create, revizyonu kaydeder ve nota özgü bir sonraki sıra numarasını atar
+	Create(revision *NoteRevision) error
+}
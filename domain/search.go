@@ -0,0 +1,40 @@
+package domain
+
+// Tam metin arama dilleri (PostgreSQL metin arama yapılandırmaları)
+const (
+	SearchLanguageTurkish = "turkish"
+	SearchLanguageEnglish = "english"
+)
+
+// IsSearchLanguage, verilen değerin desteklenen bir arama dili olup olmadığını kontrol eder
+func IsSearchLanguage(language string) bool {
+	return language == SearchLanguageTurkish || language == SearchLanguageEnglish
+}
+
+// SearchTerm, arama sorgusundaki tek bir kelime veya tırnak içindeki bir ifadedir
+type SearchTerm struct {
+	Words   []string // Birden fazla kelime, kelimelerin art arda geçmesi gereken bir ifadedir
+	Prefix  bool     // Son kelime önek olarak eşleşir ("algo*")
+	Exclude bool     // Terimi içeren içerikler sonuçlardan çıkarılır ("-terim")
+}
+
+// SearchQuery, ayrıştırılmış bir tam metin arama sorgusudur
+type SearchQuery struct {
+	Terms    []SearchTerm
+	Language string
+	UserID   uint // 0 değilse kullanıcının özel içerikleri de aranır
+	Limit    int
+	Offset   int
+}
+
+// SearchHit, bir arama sonucudur. Headline ve Snippet HTML olarak güvenlidir;
+// eşleşen kelimeler <mark> etiketiyle işaretlenir.
+type SearchHit struct {
+	ContentType string  `json:"contentType"`
+	Rank        float64 `json:"rank"`
+	Headline    string  `json:"headline"`
+	Snippet     string  `json:"snippet"`
+	PageNumber  int     `json:"pageNumber,omitempty"` // PDF sonuçlarında eşleşmenin en güçlü olduğu sayfa
+	Note        *Note   `json:"note,omitempty"`
+	PDF         *PDF    `json:"pdf,omitempty"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+)
+
+// Kullanıcı paylaşım rolleri. Her rol bir öncekinin yetkilerini de içerir.
+const (
+	ShareRoleViewer    = "viewer"    // İçeriği görebilir
+	ShareRoleCommenter = "commenter" // İçeriği görebilir ve yorum yapabilir
+	ShareRoleEditor    = "editor"    // İçeriği görebilir, yorum yapabilir ve düzenleyebilir
+)
+
+// ContentShare, bir not veya PDF'in sahibinin içeriği belirli bir kullanıcıyla paylaşmasını temsil eder.
+// Bir içerik aynı kullanıcıyla yalnızca bir kez paylaşılabilir; rol sonradan değiştirilebilir.
+type ContentShare struct {
+	ID          uint      `json:"id"`
+	ContentID   uint      `json:"contentId"`
+	ContentType string    `json:"contentType"`        // "note" veya "pdf"
+	OwnerID     uint      `json:"ownerId"`            // İçeriği paylaşan sahibi
+	UserID      uint      `json:"userId"`             // İçeriğin paylaşıldığı kullanıcı
+	Username    string    `json:"username,omitempty"` // Saklanmaz; listelenirken doldurulur
+	Role        string    `json:"role"`               // "viewer", "commenter" veya "editor"
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// SharedContentResponse, kullanıcıyla paylaşılan içeriğin başlık ve sahip bilgileriyle zenginleştirilmiş halidir
+type SharedContentResponse struct {
+	ContentShare
+	Title         string `json:"title"`
+	Description   string `json:"description,omitempty"` // Sadece PDF'ler için
+	OwnerUsername string `json:"ownerUsername"`
+}
+
+// ShareRepository, kullanıcı paylaşımlarının saklanması ve alınması için bir arayüz tanımlar
+type ShareRepository interface {
+	FindByID(id uint) (*ContentShare, error)
+	// Find, içeriğin kullanıcıyla paylaşımını bulur; paylaşım yoksa nil döndürür
+	Find(contentID uint, contentType string, userID uint) (*ContentShare, error)
+	// FindByContent, içeriğin paylaşımlarını eskiden yeniye döndürür
+	FindByContent(contentID uint, contentType string, limit, offset int) ([]*ContentShare, error)
+	// FindByUserID, kullanıcıyla paylaşılan içerikleri son paylaşılandan başlayarak döndürür.
+	// contentType boşsa tüm türler döner.
+	FindByUserID(userID uint, contentType string, limit, offset int) ([]*ContentShare, error)
+	// Create, içerik kullanıcıyla zaten paylaşılmışsa ErrDuplicateEntry döndürür
+	Create(share *ContentShare) error
+	Update(share *ContentShare) error
+	Delete(id uint) error
+}
+
+// ShareService, kullanıcı paylaşımları ile ilgili iş mantığını içerir
+type ShareService interface {
+	ShareContent(contentID uint, contentType string, ownerID uint, recipient, role string) (*ContentShare, error)
+	GetShares(contentID uint, contentType string, ownerID uint, limit, offset int) ([]*ContentShare, error)
+	UpdateShare(id, ownerID uint, role string) (*ContentShare, error)
+	RevokeShare(id, userID uint) error
+	GetSharedWithMe(userID uint, contentType string, limit, offset int) ([]*SharedContentResponse, error)
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// W3C Web Annotation veri modeli (https://www.w3.org/TR/annotation-model/) sabitleri
+const (
+	WebAnnotationContext = "http://www.w3.org/ns/anno.jsonld"
+	// WebAnnotationMediaType, JSON-LD biçimindeki işaretlemeler için önerilen içerik türüdür
+	WebAnnotationMediaType = `application/ld+json; profile="http://www.w3.org/ns/anno.jsonld"`
+
+	// PDFFragmentSpec, "page=N" biçimindeki PDF sayfa parçalarını tanımlayan belirtimdir (RFC 3778)
+	PDFFragmentSpec = "http://tools.ietf.org/rfc/rfc3778"
+	// MediaFragmentSpec, "xywh=percent:x,y,w,h" biçimindeki alan parçalarını tanımlayan belirtimdir
+	MediaFragmentSpec = "http://www.w3.org/TR/media-frags/"
+)
+
+// WebAnnotation, W3C Web Annotation modelindeki tek bir işaretlemedir
+type WebAnnotation struct {
+	Context    string                         `json:"@context,omitempty"`
+	ID         string                         `json:"id,omitempty"`
+	Type       string                         `json:"type"`
+	Motivation string                         `json:"motivation,omitempty"` // highlighting, commenting, ...
+	Created    string                         `json:"created,omitempty"`    // RFC 3339
+	Modified   string                         `json:"modified,omitempty"`   // RFC 3339
+	Body       OneOrMany[WebAnnotationBody]   `json:"body,omitempty"`
+	Target     OneOrMany[WebAnnotationTarget] `json:"target"`
+	Stylesheet *WebAnnotationStylesheet       `json:"stylesheet,omitempty"`
+}
+
+// WebAnnotationBody, işaretlemenin gövdesidir; uygulama yalnızca metin gövdelerini (TextualBody) kullanır
+type WebAnnotationBody struct {
+	ID       string `json:"id,omitempty"` // Dış kaynak gövdeler için adres
+	Type     string `json:"type,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Format   string `json:"format,omitempty"`
+	Language string `json:"language,omitempty"`
+	Purpose  string `json:"purpose,omitempty"`
+}
+
+// UnmarshalJSON, gövdenin yalnızca dış kaynak adresi olarak verildiği kısa biçimi de kabul eder
+func (b *WebAnnotationBody) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*b = WebAnnotationBody{ID: id}
+		return nil
+	}
+	type body WebAnnotationBody
+	return json.Unmarshal(data, (*body)(b))
+}
+
+// WebAnnotationTarget, işaretlemenin hedef aldığı kaynak ve kaynak içindeki konumdur
+type WebAnnotationTarget struct {
+	Source     string                           `json:"source"`
+	Selector   OneOrMany[WebAnnotationSelector] `json:"selector,omitempty"`
+	StyleClass string                           `json:"styleClass,omitempty"`
+}
+
+// UnmarshalJSON, hedefin yalnızca kaynak adresi olarak verildiği kısa biçimi de kabul eder
+func (t *WebAnnotationTarget) UnmarshalJSON(data []byte) error {
+	var source string
+	if err := json.Unmarshal(data, &source); err == nil {
+		*t = WebAnnotationTarget{Source: source}
+		return nil
+	}
+	type target WebAnnotationTarget
+	return json.Unmarshal(data, (*target)(t))
+}
+
+// WebAnnotationSelector, kaynak içindeki konumu belirtir. FragmentSelector için ConformsTo ve Value,
+// TextQuoteSelector için Exact, Prefix ve Suffix kullanılır; RefinedBy seçimi daraltır.
+type WebAnnotationSelector struct {
+	Type       string                 `json:"type"`
+	ConformsTo string                 `json:"conformsTo,omitempty"`
+	Value      string                 `json:"value,omitempty"`
+	Exact      string                 `json:"exact,omitempty"`
+	Prefix     string                 `json:"prefix,omitempty"`
+	Suffix     string                 `json:"suffix,omitempty"`
+	RefinedBy  *WebAnnotationSelector `json:"refinedBy,omitempty"`
+}
+
+// WebAnnotationStylesheet, işaretlemenin görünümünü tanımlayan CSS stil sayfasıdır
+type WebAnnotationStylesheet struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// WebAnnotationPage, işaretlemelerin bir sayfasıdır
+type WebAnnotationPage struct {
+	Context    string          `json:"@context,omitempty"`
+	ID         string          `json:"id,omitempty"`
+	Type       string          `json:"type"`
+	StartIndex int             `json:"startIndex"`
+	Items      []WebAnnotation `json:"items"`
+}
+
+// WebAnnotationCollection, işaretlemeler koleksiyonudur; tüm işaretlemeler ilk sayfada döner
+type WebAnnotationCollection struct {
+	Context string             `json:"@context"`
+	ID      string             `json:"id,omitempty"`
+	Type    string             `json:"type"`
+	Label   string             `json:"label,omitempty"`
+	Total   int                `json:"total"`
+	First   *WebAnnotationPage `json:"first,omitempty"`
+}
+
+// OneOrMany, JSON-LD'de tek değer veya dizi olarak yazılabilen alanları okur. Her zaman dizi olarak yazılır.
+type OneOrMany[T any] []T
+
+// UnmarshalJSON, tek bir değeri veya değer dizisini kabul eder
+func (m *OneOrMany[T]) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		var values []T
+		if err := json.Unmarshal(trimmed, &values); err != nil {
+			return err
+		}
+		*m = values
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*m = OneOrMany[T]{value}
+	return nil
+}
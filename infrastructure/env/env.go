@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +16,8 @@ type Config struct {
 	ServerPort string
 
 	// Database
+	DBDriver   string // "postgres" veya "sqlite"
+	DBPath     string // SQLite veritabanı dosyası
 	DBHost     string
 	DBPort     string
 	DBUser     string
@@ -27,11 +30,53 @@ type Config struct {
 	JWTExpiryHour int
 
 	// Storage
-	PDFStoragePath string
+	PDFStorageDriver     string // "local" veya "s3"
+	PDFStoragePath       string // Yerel depolama dizini ("local" sürücüsü ve depo taşıma kaynağı)
+	PDFMaxUploadMB       int    // Yüklenebilecek en büyük PDF boyutu
+	PDFContentDelivery   string // "stream" (sunucu üzerinden) veya "presigned" (depodan süreli bağlantıyla)
+	PDFPresignExpiryMins int    // Süreli indirme bağlantılarının geçerlilik süresi
+	PDFActiveContent     string // "flag" (işaretle ve kabul et) veya "reject" (JavaScript vb. içeren PDF'leri reddet)
+
+	// PDF görselleri (kapak ve sayfa önizlemeleri)
+	PDFImageStoragePath  string // Yerel görsel dizini ("local" sürücüsü)
+	PDFRenderCommand     string // Sayfaları görselleştiren pdftoppm komutu; "none" ise görsel üretilmez
+	PDFRenderTimeoutSecs int    // Tek bir görselleştirme için izin verilen en uzun süre
+	PDFThumbnailWidth    int    // Kapak görselinin piksel genişliği
+	PDFPreviewWidth      int    // Sayfa önizlemelerinin piksel genişliği
+	PDFPreviewPages      int    // Önizlemesi üretilecek ilk sayfa sayısı (0: yalnızca kapak)
+
+	// S3 uyumlu nesne deposu ("s3" sürücüsü)
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	S3UseSSL      bool
+	S3Prefix      string
+	S3ImagePrefix string // PDF görsellerinin nesne anahtarı öneki
 
 	// Security
 	MaxLoginAttempts int
 	LoginWindowMins  int
+
+	// Live editing
+	LiveSaveIntervalSecs int // Canlı düzenlenen notların kaydedilme aralığı
+
+	// Markdown
+	NoteRenderCacheSize int // Bellekte tutulacak en fazla not HTML çıktısı sayısı (0: önbellek kapalı)
+
+	// Search
+	SearchLanguage string // Dil belirtilmeyen aramalarda kullanılan metin arama yapılandırması ("turkish" veya "english")
+
+	// Invites
+	InviteBaseURL string // QR kodlarına yazılan davet bağlantılarının ön yüz adresi ({InviteBaseURL}/invite/{token})
+
+	// Background jobs
+	JobWorkers     int // Arka plan işlerini çalıştıran worker sayısı
+	JobMaxAttempts int // Bir işin ölü olarak işaretlenmeden önceki en fazla deneme sayısı
+
+	// Admin
+	AdminUserIDs []uint // Yönetici endpoint'lerine erişebilen kullanıcılar
 }
 
 // LoadConfig, çevre değişkenlerinden yapılandırmayı yükler
@@ -62,6 +107,8 @@ func LoadConfig() (*Config, error) {
 		ServerPort: getEnv("SERVER_PORT", "8080"),
 
 		// Database
+		DBDriver:   getEnv("DB_DRIVER", "postgres"),
+		DBPath:     getEnv("DB_PATH", "./storage/uninotes.db"),
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "5432"),
 		DBUser:     getEnv("DB_USER", "postgres"),
@@ -74,11 +121,53 @@ func LoadConfig() (*Config, error) {
 		JWTExpiryHour: getEnvAsInt("JWT_EXPIRY_HOUR", 24),
 
 		// Storage
-		PDFStoragePath: getEnv("PDF_STORAGE_PATH", "./storage/pdfs"),
+		PDFStorageDriver:     getEnv("PDF_STORAGE_DRIVER", "local"),
+		PDFStoragePath:       getEnv("PDF_STORAGE_PATH", "./storage/pdfs"),
+		PDFMaxUploadMB:       getEnvAsInt("PDF_MAX_UPLOAD_MB", 50),
+		PDFContentDelivery:   getEnv("PDF_CONTENT_DELIVERY", "stream"),
+		PDFPresignExpiryMins: getEnvAsInt("PDF_PRESIGN_EXPIRY_MINS", 15),
+		PDFActiveContent:     getEnv("PDF_ACTIVE_CONTENT", "flag"),
+
+		// PDF görselleri
+		PDFImageStoragePath:  getEnv("PDF_IMAGE_STORAGE_PATH", "./storage/previews"),
+		PDFRenderCommand:     getEnv("PDF_RENDER_COMMAND", "pdftoppm"),
+		PDFRenderTimeoutSecs: getEnvAsInt("PDF_RENDER_TIMEOUT_SECS", 60),
+		PDFThumbnailWidth:    getEnvAsInt("PDF_THUMBNAIL_WIDTH", 320),
+		PDFPreviewWidth:      getEnvAsInt("PDF_PREVIEW_WIDTH", 1024),
+		PDFPreviewPages:      getEnvAsInt("PDF_PREVIEW_PAGES", 0),
+
+		// S3
+		S3Endpoint:    getEnv("S3_ENDPOINT", ""),
+		S3Region:      getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:      getEnv("S3_BUCKET", ""),
+		S3AccessKey:   getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:   getEnv("S3_SECRET_KEY", ""),
+		S3UseSSL:      getEnvAsBool("S3_USE_SSL", true),
+		S3Prefix:      getEnv("S3_PREFIX", "pdfs/"),
+		S3ImagePrefix: getEnv("S3_IMAGE_PREFIX", "previews/"),
 
 		// Security
 		MaxLoginAttempts: getEnvAsInt("MAX_LOGIN_ATTEMPTS", 5),
 		LoginWindowMins:  getEnvAsInt("LOGIN_WINDOW_MINS", 15),
+
+		// Live editing
+		LiveSaveIntervalSecs: getEnvAsInt("LIVE_SAVE_INTERVAL_SECS", 5),
+
+		// Markdown
+		NoteRenderCacheSize: getEnvAsInt("NOTE_RENDER_CACHE_SIZE", 1000),
+
+		// Search
+		SearchLanguage: getEnv("SEARCH_LANGUAGE", "turkish"),
+
+		// Invites
+		InviteBaseURL: getEnv("INVITE_BASE_URL", "http://localhost:3000"),
+
+		// Background jobs
+		JobWorkers:     getEnvAsInt("JOB_WORKERS", 2),
+		JobMaxAttempts: getEnvAsInt("JOB_MAX_ATTEMPTS", 5),
+
+		// Admin
+		AdminUserIDs: getEnvAsUintList("ADMIN_USER_IDS"),
 	}
 
 	return config, nil
@@ -122,3 +211,27 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsBool, çevre değişkenini bool olarak alır veya varsayılan değeri döndürür
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valStr := getEnv(key, "")
+	if valStr == "" {
+		return defaultValue
+	}
+
+	if val, err := strconv.ParseBool(valStr); err == nil {
+		return val
+	}
+	return defaultValue
+}
+
+// getEnvAsUintList, virgülle ayrılmış çevre değişkenini uint listesi olarak alır; geçersiz değerler atlanır
+func getEnvAsUintList(key string) []uint {
+	var values []uint
+	for _, part := range strings.Split(getEnv(key, ""), ",") {
+		if val, err := strconv.ParseUint(strings.TrimSpace(part), 10, 0); err == nil {
+			values = append(values, uint(val))
+		}
+	}
+	return values
+}
@@ -0,0 +1,656 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// CatalogHandler, üniversite, bölüm, ders ve dönem kataloğu ile içeriklerin ders bağlantılarını yönetir
+type CatalogHandler struct {
+	catalogService *usecase.CatalogService
+}
+
+// NewCatalogHandler, yeni bir CatalogHandler örneği oluşturur
+func NewCatalogHandler(catalogService *usecase.CatalogService) *CatalogHandler {
+	return &CatalogHandler{
+		catalogService: catalogService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *CatalogHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware, adminMiddleware func(http.Handler) http.Handler) {
+	// Üniversiteleri yalnızca yöneticiler oluşturup düzenleyebilir
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Use(adminMiddleware)
+		r.Post("/universities", h.CreateUniversity)
+		r.Put("/universities/{id}", h.UpdateUniversity)
+		r.Post("/universities/{id}/aliases", h.AddUniversityAlias)
+	})
+
+	// Kimlik doğrulama gerektiren rotalar
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Post("/universities/{id}/departments", h.CreateDepartment)
+		r.Post("/departments/{id}/courses", h.CreateCourse)
+		r.Post("/terms", h.CreateTerm)
+		r.Put("/notes/{id}/course", h.AttachNote)
+		r.Delete("/notes/{id}/course", h.DetachNote)
+		r.Put("/pdfs/{id}/course", h.AttachPDF)
+		r.Delete("/pdfs/{id}/course", h.DetachPDF)
+	})
+
+	// Kimlik doğrulama gerektirmeyen rotalar
+	r.Get("/universities", h.SearchUniversities)
+	r.Get("/universities/resolve", h.ResolveUniversity)
+	r.Get("/universities/{id}", h.GetUniversity)
+	r.Get("/universities/{id}/departments", h.GetDepartments)
+	r.Get("/departments/{id}/courses", h.GetCourses)
+	r.Get("/courses/{id}", h.GetCourse)
+	r.Get("/terms", h.GetTerms)
+	r.Get("/courses/{id}/notes", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetCourseNotes).ServeHTTP(w, r)
+	})
+	r.Get("/courses/{id}/pdfs", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetCoursePDFs).ServeHTTP(w, r)
+	})
+	r.Get("/notes/{id}/course", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetNoteCourse).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/{id}/course", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetPDFCourse).ServeHTTP(w, r)
+	})
+}
+
+// UniversityRequest, üniversite oluşturma ve güncelleme isteği
+type UniversityRequest struct {
+	Name      string   `json:"name"`
+	ShortName string   `json:"shortName"`
+	Aliases   []string `json:"aliases"`
+}
+
+// UniversityAliasRequest, üniversiteye diğer ad ekleme isteği
+type UniversityAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+// DepartmentRequest, bölüm oluşturma isteği
+type DepartmentRequest struct {
+	Name string `json:"name"`
+}
+
+// CourseRequest, ders oluşturma isteği
+type CourseRequest struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// TermRequest, dönem oluşturma isteği
+type TermRequest struct {
+	Year   int    `json:"year"`   // Akademik yılın başladığı yıl (2024-2025 için 2024)
+	Season string `json:"season"` // "fall", "spring" veya "summer"
+}
+
+// CourseContentRequest, bir içeriği derse bağlama isteği
+type CourseContentRequest struct {
+	CourseID uint   `json:"courseId"`
+	TermID   *uint  `json:"termId"` // Opsiyonel
+	Week     int    `json:"week"`   // Opsiyonel; 0 ise hafta belirtilmemiştir
+	Topic    string `json:"topic"`  // Opsiyonel
+}
+
+// writeCatalogError, katalog servisinden dönen hatayı uygun HTTP yanıtına dönüştürür
+func writeCatalogError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case err == usecase.ErrUniversityNotFound:
+		http.Error(w, "Üniversite bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrDepartmentNotFound:
+		http.Error(w, "Bölüm bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrCourseNotFound:
+		http.Error(w, "Ders bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrTermNotFound:
+		http.Error(w, "Dönem bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrCourseContentNotFound:
+		http.Error(w, "İçerik bir derse bağlı değil", http.StatusNotFound)
+	case err == usecase.ErrContentNotFound:
+		http.Error(w, "İçerik bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrNotAuthorized:
+		http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+	case err == usecase.ErrUniversityNameTaken:
+		http.Error(w, "Bu ad başka bir üniversiteye ait", http.StatusConflict)
+	case errors.Is(err, usecase.ErrInvalidCatalog):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, action+" sırasında hata: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// catalogID, URL'deki ID parametresini ayrıştırır
+func catalogID(w http.ResponseWriter, r *http.Request, label string) (uint, bool) {
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz "+label+" ID'si", http.StatusBadRequest)
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// createdStatus, find-or-create işlemlerinde kayıt yeni oluşturulduysa 201, mevcut kayıt döndüyse 200 döndürür
+func createdStatus(created bool) int {
+	if created {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+// CreateUniversity, yeni bir üniversite oluşturur
+func (h *CatalogHandler) CreateUniversity(w http.ResponseWriter, r *http.Request) {
+	var req UniversityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	university := &domain.University{
+		Name:      req.Name,
+		ShortName: req.ShortName,
+		Aliases:   req.Aliases,
+	}
+
+	// Üniversiteyi oluştur
+	if err := h.catalogService.CreateUniversity(university); err != nil {
+		writeCatalogError(w, err, "Üniversite oluşturma")
+		return
+	}
+
+	logger.Info("Üniversite oluşturuldu - UniversityID: %d, Name: %s", university.ID, university.Name)
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(university)
+}
+
+// UpdateUniversity, üniversitenin adını, kısa adını ve diğer adlarını günceller
+func (h *CatalogHandler) UpdateUniversity(w http.ResponseWriter, r *http.Request) {
+	universityID, ok := catalogID(w, r, "üniversite")
+	if !ok {
+		return
+	}
+
+	var req UniversityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	university := &domain.University{
+		ID:        universityID,
+		Name:      req.Name,
+		ShortName: req.ShortName,
+		Aliases:   req.Aliases,
+	}
+
+	// Üniversiteyi güncelle
+	if err := h.catalogService.UpdateUniversity(university); err != nil {
+		writeCatalogError(w, err, "Üniversite güncelleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(university)
+}
+
+// AddUniversityAlias, üniversiteye yeni bir diğer ad ekler
+func (h *CatalogHandler) AddUniversityAlias(w http.ResponseWriter, r *http.Request) {
+	universityID, ok := catalogID(w, r, "üniversite")
+	if !ok {
+		return
+	}
+
+	var req UniversityAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Diğer adı ekle
+	university, err := h.catalogService.AddUniversityAlias(universityID, req.Alias)
+	if err != nil {
+		writeCatalogError(w, err, "Diğer ad ekleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(university)
+}
+
+// SearchUniversities, üniversiteleri adlarına göre arar; q verilmezse tüm üniversiteler döner
+func (h *CatalogHandler) SearchUniversities(w http.ResponseWriter, r *http.Request) {
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	universities, err := h.catalogService.SearchUniversities(r.URL.Query().Get("q"), limit, offset)
+	if err != nil {
+		writeCatalogError(w, err, "Üniversiteleri getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(universities)
+}
+
+// ResolveUniversity, adı, kısa adı veya diğer adlarından biri verilen adla eşleşen üniversiteyi getirir
+func (h *CatalogHandler) ResolveUniversity(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "Üniversite adı gerekli", http.StatusBadRequest)
+		return
+	}
+
+	university, err := h.catalogService.ResolveUniversity(name)
+	if err != nil {
+		writeCatalogError(w, err, "Üniversite getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(university)
+}
+
+// GetUniversity, üniversiteyi getirir
+func (h *CatalogHandler) GetUniversity(w http.ResponseWriter, r *http.Request) {
+	universityID, ok := catalogID(w, r, "üniversite")
+	if !ok {
+		return
+	}
+
+	university, err := h.catalogService.GetUniversity(universityID)
+	if err != nil {
+		writeCatalogError(w, err, "Üniversite getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(university)
+}
+
+// CreateDepartment, üniversitede bölümü bulur veya yoksa oluşturur
+func (h *CatalogHandler) CreateDepartment(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	universityID, ok := catalogID(w, r, "üniversite")
+	if !ok {
+		return
+	}
+
+	var req DepartmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	department := &domain.Department{
+		UniversityID: universityID,
+		Name:         req.Name,
+	}
+
+	// Bölümü bul veya oluştur
+	created, err := h.catalogService.CreateDepartment(department)
+	if err != nil {
+		writeCatalogError(w, err, "Bölüm oluşturma")
+		return
+	}
+	if created {
+		logger.Info("Bölüm oluşturuldu - UserID: %d, DepartmentID: %d", userID, department.ID)
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(createdStatus(created))
+	json.NewEncoder(w).Encode(department)
+}
+
+// GetDepartments, üniversitenin bölümlerini getirir
+func (h *CatalogHandler) GetDepartments(w http.ResponseWriter, r *http.Request) {
+	universityID, ok := catalogID(w, r, "üniversite")
+	if !ok {
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	departments, err := h.catalogService.GetDepartments(universityID, limit, offset)
+	if err != nil {
+		writeCatalogError(w, err, "Bölümleri getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(departments)
+}
+
+// CreateCourse, bölümde dersi koduna göre bulur veya yoksa oluşturur
+func (h *CatalogHandler) CreateCourse(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	departmentID, ok := catalogID(w, r, "bölüm")
+	if !ok {
+		return
+	}
+
+	var req CourseRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	course := &domain.Course{
+		DepartmentID: departmentID,
+		Code:         req.Code,
+		Name:         req.Name,
+	}
+
+	// Dersi bul veya oluştur
+	created, err := h.catalogService.CreateCourse(course)
+	if err != nil {
+		writeCatalogError(w, err, "Ders oluşturma")
+		return
+	}
+	if created {
+		logger.Info("Ders oluşturuldu - UserID: %d, CourseID: %d, Code: %s", userID, course.ID, course.Code)
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(createdStatus(created))
+	json.NewEncoder(w).Encode(course)
+}
+
+// GetCourses, bölümün derslerini getirir
+func (h *CatalogHandler) GetCourses(w http.ResponseWriter, r *http.Request) {
+	departmentID, ok := catalogID(w, r, "bölüm")
+	if !ok {
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	courses, err := h.catalogService.GetCourses(departmentID, limit, offset)
+	if err != nil {
+		writeCatalogError(w, err, "Dersleri getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(courses)
+}
+
+// GetCourse, dersi bölümü ve üniversitesiyle birlikte getirir
+func (h *CatalogHandler) GetCourse(w http.ResponseWriter, r *http.Request) {
+	courseID, ok := catalogID(w, r, "ders")
+	if !ok {
+		return
+	}
+
+	course, err := h.catalogService.GetCourse(courseID)
+	if err != nil {
+		writeCatalogError(w, err, "Ders getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(course)
+}
+
+// CreateTerm, dönemi yılına ve türüne göre bulur veya yoksa oluşturur
+func (h *CatalogHandler) CreateTerm(w http.ResponseWriter, r *http.Request) {
+	var req TermRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	term := &domain.Term{
+		Year:   req.Year,
+		Season: req.Season,
+	}
+
+	// Dönemi bul veya oluştur
+	created, err := h.catalogService.CreateTerm(term)
+	if err != nil {
+		writeCatalogError(w, err, "Dönem oluşturma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(createdStatus(created))
+	json.NewEncoder(w).Encode(term)
+}
+
+// GetTerms, dönemleri en yeniden başlayarak getirir
+func (h *CatalogHandler) GetTerms(w http.ResponseWriter, r *http.Request) {
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	terms, err := h.catalogService.GetTerms(limit, offset)
+	if err != nil {
+		writeCatalogError(w, err, "Dönemleri getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(terms)
+}
+
+// AttachNote, kullanıcının notunu bir derse bağlar
+func (h *CatalogHandler) AttachNote(w http.ResponseWriter, r *http.Request) {
+	h.attachContent(w, r, "note", "not")
+}
+
+// AttachPDF, kullanıcının PDF'ini bir derse bağlar
+func (h *CatalogHandler) AttachPDF(w http.ResponseWriter, r *http.Request) {
+	h.attachContent(w, r, "pdf", "PDF")
+}
+
+// attachContent, içeriği derse bağlar; içerik zaten bir derse bağlıysa bağlantı değiştirilir
+func (h *CatalogHandler) attachContent(w http.ResponseWriter, r *http.Request, contentType, label string) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	contentID, ok := catalogID(w, r, label)
+	if !ok {
+		return
+	}
+
+	var req CourseContentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	content := &domain.CourseContent{
+		CourseID:    req.CourseID,
+		TermID:      req.TermID,
+		ContentID:   contentID,
+		ContentType: contentType,
+		Week:        req.Week,
+		Topic:       req.Topic,
+	}
+
+	// İçeriği derse bağla
+	detail, err := h.catalogService.AttachContent(content, userID)
+	if err != nil {
+		writeCatalogError(w, err, "Ders bağlantısı")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
+
+// DetachNote, notun ders bağlantısını kaldırır
+func (h *CatalogHandler) DetachNote(w http.ResponseWriter, r *http.Request) {
+	h.detachContent(w, r, "note", "not")
+}
+
+// DetachPDF, PDF'in ders bağlantısını kaldırır
+func (h *CatalogHandler) DetachPDF(w http.ResponseWriter, r *http.Request) {
+	h.detachContent(w, r, "pdf", "PDF")
+}
+
+// detachContent, içeriğin ders bağlantısını kaldırır; içerik silinmez
+func (h *CatalogHandler) detachContent(w http.ResponseWriter, r *http.Request, contentType, label string) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	contentID, ok := catalogID(w, r, label)
+	if !ok {
+		return
+	}
+
+	// Ders bağlantısını kaldır
+	if err := h.catalogService.DetachContent(contentID, contentType, userID); err != nil {
+		writeCatalogError(w, err, "Ders bağlantısını kaldırma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Ders bağlantısı başarıyla kaldırıldı",
+	})
+}
+
+// GetNoteCourse, notun bağlı olduğu dersi getirir
+func (h *CatalogHandler) GetNoteCourse(w http.ResponseWriter, r *http.Request) {
+	h.getContentCourse(w, r, "note", "not")
+}
+
+// GetPDFCourse, PDF'in bağlı olduğu dersi getirir
+func (h *CatalogHandler) GetPDFCourse(w http.ResponseWriter, r *http.Request) {
+	h.getContentCourse(w, r, "pdf", "PDF")
+}
+
+// getContentCourse, içeriğin ders bağlantısını ders, bölüm, üniversite ve dönem bilgileriyle getirir
+func (h *CatalogHandler) getContentCourse(w http.ResponseWriter, r *http.Request, contentType, label string) {
+	contentID, ok := catalogID(w, r, label)
+	if !ok {
+		return
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	detail, err := h.catalogService.GetContentCourse(contentID, contentType, userID)
+	if err != nil {
+		writeCatalogError(w, err, "Ders bağlantısı getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
+
+// courseContentFilter, URL'deki ders ID'sinden, dönem ve hafta parametrelerinden filtre oluşturur
+func courseContentFilter(w http.ResponseWriter, r *http.Request) (*domain.CourseContentFilter, bool) {
+	courseID, ok := catalogID(w, r, "ders")
+	if !ok {
+		return nil, false
+	}
+
+	filter := &domain.CourseContentFilter{CourseID: courseID}
+	if termIDStr := r.URL.Query().Get("termId"); termIDStr != "" {
+		termID, err := strconv.ParseUint(termIDStr, 10, 32)
+		if err != nil {
+			http.Error(w, "Geçersiz dönem ID'si", http.StatusBadRequest)
+			return nil, false
+		}
+		filter.TermID = uint(termID)
+	}
+	if weekStr := r.URL.Query().Get("week"); weekStr != "" {
+		week, err := strconv.Atoi(weekStr)
+		if err != nil {
+			http.Error(w, "Geçersiz hafta", http.StatusBadRequest)
+			return nil, false
+		}
+		filter.Week = week
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel); kullanıcının kendi özel içerikleri de listelenir
+	filter.ViewerID, _ = middleware.GetUserID(r)
+	filter.Limit, filter.Offset = getPaginationParams(r)
+	return filter, true
+}
+
+// GetCourseNotes, derse bağlı notları haftaya göre sıralı getirir (?termId=, ?week=)
+func (h *CatalogHandler) GetCourseNotes(w http.ResponseWriter, r *http.Request) {
+	filter, ok := courseContentFilter(w, r)
+	if !ok {
+		return
+	}
+
+	notes, err := h.catalogService.GetCourseNotes(filter)
+	if err != nil {
+		writeCatalogError(w, err, "Ders notlarını getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(notes)
+}
+
+// GetCoursePDFs, derse bağlı PDF'leri haftaya göre sıralı getirir (?termId=, ?week=)
+func (h *CatalogHandler) GetCoursePDFs(w http.ResponseWriter, r *http.Request) {
+	filter, ok := courseContentFilter(w, r)
+	if !ok {
+		return
+	}
+
+	pdfs, err := h.catalogService.GetCoursePDFs(filter)
+	if err != nil {
+		writeCatalogError(w, err, "Ders PDF'lerini getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(pdfs)
+}
@@ -0,0 +1,522 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// CollectionHandler, koleksiyon işlemlerini yönetir
+type CollectionHandler struct {
+	collectionService *usecase.CollectionService
+	inviteService     *usecase.InviteService
+}
+
+// NewCollectionHandler, yeni bir CollectionHandler örneği oluşturur
+func NewCollectionHandler(collectionService *usecase.CollectionService, inviteService *usecase.InviteService) *CollectionHandler {
+	return &CollectionHandler{
+		collectionService: collectionService,
+		inviteService:     inviteService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *CollectionHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// Kimlik doğrulama gerektiren rotalar
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Post("/collections", h.CreateCollection)
+		r.Get("/collections/my", h.GetUserCollections)
+		r.Put("/collections/{id}", h.UpdateCollection)
+		r.Delete("/collections/{id}", h.DeleteCollection)
+		r.Post("/collections/{id}/folders", h.CreateFolder)
+		r.Put("/collections/{id}/folders/{folderId}", h.RenameFolder)
+		r.Delete("/collections/{id}/folders/{folderId}", h.DeleteFolder)
+		r.Post("/collections/{id}/folders/{folderId}/move", h.MoveFolder)
+		r.Post("/collections/{id}/items", h.AddItem)
+		r.Delete("/collections/{id}/items/{itemId}", h.RemoveItem)
+		r.Post("/collections/{id}/items/{itemId}/move", h.MoveItem)
+	})
+
+	// Kimlik doğrulama gerektirmeyen rotalar
+	r.Get("/collections/{id}", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetCollection).ServeHTTP(w, r)
+	})
+}
+
+// CollectionRequest, koleksiyon oluşturma ve güncelleme isteği
+type CollectionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"isPublic"`
+}
+
+// FolderRequest, klasör oluşturma ve yeniden adlandırma isteği
+type FolderRequest struct {
+	Name     string `json:"name"`
+	ParentID *uint  `json:"parentId"` // Opsiyonel; verilmezse klasör kökte oluşturulur
+	Position *int   `json:"position"` // Opsiyonel; verilmezse klasör sona eklenir
+}
+
+// MoveFolderRequest, klasör taşıma isteği
+type MoveFolderRequest struct {
+	ParentID *uint `json:"parentId"` // nil veya 0 ise klasör köke taşınır
+	Position *int  `json:"position"` // Opsiyonel; verilmezse klasör sona eklenir
+}
+
+// CollectionItemRequest, koleksiyona içerik ekleme isteği
+type CollectionItemRequest struct {
+	ContentID uint   `json:"contentId"`
+	Type      string `json:"type"`     // "note" veya "pdf"
+	FolderID  *uint  `json:"folderId"` // Opsiyonel; verilmezse öğe köke eklenir
+	Position  *int   `json:"position"` // Opsiyonel; verilmezse öğe sona eklenir
+}
+
+// MoveItemRequest, koleksiyon öğesi taşıma isteği
+type MoveItemRequest struct {
+	FolderID *uint `json:"folderId"` // nil veya 0 ise öğe köke taşınır
+	Position *int  `json:"position"` // Opsiyonel; verilmezse öğe sona eklenir
+}
+
+// writeCollectionError, koleksiyon servisinden dönen hatayı uygun HTTP yanıtına dönüştürür
+func writeCollectionError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case err == usecase.ErrCollectionNotFound:
+		http.Error(w, "Koleksiyon bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrCollectionFolderNotFound:
+		http.Error(w, "Klasör bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrCollectionItemNotFound:
+		http.Error(w, "Koleksiyon öğesi bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrContentNotFound:
+		http.Error(w, "İçerik bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrNotAuthorized:
+		http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+	case err == usecase.ErrCollectionItemExists:
+		http.Error(w, "İçerik koleksiyonda zaten var", http.StatusConflict)
+	case err == usecase.ErrInvalidType:
+		http.Error(w, "Geçersiz içerik türü", http.StatusBadRequest)
+	case errors.Is(err, usecase.ErrInvalidCollection):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, action+" sırasında hata: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// collectionParams, URL'deki koleksiyon ID'sini ve verilen alt kaynak ID'sini ayrıştırır
+func collectionParams(w http.ResponseWriter, r *http.Request, param, label string) (uint, uint, bool) {
+	collectionID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz koleksiyon ID'si", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	if param == "" {
+		return uint(collectionID), 0, true
+	}
+	id, err := strconv.ParseUint(chi.URLParam(r, param), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz "+label+" ID'si", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return uint(collectionID), uint(id), true
+}
+
+// CreateCollection, yeni bir koleksiyon oluşturur
+func (h *CollectionHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	var req CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	collection := &domain.Collection{
+		UserID:      userID,
+		Name:        req.Name,
+		Description: req.Description,
+		IsPublic:    req.IsPublic,
+	}
+
+	// Koleksiyonu oluştur
+	if err := h.collectionService.CreateCollection(collection); err != nil {
+		writeCollectionError(w, err, "Koleksiyon oluşturma")
+		return
+	}
+
+	logger.Info("Koleksiyon oluşturuldu - UserID: %d, CollectionID: %d", userID, collection.ID)
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(collection)
+}
+
+// GetUserCollections, kullanıcının koleksiyonlarını getirir
+func (h *CollectionHandler) GetUserCollections(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Koleksiyonları getir
+	collections, err := h.collectionService.GetUserCollections(userID, limit, offset)
+	if err != nil {
+		http.Error(w, "Koleksiyonları getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(collections)
+}
+
+// GetCollection, koleksiyonu klasörleri ve öğeleriyle birlikte getirir. Herkese açık olmayan
+// koleksiyonlar sahibi veya geçerli bir davet bağlantısı (?invite= veya X-Invite-Token) ile görüntülenebilir.
+func (h *CollectionHandler) GetCollection(w http.ResponseWriter, r *http.Request) {
+	collectionID, _, ok := collectionParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Koleksiyonu getir
+	detail, err := h.collectionService.GetCollection(collectionID, userID, invite)
+	if err != nil {
+		writeCollectionError(w, err, "Koleksiyon getirme")
+		return
+	}
+
+	// Davet bağlantısıyla erişildiyse kullanımı kaydet ve koleksiyon sahibine bildir
+	if invite != nil && invite.Type == "collection" && invite.ContentID == collectionID && detail.Collection.UserID != userID {
+		if !redeemInvite(w, r, h.inviteService, invite, userID) {
+			return
+		}
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
+
+// UpdateCollection, koleksiyonun adını, açıklamasını ve görünürlüğünü günceller
+func (h *CollectionHandler) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, _, ok := collectionParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	var req CollectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	collection := &domain.Collection{
+		ID:          collectionID,
+		UserID:      userID,
+		Name:        req.Name,
+		Description: req.Description,
+		IsPublic:    req.IsPublic,
+	}
+
+	// Koleksiyonu güncelle
+	if err := h.collectionService.UpdateCollection(collection); err != nil {
+		writeCollectionError(w, err, "Koleksiyon güncelleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(collection)
+}
+
+// DeleteCollection, koleksiyonu siler; koleksiyondaki notlar ve PDF'ler silinmez
+func (h *CollectionHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, _, ok := collectionParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Koleksiyonu sil
+	if err := h.collectionService.DeleteCollection(collectionID, userID); err != nil {
+		writeCollectionError(w, err, "Koleksiyon silme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Koleksiyon başarıyla silindi",
+	})
+}
+
+// CreateFolder, koleksiyonda yeni bir klasör oluşturur
+func (h *CollectionHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, _, ok := collectionParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	var req FolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	folder := &domain.CollectionFolder{
+		CollectionID: collectionID,
+		ParentID:     req.ParentID,
+		Name:         req.Name,
+	}
+
+	// Klasörü oluştur
+	if err := h.collectionService.CreateFolder(folder, userID, req.Position); err != nil {
+		writeCollectionError(w, err, "Klasör oluşturma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(folder)
+}
+
+// RenameFolder, klasörün adını değiştirir
+func (h *CollectionHandler) RenameFolder(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, folderID, ok := collectionParams(w, r, "folderId", "klasör")
+	if !ok {
+		return
+	}
+
+	var req FolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Klasörü yeniden adlandır
+	folder, err := h.collectionService.RenameFolder(collectionID, folderID, userID, req.Name)
+	if err != nil {
+		writeCollectionError(w, err, "Klasör güncelleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(folder)
+}
+
+// MoveFolder, klasörü başka bir üst klasöre veya aynı üst klasörde başka bir konuma taşır ve
+// koleksiyonun güncel halini döndürür
+func (h *CollectionHandler) MoveFolder(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, folderID, ok := collectionParams(w, r, "folderId", "klasör")
+	if !ok {
+		return
+	}
+
+	var req MoveFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Klasörü taşı
+	if err := h.collectionService.MoveFolder(collectionID, folderID, userID, req.ParentID, req.Position); err != nil {
+		writeCollectionError(w, err, "Klasör taşıma")
+		return
+	}
+
+	h.writeDetail(w, collectionID, userID)
+}
+
+// DeleteFolder, klasörü alt klasörleri ve içindeki öğelerle birlikte siler
+func (h *CollectionHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, folderID, ok := collectionParams(w, r, "folderId", "klasör")
+	if !ok {
+		return
+	}
+
+	// Klasörü sil
+	if err := h.collectionService.DeleteFolder(collectionID, folderID, userID); err != nil {
+		writeCollectionError(w, err, "Klasör silme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Klasör başarıyla silindi",
+	})
+}
+
+// AddItem, koleksiyona bir not veya PDF ekler
+func (h *CollectionHandler) AddItem(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, _, ok := collectionParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	var req CollectionItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	item := &domain.CollectionItem{
+		CollectionID: collectionID,
+		FolderID:     req.FolderID,
+		ContentID:    req.ContentID,
+		ContentType:  req.Type,
+	}
+
+	// Öğeyi ekle
+	if err := h.collectionService.AddItem(item, userID, req.Position); err != nil {
+		writeCollectionError(w, err, "Koleksiyona ekleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(item)
+}
+
+// MoveItem, öğeyi başka bir klasöre veya aynı klasörde başka bir konuma taşır ve koleksiyonun
+// güncel halini döndürür
+func (h *CollectionHandler) MoveItem(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, itemID, ok := collectionParams(w, r, "itemId", "öğe")
+	if !ok {
+		return
+	}
+
+	var req MoveItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Öğeyi taşı
+	if err := h.collectionService.MoveItem(collectionID, itemID, userID, req.FolderID, req.Position); err != nil {
+		writeCollectionError(w, err, "Öğe taşıma")
+		return
+	}
+
+	h.writeDetail(w, collectionID, userID)
+}
+
+// RemoveItem, öğeyi koleksiyondan çıkarır; içeriğin kendisi silinmez
+func (h *CollectionHandler) RemoveItem(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	collectionID, itemID, ok := collectionParams(w, r, "itemId", "öğe")
+	if !ok {
+		return
+	}
+
+	// Öğeyi çıkar
+	if err := h.collectionService.RemoveItem(collectionID, itemID, userID); err != nil {
+		writeCollectionError(w, err, "Koleksiyondan çıkarma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Öğe koleksiyondan çıkarıldı",
+	})
+}
+
+// writeDetail, koleksiyonun güncel halini yanıt olarak yazar
+func (h *CollectionHandler) writeDetail(w http.ResponseWriter, collectionID, userID uint) {
+	detail, err := h.collectionService.GetCollection(collectionID, userID, nil)
+	if err != nil {
+		writeCollectionError(w, err, "Koleksiyon getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
@@ -0,0 +1,166 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/adapter/localfs"
+	"github.com/OmerFErdogan/uninote/adapter/memory"
+	"github.com/OmerFErdogan/uninote/adapter/qrcode"
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/handler"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// commentFixture, davet bağlantısıyla erişim testlerinde kullanılan handler'lar ve içeriklerdir
+type commentFixture struct {
+	notes     *handler.NoteHandler
+	pdfs      *handler.PDFHandler
+	invites   *handler.InviteHandler
+	inviteSvc *usecase.InviteService
+	pdfSvc    *usecase.PDFService
+	jobs      *usecase.JobService
+	pdfRepo   domain.PDFRepository
+	storage   *recordingStorage
+	note      *domain.Note
+	pdf       *domain.PDF
+	noteToken string
+	pdfToken  string
+}
+
+// newCommentFixture, bellek içi repository'lerle sahibi 1 numaralı kullanıcı olan özel bir not ve PDF,
+// bunlar için yorum yetkili davet bağlantıları ve 2 numaralı kullanıcının yorumlarını oluşturur
+func newCommentFixture(t *testing.T) *commentFixture {
+	t.Helper()
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	notes := memory.NewNoteRepository(store)
+	comments := memory.NewCommentRepository(store)
+	pdfs := memory.NewPDFRepository(store)
+	pdfComments := memory.NewPDFCommentRepository(store)
+	groups := memory.NewGroupRepository(store)
+	collections := memory.NewCollectionRepository(store)
+	for _, name := range []string{"sahip", "davetli"} {
+		must(t, users.Create(&domain.User{Username: name, Email: name + "@example.com", Password: "x"}))
+	}
+
+	access := usecase.NewContentAccess(groups, memory.NewShareRepository(store))
+	hub := usecase.NewEventHub(notes, pdfs, access)
+	notifications := usecase.NewNotificationService(memory.NewNotificationRepository(store), memory.NewNotificationPreferenceRepository(store), users, notes, pdfs, collections, hub)
+	noteService := usecase.NewNoteService(notes, comments, memory.NewNoteRevisionRepository(store), nil, 0, access, notifications, hub)
+	files, err := localfs.NewPDFStorage(t.TempDir())
+	must(t, err)
+	storage := &recordingStorage{PDFStorage: files}
+	jobs := usecase.NewJobService(memory.NewJobRepository(store), 1, 3)
+	pdfService := usecase.NewPDFService(pdfs, pdfComments, memory.NewPDFAnnotationRepository(store), memory.NewPDFPageRepository(store), users, storage, 0, 0, nil, nil, false, usecase.PDFPreviewOptions{}, access, jobs, notifications, hub)
+	views := usecase.NewViewService(memory.NewViewRepository(store), users, notes, pdfs, logger.NewLogger())
+	invites := usecase.NewInviteService(memory.NewInviteRepository(store), notes, pdfs, collections, groups, users, qrcode.NewEncoder(), "https://uninote.test", views, notifications)
+	likes := usecase.NewLikeService(memory.NewLikeRepository(store), notes, pdfs, notifications, hub)
+	commentService := usecase.NewCommentService(notes, comments, pdfs, pdfComments, users, access)
+	search := usecase.NewSearchService(notes, pdfs, domain.SearchLanguageTurkish)
+
+	f := &commentFixture{
+		notes:     handler.NewNoteHandler(noteService, likes, commentService, search, invites),
+		pdfs:      handler.NewPDFHandler(pdfService, likes, commentService, search, invites),
+		invites:   handler.NewInviteHandler(invites, noteService, pdfService, nil, nil),
+		inviteSvc: invites,
+		pdfSvc:    pdfService,
+		jobs:      jobs,
+		pdfRepo:   pdfs,
+		storage:   storage,
+		note:      &domain.Note{Title: "Özel not", Content: "içerik", UserID: 1},
+		pdf:       &domain.PDF{Title: "Özel PDF", UserID: 1},
+	}
+	must(t, notes.Create(f.note))
+	stored, err := files.Save(strings.NewReader("%PDF-1.4\n%%EOF\n"))
+	must(t, err)
+	f.pdf.FilePath, f.pdf.FileSize, f.pdf.ContentHash = stored.Path, stored.Size, stored.SHA256
+	must(t, pdfs.Create(f.pdf))
+
+	noteInvite := &domain.Invite{ContentID: f.note.ID, Type: "note", CreatedBy: 1, Permission: domain.InvitePermissionComment}
+	must(t, invites.CreateInvite(noteInvite, ""))
+	pdfInvite := &domain.Invite{ContentID: f.pdf.ID, Type: "pdf", CreatedBy: 1, Permission: domain.InvitePermissionComment}
+	must(t, invites.CreateInvite(pdfInvite, ""))
+	f.noteToken, f.pdfToken = noteInvite.Token, pdfInvite.Token
+
+	must(t, noteService.AddComment(&domain.Comment{NoteID: f.note.ID, UserID: 2, Content: "not yorumu"}, noteInvite))
+	must(t, pdfService.AddComment(&domain.PDFComment{PDFID: f.pdf.ID, UserID: 2, Content: "PDF yorumu"}, pdfInvite))
+	return f
+}
+
+// serve, isteği verilen desenle yönlendirilen handler'a iletir. userID 0 değilse istek o kullanıcı
+// adına kimliği doğrulanmış sayılır.
+func serve(h http.HandlerFunc, pattern, target string, userID uint) *httptest.ResponseRecorder {
+	return serveRequest(h, pattern, httptest.NewRequest(http.MethodGet, target, nil), userID)
+}
+
+// serveRequest, serve gibidir; başlık veya çerez eklenmiş hazır bir isteği iletir
+func serveRequest(h http.HandlerFunc, pattern string, req *http.Request, userID uint) *httptest.ResponseRecorder {
+	router := chi.NewRouter()
+	router.Get(pattern, h)
+
+	if userID != 0 {
+		req = req.WithContext(context.WithValue(req.Context(), "userID", userID))
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetCommentsWithInvite(t *testing.T) {
+	f := newCommentFixture(t)
+	noteURL := "/notes/" + strconv.Itoa(int(f.note.ID)) + "/comments"
+	pdfURL := "/pdfs/" + strconv.Itoa(int(f.pdf.ID)) + "/comments"
+
+	tests := []struct {
+		name     string
+		handler  http.HandlerFunc
+		pattern  string
+		target   string
+		userID   uint
+		status   int
+		contains string
+	}{
+		{"not, davetsiz", f.notes.GetComments, "/notes/{id}/comments", noteURL, 2, http.StatusForbidden, ""},
+		{"not, davetle", f.notes.GetComments, "/notes/{id}/comments", noteURL + "?invite=" + f.noteToken, 2, http.StatusOK, "not yorumu"},
+		{"not, giriş yapmadan davetle", f.notes.GetComments, "/notes/{id}/comments", noteURL + "?invite=" + f.noteToken, 0, http.StatusOK, "not yorumu"},
+		{"not, başka içeriğin davetiyle", f.notes.GetComments, "/notes/{id}/comments", noteURL + "?invite=" + f.pdfToken, 2, http.StatusForbidden, ""},
+		{"not, geçersiz davetle", f.notes.GetComments, "/notes/{id}/comments", noteURL + "?invite=yok", 2, http.StatusForbidden, ""},
+		{"PDF, davetsiz", f.pdfs.GetComments, "/pdfs/{id}/comments", pdfURL, 2, http.StatusForbidden, ""},
+		{"PDF, davetle", f.pdfs.GetComments, "/pdfs/{id}/comments", pdfURL + "?invite=" + f.pdfToken, 2, http.StatusOK, "PDF yorumu"},
+		{"PDF, başka içeriğin davetiyle", f.pdfs.GetComments, "/pdfs/{id}/comments", pdfURL + "?invite=" + f.noteToken, 2, http.StatusForbidden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.handler, tt.pattern, tt.target, tt.userID)
+			if rec.Code != tt.status {
+				t.Fatalf("durum kodu %d beklenirken %d döndü: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.contains == "" {
+				return
+			}
+
+			var comments []map[string]interface{}
+			if err := json.Unmarshal(rec.Body.Bytes(), &comments); err != nil {
+				t.Fatalf("yanıt çözümlenemedi: %v", err)
+			}
+			if len(comments) != 1 || comments[0]["content"] != tt.contains {
+				t.Fatalf("beklenen yorum dönmedi: %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
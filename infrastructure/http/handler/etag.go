@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errInvalidIfMatch, If-Match başlığının çözümlenemediğini belirtir
+var errInvalidIfMatch = errors.New("geçersiz If-Match başlığı")
+
+// VersionConflictResponse, sürüm çakışmasında döndürülen yanıt
+type VersionConflictResponse struct {
+	Error   string      `json:"error"`
+	Current interface{} `json:"current"`
+}
+
+// versionETag, bir içerik sürümünü ETag değerine dönüştürür
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseIfMatch, If-Match başlığındaki sürümü döndürür.
+// Başlık yoksa veya "*" ise ok false döner; bu durumda sürüm kontrolü yapılmaz.
+func parseIfMatch(r *http.Request) (version int, ok bool, err error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, false, nil
+	}
+
+	// Zayıf ETag önekini kabul et: W/"3"
+	value = strings.TrimPrefix(value, "W/")
+	if len(value) < 2 || !strings.HasPrefix(value, `"`) || !strings.HasSuffix(value, `"`) {
+		return 0, false, errInvalidIfMatch
+	}
+
+	version, err = strconv.Atoi(value[1 : len(value)-1])
+	if err != nil || version <= 0 {
+		return 0, false, errInvalidIfMatch
+	}
+	return version, true, nil
+}
+
+// writeVersionConflict, sürüm çakışmasını içeriğin güncel haliyle birlikte yanıtlar.
+// İstemci If-Match kullandıysa 412, gövdede sürüm gönderdiyse 409 döner.
+func writeVersionConflict(w http.ResponseWriter, usedIfMatch bool, version int, current interface{}) {
+	status := http.StatusConflict
+	if usedIfMatch {
+		status = http.StatusPreconditionFailed
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", versionETag(version))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(VersionConflictResponse{
+		Error:   "İçerik siz düzenlerken başka bir istek tarafından değiştirildi",
+		Current: current,
+	})
+}
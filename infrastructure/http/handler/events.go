@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// eventHeartbeatInterval, bağlantının proxy'ler tarafından boşta sayılıp kapatılmaması için yorum satırı gönderme aralığı
+	eventHeartbeatInterval = 15 * time.Second
+	// eventDeadlineMargin, istek zaman aşımına bu kadar süre kala akış kapatılır; böylece Timeout
+	// middleware'i 504 yazmadan önce istemci Last-Event-ID ile yeniden bağlanır
+	eventDeadlineMargin = 5 * time.Second
+	// eventRetryMillis, bağlantı koptuğunda tarayıcının yeniden bağlanmadan önce bekleyeceği süre
+	eventRetryMillis = 3000
+)
+
+// EventHandler, Server-Sent Events ile anlık olay akışını yönetir
+type EventHandler struct {
+	eventHub    *usecase.EventHub
+	authService *usecase.AuthService
+}
+
+// NewEventHandler, yeni bir EventHandler örneği oluşturur
+func NewEventHandler(eventHub *usecase.EventHub, authService *usecase.AuthService) *EventHandler {
+	return &EventHandler{
+		eventHub:    eventHub,
+		authService: authService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *EventHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// EventSource başlık gönderemediği için kimlik doğrulama handler içinde yapılır
+	r.Get("/events", h.Stream)
+}
+
+// Stream, kullanıcının bildirimlerini ve takip ettiği içeriklerin olaylarını SSE olarak iletir
+func (h *EventHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcıyı JWT ile doğrula (Authorization başlığı veya ?token=)
+	token := requestAuthToken(r)
+	if token == "" {
+		http.Error(w, "Yetkilendirme gerekli", http.StatusUnauthorized)
+		return
+	}
+	userID, err := h.authService.ValidateToken(token)
+	if err != nil {
+		http.Error(w, "Geçersiz token", http.StatusUnauthorized)
+		return
+	}
+
+	// Takip edilecek içerikleri al (?topics=note:1,pdf:2)
+	var topics []string
+	for _, topic := range strings.Split(r.URL.Query().Get("topics"), ",") {
+		if topic = strings.TrimSpace(topic); topic != "" {
+			topics = append(topics, topic)
+		}
+	}
+
+	// Yeniden bağlanan istemcinin son aldığı olay (EventSource bunu başlıkla gönderir)
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	sub, err := h.eventHub.Subscribe(userID, topics, lastEventID)
+	if err != nil {
+		switch err {
+		case usecase.ErrInvalidTopic:
+			http.Error(w, "Geçersiz olay konusu", http.StatusBadRequest)
+		case usecase.ErrContentNotFound:
+			http.Error(w, "İçerik bulunamadı", http.StatusNotFound)
+		case usecase.ErrNotAuthorized:
+			http.Error(w, "Bu içeriği takip etme yetkiniz yok", http.StatusForbidden)
+		case usecase.ErrEventHubClosed:
+			http.Error(w, "Sunucu kapanıyor", http.StatusServiceUnavailable)
+		default:
+			http.Error(w, "Olay akışına abone olma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	defer h.eventHub.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	fmt.Fprintf(w, "retry: %d\n\n", eventRetryMillis)
+	if err := rc.Flush(); err != nil {
+		logger.Error("Olay akışı gönderilemedi: %v", err)
+		return
+	}
+
+	// Timeout middleware'inin süresi dolmadan akışı kapat; istemci kaldığı yerden devam eder
+	ctx := r.Context()
+	var deadline <-chan time.Time
+	if d, ok := ctx.Deadline(); ok {
+		timer := time.NewTimer(time.Until(d) - eventDeadlineMargin)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-sub.Events():
+			if !ok {
+				// Sunucu kapanıyor veya istemci olayları yeterince hızlı okuyamadı
+				return
+			}
+			if err := writeEvent(w, event); err != nil {
+				logger.Error("Olay gönderilemedi: %v", err)
+				return
+			}
+		}
+
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// writeEvent, bir olayı SSE biçiminde yazar
+func writeEvent(w http.ResponseWriter, event *domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
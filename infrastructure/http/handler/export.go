@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// ExportHandler, notların dosya olarak dışa aktarılması isteklerini işler
+type ExportHandler struct {
+	exportService *usecase.ExportService
+	inviteService *usecase.InviteService
+}
+
+// NewExportHandler, yeni bir ExportHandler örneği oluşturur
+func NewExportHandler(exportService *usecase.ExportService, inviteService *usecase.InviteService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		inviteService: inviteService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *ExportHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// Herkese açık notlar ve davet bağlantısıyla gelenler için kimlik doğrulama gerekmez
+	r.Get("/notes/{id}/export", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.ExportNote).ServeHTTP(w, r)
+	})
+}
+
+// ExportNote, notu ?format= ile istenen biçimde (pdf, md, html veya docx) indirir
+func (h *ExportHandler) ExportNote(w http.ResponseWriter, r *http.Request) {
+	// Not ID'sini al
+	idStr := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz not ID'si", http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = domain.ExportFormatPDF
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Davet bağlantısını doğrula (X-Invite-Token başlığı veya ?invite=)
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
+	// Notu dışa aktar
+	file, err := h.exportService.ExportNote(uint(noteID), userID, invite, format)
+	if err != nil {
+		switch err {
+		case usecase.ErrUnsupportedExportFormat:
+			http.Error(w, "Desteklenmeyen biçim; pdf, md, html veya docx kullanın", http.StatusBadRequest)
+		case usecase.ErrNoteNotFound:
+			http.Error(w, "Not bulunamadı", http.StatusNotFound)
+		case usecase.ErrNotAuthorized:
+			http.Error(w, "Bu nota erişim izniniz yok", http.StatusForbidden)
+		default:
+			http.Error(w, "Dışa aktarma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Davetle erişildiyse kullanımı kaydet ve içerik sahibine bildir
+	if invite != nil && invite.Type == "note" && invite.ContentID == uint(noteID) {
+		if !redeemInvite(w, r, h.inviteService, invite, userID) {
+			return
+		}
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(file.Content)))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Write(file.Content)
+}
@@ -0,0 +1,654 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// GroupHandler, çalışma grubu işlemlerini yönetir
+type GroupHandler struct {
+	groupService *usecase.GroupService
+}
+
+// NewGroupHandler, yeni bir GroupHandler örneği oluşturur
+func NewGroupHandler(groupService *usecase.GroupService) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *GroupHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// Tüm grup rotaları kimlik doğrulama gerektirir
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Post("/groups", h.CreateGroup)
+		r.Get("/groups/my", h.GetUserGroups)
+		r.Post("/groups/join/{token}", h.JoinByInvite)
+		r.Get("/groups/{id}", h.GetGroup)
+		r.Put("/groups/{id}", h.UpdateGroup)
+		r.Delete("/groups/{id}", h.DeleteGroup)
+		r.Get("/groups/{id}/members", h.GetMembers)
+		r.Put("/groups/{id}/members/{userId}", h.ChangeMemberRole)
+		r.Delete("/groups/{id}/members/{userId}", h.RemoveMember)
+		r.Post("/groups/{id}/leave", h.LeaveGroup)
+		r.Post("/groups/{id}/requests", h.RequestJoin)
+		r.Get("/groups/{id}/requests", h.GetJoinRequests)
+		r.Post("/groups/{id}/requests/{requestId}/approve", h.ApproveJoinRequest)
+		r.Post("/groups/{id}/requests/{requestId}/reject", h.RejectJoinRequest)
+		r.Post("/groups/{id}/contents", h.PostContent)
+		r.Get("/groups/{id}/contents", h.GetContents)
+		r.Delete("/groups/{id}/contents/{type}/{contentId}", h.RemoveContent)
+		r.Get("/groups/{id}/activities", h.GetActivities)
+	})
+}
+
+// GroupRequest, grup oluşturma ve güncelleme isteği
+type GroupRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GroupMemberRoleRequest, üye rolü değiştirme isteği
+type GroupMemberRoleRequest struct {
+	Role string `json:"role"` // "admin" veya "member"
+}
+
+// GroupJoinRequestBody, gruba katılma isteği
+type GroupJoinRequestBody struct {
+	Message string `json:"message"` // Opsiyonel
+}
+
+// GroupContentRequest, grup alanına içerik paylaşma isteği
+type GroupContentRequest struct {
+	ContentID uint   `json:"contentId"`
+	Type      string `json:"type"` // "note" veya "pdf"
+}
+
+// writeGroupError, grup servisinden dönen hatayı uygun HTTP yanıtına dönüştürür
+func writeGroupError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case err == usecase.ErrGroupNotFound:
+		http.Error(w, "Grup bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrGroupMemberNotFound:
+		http.Error(w, "Grup üyesi bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrGroupJoinRequestNotFound:
+		http.Error(w, "Üyelik isteği bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrGroupContentNotFound:
+		http.Error(w, "İçerik grupta bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrContentNotFound:
+		http.Error(w, "İçerik bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrInviteNotFound:
+		http.Error(w, "Davet bağlantısı bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrInviteExpired:
+		http.Error(w, "Davet bağlantısı süresi dolmuş", http.StatusForbidden)
+	case err == usecase.ErrInviteNotActive:
+		http.Error(w, "Davet bağlantısı aktif değil", http.StatusForbidden)
+	case err == usecase.ErrInviteExhausted:
+		http.Error(w, "Davet bağlantısının kullanım hakkı dolmuş", http.StatusForbidden)
+	case err == usecase.ErrInvitePasswordRequired:
+		http.Error(w, "Davet bağlantısı parola gerektiriyor", http.StatusForbidden)
+	case err == usecase.ErrInviteWrongPassword:
+		http.Error(w, "Davet bağlantısı parolası hatalı", http.StatusForbidden)
+	case err == usecase.ErrNotAuthorized:
+		http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+	case err == usecase.ErrGroupContentExists:
+		http.Error(w, "İçerik gruba zaten paylaşılmış", http.StatusConflict)
+	case err == usecase.ErrAlreadyGroupMember:
+		http.Error(w, "Grubun zaten üyesisiniz", http.StatusConflict)
+	case err == usecase.ErrGroupJoinRequestExists:
+		http.Error(w, "Bekleyen bir üyelik isteğiniz zaten var", http.StatusConflict)
+	case err == usecase.ErrInvalidType:
+		http.Error(w, "Geçersiz içerik türü", http.StatusBadRequest)
+	case errors.Is(err, usecase.ErrInvalidGroup):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, action+" sırasında hata: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// groupParams, URL'deki grup ID'sini ve verilen alt kaynak ID'sini ayrıştırır
+func groupParams(w http.ResponseWriter, r *http.Request, param, label string) (uint, uint, bool) {
+	groupID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz grup ID'si", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	if param == "" {
+		return uint(groupID), 0, true
+	}
+	id, err := strconv.ParseUint(chi.URLParam(r, param), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz "+label+" ID'si", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return uint(groupID), uint(id), true
+}
+
+// CreateGroup, yeni bir çalışma grubu oluşturur; oluşturan kullanıcı grubun sahibi olur
+func (h *GroupHandler) CreateGroup(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	var req GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	group := &domain.Group{
+		OwnerID:     userID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	// Grubu oluştur
+	if err := h.groupService.CreateGroup(group); err != nil {
+		writeGroupError(w, err, "Grup oluşturma")
+		return
+	}
+
+	logger.Info("Grup oluşturuldu - UserID: %d, GroupID: %d", userID, group.ID)
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(group)
+}
+
+// GetUserGroups, kullanıcının üyesi olduğu grupları getirir
+func (h *GroupHandler) GetUserGroups(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Grupları getir
+	groups, err := h.groupService.GetUserGroups(userID, limit, offset)
+	if err != nil {
+		http.Error(w, "Grupları getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(groups)
+}
+
+// GetGroup, grubu üye sayısı ve kullanıcının rolüyle birlikte getirir
+func (h *GroupHandler) GetGroup(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Grubu getir
+	detail, err := h.groupService.GetGroup(groupID, userID)
+	if err != nil {
+		writeGroupError(w, err, "Grup getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
+
+// UpdateGroup, grubun adını ve açıklamasını günceller
+func (h *GroupHandler) UpdateGroup(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	var req GroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	group := &domain.Group{
+		ID:          groupID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	// Grubu güncelle
+	if err := h.groupService.UpdateGroup(group, userID); err != nil {
+		writeGroupError(w, err, "Grup güncelleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(group)
+}
+
+// DeleteGroup, grubu siler; gruba paylaşılan notlar ve PDF'ler silinmez
+func (h *GroupHandler) DeleteGroup(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Grubu sil
+	if err := h.groupService.DeleteGroup(groupID, userID); err != nil {
+		writeGroupError(w, err, "Grup silme")
+		return
+	}
+
+	logger.Info("Grup silindi - UserID: %d, GroupID: %d", userID, groupID)
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Grup başarıyla silindi",
+	})
+}
+
+// GetMembers, grubun üyelerini getirir
+func (h *GroupHandler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Üyeleri getir
+	members, err := h.groupService.GetMembers(groupID, userID, limit, offset)
+	if err != nil {
+		writeGroupError(w, err, "Grup üyelerini getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(members)
+}
+
+// ChangeMemberRole, bir üyenin rolünü değiştirir
+func (h *GroupHandler) ChangeMemberRole(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, memberID, ok := groupParams(w, r, "userId", "kullanıcı")
+	if !ok {
+		return
+	}
+
+	var req GroupMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Rolü değiştir
+	member, err := h.groupService.ChangeMemberRole(groupID, memberID, userID, req.Role)
+	if err != nil {
+		writeGroupError(w, err, "Rol değiştirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(member)
+}
+
+// RemoveMember, bir üyeyi gruptan çıkarır
+func (h *GroupHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, memberID, ok := groupParams(w, r, "userId", "kullanıcı")
+	if !ok {
+		return
+	}
+
+	// Üyeyi çıkar
+	if err := h.groupService.RemoveMember(groupID, memberID, userID); err != nil {
+		writeGroupError(w, err, "Üye çıkarma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Üye gruptan çıkarıldı",
+	})
+}
+
+// LeaveGroup, kullanıcıyı gruptan çıkarır
+func (h *GroupHandler) LeaveGroup(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Gruptan ayrıl
+	if err := h.groupService.LeaveGroup(groupID, userID); err != nil {
+		writeGroupError(w, err, "Gruptan ayrılma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Gruptan ayrıldınız",
+	})
+}
+
+// RequestJoin, gruba katılma isteği gönderir
+func (h *GroupHandler) RequestJoin(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Mesaj opsiyonel olduğu için boş gövde kabul edilir
+	var req GroupJoinRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// İsteği gönder
+	request, err := h.groupService.RequestJoin(groupID, userID, req.Message)
+	if err != nil {
+		writeGroupError(w, err, "Üyelik isteği gönderme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(request)
+}
+
+// GetJoinRequests, grubun bekleyen üyelik isteklerini getirir
+func (h *GroupHandler) GetJoinRequests(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// İstekleri getir
+	requests, err := h.groupService.GetJoinRequests(groupID, userID, limit, offset)
+	if err != nil {
+		writeGroupError(w, err, "Üyelik isteklerini getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(requests)
+}
+
+// ApproveJoinRequest, bekleyen bir üyelik isteğini onaylar
+func (h *GroupHandler) ApproveJoinRequest(w http.ResponseWriter, r *http.Request) {
+	h.reviewJoinRequest(w, r, true)
+}
+
+// RejectJoinRequest, bekleyen bir üyelik isteğini reddeder
+func (h *GroupHandler) RejectJoinRequest(w http.ResponseWriter, r *http.Request) {
+	h.reviewJoinRequest(w, r, false)
+}
+
+// reviewJoinRequest, üyelik isteğini onaylar veya reddeder
+func (h *GroupHandler) reviewJoinRequest(w http.ResponseWriter, r *http.Request, approve bool) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, requestID, ok := groupParams(w, r, "requestId", "istek")
+	if !ok {
+		return
+	}
+
+	// İsteği sonuçlandır
+	request, err := h.groupService.ReviewJoinRequest(groupID, requestID, userID, approve)
+	if err != nil {
+		writeGroupError(w, err, "Üyelik isteğini sonuçlandırma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(request)
+}
+
+// JoinByInvite, grup davet bağlantısıyla kullanıcıyı gruba katar
+func (h *GroupHandler) JoinByInvite(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		http.Error(w, "Davet token'ı bulunamadı", http.StatusBadRequest)
+		return
+	}
+
+	// Gruba katıl; parola korumalı bağlantılar için parola X-Invite-Password başlığıyla gönderilir
+	detail, err := h.groupService.JoinByInvite(token, invitePassword(r), userID)
+	if err != nil {
+		if err == usecase.ErrInvalidType {
+			http.Error(w, "Bu davet bağlantısı bir grup için değil", http.StatusBadRequest)
+			return
+		}
+		writeGroupError(w, err, "Gruba katılma")
+		return
+	}
+
+	logger.Info("Davet bağlantısıyla gruba katılındı - UserID: %d, GroupID: %d", userID, detail.ID)
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(detail)
+}
+
+// PostContent, kullanıcının notunu veya PDF'ini grup alanına paylaşır
+func (h *GroupHandler) PostContent(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	var req GroupContentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	content := &domain.GroupContent{
+		GroupID:     groupID,
+		ContentID:   req.ContentID,
+		ContentType: req.Type,
+		UserID:      userID,
+	}
+
+	// İçeriği paylaş
+	if err := h.groupService.PostContent(content); err != nil {
+		writeGroupError(w, err, "Grup alanına paylaşma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(content)
+}
+
+// GetContents, grup alanındaki içerikleri getirir
+func (h *GroupHandler) GetContents(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// İçerikleri getir
+	contents, err := h.groupService.GetContents(groupID, userID, limit, offset)
+	if err != nil {
+		writeGroupError(w, err, "Grup içeriklerini getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(contents)
+}
+
+// RemoveContent, içeriği grup alanından kaldırır
+func (h *GroupHandler) RemoveContent(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, contentID, ok := groupParams(w, r, "contentId", "içerik")
+	if !ok {
+		return
+	}
+
+	// İçeriği kaldır
+	if err := h.groupService.RemoveContent(groupID, contentID, chi.URLParam(r, "type"), userID); err != nil {
+		writeGroupError(w, err, "Grup alanından kaldırma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "İçerik grup alanından kaldırıldı",
+	})
+}
+
+// GetActivities, grubun etkinlik akışını getirir
+func (h *GroupHandler) GetActivities(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	groupID, _, ok := groupParams(w, r, "", "")
+	if !ok {
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Etkinlikleri getir
+	activities, err := h.groupService.GetActivities(groupID, userID, limit, offset)
+	if err != nil {
+		writeGroupError(w, err, "Grup etkinliklerini getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(activities)
+}
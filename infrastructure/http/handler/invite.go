@@ -1,7 +1,10 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"time"
@@ -15,17 +18,21 @@ import (
 
 // InviteHandler, davet bağlantısı işlemlerini yönetir
 type InviteHandler struct {
-	inviteService *usecase.InviteService
-	noteService   *usecase.NoteService
-	pdfService    *usecase.PDFService
+	inviteService     *usecase.InviteService
+	noteService       *usecase.NoteService
+	pdfService        *usecase.PDFService
+	collectionService *usecase.CollectionService
+	groupService      *usecase.GroupService
 }
 
 // NewInviteHandler, yeni bir InviteHandler örneği oluşturur
-func NewInviteHandler(inviteService *usecase.InviteService, noteService *usecase.NoteService, pdfService *usecase.PDFService) *InviteHandler {
+func NewInviteHandler(inviteService *usecase.InviteService, noteService *usecase.NoteService, pdfService *usecase.PDFService, collectionService *usecase.CollectionService, groupService *usecase.GroupService) *InviteHandler {
 	return &InviteHandler{
-		inviteService: inviteService,
-		noteService:   noteService,
-		pdfService:    pdfService,
+		inviteService:     inviteService,
+		noteService:       noteService,
+		pdfService:        pdfService,
+		collectionService: collectionService,
+		groupService:      groupService,
 	}
 }
 
@@ -38,29 +45,81 @@ func (h *InviteHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.
 		r.Post("/pdfs/{id}/invites", h.CreatePDFInvite)
 		r.Get("/notes/{id}/invites", h.GetNoteInvites)
 		r.Get("/pdfs/{id}/invites", h.GetPDFInvites)
+		r.Post("/collections/{id}/invites", h.CreateCollectionInvite)
+		r.Get("/collections/{id}/invites", h.GetCollectionInvites)
+		r.Post("/groups/{id}/invites", h.CreateGroupInvite)
+		r.Get("/groups/{id}/invites", h.GetGroupInvites)
 		r.Delete("/invites/{id}", h.DeactivateInvite)
+		r.Get("/invites/{id}/redemptions", h.GetRedemptions)
+		r.Get("/invites/{id}/qr", h.GetInviteQRCode)
 	})
 
 	// Kimlik doğrulama gerektirmeyen rotalar
 	r.Get("/invites/{token}", h.ValidateInvite)
-	r.Get("/notes/invite/{token}", h.GetNoteByInvite)
-	r.Get("/pdfs/invite/{token}", h.GetPDFByInvite)
+	// Davet bağlantısını kullananı bildirimde gösterebilmek için kimlik bilgisi varsa okunur
+	r.Get("/notes/invite/{token}", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetNoteByInvite).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/invite/{token}", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetPDFByInvite).ServeHTTP(w, r)
+	})
 }
 
 // CreateInviteRequest, davet bağlantısı oluşturma isteği
 type CreateInviteRequest struct {
-	ExpiresAt *time.Time `json:"expiresAt,omitempty"` // Opsiyonel, belirtilmezse 7 gün sonra sona erer
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`  // Opsiyonel, belirtilmezse 7 gün sonra sona erer
+	MaxUses    int        `json:"maxUses,omitempty"`    // Opsiyonel, en fazla kullanım sayısı (0: sınırsız)
+	Password   string     `json:"password,omitempty"`   // Opsiyonel, bağlantıyı açmak için gereken parola
+	Permission string     `json:"permission,omitempty"` // Opsiyonel, "read" (varsayılan), "comment" veya "annotate"
+}
+
+// newInvite, isteğe göre kaydedilecek davet bağlantısını oluşturur
+func (req *CreateInviteRequest) newInvite(contentID uint, contentType string, userID uint) *domain.Invite {
+	invite := &domain.Invite{
+		ContentID:  contentID,
+		Type:       contentType,
+		CreatedBy:  userID,
+		Permission: req.Permission,
+		MaxUses:    req.MaxUses,
+	}
+
+	// Opsiyonel sona erme tarihi
+	if req.ExpiresAt != nil {
+		invite.ExpiresAt = *req.ExpiresAt
+	}
+	return invite
 }
 
 // InviteResponse, davet bağlantısı yanıtı
 type InviteResponse struct {
-	ID        uint      `json:"id"`
-	ContentID uint      `json:"contentId"`
-	Type      string    `json:"type"`
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expiresAt"`
-	IsActive  bool      `json:"isActive"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID          uint      `json:"id"`
+	ContentID   uint      `json:"contentId"`
+	Type        string    `json:"type"`
+	Token       string    `json:"token"`
+	Permission  string    `json:"permission"`
+	MaxUses     int       `json:"maxUses"`
+	UseCount    int       `json:"useCount"`
+	HasPassword bool      `json:"hasPassword"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	IsActive    bool      `json:"isActive"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+// newInviteResponse, davet bağlantısının yanıtını oluşturur
+func newInviteResponse(invite *domain.Invite) InviteResponse {
+	return InviteResponse{
+		ID:          invite.ID,
+		ContentID:   invite.ContentID,
+		Type:        invite.Type,
+		Token:       invite.Token,
+		Permission:  invite.Permission,
+		MaxUses:     invite.MaxUses,
+		UseCount:    invite.UseCount,
+		HasPassword: invite.HasPassword(),
+		ExpiresAt:   invite.ExpiresAt,
+		IsActive:    invite.IsActive,
+		CreatedAt:   invite.CreatedAt,
+	}
 }
 
 // CreateNoteInvite, bir not için davet bağlantısı oluşturur
@@ -87,19 +146,14 @@ func (h *InviteHandler) CreateNoteInvite(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Davet bağlantısı oluştur
-	invite := &domain.Invite{
-		ContentID: uint(noteID),
-		Type:      "note",
-		CreatedBy: userID,
-	}
-
-	// Opsiyonel sona erme tarihi
-	if req.ExpiresAt != nil {
-		invite.ExpiresAt = *req.ExpiresAt
-	}
+	invite := req.newInvite(uint(noteID), "note", userID)
 
 	// Daveti kaydet
-	if err := h.inviteService.CreateInvite(invite); err != nil {
+	if err := h.inviteService.CreateInvite(invite, req.Password); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInvite) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err == usecase.ErrContentNotFound {
 			http.Error(w, "Not bulunamadı", http.StatusNotFound)
 			return
@@ -117,15 +171,7 @@ func (h *InviteHandler) CreateNoteInvite(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Başarılı yanıt
-	response := InviteResponse{
-		ID:        invite.ID,
-		ContentID: invite.ContentID,
-		Type:      invite.Type,
-		Token:     invite.Token,
-		ExpiresAt: invite.ExpiresAt,
-		IsActive:  invite.IsActive,
-		CreatedAt: invite.CreatedAt,
-	}
+	response := newInviteResponse(invite)
 
 	logger.Info("Not için davet bağlantısı oluşturuldu - UserID: %d, NoteID: %d, Token: %s", userID, noteID, invite.Token)
 
@@ -157,19 +203,14 @@ func (h *InviteHandler) CreatePDFInvite(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Davet bağlantısı oluştur
-	invite := &domain.Invite{
-		ContentID: uint(pdfID),
-		Type:      "pdf",
-		CreatedBy: userID,
-	}
-
-	// Opsiyonel sona erme tarihi
-	if req.ExpiresAt != nil {
-		invite.ExpiresAt = *req.ExpiresAt
-	}
+	invite := req.newInvite(uint(pdfID), "pdf", userID)
 
 	// Daveti kaydet
-	if err := h.inviteService.CreateInvite(invite); err != nil {
+	if err := h.inviteService.CreateInvite(invite, req.Password); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInvite) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		if err == usecase.ErrContentNotFound {
 			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
 			return
@@ -187,15 +228,7 @@ func (h *InviteHandler) CreatePDFInvite(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Başarılı yanıt
-	response := InviteResponse{
-		ID:        invite.ID,
-		ContentID: invite.ContentID,
-		Type:      invite.Type,
-		Token:     invite.Token,
-		ExpiresAt: invite.ExpiresAt,
-		IsActive:  invite.IsActive,
-		CreatedAt: invite.CreatedAt,
-	}
+	response := newInviteResponse(invite)
 
 	logger.Info("PDF için davet bağlantısı oluşturuldu - UserID: %d, PDFID: %d, Token: %s", userID, pdfID, invite.Token)
 
@@ -203,6 +236,59 @@ func (h *InviteHandler) CreatePDFInvite(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(response)
 }
 
+// CreateCollectionInvite, bir koleksiyon için davet bağlantısı oluşturur
+func (h *InviteHandler) CreateCollectionInvite(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Koleksiyon ID'sini al
+	idStr := chi.URLParam(r, "id")
+	collectionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz koleksiyon ID'si", http.StatusBadRequest)
+		return
+	}
+
+	var req CreateInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Davet bağlantısı oluştur
+	invite := req.newInvite(uint(collectionID), "collection", userID)
+
+	// Daveti kaydet
+	if err := h.inviteService.CreateInvite(invite, req.Password); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInvite) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err == usecase.ErrContentNotFound {
+			http.Error(w, "Koleksiyon bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Davet bağlantısı oluşturma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	response := newInviteResponse(invite)
+
+	logger.Info("Koleksiyon için davet bağlantısı oluşturuldu - UserID: %d, CollectionID: %d, Token: %s", userID, collectionID, invite.Token)
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
 // GetNoteInvites, bir notun davet bağlantılarını getirir
 func (h *InviteHandler) GetNoteInvites(w http.ResponseWriter, r *http.Request) {
 	// Kullanıcı ID'sini al
@@ -254,15 +340,7 @@ func (h *InviteHandler) GetNoteInvites(w http.ResponseWriter, r *http.Request) {
 	// Yanıtı oluştur
 	responses := make([]InviteResponse, len(invites))
 	for i, invite := range invites {
-		responses[i] = InviteResponse{
-			ID:        invite.ID,
-			ContentID: invite.ContentID,
-			Type:      invite.Type,
-			Token:     invite.Token,
-			ExpiresAt: invite.ExpiresAt,
-			IsActive:  invite.IsActive,
-			CreatedAt: invite.CreatedAt,
-		}
+		responses[i] = newInviteResponse(invite)
 	}
 
 	// Başarılı yanıt
@@ -321,15 +399,167 @@ func (h *InviteHandler) GetPDFInvites(w http.ResponseWriter, r *http.Request) {
 	// Yanıtı oluştur
 	responses := make([]InviteResponse, len(invites))
 	for i, invite := range invites {
-		responses[i] = InviteResponse{
-			ID:        invite.ID,
-			ContentID: invite.ContentID,
-			Type:      invite.Type,
-			Token:     invite.Token,
-			ExpiresAt: invite.ExpiresAt,
-			IsActive:  invite.IsActive,
-			CreatedAt: invite.CreatedAt,
+		responses[i] = newInviteResponse(invite)
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(responses)
+}
+
+// GetCollectionInvites, bir koleksiyonun davet bağlantılarını getirir
+func (h *InviteHandler) GetCollectionInvites(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Koleksiyon ID'sini al
+	idStr := chi.URLParam(r, "id")
+	collectionID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz koleksiyon ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Koleksiyonun sahibi olup olmadığını kontrol et
+	if _, err := h.collectionService.GetOwnedCollection(uint(collectionID), userID); err != nil {
+		if err == usecase.ErrCollectionNotFound {
+			http.Error(w, "Koleksiyon bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
 		}
+		http.Error(w, "Koleksiyon getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Davet bağlantılarını getir
+	invites, err := h.inviteService.GetInvitesByContent(uint(collectionID), "collection")
+	if err != nil {
+		if err == usecase.ErrContentNotFound {
+			http.Error(w, "Koleksiyon bulunamadı", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Davet bağlantıları getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Yanıtı oluştur
+	responses := make([]InviteResponse, len(invites))
+	for i, invite := range invites {
+		responses[i] = newInviteResponse(invite)
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(responses)
+}
+
+// CreateGroupInvite, bir çalışma grubuna katılım için davet bağlantısı oluşturur. Grubun sahibi ve
+// yöneticileri oluşturabilir.
+func (h *InviteHandler) CreateGroupInvite(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Grup ID'sini al
+	idStr := chi.URLParam(r, "id")
+	groupID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz grup ID'si", http.StatusBadRequest)
+		return
+	}
+
+	var req CreateInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Davet bağlantısı oluştur
+	invite := req.newInvite(uint(groupID), "group", userID)
+
+	// Daveti kaydet
+	if err := h.inviteService.CreateInvite(invite, req.Password); err != nil {
+		if errors.Is(err, usecase.ErrInvalidInvite) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err == usecase.ErrContentNotFound {
+			http.Error(w, "Grup bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Davet bağlantısı oluşturma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	response := newInviteResponse(invite)
+
+	logger.Info("Grup için davet bağlantısı oluşturuldu - UserID: %d, GroupID: %d, Token: %s", userID, groupID, invite.Token)
+
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetGroupInvites, bir grubun davet bağlantılarını getirir. Grubun sahibi ve yöneticileri görebilir.
+func (h *InviteHandler) GetGroupInvites(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Grup ID'sini al
+	idStr := chi.URLParam(r, "id")
+	groupID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz grup ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Grubun sahibi veya yöneticisi olup olmadığını kontrol et
+	if _, err := h.groupService.GetManagedGroup(uint(groupID), userID); err != nil {
+		if err == usecase.ErrGroupNotFound {
+			http.Error(w, "Grup bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Grup getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Davet bağlantılarını getir
+	invites, err := h.inviteService.GetInvitesByContent(uint(groupID), "group")
+	if err != nil {
+		if err == usecase.ErrContentNotFound {
+			http.Error(w, "Grup bulunamadı", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Davet bağlantıları getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Yanıtı oluştur
+	responses := make([]InviteResponse, len(invites))
+	for i, invite := range invites {
+		responses[i] = newInviteResponse(invite)
 	}
 
 	// Başarılı yanıt
@@ -377,7 +607,101 @@ func (h *InviteHandler) DeactivateInvite(w http.ResponseWriter, r *http.Request)
 	})
 }
 
-// ValidateInvite, bir davet bağlantısının geçerli olup olmadığını kontrol eder
+// GetRedemptions, davet bağlantısının kullanım kayıtlarını getirir. Yalnızca daveti oluşturan kullanıcı görebilir.
+func (h *InviteHandler) GetRedemptions(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Davet ID'sini al
+	inviteID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz davet ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Kullanım kayıtlarını getir
+	redemptions, err := h.inviteService.GetRedemptions(uint(inviteID), userID, limit, offset)
+	if err != nil {
+		if err == usecase.ErrInviteNotFound {
+			http.Error(w, "Davet bağlantısı bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Davet kullanım kayıtlarını getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(redemptions)
+}
+
+// GetInviteQRCode, davet bağlantısının adresini içeren bir QR kodu PNG olarak döndürür. Yalnızca daveti
+// oluşturan kullanıcı alabilir.
+func (h *InviteHandler) GetInviteQRCode(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Davet ID'sini al
+	inviteID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz davet ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Opsiyonel görsel boyutu
+	size := 0
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil {
+			http.Error(w, "Geçersiz boyut", http.StatusBadRequest)
+			return
+		}
+	}
+
+	// QR kodu oluştur
+	image, err := h.inviteService.GetInviteQRCode(uint(inviteID), userID, size)
+	if err != nil {
+		if err == usecase.ErrInviteNotFound {
+			http.Error(w, "Davet bağlantısı bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidInvite) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "QR kod oluşturma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Content-Length", strconv.Itoa(len(image)))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
+
+// ValidateInvite, bir davet bağlantısının geçerli olup olmadığını kontrol eder. Parola gerekip
+// gerekmediği ve kalan kullanım hakkı da döner; bağlantı kullanılmış sayılmaz.
 func (h *InviteHandler) ValidateInvite(w http.ResponseWriter, r *http.Request) {
 	// Token'ı al - önce URL parametresinden dene
 	token := chi.URLParam(r, "token")
@@ -395,7 +719,8 @@ func (h *InviteHandler) ValidateInvite(w http.ResponseWriter, r *http.Request) {
 	// CORS header'larını ekle
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token, X-Invite-Password, X-Invite-Redemption")
+	w.Header().Set("Access-Control-Expose-Headers", "X-Invite-Redemption")
 
 	// OPTIONS isteği ise hemen yanıt ver
 	if r.Method == "OPTIONS" {
@@ -406,23 +731,7 @@ func (h *InviteHandler) ValidateInvite(w http.ResponseWriter, r *http.Request) {
 	// Daveti doğrula
 	valid, invite, err := h.inviteService.ValidateInvite(token)
 	if err != nil {
-		if err == usecase.ErrInviteNotFound {
-			http.Error(w, "Davet bağlantısı bulunamadı", http.StatusNotFound)
-			return
-		}
-		if err == usecase.ErrInviteNotActive {
-			http.Error(w, "Davet bağlantısı aktif değil", http.StatusForbidden)
-			return
-		}
-		if err == usecase.ErrInviteExpired {
-			http.Error(w, "Davet bağlantısı süresi dolmuş", http.StatusForbidden)
-			return
-		}
-		if err == usecase.ErrContentNotFound {
-			http.Error(w, "İçerik bulunamadı", http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Davet bağlantısı doğrulama sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		writeInviteError(w, err, "İçerik bulunamadı")
 		return
 	}
 
@@ -431,10 +740,14 @@ func (h *InviteHandler) ValidateInvite(w http.ResponseWriter, r *http.Request) {
 
 	// Başarılı yanıt
 	response := map[string]interface{}{
-		"valid":     valid,
-		"contentId": invite.ContentID,
-		"type":      invite.Type,
-		"expiresAt": invite.ExpiresAt,
+		"valid":       valid,
+		"contentId":   invite.ContentID,
+		"type":        invite.Type,
+		"permission":  invite.Permission,
+		"hasPassword": invite.HasPassword(),
+		"maxUses":     invite.MaxUses,
+		"useCount":    invite.UseCount,
+		"expiresAt":   invite.ExpiresAt,
 	}
 
 	w.WriteHeader(http.StatusOK)
@@ -459,7 +772,8 @@ func (h *InviteHandler) GetNoteByInvite(w http.ResponseWriter, r *http.Request)
 	// CORS header'larını ekle
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token, X-Invite-Password, X-Invite-Redemption")
+	w.Header().Set("Access-Control-Expose-Headers", "X-Invite-Redemption")
 
 	// OPTIONS isteği ise hemen yanıt ver
 	if r.Method == "OPTIONS" {
@@ -467,31 +781,11 @@ func (h *InviteHandler) GetNoteByInvite(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Daveti doğrula
-	valid, invite, err := h.inviteService.ValidateInvite(token)
+	// Daveti, parolayı ve kalan kullanım hakkını doğrula
+	userID, _ := middleware.GetUserID(r)
+	invite, err := h.inviteService.AuthorizeInvite(token, invitePassword(r), userID, inviteVisitorKey(r, token))
 	if err != nil {
-		if err == usecase.ErrInviteNotFound {
-			http.Error(w, "Davet bağlantısı bulunamadı", http.StatusNotFound)
-			return
-		}
-		if err == usecase.ErrInviteNotActive {
-			http.Error(w, "Davet bağlantısı aktif değil", http.StatusForbidden)
-			return
-		}
-		if err == usecase.ErrInviteExpired {
-			http.Error(w, "Davet bağlantısı süresi dolmuş", http.StatusForbidden)
-			return
-		}
-		if err == usecase.ErrContentNotFound {
-			http.Error(w, "Not bulunamadı", http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Davet bağlantısı doğrulama sırasında hata: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if !valid {
-		http.Error(w, "Geçersiz davet bağlantısı", http.StatusForbidden)
+		writeInviteError(w, err, "Not bulunamadı")
 		return
 	}
 
@@ -512,6 +806,16 @@ func (h *InviteHandler) GetNoteByInvite(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// İstendiyse içeriği HTML'e dönüştür
+	if !renderIfRequested(w, r, h.noteService, note) {
+		return
+	}
+
+	// Kullanımı kaydet ve içerik sahibine bildir
+	if !redeemInvite(w, r, h.inviteService, invite, userID) {
+		return
+	}
+
 	// Content-Type header'ını ayarla
 	w.Header().Set("Content-Type", "application/json")
 
@@ -539,7 +843,8 @@ func (h *InviteHandler) GetPDFByInvite(w http.ResponseWriter, r *http.Request) {
 	// CORS header'larını ekle
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token, X-Invite-Password, X-Invite-Redemption")
+	w.Header().Set("Access-Control-Expose-Headers", "X-Invite-Redemption")
 
 	// OPTIONS isteği ise hemen yanıt ver
 	if r.Method == "OPTIONS" {
@@ -547,31 +852,11 @@ func (h *InviteHandler) GetPDFByInvite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Daveti doğrula
-	valid, invite, err := h.inviteService.ValidateInvite(token)
+	// Daveti, parolayı ve kalan kullanım hakkını doğrula
+	userID, _ := middleware.GetUserID(r)
+	invite, err := h.inviteService.AuthorizeInvite(token, invitePassword(r), userID, inviteVisitorKey(r, token))
 	if err != nil {
-		if err == usecase.ErrInviteNotFound {
-			http.Error(w, "Davet bağlantısı bulunamadı", http.StatusNotFound)
-			return
-		}
-		if err == usecase.ErrInviteNotActive {
-			http.Error(w, "Davet bağlantısı aktif değil", http.StatusForbidden)
-			return
-		}
-		if err == usecase.ErrInviteExpired {
-			http.Error(w, "Davet bağlantısı süresi dolmuş", http.StatusForbidden)
-			return
-		}
-		if err == usecase.ErrContentNotFound {
-			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
-			return
-		}
-		http.Error(w, "Davet bağlantısı doğrulama sırasında hata: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if !valid {
-		http.Error(w, "Geçersiz davet bağlantısı", http.StatusForbidden)
+		writeInviteError(w, err, "PDF bulunamadı")
 		return
 	}
 
@@ -592,6 +877,11 @@ func (h *InviteHandler) GetPDFByInvite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Kullanımı kaydet ve içerik sahibine bildir
+	if !redeemInvite(w, r, h.inviteService, invite, userID) {
+		return
+	}
+
 	// Content-Type header'ını ayarla
 	w.Header().Set("Content-Type", "application/json")
 
@@ -600,3 +890,117 @@ func (h *InviteHandler) GetPDFByInvite(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(pdf)
 }
+
+// writeInviteError, davet bağlantısı doğrulama hatasını uygun HTTP yanıtına dönüştürür.
+// contentNotFound, davetin içeriği bulunamadığında döndürülecek mesajdır.
+func writeInviteError(w http.ResponseWriter, err error, contentNotFound string) {
+	switch err {
+	case usecase.ErrInviteNotFound:
+		http.Error(w, "Davet bağlantısı bulunamadı", http.StatusNotFound)
+	case usecase.ErrInviteNotActive:
+		http.Error(w, "Davet bağlantısı aktif değil", http.StatusForbidden)
+	case usecase.ErrInviteExpired:
+		http.Error(w, "Davet bağlantısı süresi dolmuş", http.StatusForbidden)
+	case usecase.ErrInviteExhausted:
+		http.Error(w, "Davet bağlantısının kullanım hakkı dolmuş", http.StatusForbidden)
+	case usecase.ErrInvitePasswordRequired:
+		http.Error(w, "Davet bağlantısı parola gerektiriyor", http.StatusForbidden)
+	case usecase.ErrInviteWrongPassword:
+		http.Error(w, "Davet bağlantısı parolası hatalı", http.StatusForbidden)
+	case usecase.ErrContentNotFound:
+		http.Error(w, contentNotFound, http.StatusNotFound)
+	default:
+		http.Error(w, "Davet bağlantısı doğrulama sırasında hata: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// invitePassword, parola korumalı davet bağlantısının parolasını X-Invite-Password başlığından okur.
+// Başlık ekleyemeyen istemciler (ör. WebSocket) için ?invitePassword= parametresi de kabul edilir.
+func invitePassword(r *http.Request) string {
+	if password := r.Header.Get("X-Invite-Password"); password != "" {
+		return password
+	}
+	return r.URL.Query().Get("invitePassword")
+}
+
+// requestInvite, isteğe eklenmiş davet bağlantısını (?invite= veya X-Invite-Token başlığı) doğrular.
+// Davet bağlantısı yoksa nil döner; geçersizse 403 yanıtı yazılır ve false döner. Bağlantı kullanılmış
+// sayılmaz.
+func requestInvite(w http.ResponseWriter, r *http.Request, inviteService *usecase.InviteService) (*domain.Invite, bool) {
+	userID, _ := middleware.GetUserID(r)
+	return requestInviteFor(w, r, inviteService, userID)
+}
+
+// requestInviteFor, requestInvite gibidir; kimliği başka yolla doğrulanan istekler için kullanıcıyı
+// parametre olarak alır (giriş yapmamış kullanıcılar için 0)
+func requestInviteFor(w http.ResponseWriter, r *http.Request, inviteService *usecase.InviteService, userID uint) (*domain.Invite, bool) {
+	token := r.URL.Query().Get("invite")
+	if token == "" {
+		token = r.Header.Get("X-Invite-Token")
+	}
+	if token == "" {
+		return nil, true
+	}
+
+	invite, err := inviteService.AuthorizeInvite(token, invitePassword(r), userID, inviteVisitorKey(r, token))
+	if err != nil {
+		switch err {
+		case usecase.ErrInviteExhausted, usecase.ErrInvitePasswordRequired, usecase.ErrInviteWrongPassword:
+			writeInviteError(w, err, "")
+		default:
+			http.Error(w, "Davet bağlantısı geçersiz veya süresi dolmuş", http.StatusForbidden)
+		}
+		return nil, false
+	}
+	return invite, true
+}
+
+// redeemInvite, davet bağlantısının kullanıldığını kaydeder. Kullanım hakkı dolmuşsa 403 yanıtı yazılır
+// ve false döner; bildirim veya görüntüleme kaydı hataları isteği engellemez, yalnızca loglanır. Giriş
+// yapmamış ziyaretçiye verilen ziyaretçi anahtarı X-Invite-Redemption başlığında ve çerezde döndürülür.
+func redeemInvite(w http.ResponseWriter, r *http.Request, inviteService *usecase.InviteService, invite *domain.Invite, userID uint) bool {
+	visitorKey, err := inviteService.RedeemInvite(invite, userID, inviteVisitorKey(r, invite.Token))
+	if err == usecase.ErrInviteExhausted {
+		writeInviteError(w, err, "")
+		return false
+	}
+	if err != nil {
+		logger.Error("Davet bağlantısı kullanımı kaydedilirken hata oluştu: %v", err)
+	}
+	if visitorKey != "" {
+		w.Header().Set("X-Invite-Redemption", visitorKey)
+		http.SetCookie(w, &http.Cookie{
+			Name:     inviteVisitorCookie(invite.Token),
+			Value:    visitorKey,
+			Path:     "/",
+			Expires:  invite.ExpiresAt,
+			HttpOnly: true,
+			Secure:   r.TLS != nil,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+	return true
+}
+
+// inviteVisitorKey, giriş yapmamış ziyaretçinin davet bağlantısını ilk kullanımında aldığı ziyaretçi
+// anahtarını X-Invite-Redemption başlığından, ?inviteRedemption= parametresinden veya çerezden okur.
+// Anahtarla gelen istekler yeni kullanım hakkı harcamaz.
+func inviteVisitorKey(r *http.Request, token string) string {
+	if key := r.Header.Get("X-Invite-Redemption"); key != "" {
+		return key
+	}
+	if key := r.URL.Query().Get("inviteRedemption"); key != "" {
+		return key
+	}
+	if cookie, err := r.Cookie(inviteVisitorCookie(token)); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// inviteVisitorCookie, davet bağlantısına ait ziyaretçi anahtarı çerezinin adını döndürür. Token çerez
+// adında açık kalmasın diye özeti kullanılır.
+func inviteVisitorCookie(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return "invite_" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,54 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+func TestAnonymousInviteRedeemedOncePerVisitor(t *testing.T) {
+	f := newCommentFixture(t)
+	invite := &domain.Invite{ContentID: f.note.ID, Type: "note", CreatedBy: 1, MaxUses: 1}
+	must(t, f.inviteSvc.CreateInvite(invite, ""))
+	entryURL := "/notes/invite/" + invite.Token
+	commentsURL := "/notes/" + strconv.Itoa(int(f.note.ID)) + "/comments?invite=" + invite.Token
+
+	// Son kullanım hakkını giriş yapmamış bir ziyaretçi harcar ve ziyaretçi anahtarı alır
+	rec := serve(f.invites.GetNoteByInvite, "/notes/invite/{token}", entryURL, 0)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ilk açılış reddedildi: %d %s", rec.Code, rec.Body.String())
+	}
+	key := rec.Header().Get("X-Invite-Redemption")
+	cookies := rec.Result().Cookies()
+	if key == "" || len(cookies) != 1 || cookies[0].Value != key || !cookies[0].HttpOnly {
+		t.Fatalf("ziyaretçi anahtarı başlıkta ve çerezde döndürülmedi: %q, %+v", key, cookies)
+	}
+
+	// Çerezle yeniden yükleme ve anahtarla yapılan sonraki istekler kabul edilir, hak harcamaz
+	reload := httptest.NewRequest(http.MethodGet, entryURL, nil)
+	reload.AddCookie(cookies[0])
+	if rec := serveRequest(f.invites.GetNoteByInvite, "/notes/invite/{token}", reload, 0); rec.Code != http.StatusOK {
+		t.Fatalf("çerezle yeniden yükleme reddedildi: %d %s", rec.Code, rec.Body.String())
+	}
+	followUp := httptest.NewRequest(http.MethodGet, commentsURL, nil)
+	followUp.Header.Set("X-Invite-Redemption", key)
+	if rec := serveRequest(f.notes.GetComments, "/notes/{id}/comments", followUp, 0); rec.Code != http.StatusOK {
+		t.Fatalf("anahtarla gelen sonraki istek reddedildi: %d %s", rec.Code, rec.Body.String())
+	}
+	stored, err := f.inviteSvc.GetInvite(invite.Token)
+	must(t, err)
+	if stored.UseCount != 1 {
+		t.Fatalf("ziyaretçinin tekrar gelişi kullanım hakkı harcamamalıydı: %d", stored.UseCount)
+	}
+
+	// Anahtarı olmayan veya geçersiz anahtarla gelen başka ziyaretçiler reddedilir
+	if rec := serve(f.invites.GetNoteByInvite, "/notes/invite/{token}", entryURL, 0); rec.Code != http.StatusForbidden {
+		t.Fatalf("kullanım hakkı dolmuş davet başka ziyaretçiye açıldı: %d", rec.Code)
+	}
+	if rec := serve(f.notes.GetComments, "/notes/{id}/comments", commentsURL+"&inviteRedemption=yok", 0); rec.Code != http.StatusForbidden {
+		t.Fatalf("geçersiz ziyaretçi anahtarı kabul edildi: %d", rec.Code)
+	}
+}
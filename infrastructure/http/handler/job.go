@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// JobHandler, yöneticiler için arka plan iş kuyruğu işlemlerini yönetir
+type JobHandler struct {
+	jobService *usecase.JobService
+}
+
+// NewJobHandler, yeni bir JobHandler örneği oluşturur
+func NewJobHandler(jobService *usecase.JobService) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *JobHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware, adminMiddleware func(http.Handler) http.Handler) {
+	// Tüm iş rotaları kimlik doğrulama ve yönetici yetkisi gerektirir
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Use(adminMiddleware)
+		r.Get("/admin/jobs", h.ListJobs)
+		r.Get("/admin/jobs/stats", h.GetStats)
+		r.Get("/admin/jobs/{id}", h.GetJob)
+		r.Post("/admin/jobs/{id}/retry", h.RetryJob)
+	})
+}
+
+// ListJobs, işleri durum ve türe göre filtreleyerek listeler
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	// Sayfalama ve filtre parametrelerini al
+	limit, offset := getPaginationParams(r)
+	status := r.URL.Query().Get("status")
+	jobType := r.URL.Query().Get("type")
+
+	jobs, err := h.jobService.ListJobs(status, jobType, limit, offset)
+	if err != nil {
+		if err == usecase.ErrInvalidParameters {
+			http.Error(w, "Geçersiz iş durumu", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "İşleri getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(jobs)
+}
+
+// GetStats, her durumdaki iş sayısını getirir
+func (h *JobHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.jobService.Stats()
+	if err != nil {
+		http.Error(w, "İş istatistiklerini getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(stats)
+}
+
+// GetJob, bir işin ayrıntılarını getirir
+func (h *JobHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	// İş ID'sini al
+	idStr := chi.URLParam(r, "id")
+	jobID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz iş ID'si", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobService.GetJob(uint(jobID))
+	if err != nil {
+		if err == usecase.ErrJobNotFound {
+			http.Error(w, "İş bulunamadı", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "İşi getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
+
+// RetryJob, ölü bir işi yeniden çalıştırılmak üzere kuyruğa alır
+func (h *JobHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	// İş ID'sini al
+	idStr := chi.URLParam(r, "id")
+	jobID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz iş ID'si", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.jobService.RetryJob(uint(jobID))
+	if err != nil {
+		switch err {
+		case usecase.ErrJobNotFound:
+			http.Error(w, "İş bulunamadı", http.StatusNotFound)
+		case usecase.ErrJobNotDead:
+			http.Error(w, "Yalnızca ölü işler yeniden denenebilir", http.StatusConflict)
+		default:
+			http.Error(w, "İşi yeniden deneme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(job)
+}
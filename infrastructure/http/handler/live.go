@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// liveWriteWait, bir mesajın yazılması için beklenecek en uzun süre
+	liveWriteWait = 10 * time.Second
+	// livePongWait, istemciden pong beklenecek en uzun süre
+	livePongWait = 60 * time.Second
+	// livePingPeriod, istemciye ping gönderme aralığı (livePongWait'ten kısa olmalıdır)
+	livePingPeriod = (livePongWait * 9) / 10
+	// liveMaxMessageSize, istemciden kabul edilen en büyük mesaj boyutu
+	liveMaxMessageSize = 1 << 20
+)
+
+// LiveHandler, notların gerçek zamanlı ortak düzenlemesi için WebSocket isteklerini işler
+type LiveHandler struct {
+	liveService   *usecase.LiveService
+	authService   *usecase.AuthService
+	inviteService *usecase.InviteService
+	upgrader      websocket.Upgrader
+}
+
+// NewLiveHandler, yeni bir LiveHandler örneği oluşturur
+func NewLiveHandler(liveService *usecase.LiveService, authService *usecase.AuthService, inviteService *usecase.InviteService) *LiveHandler {
+	return &LiveHandler{
+		liveService:   liveService,
+		authService:   authService,
+		inviteService: inviteService,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  4096,
+			WriteBufferSize: 4096,
+			// API tüm kaynaklara açık (CORS: *); kimlik doğrulama token ile yapılır
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *LiveHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// Tarayıcılar WebSocket isteklerinde başlık gönderemediği için token sorgu parametresiyle de
+	// kabul edilir; bu yüzden kimlik doğrulama handler içinde yapılır
+	r.Get("/notes/{id}/live", h.Live)
+}
+
+// Live, bir notun canlı düzenleme oturumuna WebSocket bağlantısı açar
+func (h *LiveHandler) Live(w http.ResponseWriter, r *http.Request) {
+	// Not ID'sini al
+	idStr := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz not ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Kullanıcıyı JWT ile doğrula (Authorization başlığı veya ?token=)
+	var userID uint
+	if token := requestAuthToken(r); token != "" {
+		userID, err = h.authService.ValidateToken(token)
+		if err != nil {
+			http.Error(w, "Geçersiz token", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	// Davet bağlantısını doğrula (X-Invite-Token başlığı veya ?invite=)
+	invite, ok := requestInviteFor(w, r, h.inviteService, userID)
+	if !ok {
+		return
+	}
+
+	if userID == 0 && invite == nil {
+		http.Error(w, "Kimlik doğrulama gerekli", http.StatusUnauthorized)
+		return
+	}
+
+	// Oturuma katıl
+	client, err := h.liveService.Join(uint(noteID), userID, invite)
+	if err != nil {
+		if err == usecase.ErrNoteNotFound {
+			http.Error(w, "Not bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu nota erişim izniniz yok", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Canlı oturuma katılma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Davetle katılındıysa kullanımı kaydet ve içerik sahibine bildir
+	invited := invite != nil && invite.Type == "note" && invite.ContentID == uint(noteID)
+	if invited && !redeemInvite(w, r, h.inviteService, invite, userID) {
+		h.liveService.Leave(client)
+		return
+	}
+
+	// Bağlantıyı WebSocket'e yükselt (hata yanıtını Upgrade kendisi yazar)
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.liveService.Leave(client)
+		return
+	}
+
+	go h.writeLoop(conn, client)
+	h.readLoop(conn, client)
+}
+
+// readLoop, istemciden gelen mesajları okuyup oturuma iletir; bağlantı kapanınca istemciyi oturumdan çıkarır
+func (h *LiveHandler) readLoop(conn *websocket.Conn, client *usecase.LiveClient) {
+	defer func() {
+		h.liveService.Leave(client)
+		conn.Close()
+	}()
+
+	conn.SetReadLimit(liveMaxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(livePongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(livePongWait))
+	})
+
+	for {
+		var msg domain.LiveMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
+				logger.Error("Canlı düzenleme bağlantısı beklenmedik şekilde kapandı: %v", err)
+			}
+			return
+		}
+		h.liveService.Receive(client, msg)
+	}
+}
+
+// writeLoop, oturumdan gelen mesajları istemciye yazar ve bağlantıyı ping ile canlı tutar
+func (h *LiveHandler) writeLoop(conn *websocket.Conn, client *usecase.LiveClient) {
+	ticker := time.NewTicker(livePingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-client.Messages():
+			conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if !ok {
+				// İstemci oturumdan çıkarıldı
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(liveWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// requestAuthToken, JWT'yi Authorization başlığından veya token sorgu parametresinden alır.
+// Tarayıcılar WebSocket ve EventSource bağlantılarında başlık gönderemediği için sorgu parametresi de kabul edilir.
+func requestAuthToken(r *http.Request) string {
+	if parts := strings.Split(r.Header.Get("Authorization"), " "); len(parts) == 2 && parts[0] == "Bearer" {
+		return parts[1]
+	}
+	return r.URL.Query().Get("token")
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -17,14 +18,18 @@ type NoteHandler struct {
 	noteService    *usecase.NoteService
 	likeService    *usecase.LikeService
 	commentService *usecase.CommentService
+	searchService  *usecase.SearchService
+	inviteService  *usecase.InviteService
 }
 
 // NewNoteHandler, yeni bir NoteHandler örneği oluşturur
-func NewNoteHandler(noteService *usecase.NoteService, likeService *usecase.LikeService, commentService *usecase.CommentService) *NoteHandler {
+func NewNoteHandler(noteService *usecase.NoteService, likeService *usecase.LikeService, commentService *usecase.CommentService, searchService *usecase.SearchService, inviteService *usecase.InviteService) *NoteHandler {
 	return &NoteHandler{
 		noteService:    noteService,
 		likeService:    likeService,
 		commentService: commentService,
+		searchService:  searchService,
+		inviteService:  inviteService,
 	}
 }
 
@@ -41,6 +46,7 @@ func (h *NoteHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.Au
 		r.Post("/notes/{id}/like", h.LikeNote)
 		r.Delete("/notes/{id}/like", h.UnlikeNote)
 		r.Get("/notes/liked", h.GetLikedNotes)
+		r.Post("/notes/{id}/revisions/{number}/restore", h.RestoreRevision)
 	})
 
 	// Kimlik doğrulama gerektirmeyen rotalar
@@ -48,9 +54,37 @@ func (h *NoteHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.Au
 	r.Get("/notes/{id}", func(w http.ResponseWriter, r *http.Request) {
 		middleware.OptionalAuth(authMiddleware, h.GetNote).ServeHTTP(w, r)
 	})
-	r.Get("/notes/{id}/comments", h.GetComments)
-	r.Get("/notes/search", h.SearchNotes)
+	r.Get("/notes/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetComments).ServeHTTP(w, r)
+	})
+	r.Get("/notes/search", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.SearchNotes).ServeHTTP(w, r)
+	})
 	r.Get("/notes/tag/{tag}", h.GetNotesByTag)
+
+	// Revizyon geçmişi (özel notlar için kimlik doğrulama gerekir)
+	r.Get("/notes/{id}/revisions", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetRevisions).ServeHTTP(w, r)
+	})
+	r.Get("/notes/{id}/revisions/diff", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.DiffRevisions).ServeHTTP(w, r)
+	})
+	r.Get("/notes/{id}/revisions/{number}", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetRevision).ServeHTTP(w, r)
+	})
+}
+
+// renderIfRequested, istemci ?render=html ile istediyse notların Markdown içeriklerini güvenli HTML'e
+// dönüştürerek contentHtml alanına yazar. Dönüştürme başarısız olursa hata yanıtını yazar ve false döner.
+func renderIfRequested(w http.ResponseWriter, r *http.Request, noteService *usecase.NoteService, notes ...*domain.Note) bool {
+	if r.URL.Query().Get("render") != "html" {
+		return true
+	}
+	if err := noteService.RenderNotes(notes...); err != nil {
+		http.Error(w, "Not içeriği dönüştürülürken hata: "+err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	return true
 }
 
 // CreateNoteRequest, not oluşturma isteği
@@ -67,6 +101,7 @@ type UpdateNoteRequest struct {
 	Content  string   `json:"content"`
 	Tags     []string `json:"tags"`
 	IsPublic bool     `json:"isPublic"`
+	Version  int      `json:"version"` // Opsiyonel; If-Match başlığı varsa o kullanılır
 }
 
 // CommentRequest, yorum isteği
@@ -130,11 +165,21 @@ func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Beklenen sürümü al (opsiyonel)
+	ifMatchVersion, hasIfMatch, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, "Geçersiz If-Match başlığı", http.StatusBadRequest)
+		return
+	}
+
 	var req UpdateNoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
 		return
 	}
+	if hasIfMatch {
+		req.Version = ifMatchVersion
+	}
 
 	// Not güncelle
 	note := &domain.Note{
@@ -144,9 +189,15 @@ func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
 		UserID:   userID,
 		Tags:     req.Tags,
 		IsPublic: req.IsPublic,
+		Version:  req.Version,
 	}
 
 	if err := h.noteService.UpdateNote(note); err != nil {
+		var conflict *usecase.NoteConflictError
+		if errors.As(err, &conflict) {
+			writeVersionConflict(w, hasIfMatch, conflict.Current.Version, conflict.Current)
+			return
+		}
 		if err == usecase.ErrNoteNotFound {
 			http.Error(w, "Not bulunamadı", http.StatusNotFound)
 			return
@@ -160,6 +211,7 @@ func (h *NoteHandler) UpdateNote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Başarılı yanıt
+	w.Header().Set("ETag", versionETag(note.Version))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(note)
 }
@@ -224,15 +276,26 @@ func (h *NoteHandler) GetNote(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Kullanıcı ID'sini al (opsiyonel)
-	userID, ok := middleware.GetUserID(r)
+	userID, _ := middleware.GetUserID(r)
 
-	// Eğer not herkese açık değilse ve kullanıcı notu oluşturan değilse, erişimi reddet
-	if !note.IsPublic && (!ok || note.UserID != userID) {
+	// Not herkese açık değilse yalnızca sahibi ve notun paylaşıldığı grupların üyeleri görebilir
+	canView, err := h.noteService.CanViewNote(note, userID)
+	if err != nil {
+		http.Error(w, "Erişim kontrolü sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !canView {
 		http.Error(w, "Bu nota erişim izniniz yok", http.StatusForbidden)
 		return
 	}
 
+	// İstendiyse içeriği HTML'e dönüştür
+	if !renderIfRequested(w, r, h.noteService, note) {
+		return
+	}
+
 	// Başarılı yanıt
+	w.Header().Set("ETag", versionETag(note.Version))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(note)
 }
@@ -271,6 +334,11 @@ func (h *NoteHandler) GetUserNotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// İstendiyse içeriği HTML'e dönüştür
+	if !renderIfRequested(w, r, h.noteService, notes...) {
+		return
+	}
+
 	// Başarılı yanıt
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(notes)
@@ -303,6 +371,11 @@ func (h *NoteHandler) GetPublicNotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// İstendiyse içeriği HTML'e dönüştür
+	if !renderIfRequested(w, r, h.noteService, notes...) {
+		return
+	}
+
 	// Başarılı yanıt
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(notes)
@@ -335,17 +408,32 @@ func (h *NoteHandler) SearchNotes(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Notları ara
-	notes, err := h.noteService.SearchNotes(query, limit, offset)
+	// Notları ara (giriş yapmış kullanıcının özel notları da aranır)
+	userID, _ := middleware.GetUserID(r)
+	hits, err := h.searchService.Search(query, "note", r.URL.Query().Get("lang"), userID, limit, offset)
 	if err != nil {
 		if err == usecase.ErrInvalidParameters {
-			http.Error(w, "Geçersiz parametreler", http.StatusBadRequest)
+			http.Error(w, "Geçersiz arama sorgusu", http.StatusBadRequest)
+			return
+		}
+		if err == usecase.ErrInvalidSearchLanguage {
+			http.Error(w, "Geçersiz arama dili", http.StatusBadRequest)
 			return
 		}
 		http.Error(w, "Not arama sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	notes := make([]*domain.Note, 0, len(hits))
+	for _, hit := range hits {
+		notes = append(notes, hit.Note)
+	}
+
+	// İstendiyse içeriği HTML'e dönüştür
+	if !renderIfRequested(w, r, h.noteService, notes...) {
+		return
+	}
+
 	// Başarılı yanıt
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(notes)
@@ -379,18 +467,24 @@ func (h *NoteHandler) GetNotesByTag(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Notları getir
-	notes, err := h.noteService.SearchNotes(tag, limit, offset)
+	notes, err := h.noteService.GetNotesByTag(tag, limit, offset)
 	if err != nil {
 		http.Error(w, "Notları getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// İstendiyse içeriği HTML'e dönüştür
+	if !renderIfRequested(w, r, h.noteService, notes...) {
+		return
+	}
+
 	// Başarılı yanıt
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(notes)
 }
 
-// AddComment, bir nota yorum ekler
+// AddComment, bir nota yorum ekler. Yorum yetkili bir davet bağlantısı (?invite= veya X-Invite-Token)
+// ile özel notlara da yorum yapılabilir.
 func (h *NoteHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 	// Kullanıcı ID'sini al
 	userID, ok := middleware.GetUserID(r)
@@ -413,6 +507,11 @@ func (h *NoteHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
 	// Yorum oluştur
 	comment := &domain.Comment{
 		NoteID:  uint(noteID),
@@ -421,11 +520,15 @@ func (h *NoteHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Yorumu ekle
-	if err := h.noteService.AddComment(comment); err != nil {
+	if err := h.noteService.AddComment(comment, invite); err != nil {
 		if err == usecase.ErrNoteNotFound {
 			http.Error(w, "Not bulunamadı", http.StatusNotFound)
 			return
 		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu nota yorum yapma yetkiniz yok", http.StatusForbidden)
+			return
+		}
 		http.Error(w, "Yorum ekleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -459,8 +562,19 @@ func (h *NoteHandler) GetComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Eğer not herkese açık değilse ve kullanıcı notu oluşturan değilse, erişimi reddet
-	if !note.IsPublic && note.UserID != userID {
+	// Yorum yapabilen davetliler yorumları da okuyabilsin diye davet bağlantısı kabul edilir
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
+	// Özel notların yorumlarını notu görebilenler ve not için davet bağlantısıyla gelenler görebilir
+	canView, err := h.noteService.CanViewNoteWithInvite(note, userID, invite)
+	if err != nil {
+		http.Error(w, "Erişim kontrolü sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !canView {
 		http.Error(w, "Bu notun yorumlarına erişim izniniz yok", http.StatusForbidden)
 		return
 	}
@@ -626,6 +740,11 @@ func (h *NoteHandler) GetLikedNotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// İstendiyse içeriği HTML'e dönüştür
+	if !renderIfRequested(w, r, h.noteService, notes...) {
+		return
+	}
+
 	// Başarılı yanıt
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(notes)
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/utils"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// NotificationHandler, bildirim işlemlerini yönetir
+type NotificationHandler struct {
+	notificationService *usecase.NotificationService
+}
+
+// NewNotificationHandler, yeni bir NotificationHandler örneği oluşturur
+func NewNotificationHandler(notificationService *usecase.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *NotificationHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// Tüm bildirim rotaları kimlik doğrulama gerektirir
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Get("/notifications", h.GetNotifications)
+		r.Get("/notifications/unread-count", h.GetUnreadCount)
+		r.Post("/notifications/read-all", h.MarkAllAsRead)
+		r.Post("/notifications/{id}/read", h.MarkAsRead)
+		r.Get("/notifications/preferences", h.GetPreferences)
+		r.Put("/notifications/preferences", h.UpdatePreferences)
+	})
+}
+
+// NotificationPreferencesRequest, bildirim tercihleri güncelleme isteği.
+// Gönderilmeyen alanlar mevcut değerini korur.
+type NotificationPreferencesRequest struct {
+	Likes    *bool `json:"likes,omitempty"`
+	Comments *bool `json:"comments,omitempty"`
+	Mentions *bool `json:"mentions,omitempty"`
+	Invites  *bool `json:"invites,omitempty"`
+}
+
+// GetNotifications, kullanıcının bildirimlerini getirir
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Sayfalama ve filtre parametrelerini al
+	limit, offset := utils.GetPaginationParams(r)
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	// Bildirimleri getir
+	notifications, err := h.notificationService.GetNotifications(userID, unreadOnly, limit, offset)
+	if err != nil {
+		http.Error(w, "Bildirimleri getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(notifications)
+}
+
+// GetUnreadCount, kullanıcının okunmamış bildirim sayısını getirir
+func (h *NotificationHandler) GetUnreadCount(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	count, err := h.notificationService.GetUnreadCount(userID)
+	if err != nil {
+		http.Error(w, "Okunmamış bildirim sayısını getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]int64{
+		"count": count,
+	})
+}
+
+// MarkAsRead, bir bildirimi okundu olarak işaretler
+func (h *NotificationHandler) MarkAsRead(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Bildirim ID'sini al
+	idStr := chi.URLParam(r, "id")
+	notificationID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz bildirim ID'si", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.notificationService.MarkAsRead(uint(notificationID), userID); err != nil {
+		if err == usecase.ErrNotificationNotFound {
+			http.Error(w, "Bildirim bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Bildirimi okundu olarak işaretleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Bildirim okundu olarak işaretlendi",
+	})
+}
+
+// MarkAllAsRead, kullanıcının tüm bildirimlerini okundu olarak işaretler
+func (h *NotificationHandler) MarkAllAsRead(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.notificationService.MarkAllAsRead(userID); err != nil {
+		http.Error(w, "Bildirimleri okundu olarak işaretleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Tüm bildirimler okundu olarak işaretlendi",
+	})
+}
+
+// GetPreferences, kullanıcının bildirim tercihlerini getirir
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	preferences, err := h.notificationService.GetPreferences(userID)
+	if err != nil {
+		http.Error(w, "Bildirim tercihlerini getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(preferences)
+}
+
+// UpdatePreferences, kullanıcının bildirim tercihlerini günceller
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// İstek gövdesini parse et
+	var req NotificationPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Mevcut tercihleri getir ve yalnızca gönderilen alanları değiştir
+	preferences, err := h.notificationService.GetPreferences(userID)
+	if err != nil {
+		http.Error(w, "Bildirim tercihlerini getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	applyPreference(&preferences.Likes, req.Likes)
+	applyPreference(&preferences.Comments, req.Comments)
+	applyPreference(&preferences.Mentions, req.Mentions)
+	applyPreference(&preferences.Invites, req.Invites)
+
+	if err := h.notificationService.UpdatePreferences(preferences); err != nil {
+		http.Error(w, "Bildirim tercihlerini güncelleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(preferences)
+}
+
+// applyPreference, istekte gönderilen tercih değerini uygular
+func applyPreference(target *bool, value *bool) {
+	if value != nil {
+		*target = *value
+	}
+}
@@ -2,9 +2,12 @@ package handler
 
 import (
 	"encoding/json"
-	"io/ioutil"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/OmerFErdogan/uninote/domain"
 	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
@@ -13,19 +16,32 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+const (
+	// uploadMemoryLimit, yüklemelerde belleğe alınan en fazla form verisi; aşan dosya parçaları geçici dosyaya yazılır
+	uploadMemoryLimit = 1 << 20
+	// uploadFormOverhead, dosya boyutu sınırına ek olarak form alanları ve multipart sınırları için izin verilen bayt
+	uploadFormOverhead = 1 << 20
+	// maxAnnotationImportSize, içe aktarılan JSON-LD işaretleme belgesinin en fazla boyutu
+	maxAnnotationImportSize = 5 << 20
+)
+
 // PDFHandler, PDF işlemlerini yönetir
 type PDFHandler struct {
 	pdfService     *usecase.PDFService
 	likeService    *usecase.LikeService
 	commentService *usecase.CommentService
+	searchService  *usecase.SearchService
+	inviteService  *usecase.InviteService
 }
 
 // NewPDFHandler, yeni bir PDFHandler örneği oluşturur
-func NewPDFHandler(pdfService *usecase.PDFService, likeService *usecase.LikeService, commentService *usecase.CommentService) *PDFHandler {
+func NewPDFHandler(pdfService *usecase.PDFService, likeService *usecase.LikeService, commentService *usecase.CommentService, searchService *usecase.SearchService, inviteService *usecase.InviteService) *PDFHandler {
 	return &PDFHandler{
 		pdfService:     pdfService,
 		likeService:    likeService,
 		commentService: commentService,
+		searchService:  searchService,
+		inviteService:  inviteService,
 	}
 }
 
@@ -41,9 +57,15 @@ func (h *PDFHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.Aut
 		r.Post("/pdfs/{id}/comments", h.AddComment)
 		r.Post("/pdfs/{id}/annotations", h.AddAnnotation)
 		r.Get("/pdfs/{id}/annotations", h.GetAnnotations)
+		r.Get("/pdfs/{id}/annotations/export", h.ExportAnnotations)
+		r.Post("/pdfs/{id}/annotations/import", h.ImportAnnotations)
+		r.Put("/pdfs/{id}/annotations/{annotationId}", h.UpdateAnnotation)
+		r.Delete("/pdfs/{id}/annotations/{annotationId}", h.DeleteAnnotation)
+		r.Post("/pdfs/{id}/extract-text", h.ExtractText)
 		r.Post("/pdfs/{id}/like", h.LikePDF)
 		r.Delete("/pdfs/{id}/like", h.UnlikePDF)
 		r.Get("/pdfs/liked", h.GetLikedPDFs)
+		r.Get("/pdfs/duplicates", h.GetPublicDuplicates)
 	})
 
 	// Kimlik doğrulama gerektirmeyen rotalar
@@ -51,9 +73,27 @@ func (h *PDFHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.Aut
 	r.Get("/pdfs/{id}", func(w http.ResponseWriter, r *http.Request) {
 		middleware.OptionalAuth(authMiddleware, h.GetPDF).ServeHTTP(w, r)
 	})
-	r.Get("/pdfs/{id}/content", h.GetPDFContent)
-	r.Get("/pdfs/{id}/comments", h.GetComments)
-	r.Get("/pdfs/search", h.SearchPDFs)
+	r.Get("/pdfs/{id}/content", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetPDFContent).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/{id}/annotations/layers", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetAnnotationLayers).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/{id}/thumbnail", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetThumbnail).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/{id}/pages/{page}/preview", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetPagePreview).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/{id}/comments", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetComments).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/{id}/pages", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.GetPages).ServeHTTP(w, r)
+	})
+	r.Get("/pdfs/search", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.SearchPDFs).ServeHTTP(w, r)
+	})
 	r.Get("/pdfs/tag/{tag}", h.GetPDFsByTag)
 }
 
@@ -71,24 +111,61 @@ type UpdatePDFRequest struct {
 	Description string   `json:"description"`
 	Tags        []string `json:"tags"`
 	IsPublic    bool     `json:"isPublic"`
+	Version     int      `json:"version"` // Opsiyonel; If-Match başlığı varsa o kullanılır
 }
 
 // CommentRequest, yorum isteği
 type PDFCommentRequest struct {
-	Content    string `json:"content"`
-	PageNumber int    `json:"pageNumber"`
+	Content    string                    `json:"content"`
+	PageNumber int                       `json:"pageNumber"`
+	Quote      *domain.TextQuoteSelector `json:"quote,omitempty"` // Opsiyonel; yorumun alıntıladığı metin
 }
 
 // AnnotationRequest, işaretleme isteği
 type AnnotationRequest struct {
-	PageNumber int     `json:"pageNumber"`
-	Content    string  `json:"content"`
-	X          float64 `json:"x"`
-	Y          float64 `json:"y"`
-	Width      float64 `json:"width"`
-	Height     float64 `json:"height"`
-	Type       string  `json:"type"`
-	Color      string  `json:"color"`
+	PageNumber int                       `json:"pageNumber"`
+	Content    string                    `json:"content"`
+	X          float64                   `json:"x"`
+	Y          float64                   `json:"y"`
+	Width      float64                   `json:"width"`
+	Height     float64                   `json:"height"`
+	Rects      []domain.PDFRect          `json:"rects,omitempty"` // Opsiyonel; çok satırlı seçimler için
+	Quote      *domain.TextQuoteSelector `json:"quote,omitempty"` // Opsiyonel; işaretlenen metin
+	Type       string                    `json:"type"`            // Opsiyonel; varsayılan highlight
+	Color      string                    `json:"color"`           // Opsiyonel; varsayılan #FFFF00
+	Visibility string                    `json:"visibility"`      // Opsiyonel; private (varsayılan), shared veya public
+}
+
+// toAnnotation, istekten PDF işaretlemesi oluşturur
+func (req *AnnotationRequest) toAnnotation(pdfID, userID uint) *domain.PDFAnnotation {
+	return &domain.PDFAnnotation{
+		PDFID:      pdfID,
+		UserID:     userID,
+		PageNumber: req.PageNumber,
+		Content:    req.Content,
+		X:          req.X,
+		Y:          req.Y,
+		Width:      req.Width,
+		Height:     req.Height,
+		Rects:      req.Rects,
+		Quote:      req.Quote,
+		Type:       req.Type,
+		Color:      req.Color,
+		Visibility: req.Visibility,
+	}
+}
+
+// UploadPDFResponse, PDF yükleme yanıtı; yüklenen PDF'in alanlarına ek olarak aynı içerikli
+// herkese açık PDF'leri içerir
+type UploadPDFResponse struct {
+	*domain.PDF
+	PublicDuplicates []*domain.PDF `json:"publicDuplicates,omitempty"`
+}
+
+// ExtractTextResponse, metin çıkarma yanıtı
+type ExtractTextResponse struct {
+	PDFID     uint `json:"pdfId"`
+	PageCount int  `json:"pageCount"` // Metin bulunan sayfa sayısı
 }
 
 // UploadPDF, yeni bir PDF yükler
@@ -100,12 +177,22 @@ func (h *PDFHandler) UploadPDF(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Multipart form'u parse et
-	err := r.ParseMultipartForm(10 << 20) // 10 MB
-	if err != nil {
+	// İstek gövdesini dosya boyutu sınırıyla kısıtla; form alanları için pay bırakılır
+	if maxSize := h.pdfService.MaxFileSize(); maxSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize+uploadFormOverhead)
+	}
+
+	// Multipart form'u parse et; bellek sınırını aşan dosya parçaları geçici dosyaya yazılır
+	if err := r.ParseMultipartForm(uploadMemoryLimit); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "PDF dosyası izin verilen boyutu aşıyor", http.StatusRequestEntityTooLarge)
+			return
+		}
 		http.Error(w, "Form parse hatası: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	defer r.MultipartForm.RemoveAll()
 
 	// PDF dosyasını al
 	file, _, err := r.FormFile("file")
@@ -115,13 +202,6 @@ func (h *PDFHandler) UploadPDF(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	// Dosya içeriğini oku
-	fileContent, err := ioutil.ReadAll(file)
-	if err != nil {
-		http.Error(w, "Dosya okuma hatası: "+err.Error(), http.StatusInternalServerError)
-		return
-	}
-
 	// Form verilerini al
 	title := r.FormValue("title")
 	description := r.FormValue("description")
@@ -153,18 +233,60 @@ func (h *PDFHandler) UploadPDF(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// PDF'i yükle
-	if err := h.pdfService.UploadPDF(pdf, fileContent); err != nil {
+	if err := h.pdfService.UploadPDF(pdf, file); err != nil {
 		if err == usecase.ErrInvalidParameters {
 			http.Error(w, "Geçersiz parametreler", http.StatusBadRequest)
 			return
 		}
+		if err == usecase.ErrPDFTooLarge {
+			http.Error(w, "PDF dosyası izin verilen boyutu aşıyor", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if errors.Is(err, domain.ErrPDFEncrypted) {
+			http.Error(w, "Şifrelenmiş PDF dosyaları kabul edilmiyor; lütfen şifresiz bir kopya yükleyin", http.StatusUnprocessableEntity)
+			return
+		}
+		if errors.Is(err, domain.ErrPDFMalformed) {
+			http.Error(w, "PDF doğrulanamadı: "+err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		if err == usecase.ErrPDFActiveContent {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
 		http.Error(w, "PDF yükleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// Aynı belge zaten herkese açık paylaşılmışsa yükleyene bildir; hata yüklemeyi geçersiz kılmaz
+	duplicates, err := h.pdfService.FindPublicDuplicates(pdf.ContentHash, pdf.ID)
+	if err != nil {
+		logger.Error("Aynı içerikli PDF'ler aranırken hata: %v", err)
+	}
+
 	// Başarılı yanıt
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(pdf)
+	json.NewEncoder(w).Encode(UploadPDFResponse{PDF: pdf, PublicDuplicates: duplicates})
+}
+
+// GetPublicDuplicates, SHA-256 özeti verilen dosyayla aynı içerikli herkese açık PDF'leri getirir.
+// İstemci dosyayı yüklemeden önce özetini hesaplayıp belgenin zaten paylaşılıp paylaşılmadığını sorabilir.
+func (h *PDFHandler) GetPublicDuplicates(w http.ResponseWriter, r *http.Request) {
+	contentHash := strings.ToLower(r.URL.Query().Get("sha256"))
+
+	pdfs, err := h.pdfService.FindPublicDuplicates(contentHash, 0)
+	if err != nil {
+		if err == usecase.ErrInvalidParameters {
+			http.Error(w, "Geçersiz SHA-256 özeti", http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "PDF arama sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(pdfs)
 }
 
 // UpdatePDF, bir PDF'i günceller
@@ -184,11 +306,21 @@ func (h *PDFHandler) UpdatePDF(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Beklenen sürümü al (opsiyonel)
+	ifMatchVersion, hasIfMatch, err := parseIfMatch(r)
+	if err != nil {
+		http.Error(w, "Geçersiz If-Match başlığı", http.StatusBadRequest)
+		return
+	}
+
 	var req UpdatePDFRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
 		return
 	}
+	if hasIfMatch {
+		req.Version = ifMatchVersion
+	}
 
 	// PDF güncelle
 	pdf := &domain.PDF{
@@ -198,9 +330,15 @@ func (h *PDFHandler) UpdatePDF(w http.ResponseWriter, r *http.Request) {
 		UserID:      userID,
 		Tags:        req.Tags,
 		IsPublic:    req.IsPublic,
+		Version:     req.Version,
 	}
 
 	if err := h.pdfService.UpdatePDF(pdf); err != nil {
+		var conflict *usecase.PDFConflictError
+		if errors.As(err, &conflict) {
+			writeVersionConflict(w, hasIfMatch, conflict.Current.Version, conflict.Current)
+			return
+		}
 		if err == usecase.ErrPDFNotFound {
 			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
 			return
@@ -214,6 +352,7 @@ func (h *PDFHandler) UpdatePDF(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Başarılı yanıt
+	w.Header().Set("ETag", versionETag(pdf.Version))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(pdf)
 }
@@ -278,15 +417,21 @@ func (h *PDFHandler) GetPDF(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Kullanıcı ID'sini al (opsiyonel)
-	userID, ok := middleware.GetUserID(r)
+	userID, _ := middleware.GetUserID(r)
 
-	// Eğer PDF herkese açık değilse ve kullanıcı PDF'i yükleyen değilse, erişimi reddet
-	if !pdf.IsPublic && (!ok || pdf.UserID != userID) {
+	// PDF herkese açık değilse yalnızca sahibi ve PDF'in paylaşıldığı grupların üyeleri görebilir
+	canView, err := h.pdfService.CanViewPDF(pdf, userID)
+	if err != nil {
+		http.Error(w, "Erişim kontrolü sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !canView {
 		http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
 		return
 	}
 
 	// Başarılı yanıt
+	w.Header().Set("ETag", versionETag(pdf.Version))
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(pdf)
 }
@@ -301,38 +446,107 @@ func (h *PDFHandler) GetPDFContent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// PDF'i getir
-	pdf, err := h.pdfService.GetPDF(uint(id))
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// PDF'i ve dosyasına erişim bilgisini getir. PDF herkese açık değilse yalnızca sahibi ve PDF'in
+	// paylaşıldığı grupların üyeleri görebilir; erişim kontrolü dosya açılmadan önce yapılır.
+	content, err := h.pdfService.GetPDFContent(uint(id), userID)
 	if err != nil {
-		if err == usecase.ErrPDFNotFound {
+		switch err {
+		case usecase.ErrPDFNotFound:
 			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
-			return
+		case usecase.ErrNotAuthorized:
+			http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
+		default:
+			http.Error(w, "PDF içeriği getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		}
-		http.Error(w, "PDF getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if content.File != nil {
+		defer content.File.Close()
+	}
+	pdf := content.PDF
 
-	// Kullanıcı ID'sini al (opsiyonel)
-	userID, ok := middleware.GetUserID(r)
+	// Depo süreli bağlantı verdiyse istemci dosyayı doğrudan depodan indirir. Bağlantı yalnızca
+	// erişim kontrolünden geçen istemciye verildiği için yönlendirme önbelleğe alınmamalıdır.
+	if content.URL != "" {
+		w.Header().Set("Cache-Control", "private, no-store")
+		http.Redirect(w, r, content.URL, http.StatusFound)
+		return
+	}
 
-	// Eğer PDF herkese açık değilse ve kullanıcı PDF'i yükleyen değilse, erişimi reddet
-	if !pdf.IsPublic && (!ok || pdf.UserID != userID) {
-		http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
+	// PDF yanıtı; ServeContent aralık (Range) ve koşullu istekleri karşılar, böylece görüntüleyiciler
+	// dosyanın yalnızca ihtiyaç duydukları kısmını indirebilir. Dosya içeriği yüklendikten sonra
+	// değişmediği için içerik özeti güçlü ETag olarak kullanılır.
+	w.Header().Set("Content-Type", "application/pdf")
+	// Etkin içerik bulunan PDF'ler tarayıcıda açılmaz, indirilir
+	disposition := "inline"
+	if len(pdf.ActiveContent) > 0 {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", disposition+"; filename="+pdf.Title+".pdf")
+	if pdf.ContentHash != "" {
+		w.Header().Set("ETag", `"`+pdf.ContentHash+`"`)
+	}
+	http.ServeContent(w, r, pdf.Title+".pdf", pdf.CreatedAt, content.File)
+}
+
+// GetThumbnail, PDF'in kapak görselini (ilk sayfa) getirir
+func (h *PDFHandler) GetThumbnail(w http.ResponseWriter, r *http.Request) {
+	h.servePDFImage(w, r, 0)
+}
+
+// GetPagePreview, PDF'in bir sayfasının önizleme görselini getirir
+func (h *PDFHandler) GetPagePreview(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(chi.URLParam(r, "page"))
+	if err != nil || page < 1 {
+		http.Error(w, "Geçersiz sayfa numarası", http.StatusBadRequest)
 		return
 	}
+	h.servePDFImage(w, r, page)
+}
 
-	// PDF içeriğini getir
-	content, err := h.pdfService.GetPDFContent(uint(id))
+// servePDFImage, PDF'in kapak (page 0) veya sayfa önizleme görselini önbellek başlıklarıyla sunar
+func (h *PDFHandler) servePDFImage(w http.ResponseWriter, r *http.Request, page int) {
+	// PDF ID'sini al
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		http.Error(w, "PDF içeriği getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
 		return
 	}
 
-	// PDF yanıtı
-	w.Header().Set("Content-Type", "application/pdf")
-	w.Header().Set("Content-Disposition", "inline; filename="+pdf.Title+".pdf")
-	w.WriteHeader(http.StatusOK)
-	w.Write(content)
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Görseli getir
+	image, err := h.pdfService.GetPDFImage(uint(id), userID, page)
+	if err != nil {
+		switch err {
+		case usecase.ErrPDFNotFound:
+			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
+		case usecase.ErrNotAuthorized:
+			http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
+		case usecase.ErrPreviewNotFound:
+			http.Error(w, "Görsel henüz hazır değil veya bulunamadı", http.StatusNotFound)
+		default:
+			http.Error(w, "Görsel getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	defer image.File.Close()
+
+	// Görseller PDF içeriğinden üretildiği için içerik değişmedikçe aynı kalır. Özel PDF'lerin
+	// görselleri paylaşılan önbelleklerde saklanmaz.
+	cacheControl := "public, max-age=86400"
+	if !image.PDF.IsPublic {
+		cacheControl = "private, max-age=3600"
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("Content-Type", image.ContentType)
+	w.Header().Set("ETag", `"`+image.ETag+`"`)
+	http.ServeContent(w, r, "", time.Time{}, image.File)
 }
 
 // GetUserPDFs, kullanıcının PDF'lerini getirir
@@ -388,17 +602,27 @@ func (h *PDFHandler) SearchPDFs(w http.ResponseWriter, r *http.Request) {
 	// Sayfalama parametrelerini al
 	limit, offset := getPaginationParams(r)
 
-	// PDF'leri ara
-	pdfs, err := h.pdfService.SearchPDFs(query, limit, offset)
+	// PDF'leri ara (giriş yapmış kullanıcının özel PDF'leri de aranır)
+	userID, _ := middleware.GetUserID(r)
+	hits, err := h.searchService.Search(query, "pdf", r.URL.Query().Get("lang"), userID, limit, offset)
 	if err != nil {
 		if err == usecase.ErrInvalidParameters {
-			http.Error(w, "Geçersiz parametreler", http.StatusBadRequest)
+			http.Error(w, "Geçersiz arama sorgusu", http.StatusBadRequest)
+			return
+		}
+		if err == usecase.ErrInvalidSearchLanguage {
+			http.Error(w, "Geçersiz arama dili", http.StatusBadRequest)
 			return
 		}
 		http.Error(w, "PDF arama sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	pdfs := make([]*domain.PDF, 0, len(hits))
+	for _, hit := range hits {
+		pdfs = append(pdfs, hit.PDF)
+	}
+
 	// Başarılı yanıt
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(pdfs)
@@ -417,7 +641,7 @@ func (h *PDFHandler) GetPDFsByTag(w http.ResponseWriter, r *http.Request) {
 	limit, offset := getPaginationParams(r)
 
 	// PDF'leri getir
-	pdfs, err := h.pdfService.SearchPDFs(tag, limit, offset)
+	pdfs, err := h.pdfService.GetPDFsByTag(tag, limit, offset)
 	if err != nil {
 		http.Error(w, "PDF'leri getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -428,7 +652,8 @@ func (h *PDFHandler) GetPDFsByTag(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(pdfs)
 }
 
-// AddComment, bir PDF'e yorum ekler
+// AddComment, bir PDF'e yorum ekler. Yorum veya işaretleme yetkili bir davet bağlantısı (?invite= veya
+// X-Invite-Token) ile özel PDF'lere de yorum yapılabilir.
 func (h *PDFHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 	// Kullanıcı ID'sini al
 	userID, ok := middleware.GetUserID(r)
@@ -451,20 +676,34 @@ func (h *PDFHandler) AddComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
 	// Yorum oluştur
 	comment := &domain.PDFComment{
 		PDFID:      uint(pdfID),
 		UserID:     userID,
 		Content:    req.Content,
 		PageNumber: req.PageNumber,
+		Quote:      req.Quote,
 	}
 
 	// Yorumu ekle
-	if err := h.pdfService.AddComment(comment); err != nil {
+	if err := h.pdfService.AddComment(comment, invite); err != nil {
 		if err == usecase.ErrPDFNotFound {
 			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
 			return
 		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu PDF'e yorum yapma yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidParameters) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "Yorum ekleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -498,8 +737,19 @@ func (h *PDFHandler) GetComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Eğer PDF herkese açık değilse ve kullanıcı PDF'i yükleyen değilse, erişimi reddet
-	if !pdf.IsPublic && pdf.UserID != userID {
+	// Yorum yapabilen davetliler yorumları da okuyabilsin diye davet bağlantısı kabul edilir
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
+	// Özel PDF'lerin yorumlarını PDF'i görebilenler ve PDF için davet bağlantısıyla gelenler görebilir
+	canView, err := h.pdfService.CanViewPDFWithInvite(pdf, userID, invite)
+	if err != nil {
+		http.Error(w, "Erişim kontrolü sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !canView {
 		http.Error(w, "Bu PDF'in yorumlarına erişim izniniz yok", http.StatusForbidden)
 		return
 	}
@@ -553,26 +803,29 @@ func (h *PDFHandler) AddAnnotation(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// İşaretleme oluştur
-	annotation := &domain.PDFAnnotation{
-		PDFID:      uint(pdfID),
-		UserID:     userID,
-		PageNumber: req.PageNumber,
-		Content:    req.Content,
-		X:          req.X,
-		Y:          req.Y,
-		Width:      req.Width,
-		Height:     req.Height,
-		Type:       req.Type,
-		Color:      req.Color,
+	// Özel PDF'lere davet bağlantısıyla gelenler de işaretleme ekleyebilir
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
 	}
 
+	// İşaretleme oluştur
+	annotation := req.toAnnotation(uint(pdfID), userID)
+
 	// İşaretlemeyi ekle
-	if err := h.pdfService.AddAnnotation(annotation); err != nil {
+	if err := h.pdfService.AddAnnotation(annotation, invite); err != nil {
 		if err == usecase.ErrPDFNotFound {
 			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
 			return
 		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidAnnotation) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		http.Error(w, "İşaretleme ekleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -582,6 +835,136 @@ func (h *PDFHandler) AddAnnotation(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(annotation)
 }
 
+// UpdateAnnotation, kullanıcının kendi işaretlemesini günceller
+func (h *PDFHandler) UpdateAnnotation(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// PDF ve işaretleme ID'lerini al
+	pdfID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
+		return
+	}
+	annotationID, err := strconv.ParseUint(chi.URLParam(r, "annotationId"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz işaretleme ID'si", http.StatusBadRequest)
+		return
+	}
+
+	var req AnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	annotation := req.toAnnotation(uint(pdfID), userID)
+	annotation.ID = uint(annotationID)
+
+	// İşaretlemeyi güncelle
+	if err := h.pdfService.UpdateAnnotation(annotation, userID); err != nil {
+		switch {
+		case err == usecase.ErrAnnotationNotFound:
+			http.Error(w, "İşaretleme bulunamadı", http.StatusNotFound)
+		case err == usecase.ErrPDFNotFound:
+			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
+		case err == usecase.ErrNotAuthorized:
+			http.Error(w, "Bu işaretlemeyi güncelleme yetkiniz yok", http.StatusForbidden)
+		case errors.Is(err, usecase.ErrInvalidAnnotation):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "İşaretleme güncelleme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(annotation)
+}
+
+// DeleteAnnotation, kullanıcının kendi işaretlemesini siler
+func (h *PDFHandler) DeleteAnnotation(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// PDF ve işaretleme ID'lerini al
+	pdfID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
+		return
+	}
+	annotationID, err := strconv.ParseUint(chi.URLParam(r, "annotationId"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz işaretleme ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// İşaretlemeyi sil
+	if err := h.pdfService.DeleteAnnotation(uint(pdfID), uint(annotationID), userID); err != nil {
+		switch err {
+		case usecase.ErrAnnotationNotFound:
+			http.Error(w, "İşaretleme bulunamadı", http.StatusNotFound)
+		case usecase.ErrNotAuthorized:
+			http.Error(w, "Bu işaretlemeyi silme yetkiniz yok", http.StatusForbidden)
+		default:
+			http.Error(w, "İşaretleme silme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAnnotationLayers, PDF üzerinde diğer kullanıcıların görülebilen işaretlemelerini kullanıcı
+// başına katmanlar halinde getirir
+func (h *PDFHandler) GetAnnotationLayers(w http.ResponseWriter, r *http.Request) {
+	// PDF ID'sini al
+	idStr := chi.URLParam(r, "id")
+	pdfID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Davet bağlantısıyla gelenler paylaşılan işaretlemeleri de görür
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
+	// Katmanları getir
+	layers, err := h.pdfService.GetAnnotationLayers(uint(pdfID), userID, invite)
+	if err != nil {
+		switch err {
+		case usecase.ErrPDFNotFound:
+			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
+		case usecase.ErrNotAuthorized:
+			http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
+		default:
+			http.Error(w, "İşaretleme katmanlarını getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(layers)
+}
+
 // GetAnnotations, bir PDF'in işaretlemelerini getirir
 func (h *PDFHandler) GetAnnotations(w http.ResponseWriter, r *http.Request) {
 	// Kullanıcı ID'sini al
@@ -615,6 +998,197 @@ func (h *PDFHandler) GetAnnotations(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(annotations)
 }
 
+// ExportAnnotations, kullanıcının PDF üzerindeki işaretlemelerini W3C Web Annotation (JSON-LD)
+// koleksiyonu olarak indirir
+func (h *PDFHandler) ExportAnnotations(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// PDF ID'sini al
+	idStr := chi.URLParam(r, "id")
+	pdfID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// İşaretleme adresleri PDF'in API'deki mutlak adresinden türetilir
+	pdfIRI := requestBaseURL(r) + strings.TrimSuffix(r.URL.Path, "/annotations/export")
+
+	// İşaretlemeleri dışa aktar
+	collection, err := h.pdfService.ExportAnnotations(uint(pdfID), userID, pdfIRI)
+	if err != nil {
+		if err == usecase.ErrPDFNotFound {
+			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "İşaretlemeleri dışa aktarma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", domain.WebAnnotationMediaType)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(collection)
+}
+
+// ImportAnnotations, W3C Web Annotation (JSON-LD) biçimindeki işaretlemeleri kullanıcının PDF
+// üzerindeki işaretlemeleri olarak kaydeder
+func (h *PDFHandler) ImportAnnotations(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// PDF ID'sini al
+	idStr := chi.URLParam(r, "id")
+	pdfID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// İstek gövdesini oku
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxAnnotationImportSize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "İçe aktarılan belge çok büyük", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	invite, ok := requestInvite(w, r, h.inviteService)
+	if !ok {
+		return
+	}
+
+	// İşaretlemeleri içe aktar
+	annotations, err := h.pdfService.ImportAnnotations(uint(pdfID), userID, invite, r.URL.Query().Get("visibility"), data)
+	if err != nil {
+		if err == usecase.ErrPDFNotFound {
+			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidAnnotation) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "İşaretlemeleri içe aktarma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(annotations)
+}
+
+// requestBaseURL, isteğin geldiği şema ve sunucu adından mutlak adres önekini oluşturur. Ters vekil
+// arkasında çalışırken X-Forwarded-Proto başlığındaki şema kullanılır.
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := strings.TrimSpace(strings.Split(r.Header.Get("X-Forwarded-Proto"), ",")[0]); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+// ExtractText, PDF'in sayfa metinlerini yeniden çıkarır ve arama için indeksler
+func (h *PDFHandler) ExtractText(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// PDF ID'sini al
+	idStr := chi.URLParam(r, "id")
+	pdfID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Metni çıkar
+	pages, err := h.pdfService.ExtractText(uint(pdfID), userID)
+	if err != nil {
+		if err == usecase.ErrPDFNotFound {
+			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, usecase.ErrTextExtract) {
+			http.Error(w, "PDF metni çıkarılamadı: "+err.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		http.Error(w, "Metin çıkarma sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ExtractTextResponse{
+		PDFID:     uint(pdfID),
+		PageCount: len(pages),
+	})
+}
+
+// GetPages, PDF'in sayfalarından çıkarılan metinleri getirir
+func (h *PDFHandler) GetPages(w http.ResponseWriter, r *http.Request) {
+	// PDF ID'sini al
+	idStr := chi.URLParam(r, "id")
+	pdfID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz PDF ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Sayfa metinlerini getir
+	pages, err := h.pdfService.GetPages(uint(pdfID), userID, limit, offset)
+	if err != nil {
+		if err == usecase.ErrPDFNotFound {
+			http.Error(w, "PDF bulunamadı", http.StatusNotFound)
+			return
+		}
+		if err == usecase.ErrNotAuthorized {
+			http.Error(w, "Bu PDF'e erişim izniniz yok", http.StatusForbidden)
+			return
+		}
+		http.Error(w, "Sayfa metinlerini getirme sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(pages)
+}
+
 // LikePDF, bir PDF'i beğenir
 func (h *PDFHandler) LikePDF(w http.ResponseWriter, r *http.Request) {
 	// Kullanıcı ID'sini al
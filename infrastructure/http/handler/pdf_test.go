@@ -0,0 +1,100 @@
+package handler_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// recordingStorage, açılan dosyaları kaydeden bir depo sarmalayıcısıdır
+type recordingStorage struct {
+	domain.PDFStorage
+	opened []string
+}
+
+func (s *recordingStorage) Open(filePath string) (io.ReadSeekCloser, error) {
+	s.opened = append(s.opened, filePath)
+	return s.PDFStorage.Open(filePath)
+}
+
+func TestGetPDFContentAuthorizesBeforeOpening(t *testing.T) {
+	f := newCommentFixture(t)
+	target := "/pdfs/" + strconv.Itoa(int(f.pdf.ID)) + "/content"
+
+	for _, userID := range []uint{0, 2} {
+		if rec := serve(f.pdfs.GetPDFContent, "/pdfs/{id}/content", target, userID); rec.Code != http.StatusForbidden {
+			t.Fatalf("kullanıcı %d için 403 beklenirken %d döndü", userID, rec.Code)
+		}
+	}
+	if len(f.storage.opened) != 0 {
+		t.Fatalf("erişim izni olmayan istekler dosyayı açtı: %v", f.storage.opened)
+	}
+
+	rec := serve(f.pdfs.GetPDFContent, "/pdfs/{id}/content", target, 1)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/pdf" {
+		t.Fatalf("sahibin isteği için PDF dönmedi: %d %s", rec.Code, rec.Body.String())
+	}
+	if len(f.storage.opened) != 1 || f.storage.opened[0] != f.pdf.FilePath {
+		t.Fatalf("sahibin isteği dosyayı bir kez açmalıydı: %v", f.storage.opened)
+	}
+}
+
+func TestLegacyPDFContentHashBackfill(t *testing.T) {
+	f := newCommentFixture(t)
+	content := "%PDF-1.4\n% eski yükleme\n%%EOF\n"
+	sum := sha256.Sum256([]byte(content))
+	hash := hex.EncodeToString(sum[:])
+
+	// İçerik özeti sütunundan önce yüklenmiş, dosyası özetle adlandırılmamış herkese açık bir PDF
+	legacyPath := filepath.Join(filepath.Dir(f.pdf.FilePath), "eski.pdf")
+	must(t, os.WriteFile(legacyPath, []byte(content), 0644))
+	legacy := &domain.PDF{Title: "Eski PDF", FilePath: legacyPath, FileSize: int64(len(content)), UserID: 1, IsPublic: true}
+	must(t, f.pdfRepo.Create(legacy))
+
+	duplicates := func() []*domain.PDF {
+		rec := serve(f.pdfs.GetPublicDuplicates, "/pdfs/duplicates", "/pdfs/duplicates?sha256="+hash, 0)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("kopya araması başarısız: %d %s", rec.Code, rec.Body.String())
+		}
+		var pdfs []*domain.PDF
+		must(t, json.Unmarshal(rec.Body.Bytes(), &pdfs))
+		return pdfs
+	}
+	if found := duplicates(); len(found) != 0 {
+		t.Fatalf("özeti olmayan PDF kopya olarak bulunmamalıydı: %+v", found)
+	}
+
+	// Özeti olmayan PDF için tek iş kuyruğa alınır; yeniden çağrıldığında iş tekrarlanmaz
+	f.jobs.Register(domain.JobHashPDFContent, f.pdfSvc.RunHashContentJob)
+	count, err := f.pdfSvc.EnqueueContentHashBackfill()
+	must(t, err)
+	if count != 1 {
+		t.Fatalf("1 iş kuyruğa alınmalıydı, %d alındı", count)
+	}
+	if count, err := f.pdfSvc.EnqueueContentHashBackfill(); err != nil || count != 0 {
+		t.Fatalf("kuyruktaki PDF için yeniden iş alınmamalıydı: %d, %v", count, err)
+	}
+	jobs, err := f.jobs.ListJobs("", domain.JobHashPDFContent, 10, 0)
+	must(t, err)
+	must(t, f.pdfSvc.RunHashContentJob(jobs[0]))
+
+	found := duplicates()
+	if len(found) != 1 || found[0].ID != legacy.ID || found[0].ContentHash != hash {
+		t.Fatalf("özeti hesaplanan eski PDF kopya olarak bulunmalıydı: %+v", found)
+	}
+	if _, err := os.Stat(legacyPath); !os.IsNotExist(err) {
+		t.Fatalf("kullanılmayan eski dosya silinmeliydi: %v", err)
+	}
+	rec := serve(f.pdfs.GetPDFContent, "/pdfs/{id}/content", "/pdfs/"+strconv.Itoa(int(legacy.ID))+"/content", 0)
+	if rec.Code != http.StatusOK || rec.Body.String() != content {
+		t.Fatalf("eski PDF yeni dosyasından okunamadı: %d %q", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// writeRevisionError, revizyon işlemlerinde oluşan hataları HTTP yanıtına dönüştürür
+func writeRevisionError(w http.ResponseWriter, err error, action string) {
+	var conflict *usecase.NoteConflictError
+	if errors.As(err, &conflict) {
+		writeVersionConflict(w, false, conflict.Current.Version, conflict.Current)
+		return
+	}
+
+	switch err {
+	case usecase.ErrNoteNotFound:
+		http.Error(w, "Not bulunamadı", http.StatusNotFound)
+	case usecase.ErrRevisionNotFound:
+		http.Error(w, "Revizyon bulunamadı", http.StatusNotFound)
+	case usecase.ErrNotAuthorized:
+		http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+	default:
+		http.Error(w, action+" sırasında hata: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// GetRevisions, bir notun revizyon geçmişini getirir
+func (h *NoteHandler) GetRevisions(w http.ResponseWriter, r *http.Request) {
+	// Not ID'sini al
+	idStr := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz not ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Sayfalama parametrelerini al
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	limit := 10 // Varsayılan limit
+	if limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	offset := 0 // Varsayılan offset
+	if offsetStr != "" {
+		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	// Revizyonları getir
+	revisions, err := h.noteService.GetRevisions(uint(noteID), userID, limit, offset)
+	if err != nil {
+		writeRevisionError(w, err, "Revizyonları getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(revisions)
+}
+
+// GetRevision, bir notun belirli bir revizyonunu getirir
+func (h *NoteHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	// Not ID'sini al
+	idStr := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz not ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Revizyon numarasını al
+	number, err := strconv.Atoi(chi.URLParam(r, "number"))
+	if err != nil || number <= 0 {
+		http.Error(w, "Geçersiz revizyon numarası", http.StatusBadRequest)
+		return
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Revizyonu getir
+	revision, err := h.noteService.GetRevision(uint(noteID), userID, number)
+	if err != nil {
+		writeRevisionError(w, err, "Revizyon getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(revision)
+}
+
+// DiffRevisions, bir notun iki revizyonu arasındaki satır bazlı farkı getirir
+func (h *NoteHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	// Not ID'sini al
+	idStr := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz not ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Karşılaştırılacak revizyonları al
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil || from <= 0 {
+		http.Error(w, "Geçersiz 'from' revizyon numarası", http.StatusBadRequest)
+		return
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil || to <= 0 {
+		http.Error(w, "Geçersiz 'to' revizyon numarası", http.StatusBadRequest)
+		return
+	}
+
+	// Kullanıcı ID'sini al (opsiyonel)
+	userID, _ := middleware.GetUserID(r)
+
+	// Farkı hesapla
+	diff, err := h.noteService.DiffRevisions(uint(noteID), userID, from, to)
+	if err != nil {
+		writeRevisionError(w, err, "Revizyon karşılaştırma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(diff)
+}
+
+// RestoreRevision, notu önceki bir revizyonuna geri döndürür
+func (h *NoteHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Not ID'sini al
+	idStr := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz not ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Revizyon numarasını al
+	number, err := strconv.Atoi(chi.URLParam(r, "number"))
+	if err != nil || number <= 0 {
+		http.Error(w, "Geçersiz revizyon numarası", http.StatusBadRequest)
+		return
+	}
+
+	// Revizyonu geri yükle
+	note, err := h.noteService.RestoreRevision(uint(noteID), userID, number)
+	if err != nil {
+		writeRevisionError(w, err, "Revizyon geri yükleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(note)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/infrastructure/http/utils"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// SearchHandler, notlar ve PDF'ler üzerinde birleşik aramayı yönetir
+type SearchHandler struct {
+	searchService *usecase.SearchService
+}
+
+// NewSearchHandler, yeni bir SearchHandler örneği oluşturur
+func NewSearchHandler(searchService *usecase.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *SearchHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// Giriş yapmış kullanıcıların özel içerikleri de aranır
+	r.Get("/search", func(w http.ResponseWriter, r *http.Request) {
+		middleware.OptionalAuth(authMiddleware, h.Search).ServeHTTP(w, r)
+	})
+}
+
+// Search, notlar ve PDF'ler içinde arama yapar ve sonuçları puana göre sıralı döndürür
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	// Arama sorgusunu al
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		http.Error(w, "Arama sorgusu gerekli", http.StatusBadRequest)
+		return
+	}
+
+	// İçerik türü filtresi (note, pdf veya tümü)
+	contentType := r.URL.Query().Get("type")
+	if contentType == "all" {
+		contentType = ""
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := utils.GetPaginationParams(r)
+
+	userID, _ := middleware.GetUserID(r)
+	hits, err := h.searchService.Search(query, contentType, r.URL.Query().Get("lang"), userID, limit, offset)
+	if err != nil {
+		switch err {
+		case usecase.ErrInvalidParameters:
+			http.Error(w, "Geçersiz arama sorgusu", http.StatusBadRequest)
+		case usecase.ErrInvalidSearchLanguage:
+			http.Error(w, "Geçersiz arama dili", http.StatusBadRequest)
+		case usecase.ErrInvalidType:
+			http.Error(w, "Geçersiz içerik türü", http.StatusBadRequest)
+		default:
+			http.Error(w, "Arama sırasında hata: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	// Başarılı yanıt
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(hits)
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/OmerFErdogan/uninote/infrastructure/http/middleware"
+	"github.com/OmerFErdogan/uninote/usecase"
+	"github.com/go-chi/chi/v5"
+)
+
+// ShareHandler, not ve PDF'lerin belirli kullanıcılarla paylaşılması işlemlerini yönetir
+type ShareHandler struct {
+	shareService *usecase.ShareService
+}
+
+// NewShareHandler, yeni bir ShareHandler örneği oluşturur
+func NewShareHandler(shareService *usecase.ShareService) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+	}
+}
+
+// RegisterRoutes, yönlendirmeleri kaydeder
+func (h *ShareHandler) RegisterRoutes(r chi.Router, authMiddleware *middleware.AuthMiddleware) {
+	// Tüm paylaşım rotaları kimlik doğrulama gerektirir
+	r.Group(func(r chi.Router) {
+		r.Use(authMiddleware.Middleware)
+		r.Post("/notes/{id}/shares", h.ShareNote)
+		r.Get("/notes/{id}/shares", h.GetNoteShares)
+		r.Post("/pdfs/{id}/shares", h.SharePDF)
+		r.Get("/pdfs/{id}/shares", h.GetPDFShares)
+		r.Get("/shares/with-me", h.GetSharedWithMe)
+		r.Put("/shares/{id}", h.UpdateShare)
+		r.Delete("/shares/{id}", h.RevokeShare)
+	})
+}
+
+// ShareRequest, içeriği bir kullanıcıyla paylaşma isteği
+type ShareRequest struct {
+	User string `json:"user"` // Kullanıcı adı veya e-posta adresi
+	Role string `json:"role"` // "viewer", "commenter" veya "editor"
+}
+
+// ShareRoleRequest, paylaşım rolünü değiştirme isteği
+type ShareRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// writeShareError, paylaşım servisinden dönen hatayı uygun HTTP yanıtına dönüştürür
+func writeShareError(w http.ResponseWriter, err error, action string) {
+	switch {
+	case err == usecase.ErrContentNotFound:
+		http.Error(w, "İçerik bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrShareNotFound:
+		http.Error(w, "Paylaşım bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrShareUserNotFound:
+		http.Error(w, "Paylaşılacak kullanıcı bulunamadı", http.StatusNotFound)
+	case err == usecase.ErrNotAuthorized:
+		http.Error(w, "Bu işlem için yetkiniz yok", http.StatusForbidden)
+	case err == usecase.ErrInvalidType:
+		http.Error(w, "Geçersiz içerik türü", http.StatusBadRequest)
+	case errors.Is(err, usecase.ErrInvalidShare):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, action+" sırasında hata: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ShareNote, notu bir kullanıcıyla paylaşır
+func (h *ShareHandler) ShareNote(w http.ResponseWriter, r *http.Request) {
+	h.shareContent(w, r, "note", "Geçersiz not ID'si")
+}
+
+// SharePDF, PDF'i bir kullanıcıyla paylaşır
+func (h *ShareHandler) SharePDF(w http.ResponseWriter, r *http.Request) {
+	h.shareContent(w, r, "pdf", "Geçersiz PDF ID'si")
+}
+
+func (h *ShareHandler) shareContent(w http.ResponseWriter, r *http.Request, contentType, invalidID string) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	contentID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, invalidID, http.StatusBadRequest)
+		return
+	}
+
+	var req ShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// İçeriği paylaş; kullanıcıyla zaten paylaşılmışsa rol güncellenir
+	share, err := h.shareService.ShareContent(uint(contentID), contentType, userID, req.User, req.Role)
+	if err != nil {
+		writeShareError(w, err, "Paylaşma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(share)
+}
+
+// GetNoteShares, notun paylaşıldığı kullanıcıları getirir
+func (h *ShareHandler) GetNoteShares(w http.ResponseWriter, r *http.Request) {
+	h.getShares(w, r, "note", "Geçersiz not ID'si")
+}
+
+// GetPDFShares, PDF'in paylaşıldığı kullanıcıları getirir
+func (h *ShareHandler) GetPDFShares(w http.ResponseWriter, r *http.Request) {
+	h.getShares(w, r, "pdf", "Geçersiz PDF ID'si")
+}
+
+func (h *ShareHandler) getShares(w http.ResponseWriter, r *http.Request, contentType, invalidID string) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	contentID, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, invalidID, http.StatusBadRequest)
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Paylaşımları getir
+	shares, err := h.shareService.GetShares(uint(contentID), contentType, userID, limit, offset)
+	if err != nil {
+		writeShareError(w, err, "Paylaşımları getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(shares)
+}
+
+// GetSharedWithMe, kullanıcıyla paylaşılan not ve PDF'leri getirir
+func (h *ShareHandler) GetSharedWithMe(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	// Sayfalama parametrelerini al
+	limit, offset := getPaginationParams(r)
+
+	// Paylaşılan içerikleri getir
+	contents, err := h.shareService.GetSharedWithMe(userID, r.URL.Query().Get("type"), limit, offset)
+	if err != nil {
+		writeShareError(w, err, "Paylaşılan içerikleri getirme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(contents)
+}
+
+// UpdateShare, paylaşımın rolünü değiştirir
+func (h *ShareHandler) UpdateShare(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz paylaşım ID'si", http.StatusBadRequest)
+		return
+	}
+
+	var req ShareRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Geçersiz istek formatı", http.StatusBadRequest)
+		return
+	}
+
+	// Rolü değiştir
+	share, err := h.shareService.UpdateShare(uint(id), userID, req.Role)
+	if err != nil {
+		writeShareError(w, err, "Paylaşım güncelleme")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(share)
+}
+
+// RevokeShare, paylaşımı kaldırır
+func (h *ShareHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	// Kullanıcı ID'sini al
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.ParseUint(chi.URLParam(r, "id"), 10, 32)
+	if err != nil {
+		http.Error(w, "Geçersiz paylaşım ID'si", http.StatusBadRequest)
+		return
+	}
+
+	// Paylaşımı kaldır
+	if err := h.shareService.RevokeShare(uint(id), userID); err != nil {
+		writeShareError(w, err, "Paylaşım kaldırma")
+		return
+	}
+
+	// Başarılı yanıt
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{
+		"message": "Paylaşım kaldırıldı",
+	})
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// RequireAdmin, isteği yalnızca yönetici olarak tanımlanmış kullanıcılara geçiren bir middleware döndürür.
+// Kullanıcı kimliğini context'ten okuduğu için AuthMiddleware'den sonra kullanılmalıdır.
+// Yönetici listesi boşsa yönetici endpoint'lerine kimse erişemez.
+func RequireAdmin(adminUserIDs []uint) func(http.Handler) http.Handler {
+	admins := make(map[uint]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, ok := GetUserID(r)
+			if !ok {
+				http.Error(w, "Kullanıcı kimliği bulunamadı", http.StatusUnauthorized)
+				return
+			}
+			if !admins[userID] {
+				http.Error(w, "Bu işlem için yönetici yetkisi gerekiyor", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
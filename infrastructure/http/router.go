@@ -29,7 +29,8 @@ func NewRouter() *Router {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Invite-Token, X-Invite-Password, If-Match, If-None-Match, If-Range, Range, Last-Event-ID")
+			w.Header().Set("Access-Control-Expose-Headers", "ETag, Accept-Ranges, Content-Range, Content-Length")
 
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
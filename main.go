@@ -19,8 +19,10 @@ func main() {
 		log.Fatalf("Çalışma dizini alınamadı: %v", err)
 	}
 
-	// cmd/server/main.go'yu çalıştır
-	cmd := exec.Command("go", "run", filepath.Join(wd, "cmd", "server", "main.go"))
+	// cmd/server paketini çalıştır
+	// Komut satırı argümanlarını (ör. "migrate up") sunucuya aktar
+	args := append([]string{"run", filepath.Join(wd, "cmd", "server")}, os.Args[1:]...)
+	cmd := exec.Command("go", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// Erişim düzeyleri; her düzey bir öncekinin yetkilerini de içerir
+const (
+	accessNone = iota
+	accessView
+	accessComment
+	accessEdit
+	accessOwner
+)
+
+// shareRoleLevels, kullanıcı paylaşım rollerinin karşılık geldiği erişim düzeyleri
+var shareRoleLevels = map[string]int{
+	domain.ShareRoleViewer:    accessView,
+	domain.ShareRoleCommenter: accessComment,
+	domain.ShareRoleEditor:    accessEdit,
+}
+
+// ContentAccess, not ve PDF'lerin kimler tarafından görüntülenebileceğine, yorumlanabileceğine ve
+// düzenlenebileceğine karar verir. Herkese açık içerikleri herkes görebilir ve giriş yapmış herkes
+// yorumlayabilir. Özel içerikleri sahibi, içeriğin paylaşıldığı çalışma gruplarının üyeleri ve içeriğin
+// doğrudan paylaşıldığı kullanıcılar paylaşım rollerinin izin verdiği ölçüde kullanabilir. Düzenleme
+// yalnızca sahibe ve editör rolündeki kullanıcılara açıktır.
+type ContentAccess struct {
+	groupRepo domain.GroupRepository
+	shareRepo domain.ShareRepository
+}
+
+// NewContentAccess, yeni bir ContentAccess örneği oluşturur
+func NewContentAccess(groupRepo domain.GroupRepository, shareRepo domain.ShareRepository) *ContentAccess {
+	return &ContentAccess{groupRepo: groupRepo, shareRepo: shareRepo}
+}
+
+// CanViewNote, kullanıcının notu görüp göremeyeceğini döndürür. userID 0 ise kullanıcı giriş yapmamıştır.
+func (a *ContentAccess) CanViewNote(note *domain.Note, userID uint) (bool, error) {
+	return a.allows(note.ID, "note", note.UserID, note.IsPublic, userID, accessView)
+}
+
+// CanViewPDF, kullanıcının PDF'i görüp göremeyeceğini döndürür. userID 0 ise kullanıcı giriş yapmamıştır.
+func (a *ContentAccess) CanViewPDF(pdf *domain.PDF, userID uint) (bool, error) {
+	return a.allows(pdf.ID, "pdf", pdf.UserID, pdf.IsPublic, userID, accessView)
+}
+
+// CanCommentNote, kullanıcının nota yorum yapıp yapamayacağını döndürür
+func (a *ContentAccess) CanCommentNote(note *domain.Note, userID uint) (bool, error) {
+	return a.allows(note.ID, "note", note.UserID, note.IsPublic, userID, accessComment)
+}
+
+// CanCommentPDF, kullanıcının PDF'e yorum yapıp yapamayacağını döndürür
+func (a *ContentAccess) CanCommentPDF(pdf *domain.PDF, userID uint) (bool, error) {
+	return a.allows(pdf.ID, "pdf", pdf.UserID, pdf.IsPublic, userID, accessComment)
+}
+
+// CanEditNote, kullanıcının notu düzenleyip düzenleyemeyeceğini döndürür
+func (a *ContentAccess) CanEditNote(note *domain.Note, userID uint) (bool, error) {
+	return a.allows(note.ID, "note", note.UserID, note.IsPublic, userID, accessEdit)
+}
+
+// CanEditPDF, kullanıcının PDF bilgilerini düzenleyip düzenleyemeyeceğini döndürür
+func (a *ContentAccess) CanEditPDF(pdf *domain.PDF, userID uint) (bool, error) {
+	return a.allows(pdf.ID, "pdf", pdf.UserID, pdf.IsPublic, userID, accessEdit)
+}
+
+func (a *ContentAccess) allows(contentID uint, contentType string, ownerID uint, isPublic bool, userID uint, required int) (bool, error) {
+	level, err := a.level(contentID, contentType, ownerID, isPublic, userID)
+	if err != nil {
+		return false, err
+	}
+	return level >= required, nil
+}
+
+// level, kullanıcının içerik üzerindeki en yüksek erişim düzeyini döndürür
+func (a *ContentAccess) level(contentID uint, contentType string, ownerID uint, isPublic bool, userID uint) (int, error) {
+	if userID == 0 {
+		if isPublic {
+			return accessView, nil
+		}
+		return accessNone, nil
+	}
+	if ownerID == userID {
+		return accessOwner, nil
+	}
+
+	// Doğrudan paylaşımın rolü
+	level := accessNone
+	share, err := a.shareRepo.Find(contentID, contentType, userID)
+	if err != nil {
+		return accessNone, fmt.Errorf("kullanıcı paylaşımı kontrolü sırasında hata: %w", err)
+	}
+	if share != nil {
+		level = shareRoleLevels[share.Role]
+	}
+	if level >= accessComment {
+		return level, nil
+	}
+
+	// Herkese açık içerikleri ve üyesi olunan bir grubun alanına paylaşılmış içerikleri yorumlayabilir
+	if isPublic {
+		return accessComment, nil
+	}
+	shared, err := a.groupRepo.IsContentShared(contentID, contentType, userID)
+	if err != nil {
+		return accessNone, fmt.Errorf("grup paylaşımı kontrolü sırasında hata: %w", err)
+	}
+	if shared {
+		return accessComment, nil
+	}
+	return level, nil
+}
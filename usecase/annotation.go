@@ -0,0 +1,649 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	// ErrInvalidAnnotation, işaretlemenin sayfası, konumu, türü, rengi, görünürlüğü, alıntısı veya
+	// içe aktarılan belge geçersiz olduğunda döner
+	ErrInvalidAnnotation  = errors.New("geçersiz işaretleme")
+	ErrAnnotationNotFound = errors.New("işaretleme bulunamadı")
+)
+
+const (
+	// MaxAnnotationImport, tek istekte içe aktarılabilecek en fazla işaretleme sayısı
+	MaxAnnotationImport = 1000
+	// MaxLayerAnnotations, katmanlar listelenirken döndürülen en fazla işaretleme sayısı
+	MaxLayerAnnotations = 5000
+
+	maxAnnotationRects    = 200  // Bir işaretlemedeki en fazla satır dikdörtgeni
+	maxQuoteExactLength   = 5000 // Alıntılanan metnin karakter cinsinden en fazla uzunluğu
+	maxQuoteContextLength = 500  // Alıntının önündeki ve arkasındaki metnin en fazla uzunluğu
+
+	// coordinateTolerance, istemcilerde kayan nokta hesabından doğan küçük taşmaları kabul eder
+	coordinateTolerance = 1e-6
+
+	defaultAnnotationColor = "#FFFF00"
+)
+
+var (
+	// hexColor, işaretleme rengi olarak kabul edilen #RGB, #RGBA, #RRGGBB ve #RRGGBBAA biçimleridir
+	hexColor = regexp.MustCompile(`^#(?:[0-9A-Fa-f]{8}|[0-9A-Fa-f]{6}|[0-9A-Fa-f]{3,4})$`)
+	// cssHexColor, CSS stil sayfası içindeki onaltılık renkleri bulur
+	cssHexColor = regexp.MustCompile(`#(?:[0-9A-Fa-f]{8}|[0-9A-Fa-f]{6}|[0-9A-Fa-f]{3,4})\b`)
+)
+
+// canViewPDF, kullanıcının PDF'i görüp göremeyeceğini belirler: PDF herkese açıksa herkes, özelse
+// sahibi, PDF'in paylaşıldığı grupların üyeleri ve PDF için oluşturulmuş geçerli bir davet
+// bağlantısıyla gelenler görebilir
+func (s *PDFService) canViewPDF(pdf *domain.PDF, userID uint, invite *domain.Invite) (bool, error) {
+	if invitedToPDF(invite, pdf.ID) {
+		return true, nil
+	}
+	return s.access.CanViewPDF(pdf, userID)
+}
+
+// canAnnotatePDF, kullanıcının PDF'e işaretleme ekleyip ekleyemeyeceğini belirler: PDF'i davet
+// bağlantısı olmadan görebilenler ve PDF için işaretleme yetkili bir davet bağlantısıyla gelenler
+// ekleyebilir
+func (s *PDFService) canAnnotatePDF(pdf *domain.PDF, userID uint, invite *domain.Invite) (bool, error) {
+	if invitePermits(invite, "pdf", pdf.ID, domain.InvitePermissionAnnotate) {
+		return true, nil
+	}
+	return s.access.CanViewPDF(pdf, userID)
+}
+
+// invitedToPDF, davet bağlantısının verilen PDF için oluşturulup oluşturulmadığını kontrol eder
+func invitedToPDF(invite *domain.Invite, pdfID uint) bool {
+	return invitePermits(invite, "pdf", pdfID, domain.InvitePermissionRead)
+}
+
+// knownAnnotationType, işaretleme türünün desteklenen türlerden biri olup olmadığını kontrol eder
+func knownAnnotationType(annotationType string) bool {
+	switch annotationType {
+	case domain.AnnotationTypeHighlight, domain.AnnotationTypeUnderline, domain.AnnotationTypeStrikeout, domain.AnnotationTypeNote:
+		return true
+	}
+	return false
+}
+
+// validAnnotationVisibility, görünürlüğün desteklenen değerlerden biri olup olmadığını kontrol eder
+func validAnnotationVisibility(visibility string) bool {
+	switch visibility {
+	case domain.AnnotationVisibilityPrivate, domain.AnnotationVisibilityShared, domain.AnnotationVisibilityPublic:
+		return true
+	}
+	return false
+}
+
+// validRect, dikdörtgenin normalleştirilmiş koordinatlarla sayfanın içinde kalıp kalmadığını kontrol eder
+func validRect(x, y, width, height float64) bool {
+	for _, v := range []float64{x, y, width, height} {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v < 0 || v > 1 {
+			return false
+		}
+	}
+	return x+width <= 1+coordinateTolerance && y+height <= 1+coordinateTolerance
+}
+
+// validateQuote, alıntının boş olmadığını ve uzunluk sınırlarını aşmadığını kontrol eder
+func validateQuote(quote *domain.TextQuoteSelector) error {
+	if quote == nil {
+		return nil
+	}
+	if strings.TrimSpace(quote.Exact) == "" {
+		return errors.New("alıntılanan metin boş olamaz")
+	}
+	if utf8.RuneCountInString(quote.Exact) > maxQuoteExactLength {
+		return fmt.Errorf("alıntılanan metin en fazla %d karakter olabilir", maxQuoteExactLength)
+	}
+	if utf8.RuneCountInString(quote.Prefix) > maxQuoteContextLength || utf8.RuneCountInString(quote.Suffix) > maxQuoteContextLength {
+		return fmt.Errorf("alıntının önündeki ve arkasındaki metin en fazla %d karakter olabilir", maxQuoteContextLength)
+	}
+	return nil
+}
+
+// prepareAnnotation, işaretlemeyi PDF'e göre doğrular: sayfa numarası PDF'in sayfa aralığında
+// (sayfa sayısı henüz bilinmiyorsa 1 veya daha büyük), konum sayfanın içinde, tür ve görünürlük
+// desteklenen değerlerden, renk onaltılık olmalıdır. Tür, renk ve görünürlük boşsa varsayılanlar
+// (highlight, #FFFF00, private) atanır; kapsayan dikdörtgen verilmemişse satır dikdörtgenlerinden hesaplanır.
+func prepareAnnotation(annotation *domain.PDFAnnotation, pdf *domain.PDF) error {
+	if annotation.PageNumber < 1 {
+		return fmt.Errorf("%w: sayfa numarası 1 veya daha büyük olmalı", ErrInvalidAnnotation)
+	}
+	if pdf.PageCount > 0 && annotation.PageNumber > pdf.PageCount {
+		return fmt.Errorf("%w: sayfa numarası PDF'in sayfa sayısını (%d) aşıyor", ErrInvalidAnnotation, pdf.PageCount)
+	}
+
+	if annotation.Type == "" {
+		annotation.Type = domain.AnnotationTypeHighlight
+	}
+	if !knownAnnotationType(annotation.Type) {
+		return fmt.Errorf("%w: tür highlight, underline, strikeout veya note olmalı", ErrInvalidAnnotation)
+	}
+	if annotation.Color == "" {
+		annotation.Color = defaultAnnotationColor
+	}
+	if !hexColor.MatchString(annotation.Color) {
+		return fmt.Errorf("%w: renk #RRGGBB biçiminde onaltılık olmalı", ErrInvalidAnnotation)
+	}
+	if annotation.Visibility == "" {
+		annotation.Visibility = domain.AnnotationVisibilityPrivate
+	}
+	if !validAnnotationVisibility(annotation.Visibility) {
+		return fmt.Errorf("%w: görünürlük private, shared veya public olmalı", ErrInvalidAnnotation)
+	}
+
+	if len(annotation.Rects) > maxAnnotationRects {
+		return fmt.Errorf("%w: en fazla %d dikdörtgen verilebilir", ErrInvalidAnnotation, maxAnnotationRects)
+	}
+	for _, rect := range annotation.Rects {
+		if !validRect(rect.X, rect.Y, rect.Width, rect.Height) {
+			return fmt.Errorf("%w: dikdörtgenler sayfa boyutuna göre 0 ile 1 arasında normalleştirilmiş koordinatlarla verilmeli", ErrInvalidAnnotation)
+		}
+	}
+	if annotation.Width == 0 && annotation.Height == 0 && len(annotation.Rects) > 0 {
+		annotation.X, annotation.Y, annotation.Width, annotation.Height = boundingBox(annotation.Rects)
+	}
+	if !validRect(annotation.X, annotation.Y, annotation.Width, annotation.Height) {
+		return fmt.Errorf("%w: konum sayfa boyutuna göre 0 ile 1 arasında normalleştirilmiş koordinatlarla verilmeli", ErrInvalidAnnotation)
+	}
+
+	if err := validateQuote(annotation.Quote); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAnnotation, err)
+	}
+	return nil
+}
+
+// boundingBox, dikdörtgenlerin tamamını kapsayan en küçük dikdörtgeni döndürür
+func boundingBox(rects []domain.PDFRect) (x, y, width, height float64) {
+	left, top := rects[0].X, rects[0].Y
+	right, bottom := rects[0].X+rects[0].Width, rects[0].Y+rects[0].Height
+	for _, rect := range rects[1:] {
+		left = math.Min(left, rect.X)
+		top = math.Min(top, rect.Y)
+		right = math.Max(right, rect.X+rect.Width)
+		bottom = math.Max(bottom, rect.Y+rect.Height)
+	}
+	return left, top, math.Min(right, 1) - left, math.Min(bottom, 1) - top
+}
+
+// UpdateAnnotation, işaretlemenin metnini, konumunu, alıntısını, türünü, rengini ve görünürlüğünü
+// verilen değerlerle değiştirir. Yalnızca işaretlemeyi oluşturan kullanıcı güncelleyebilir. İşaretleme
+// annotation.PDFID ile belirtilen PDF'e ait değilse ErrAnnotationNotFound döner. Başarılı olursa
+// annotation kaydedilen haliyle doldurulur.
+func (s *PDFService) UpdateAnnotation(annotation *domain.PDFAnnotation, userID uint) error {
+	// İşaretlemeyi bul
+	existing, err := s.pdfAnnotRepo.FindByID(annotation.ID)
+	if err != nil {
+		return fmt.Errorf("işaretleme arama sırasında hata: %w", err)
+	}
+	if existing == nil || existing.PDFID != annotation.PDFID {
+		return ErrAnnotationNotFound
+	}
+
+	// Kullanıcı yetkisi kontrol et
+	if existing.UserID != userID {
+		return ErrNotAuthorized
+	}
+
+	// PDF'i bul; sayfa aralığı doğrulaması için gerekir
+	pdf, err := s.pdfRepo.FindByID(existing.PDFID)
+	if err != nil {
+		return fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil {
+		return ErrPDFNotFound
+	}
+
+	annotation.UserID = existing.UserID
+	if err := prepareAnnotation(annotation, pdf); err != nil {
+		return err
+	}
+
+	// İşaretlemeyi güncelle
+	if err := s.pdfAnnotRepo.Update(annotation); err != nil {
+		return err
+	}
+
+	updated, err := s.pdfAnnotRepo.FindByID(annotation.ID)
+	if err != nil {
+		return fmt.Errorf("işaretleme arama sırasında hata: %w", err)
+	}
+	if updated != nil {
+		*annotation = *updated
+	}
+	return nil
+}
+
+// DeleteAnnotation, işaretlemeyi siler. Yalnızca işaretlemeyi oluşturan kullanıcı silebilir.
+func (s *PDFService) DeleteAnnotation(pdfID, annotationID, userID uint) error {
+	// İşaretlemeyi bul
+	annotation, err := s.pdfAnnotRepo.FindByID(annotationID)
+	if err != nil {
+		return fmt.Errorf("işaretleme arama sırasında hata: %w", err)
+	}
+	if annotation == nil || annotation.PDFID != pdfID {
+		return ErrAnnotationNotFound
+	}
+
+	// Kullanıcı yetkisi kontrol et
+	if annotation.UserID != userID {
+		return ErrNotAuthorized
+	}
+
+	return s.pdfAnnotRepo.Delete(annotationID)
+}
+
+// GetAnnotationLayers, PDF üzerinde diğer kullanıcıların görülebilen işaretlemelerini kullanıcı başına
+// bir katman olarak döndürür. PDF'i görebilen herkes herkese açık (public) işaretlemeleri; PDF sahibi ve
+// PDF için geçerli bir davet bağlantısıyla gelenler ayrıca paylaşılan (shared) işaretlemeleri görür.
+// Kullanıcının kendi işaretlemeleri katmanlarda yer almaz. userID 0 ise kullanıcı giriş yapmamıştır;
+// invite nil olabilir.
+func (s *PDFService) GetAnnotationLayers(pdfID, userID uint, invite *domain.Invite) ([]*domain.AnnotationLayer, error) {
+	// PDF'i bul
+	pdf, err := s.pdfRepo.FindByID(pdfID)
+	if err != nil {
+		return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil {
+		return nil, ErrPDFNotFound
+	}
+	canView, err := s.canViewPDF(pdf, userID, invite)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrNotAuthorized
+	}
+
+	visibilities := []string{domain.AnnotationVisibilityPublic}
+	if (userID != 0 && pdf.UserID == userID) || invitedToPDF(invite, pdf.ID) {
+		visibilities = append(visibilities, domain.AnnotationVisibilityShared)
+	}
+
+	annotations, err := s.pdfAnnotRepo.FindVisibleByPDFID(pdf.ID, visibilities, userID, MaxLayerAnnotations)
+	if err != nil {
+		return nil, fmt.Errorf("işaretleme arama sırasında hata: %w", err)
+	}
+
+	// İşaretlemeler kullanıcıya göre sıralı geldiği için ardışık olanlar aynı katmana girer
+	layers := make([]*domain.AnnotationLayer, 0)
+	for _, annotation := range annotations {
+		if n := len(layers); n == 0 || layers[n-1].UserID != annotation.UserID {
+			layer, err := s.newAnnotationLayer(annotation.UserID)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, layer)
+		}
+		layer := layers[len(layers)-1]
+		layer.Annotations = append(layer.Annotations, annotation)
+	}
+	return layers, nil
+}
+
+// newAnnotationLayer, kullanıcının bilgileriyle boş bir katman oluşturur
+func (s *PDFService) newAnnotationLayer(userID uint) (*domain.AnnotationLayer, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı arama sırasında hata: %w", err)
+	}
+
+	// Kullanıcı bulunamadıysa, varsayılan değerler kullan
+	layer := &domain.AnnotationLayer{
+		UserID:   userID,
+		Username: "Silinmiş Kullanıcı",
+		FullName: "Silinmiş Kullanıcı",
+	}
+	if user != nil {
+		layer.Username = user.Username
+		layer.FullName = user.FirstName + " " + user.LastName
+	}
+	return layer, nil
+}
+
+// ExportAnnotations, kullanıcının PDF üzerindeki işaretlemelerini W3C Web Annotation (JSON-LD)
+// koleksiyonu olarak döndürür. pdfIRI, PDF'in API'deki mutlak adresidir
+// (ör. https://ornek.com/api/v1/pdfs/5); işaretleme ve hedef adresleri bundan türetilir.
+func (s *PDFService) ExportAnnotations(pdfID, userID uint, pdfIRI string) (*domain.WebAnnotationCollection, error) {
+	annotations, err := s.GetAnnotations(pdfID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.WebAnnotation, 0, len(annotations))
+	for _, annotation := range annotations {
+		items = append(items, toWebAnnotation(annotation, pdfIRI))
+	}
+
+	return &domain.WebAnnotationCollection{
+		Context: domain.WebAnnotationContext,
+		ID:      pdfIRI + "/annotations/export",
+		Type:    "AnnotationCollection",
+		Total:   len(items),
+		First: &domain.WebAnnotationPage{
+			Type:  "AnnotationPage",
+			Items: items,
+		},
+	}, nil
+}
+
+// ImportAnnotations, W3C Web Annotation (JSON-LD) biçimindeki işaretlemeleri kullanıcının PDF
+// üzerindeki işaretlemeleri olarak kaydeder. Tek bir Annotation, Annotation dizisi, AnnotationPage
+// veya gömülü ilk sayfası olan AnnotationCollection kabul edilir. Hedefin kaynağı dikkate alınmaz;
+// işaretlemeler pdfID ile belirtilen PDF'e verilen görünürlükle (boşsa private) eklenir. Erişim
+// kuralları AddAnnotation ile aynıdır. İşaretlemelerden biri geçersizse hiçbiri kaydedilmez.
+func (s *PDFService) ImportAnnotations(pdfID, userID uint, invite *domain.Invite, visibility string, data []byte) ([]*domain.PDFAnnotation, error) {
+	// PDF'i bul
+	pdf, err := s.pdfRepo.FindByID(pdfID)
+	if err != nil {
+		return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil {
+		return nil, ErrPDFNotFound
+	}
+	canAnnotate, err := s.canAnnotatePDF(pdf, userID, invite)
+	if err != nil {
+		return nil, err
+	}
+	if !canAnnotate {
+		return nil, ErrNotAuthorized
+	}
+
+	items, err := parseWebAnnotations(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: içe aktarılacak işaretleme bulunamadı", ErrInvalidAnnotation)
+	}
+	if len(items) > MaxAnnotationImport {
+		return nil, fmt.Errorf("%w: tek seferde en fazla %d işaretleme içe aktarılabilir", ErrInvalidAnnotation, MaxAnnotationImport)
+	}
+
+	// Önce tümünü doğrula; böylece geçersiz bir öğe yarım kalmış bir içe aktarmaya yol açmaz
+	annotations := make([]*domain.PDFAnnotation, 0, len(items))
+	for i := range items {
+		annotation, err := fromWebAnnotation(&items[i])
+		if err == nil {
+			annotation.Visibility = visibility
+			err = prepareAnnotation(annotation, pdf)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%d. işaretleme: %w", i+1, err)
+		}
+		annotation.PDFID = pdf.ID
+		annotation.UserID = userID
+		annotations = append(annotations, annotation)
+	}
+
+	for _, annotation := range annotations {
+		if err := s.pdfAnnotRepo.Create(annotation); err != nil {
+			return nil, fmt.Errorf("işaretleme kaydedilirken hata: %w", err)
+		}
+	}
+	return annotations, nil
+}
+
+// toWebAnnotation, işaretlemeyi W3C Web Annotation modeline dönüştürür. Sayfa RFC 3778 parça
+// seçicisiyle, sayfa içindeki alan yüzde cinsinden Media Fragments seçicisiyle belirtilir; alıntı
+// varsa aynı sayfayı daraltan ikinci bir seçenek olarak TextQuoteSelector eklenir.
+func toWebAnnotation(annotation *domain.PDFAnnotation, pdfIRI string) domain.WebAnnotation {
+	page := fmt.Sprintf("page=%d", annotation.PageNumber)
+	area := domain.WebAnnotationSelector{
+		Type:       "FragmentSelector",
+		ConformsTo: domain.PDFFragmentSpec,
+		Value:      page,
+	}
+	if annotation.Width > 0 || annotation.Height > 0 {
+		area.RefinedBy = &domain.WebAnnotationSelector{
+			Type:       "FragmentSelector",
+			ConformsTo: domain.MediaFragmentSpec,
+			Value: "xywh=percent:" + strings.Join([]string{
+				percent(annotation.X), percent(annotation.Y), percent(annotation.Width), percent(annotation.Height),
+			}, ","),
+		}
+	}
+	selectors := domain.OneOrMany[domain.WebAnnotationSelector]{area}
+	if annotation.Quote != nil {
+		selectors = append(selectors, domain.WebAnnotationSelector{
+			Type:       "FragmentSelector",
+			ConformsTo: domain.PDFFragmentSpec,
+			Value:      page,
+			RefinedBy: &domain.WebAnnotationSelector{
+				Type:   "TextQuoteSelector",
+				Exact:  annotation.Quote.Exact,
+				Prefix: annotation.Quote.Prefix,
+				Suffix: annotation.Quote.Suffix,
+			},
+		})
+	}
+
+	target := domain.WebAnnotationTarget{
+		Source:   pdfIRI + "/content",
+		Selector: selectors,
+	}
+
+	motivation := "highlighting"
+	if annotation.Type == domain.AnnotationTypeNote {
+		motivation = "commenting"
+	}
+
+	webAnnotation := domain.WebAnnotation{
+		ID:         fmt.Sprintf("%s/annotations/%d", pdfIRI, annotation.ID),
+		Type:       "Annotation",
+		Motivation: motivation,
+		Created:    annotation.CreatedAt.UTC().Format(time.RFC3339),
+		Modified:   annotation.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+	if annotation.Content != "" {
+		webAnnotation.Body = domain.OneOrMany[domain.WebAnnotationBody]{{
+			Type:    "TextualBody",
+			Value:   annotation.Content,
+			Format:  "text/plain",
+			Purpose: "commenting",
+		}}
+	}
+	if class := styleClass(annotation.Type); class != "" {
+		target.StyleClass = class
+		if annotation.Color != "" && cssHexColor.MatchString(annotation.Color) {
+			webAnnotation.Stylesheet = &domain.WebAnnotationStylesheet{
+				Type:  "CssStylesheet",
+				Value: fmt.Sprintf(".%s { background-color: %s; }", class, annotation.Color),
+			}
+		}
+	}
+	webAnnotation.Target = domain.OneOrMany[domain.WebAnnotationTarget]{target}
+	return webAnnotation
+}
+
+// percent, normalleştirilmiş koordinatı yüzdeye çevirir; kayan nokta gürültüsünü önlemek için
+// dört ondalık basamağa yuvarlar
+func percent(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e4, 'f', -1, 64)
+}
+
+// styleClass, işaretleme türünü CSS sınıf adı olarak kullanılabilecek hale getirir
+func styleClass(annotationType string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return -1
+	}, annotationType)
+}
+
+// parseWebAnnotations, içe aktarılan JSON-LD belgesindeki işaretlemeleri döndürür
+func parseWebAnnotations(data []byte) ([]domain.WebAnnotation, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: istek gövdesi boş", ErrInvalidAnnotation)
+	}
+
+	if data[0] == '[' {
+		var items []domain.WebAnnotation
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("%w: JSON-LD belgesi okunamadı: %v", ErrInvalidAnnotation, err)
+		}
+		return items, nil
+	}
+
+	var probe struct {
+		Type  string          `json:"type"`
+		First json.RawMessage `json:"first"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("%w: JSON-LD belgesi okunamadı: %v", ErrInvalidAnnotation, err)
+	}
+
+	switch probe.Type {
+	case "Annotation":
+		var item domain.WebAnnotation
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, fmt.Errorf("%w: JSON-LD belgesi okunamadı: %v", ErrInvalidAnnotation, err)
+		}
+		return []domain.WebAnnotation{item}, nil
+	case "AnnotationPage":
+		return parseAnnotationPage(data)
+	case "AnnotationCollection":
+		first := bytes.TrimSpace(probe.First)
+		if len(first) == 0 || bytes.Equal(first, []byte("null")) {
+			return nil, nil
+		}
+		if first[0] != '{' {
+			return nil, fmt.Errorf("%w: koleksiyonun ilk sayfası belgeye gömülü olmalı", ErrInvalidAnnotation)
+		}
+		return parseAnnotationPage(first)
+	default:
+		return nil, fmt.Errorf("%w: desteklenmeyen JSON-LD türü %q; Annotation, AnnotationPage veya AnnotationCollection bekleniyor", ErrInvalidAnnotation, probe.Type)
+	}
+}
+
+// parseAnnotationPage, bir AnnotationPage'in öğelerini döndürür
+func parseAnnotationPage(data []byte) ([]domain.WebAnnotation, error) {
+	var page domain.WebAnnotationPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("%w: JSON-LD belgesi okunamadı: %v", ErrInvalidAnnotation, err)
+	}
+	return page.Items, nil
+}
+
+// fromWebAnnotation, W3C Web Annotation modelindeki işaretlemeyi PDF işaretlemesine dönüştürür.
+// Birden fazla hedef varsa ilki kullanılır. Sayfa, hedef kaynağındaki #page=N parçasından veya
+// seçicilerden; alan, yüzde cinsinden xywh seçicisinden; alıntı TextQuoteSelector'dan alınır.
+func fromWebAnnotation(item *domain.WebAnnotation) (*domain.PDFAnnotation, error) {
+	if item.Type != "Annotation" {
+		return nil, fmt.Errorf("%w: öğe türü Annotation olmalı", ErrInvalidAnnotation)
+	}
+	if len(item.Target) == 0 {
+		return nil, fmt.Errorf("%w: işaretlemenin hedefi yok", ErrInvalidAnnotation)
+	}
+	target := item.Target[0]
+
+	annotation := &domain.PDFAnnotation{}
+	if i := strings.IndexByte(target.Source, '#'); i >= 0 {
+		if page, ok := parsePageFragment(target.Source[i+1:]); ok {
+			annotation.PageNumber = page
+		}
+	}
+	for i := range target.Selector {
+		for selector := &target.Selector[i]; selector != nil; selector = selector.RefinedBy {
+			applySelector(annotation, selector)
+		}
+	}
+
+	for _, body := range item.Body {
+		if (body.Type == "TextualBody" || body.Type == "") && body.Purpose != "tagging" && body.Value != "" {
+			annotation.Content = body.Value
+			break
+		}
+	}
+
+	annotation.Type = annotationType(target.StyleClass, item.Motivation, annotation)
+	annotation.Color = defaultAnnotationColor
+	if item.Stylesheet != nil {
+		if color := cssHexColor.FindString(item.Stylesheet.Value); color != "" {
+			annotation.Color = color
+		}
+	}
+	return annotation, nil
+}
+
+// applySelector, tek bir seçicinin belirttiği sayfa, alan veya alıntıyı işaretlemeye uygular
+func applySelector(annotation *domain.PDFAnnotation, selector *domain.WebAnnotationSelector) {
+	switch selector.Type {
+	case "FragmentSelector":
+		if page, ok := parsePageFragment(selector.Value); ok {
+			annotation.PageNumber = page
+		} else if x, y, width, height, ok := parsePercentXYWH(selector.Value); ok {
+			annotation.X, annotation.Y, annotation.Width, annotation.Height = x, y, width, height
+		}
+	case "TextQuoteSelector":
+		if annotation.Quote == nil && selector.Exact != "" {
+			annotation.Quote = &domain.TextQuoteSelector{
+				Exact:  selector.Exact,
+				Prefix: selector.Prefix,
+				Suffix: selector.Suffix,
+			}
+		}
+	}
+}
+
+// annotationType, içe aktarılan işaretlemenin türünü stil sınıfından, yoksa amacından belirler
+func annotationType(class, motivation string, annotation *domain.PDFAnnotation) string {
+	switch class {
+	case domain.AnnotationTypeHighlight, domain.AnnotationTypeUnderline, domain.AnnotationTypeStrikeout, domain.AnnotationTypeNote:
+		return class
+	}
+	// Metne veya alana bağlı olmayan yorumlar sayfaya iliştirilmiş not olarak kabul edilir
+	if motivation == "commenting" && annotation.Quote == nil && annotation.Width == 0 && annotation.Height == 0 {
+		return domain.AnnotationTypeNote
+	}
+	return domain.AnnotationTypeHighlight
+}
+
+// parsePageFragment, "page=N" biçimindeki PDF parçasından sayfa numarasını okur (RFC 3778)
+func parsePageFragment(fragment string) (int, bool) {
+	for _, part := range strings.Split(fragment, "&") {
+		if value, ok := strings.CutPrefix(part, "page="); ok {
+			page, err := strconv.Atoi(value)
+			return page, err == nil
+		}
+	}
+	return 0, false
+}
+
+// parsePercentXYWH, "xywh=percent:x,y,w,h" biçimindeki alanı normalleştirilmiş koordinatlara çevirir.
+// Piksel cinsinden alanlar sayfa boyutu bilinmeden normalleştirilemeyeceği için kabul edilmez.
+func parsePercentXYWH(fragment string) (x, y, width, height float64, ok bool) {
+	value, found := strings.CutPrefix(fragment, "xywh=percent:")
+	if !found {
+		return 0, 0, 0, 0, false
+	}
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return 0, 0, 0, 0, false
+	}
+	var coords [4]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return 0, 0, 0, 0, false
+		}
+		coords[i] = v / 100
+	}
+	return coords[0], coords[1], coords[2], coords[3], true
+}
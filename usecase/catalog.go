@@ -0,0 +1,548 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	ErrUniversityNotFound    = errors.New("üniversite bulunamadı")
+	ErrDepartmentNotFound    = errors.New("bölüm bulunamadı")
+	ErrCourseNotFound        = errors.New("ders bulunamadı")
+	ErrTermNotFound          = errors.New("dönem bulunamadı")
+	ErrCourseContentNotFound = errors.New("içerik bir derse bağlı değil")
+	ErrUniversityNameTaken   = errors.New("bu ad başka bir üniversiteye ait")
+	// ErrInvalidCatalog, katalog veya ders bağlantısı isteği geçersiz olduğunda döner; ayrıntılı
+	// hatalar bu hatayı sarar
+	ErrInvalidCatalog = errors.New("geçersiz katalog isteği")
+)
+
+const (
+	// MaxCourseWeek, bir içeriğin bağlanabileceği en büyük hafta numarasıdır
+	MaxCourseWeek = 20
+	// MaxUniversityAliases, bir üniversitenin en fazla diğer ad sayısıdır
+	MaxUniversityAliases = 20
+
+	maxCatalogNameLength     = 200
+	maxUniversityShortLength = 50
+	maxCourseCodeLength      = 20
+	maxCourseTopicLength     = 200
+	minTermYear              = 1900
+	maxTermYear              = 2200
+)
+
+// termSeasonNames, dönem türlerinin Türkçe adlarıdır
+var termSeasonNames = map[string]string{
+	domain.TermSeasonFall:   "Güz",
+	domain.TermSeasonSpring: "Bahar",
+	domain.TermSeasonSummer: "Yaz",
+}
+
+// CatalogService, üniversite, bölüm, ders ve dönem kataloğu ile içeriklerin derslere bağlanmasıyla
+// ilgili iş mantığını içerir
+type CatalogService struct {
+	catalogRepo domain.CatalogRepository
+	noteRepo    domain.NoteRepository
+	pdfRepo     domain.PDFRepository
+}
+
+// NewCatalogService, yeni bir CatalogService örneği oluşturur
+func NewCatalogService(catalogRepo domain.CatalogRepository, noteRepo domain.NoteRepository, pdfRepo domain.PDFRepository) *CatalogService {
+	return &CatalogService{
+		catalogRepo: catalogRepo,
+		noteRepo:    noteRepo,
+		pdfRepo:     pdfRepo,
+	}
+}
+
+// cleanCatalogName, adın baştaki ve sondaki boşluklarını kırpar, aradaki boşlukları teke indirir ve
+// uzunluğunu kontrol eder. Harf veya rakam içermeyen adlar geçersizdir.
+func cleanCatalogName(name, field string, maxLength int) (string, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if domain.CatalogKey(name) == "" {
+		return "", fmt.Errorf("%w: %s boş olamaz", ErrInvalidCatalog, field)
+	}
+	if utf8.RuneCountInString(name) > maxLength {
+		return "", fmt.Errorf("%w: %s en fazla %d karakter olabilir", ErrInvalidCatalog, field, maxLength)
+	}
+	return name, nil
+}
+
+// termName, dönemin görünen adını üretir (ör. "2024-2025 Güz")
+func termName(year int, season string) string {
+	return fmt.Sprintf("%d-%d %s", year, year+1, termSeasonNames[season])
+}
+
+// cleanUniversity, üniversitenin adlarını temizler ve ad veya kısa adla aynı anahtara sahip ya da
+// tekrarlanan diğer adları çıkarır
+func cleanUniversity(university *domain.University) error {
+	name, err := cleanCatalogName(university.Name, "üniversite adı", maxCatalogNameLength)
+	if err != nil {
+		return err
+	}
+	university.Name = name
+
+	seen := map[string]bool{domain.CatalogKey(name): true}
+	if strings.TrimSpace(university.ShortName) != "" {
+		shortName, err := cleanCatalogName(university.ShortName, "kısa ad", maxUniversityShortLength)
+		if err != nil {
+			return err
+		}
+		university.ShortName = shortName
+		if key := domain.CatalogKey(shortName); seen[key] {
+			university.ShortName = ""
+		} else {
+			seen[key] = true
+		}
+	} else {
+		university.ShortName = ""
+	}
+
+	aliases := make([]string, 0, len(university.Aliases))
+	for _, alias := range university.Aliases {
+		alias, err := cleanCatalogName(alias, "diğer ad", maxCatalogNameLength)
+		if err != nil {
+			return err
+		}
+		if key := domain.CatalogKey(alias); !seen[key] {
+			seen[key] = true
+			aliases = append(aliases, alias)
+		}
+	}
+	if len(aliases) > MaxUniversityAliases {
+		return fmt.Errorf("%w: bir üniversitenin en fazla %d diğer adı olabilir", ErrInvalidCatalog, MaxUniversityAliases)
+	}
+	university.Aliases = aliases
+	return nil
+}
+
+// CreateUniversity, yeni bir üniversite oluşturur. Adlardan biri başka bir üniversiteye aitse
+// ErrUniversityNameTaken döner.
+func (s *CatalogService) CreateUniversity(university *domain.University) error {
+	if err := cleanUniversity(university); err != nil {
+		return err
+	}
+	if err := s.catalogRepo.CreateUniversity(university); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return ErrUniversityNameTaken
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateUniversity, üniversitenin adını, kısa adını ve diğer adlarını günceller
+func (s *CatalogService) UpdateUniversity(university *domain.University) error {
+	existing, err := s.GetUniversity(university.ID)
+	if err != nil {
+		return err
+	}
+	if err := cleanUniversity(university); err != nil {
+		return err
+	}
+	if err := s.catalogRepo.UpdateUniversity(university); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return ErrUniversityNameTaken
+		}
+		return err
+	}
+	university.CreatedAt = existing.CreatedAt
+	return nil
+}
+
+// AddUniversityAlias, üniversiteye yeni bir diğer ad ekler
+func (s *CatalogService) AddUniversityAlias(id uint, alias string) (*domain.University, error) {
+	university, err := s.GetUniversity(id)
+	if err != nil {
+		return nil, err
+	}
+	university.Aliases = append(university.Aliases, alias)
+	if err := s.UpdateUniversity(university); err != nil {
+		return nil, err
+	}
+	return university, nil
+}
+
+// GetUniversity, üniversiteyi getirir
+func (s *CatalogService) GetUniversity(id uint) (*domain.University, error) {
+	university, err := s.catalogRepo.FindUniversityByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("üniversite arama sırasında hata: %w", err)
+	}
+	if university == nil {
+		return nil, ErrUniversityNotFound
+	}
+	return university, nil
+}
+
+// ResolveUniversity, adı, kısa adı veya diğer adlarından biri verilen adla eşleşen üniversiteyi getirir.
+// Büyük/küçük harf, Türkçe karakterler ve noktalama farkları yok sayılır ("İTÜ", "itu", "I.T.U.").
+func (s *CatalogService) ResolveUniversity(name string) (*domain.University, error) {
+	university, err := s.catalogRepo.FindUniversityByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("üniversite arama sırasında hata: %w", err)
+	}
+	if university == nil {
+		return nil, ErrUniversityNotFound
+	}
+	return university, nil
+}
+
+// SearchUniversities, adlarından biri sorguyu içeren üniversiteleri getirir; sorgu boşsa tüm üniversiteler döner
+func (s *CatalogService) SearchUniversities(query string, limit, offset int) ([]*domain.University, error) {
+	limit, offset = catalogPage(limit, offset)
+	return s.catalogRepo.FindUniversities(query, limit, offset)
+}
+
+// catalogPage, sayfalama parametrelerini varsayılan değerlerle düzeltir
+func catalogPage(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+// CreateDepartment, üniversitede bölümü bulur veya yoksa oluşturur. Aynı adlı (büyük/küçük harf ve
+// Türkçe karakter farkları yok sayılarak) bir bölüm varsa department onunla doldurulur ve created false döner.
+func (s *CatalogService) CreateDepartment(department *domain.Department) (bool, error) {
+	name, err := cleanCatalogName(department.Name, "bölüm adı", maxCatalogNameLength)
+	if err != nil {
+		return false, err
+	}
+	department.Name = name
+	if _, err := s.GetUniversity(department.UniversityID); err != nil {
+		return false, err
+	}
+
+	existing, err := s.catalogRepo.FindDepartmentByName(department.UniversityID, name)
+	if err != nil {
+		return false, fmt.Errorf("bölüm arama sırasında hata: %w", err)
+	}
+	if existing == nil {
+		err = s.catalogRepo.CreateDepartment(department)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, domain.ErrDuplicateEntry) {
+			return false, err
+		}
+		// Aynı bölüm eşzamanlı bir istekle oluşturulmuş
+		if existing, err = s.catalogRepo.FindDepartmentByName(department.UniversityID, name); err != nil {
+			return false, fmt.Errorf("bölüm arama sırasında hata: %w", err)
+		}
+		if existing == nil {
+			return false, domain.ErrDuplicateEntry
+		}
+	}
+	*department = *existing
+	return false, nil
+}
+
+// GetDepartment, bölümü getirir
+func (s *CatalogService) GetDepartment(id uint) (*domain.Department, error) {
+	department, err := s.catalogRepo.FindDepartmentByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("bölüm arama sırasında hata: %w", err)
+	}
+	if department == nil {
+		return nil, ErrDepartmentNotFound
+	}
+	return department, nil
+}
+
+// GetDepartments, üniversitenin bölümlerini getirir
+func (s *CatalogService) GetDepartments(universityID uint, limit, offset int) ([]*domain.Department, error) {
+	if _, err := s.GetUniversity(universityID); err != nil {
+		return nil, err
+	}
+	limit, offset = catalogPage(limit, offset)
+	return s.catalogRepo.FindDepartments(universityID, limit, offset)
+}
+
+// CreateCourse, bölümde dersi koduna göre bulur veya yoksa oluşturur. Aynı kodlu ("BLG 101E" ve
+// "blg101e" aynı kabul edilir) bir ders varsa course onunla doldurulur ve created false döner.
+func (s *CatalogService) CreateCourse(course *domain.Course) (bool, error) {
+	code, err := cleanCatalogName(course.Code, "ders kodu", maxCourseCodeLength)
+	if err != nil {
+		return false, err
+	}
+	name, err := cleanCatalogName(course.Name, "ders adı", maxCatalogNameLength)
+	if err != nil {
+		return false, err
+	}
+	course.Code = strings.ToUpper(code)
+	course.Name = name
+	if _, err := s.GetDepartment(course.DepartmentID); err != nil {
+		return false, err
+	}
+
+	existing, err := s.catalogRepo.FindCourseByCode(course.DepartmentID, code)
+	if err != nil {
+		return false, fmt.Errorf("ders arama sırasında hata: %w", err)
+	}
+	if existing == nil {
+		err = s.catalogRepo.CreateCourse(course)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, domain.ErrDuplicateEntry) {
+			return false, err
+		}
+		// Aynı ders eşzamanlı bir istekle oluşturulmuş
+		if existing, err = s.catalogRepo.FindCourseByCode(course.DepartmentID, code); err != nil {
+			return false, fmt.Errorf("ders arama sırasında hata: %w", err)
+		}
+		if existing == nil {
+			return false, domain.ErrDuplicateEntry
+		}
+	}
+	*course = *existing
+	return false, nil
+}
+
+// GetCourse, dersi bölümü ve üniversitesiyle birlikte getirir
+func (s *CatalogService) GetCourse(id uint) (*domain.CourseDetail, error) {
+	course, err := s.catalogRepo.FindCourseByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("ders arama sırasında hata: %w", err)
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+	department, err := s.GetDepartment(course.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+	university, err := s.GetUniversity(department.UniversityID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.CourseDetail{Course: course, Department: department, University: university}, nil
+}
+
+// GetCourses, bölümün derslerini getirir
+func (s *CatalogService) GetCourses(departmentID uint, limit, offset int) ([]*domain.Course, error) {
+	if _, err := s.GetDepartment(departmentID); err != nil {
+		return nil, err
+	}
+	limit, offset = catalogPage(limit, offset)
+	return s.catalogRepo.FindCourses(departmentID, limit, offset)
+}
+
+// CreateTerm, dönemi yılına ve türüne göre bulur veya yoksa oluşturur. Dönem zaten varsa term onunla
+// doldurulur ve created false döner.
+func (s *CatalogService) CreateTerm(term *domain.Term) (bool, error) {
+	if !domain.IsTermSeason(term.Season) {
+		return false, fmt.Errorf("%w: dönem türü fall, spring veya summer olmalıdır", ErrInvalidCatalog)
+	}
+	if term.Year < minTermYear || term.Year > maxTermYear {
+		return false, fmt.Errorf("%w: dönem yılı %d ile %d arasında olmalıdır", ErrInvalidCatalog, minTermYear, maxTermYear)
+	}
+
+	existing, err := s.catalogRepo.FindTerm(term.Year, term.Season)
+	if err != nil {
+		return false, fmt.Errorf("dönem arama sırasında hata: %w", err)
+	}
+	if existing == nil {
+		term.Name = termName(term.Year, term.Season)
+		err = s.catalogRepo.CreateTerm(term)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, domain.ErrDuplicateEntry) {
+			return false, err
+		}
+		// Aynı dönem eşzamanlı bir istekle oluşturulmuş
+		if existing, err = s.catalogRepo.FindTerm(term.Year, term.Season); err != nil {
+			return false, fmt.Errorf("dönem arama sırasında hata: %w", err)
+		}
+		if existing == nil {
+			return false, domain.ErrDuplicateEntry
+		}
+	}
+	*term = *existing
+	return false, nil
+}
+
+// GetTerms, dönemleri en yeniden başlayarak getirir
+func (s *CatalogService) GetTerms(limit, offset int) ([]*domain.Term, error) {
+	limit, offset = catalogPage(limit, offset)
+	return s.catalogRepo.FindTerms(limit, offset)
+}
+
+// contentAccess, içeriğin sahibini ve herkese açık olup olmadığını döndürür
+func (s *CatalogService) contentAccess(contentID uint, contentType string) (uint, bool, error) {
+	switch contentType {
+	case "note":
+		note, err := s.noteRepo.FindByID(contentID)
+		if err != nil {
+			return 0, false, fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return 0, false, ErrContentNotFound
+		}
+		return note.UserID, note.IsPublic, nil
+	case "pdf":
+		pdf, err := s.pdfRepo.FindByID(contentID)
+		if err != nil {
+			return 0, false, fmt.Errorf("PDF arama sırasında hata: %w", err)
+		}
+		if pdf == nil {
+			return 0, false, ErrContentNotFound
+		}
+		return pdf.UserID, pdf.IsPublic, nil
+	default:
+		return 0, false, ErrInvalidType
+	}
+}
+
+// contentDetail, ders bağlantısını ders, bölüm, üniversite ve dönem bilgileriyle zenginleştirir
+func (s *CatalogService) contentDetail(content *domain.CourseContent) (*domain.CourseContentDetail, error) {
+	course, err := s.GetCourse(content.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	detail := &domain.CourseContentDetail{
+		CourseContent: content,
+		Course:        course.Course,
+		Department:    course.Department,
+		University:    course.University,
+	}
+	if content.TermID != nil {
+		term, err := s.catalogRepo.FindTermByID(*content.TermID)
+		if err != nil {
+			return nil, fmt.Errorf("dönem arama sırasında hata: %w", err)
+		}
+		detail.Term = term
+	}
+	return detail, nil
+}
+
+// AttachContent, kullanıcının kendi notunu veya PDF'ini bir derse, isteğe bağlı olarak bir döneme,
+// haftaya ve konuya bağlar. İçerik zaten bir derse bağlıysa bağlantı değiştirilir.
+func (s *CatalogService) AttachContent(content *domain.CourseContent, userID uint) (*domain.CourseContentDetail, error) {
+	ownerID, _, err := s.contentAccess(content.ContentID, content.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != userID {
+		return nil, ErrNotAuthorized
+	}
+
+	if content.Week < 0 || content.Week > MaxCourseWeek {
+		return nil, fmt.Errorf("%w: hafta 1 ile %d arasında olmalıdır", ErrInvalidCatalog, MaxCourseWeek)
+	}
+	content.Topic = strings.Join(strings.Fields(content.Topic), " ")
+	if utf8.RuneCountInString(content.Topic) > maxCourseTopicLength {
+		return nil, fmt.Errorf("%w: konu en fazla %d karakter olabilir", ErrInvalidCatalog, maxCourseTopicLength)
+	}
+
+	course, err := s.catalogRepo.FindCourseByID(content.CourseID)
+	if err != nil {
+		return nil, fmt.Errorf("ders arama sırasında hata: %w", err)
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+	if content.TermID != nil && *content.TermID == 0 {
+		content.TermID = nil
+	}
+	if content.TermID != nil {
+		term, err := s.catalogRepo.FindTermByID(*content.TermID)
+		if err != nil {
+			return nil, fmt.Errorf("dönem arama sırasında hata: %w", err)
+		}
+		if term == nil {
+			return nil, ErrTermNotFound
+		}
+	}
+
+	if err := s.catalogRepo.SaveCourseContent(content); err != nil {
+		return nil, err
+	}
+	return s.contentDetail(content)
+}
+
+// DetachContent, kullanıcının kendi içeriğinin ders bağlantısını kaldırır
+func (s *CatalogService) DetachContent(contentID uint, contentType string, userID uint) error {
+	ownerID, _, err := s.contentAccess(contentID, contentType)
+	if err != nil {
+		return err
+	}
+	if ownerID != userID {
+		return ErrNotAuthorized
+	}
+
+	content, err := s.catalogRepo.FindCourseContent(contentID, contentType)
+	if err != nil {
+		return fmt.Errorf("ders bağlantısı arama sırasında hata: %w", err)
+	}
+	if content == nil {
+		return ErrCourseContentNotFound
+	}
+	return s.catalogRepo.DeleteCourseContent(contentID, contentType)
+}
+
+// GetContentCourse, içeriğin bağlı olduğu dersi getirir. İçerik herkese açık değilse yalnızca sahibi görebilir.
+func (s *CatalogService) GetContentCourse(contentID uint, contentType string, userID uint) (*domain.CourseContentDetail, error) {
+	ownerID, isPublic, err := s.contentAccess(contentID, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if !isPublic && (userID == 0 || ownerID != userID) {
+		return nil, ErrNotAuthorized
+	}
+
+	content, err := s.catalogRepo.FindCourseContent(contentID, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("ders bağlantısı arama sırasında hata: %w", err)
+	}
+	if content == nil {
+		return nil, ErrCourseContentNotFound
+	}
+	return s.contentDetail(content)
+}
+
+// checkCourseFilter, ders içerikleri filtresini doğrular ve sayfalama parametrelerini düzeltir
+func (s *CatalogService) checkCourseFilter(filter *domain.CourseContentFilter) error {
+	course, err := s.catalogRepo.FindCourseByID(filter.CourseID)
+	if err != nil {
+		return fmt.Errorf("ders arama sırasında hata: %w", err)
+	}
+	if course == nil {
+		return ErrCourseNotFound
+	}
+	if filter.Week < 0 || filter.Week > MaxCourseWeek {
+		return fmt.Errorf("%w: hafta 1 ile %d arasında olmalıdır", ErrInvalidCatalog, MaxCourseWeek)
+	}
+	filter.Limit, filter.Offset = catalogPage(filter.Limit, filter.Offset)
+	return nil
+}
+
+// GetCourseNotes, derse bağlı notları haftaya göre sıralı getirir. Herkese açık notların yanında
+// görüntüleyen kullanıcının kendi özel notları da döner.
+func (s *CatalogService) GetCourseNotes(filter *domain.CourseContentFilter) ([]*domain.Note, error) {
+	if err := s.checkCourseFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.catalogRepo.FindCourseNotes(filter)
+}
+
+// GetCoursePDFs, derse bağlı PDF'leri haftaya göre sıralı getirir. Herkese açık PDF'lerin yanında
+// görüntüleyen kullanıcının kendi özel PDF'leri de döner.
+func (s *CatalogService) GetCoursePDFs(filter *domain.CourseContentFilter) ([]*domain.PDF, error) {
+	if err := s.checkCourseFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.catalogRepo.FindCoursePDFs(filter)
+}
+
+// Ensure CatalogService implements domain.CatalogService
+var _ domain.CatalogService = (*CatalogService)(nil)
@@ -0,0 +1,639 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	ErrCollectionNotFound       = errors.New("koleksiyon bulunamadı")
+	ErrCollectionFolderNotFound = errors.New("klasör bulunamadı")
+	ErrCollectionItemNotFound   = errors.New("koleksiyon öğesi bulunamadı")
+	ErrCollectionItemExists     = errors.New("içerik koleksiyonda zaten var")
+	// ErrInvalidCollection, koleksiyon, klasör veya öğe isteği geçersiz olduğunda döner; ayrıntılı
+	// hatalar bu hatayı sarar
+	ErrInvalidCollection = errors.New("geçersiz koleksiyon isteği")
+)
+
+const (
+	// MaxCollectionItems, bir koleksiyondaki en fazla öğe sayısıdır
+	MaxCollectionItems = 1000
+	// MaxCollectionFolders, bir koleksiyondaki en fazla klasör sayısıdır
+	MaxCollectionFolders = 200
+	// MaxCollectionFolderDepth, iç içe klasörlerin en fazla derinliğidir (kökteki klasörler 1. düzeydedir)
+	MaxCollectionFolderDepth = 8
+
+	maxCollectionNameLength        = 100
+	maxCollectionDescriptionLength = 2000
+)
+
+// CollectionService, koleksiyon ile ilgili iş mantığını içerir
+type CollectionService struct {
+	collectionRepo domain.CollectionRepository
+	noteRepo       domain.NoteRepository
+	pdfRepo        domain.PDFRepository
+}
+
+// NewCollectionService, yeni bir CollectionService örneği oluşturur
+func NewCollectionService(collectionRepo domain.CollectionRepository, noteRepo domain.NoteRepository, pdfRepo domain.PDFRepository) *CollectionService {
+	return &CollectionService{
+		collectionRepo: collectionRepo,
+		noteRepo:       noteRepo,
+		pdfRepo:        pdfRepo,
+	}
+}
+
+// invitedToCollection, davet bağlantısının verilen koleksiyon için olup olmadığını kontrol eder
+func invitedToCollection(invite *domain.Invite, collectionID uint) bool {
+	return invite != nil && invite.Type == "collection" && invite.ContentID == collectionID
+}
+
+// validCollectionName, adı kırpar ve boş olmadığını ve uzunluk sınırını aşmadığını kontrol eder
+func validCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: ad boş olamaz", ErrInvalidCollection)
+	}
+	if utf8.RuneCountInString(name) > maxCollectionNameLength {
+		return "", fmt.Errorf("%w: ad en fazla %d karakter olabilir", ErrInvalidCollection, maxCollectionNameLength)
+	}
+	return name, nil
+}
+
+// folderRef, kök klasörü belirten 0 değerini nil'e çevirir
+func folderRef(id *uint) *uint {
+	if id == nil || *id == 0 {
+		return nil
+	}
+	return id
+}
+
+// sameFolder, iki klasör referansının aynı klasörü (veya ikisinin de kökü) gösterip göstermediğini kontrol eder
+func sameFolder(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// insertAt, ID'yi listeden çıkarır ve verilen konuma ekler. Konum verilmemişse veya liste
+// uzunluğunu aşıyorsa sona, negatifse başa eklenir.
+func insertAt(ids []uint, id uint, position *int) []uint {
+	out := make([]uint, 0, len(ids)+1)
+	for _, existing := range ids {
+		if existing != id {
+			out = append(out, existing)
+		}
+	}
+
+	index := len(out)
+	if position != nil && *position < index {
+		index = max(*position, 0)
+	}
+	out = append(out, 0)
+	copy(out[index+1:], out[index:])
+	out[index] = id
+	return out
+}
+
+// folderDepth, klasörün kökten itibaren düzeyini döndürür (kökteki klasörler için 1)
+func folderDepth(byID map[uint]*domain.CollectionFolder, id uint) int {
+	depth := 0
+	for current := &id; current != nil && depth <= len(byID); depth++ {
+		folder, ok := byID[*current]
+		if !ok {
+			break
+		}
+		current = folder.ParentID
+	}
+	return depth
+}
+
+// folderSubtree, klasörü ve tüm alt klasörlerinin ID'lerini döndürür; ikinci değer alt ağacın
+// klasörün kendisi dahil düzey sayısıdır
+func folderSubtree(folders []*domain.CollectionFolder, id uint) ([]uint, int) {
+	children := make(map[uint][]uint)
+	for _, folder := range folders {
+		if folder.ParentID != nil {
+			children[*folder.ParentID] = append(children[*folder.ParentID], folder.ID)
+		}
+	}
+
+	ids := []uint{id}
+	height := 0
+	for level := []uint{id}; len(level) > 0 && height <= len(folders); height++ {
+		var next []uint
+		for _, parent := range level {
+			next = append(next, children[parent]...)
+		}
+		ids = append(ids, next...)
+		level = next
+	}
+	return ids, height
+}
+
+// findOwnedCollection, koleksiyonu bulur ve kullanıcının sahibi olduğunu kontrol eder
+func (s *CollectionService) findOwnedCollection(id, userID uint) (*domain.Collection, error) {
+	collection, err := s.collectionRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("koleksiyon arama sırasında hata: %w", err)
+	}
+	if collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+	if collection.UserID != userID {
+		return nil, ErrNotAuthorized
+	}
+	return collection, nil
+}
+
+// findFolder, klasörü bulur ve koleksiyona ait olduğunu kontrol eder
+func (s *CollectionService) findFolder(collectionID, folderID uint) (*domain.CollectionFolder, error) {
+	folder, err := s.collectionRepo.FindFolderByID(folderID)
+	if err != nil {
+		return nil, fmt.Errorf("klasör arama sırasında hata: %w", err)
+	}
+	if folder == nil || folder.CollectionID != collectionID {
+		return nil, ErrCollectionFolderNotFound
+	}
+	return folder, nil
+}
+
+// findItem, öğeyi bulur ve koleksiyona ait olduğunu kontrol eder
+func (s *CollectionService) findItem(collectionID, itemID uint) (*domain.CollectionItem, error) {
+	item, err := s.collectionRepo.FindItemByID(itemID)
+	if err != nil {
+		return nil, fmt.Errorf("koleksiyon öğesi arama sırasında hata: %w", err)
+	}
+	if item == nil || item.CollectionID != collectionID {
+		return nil, ErrCollectionItemNotFound
+	}
+	return item, nil
+}
+
+// touch, koleksiyonun güncellenme zamanını yeniler; koleksiyon listesinde son değişen koleksiyonlar önce gelir
+func (s *CollectionService) touch(collection *domain.Collection) error {
+	if err := s.collectionRepo.Update(collection); err != nil {
+		return fmt.Errorf("koleksiyon güncelleme sırasında hata: %w", err)
+	}
+	return nil
+}
+
+// CreateCollection, yeni bir koleksiyon oluşturur
+func (s *CollectionService) CreateCollection(collection *domain.Collection) error {
+	name, err := validCollectionName(collection.Name)
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(collection.Description) > maxCollectionDescriptionLength {
+		return fmt.Errorf("%w: açıklama en fazla %d karakter olabilir", ErrInvalidCollection, maxCollectionDescriptionLength)
+	}
+	collection.Name = name
+
+	return s.collectionRepo.Create(collection)
+}
+
+// UpdateCollection, koleksiyonun adını, açıklamasını ve görünürlüğünü günceller. Yalnızca sahibi güncelleyebilir.
+func (s *CollectionService) UpdateCollection(collection *domain.Collection) error {
+	existing, err := s.findOwnedCollection(collection.ID, collection.UserID)
+	if err != nil {
+		return err
+	}
+
+	name, err := validCollectionName(collection.Name)
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(collection.Description) > maxCollectionDescriptionLength {
+		return fmt.Errorf("%w: açıklama en fazla %d karakter olabilir", ErrInvalidCollection, maxCollectionDescriptionLength)
+	}
+
+	existing.Name = name
+	existing.Description = collection.Description
+	existing.IsPublic = collection.IsPublic
+	if err := s.collectionRepo.Update(existing); err != nil {
+		return err
+	}
+	*collection = *existing
+	return nil
+}
+
+// DeleteCollection, koleksiyonu klasörleri ve öğeleriyle birlikte siler; notlar ve PDF'ler silinmez
+func (s *CollectionService) DeleteCollection(id, userID uint) error {
+	if _, err := s.findOwnedCollection(id, userID); err != nil {
+		return err
+	}
+	return s.collectionRepo.Delete(id)
+}
+
+// GetOwnedCollection, kullanıcının sahibi olduğu koleksiyonu getirir
+func (s *CollectionService) GetOwnedCollection(id, userID uint) (*domain.Collection, error) {
+	return s.findOwnedCollection(id, userID)
+}
+
+// GetUserCollections, kullanıcının koleksiyonlarını son güncellenenden başlayarak getirir
+func (s *CollectionService) GetUserCollections(userID uint, limit, offset int) ([]*domain.Collection, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.collectionRepo.FindByUserID(userID, limit, offset)
+}
+
+// GetCollection, koleksiyonu klasörleri ve öğeleriyle birlikte getirir. Koleksiyonu sahibi, herkese
+// açıksa herkes veya koleksiyon için oluşturulmuş geçerli bir davet bağlantısıyla gelenler görebilir.
+// Öğeler yalnızca görüntüleyen kullanıcının erişebildiği içerikleri (herkese açık veya kendisine ait)
+// içerir; silinmiş içerikler atlanır.
+func (s *CollectionService) GetCollection(id, userID uint, invite *domain.Invite) (*domain.CollectionDetail, error) {
+	collection, err := s.collectionRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("koleksiyon arama sırasında hata: %w", err)
+	}
+	if collection == nil {
+		return nil, ErrCollectionNotFound
+	}
+	owner := userID != 0 && collection.UserID == userID
+	if !collection.IsPublic && !owner && !invitedToCollection(invite, id) {
+		return nil, ErrNotAuthorized
+	}
+
+	folders, err := s.collectionRepo.FindFolders(id)
+	if err != nil {
+		return nil, fmt.Errorf("klasör arama sırasında hata: %w", err)
+	}
+	items, err := s.collectionRepo.FindItems(id)
+	if err != nil {
+		return nil, fmt.Errorf("koleksiyon öğesi arama sırasında hata: %w", err)
+	}
+
+	detail := &domain.CollectionDetail{
+		Collection: collection,
+		Folders:    folders,
+		Items:      make([]*domain.CollectionItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		response, err := s.itemResponse(item, userID)
+		if err != nil {
+			return nil, err
+		}
+		if response != nil {
+			detail.Items = append(detail.Items, response)
+		}
+	}
+	return detail, nil
+}
+
+// itemResponse, öğeyi içerik bilgileriyle zenginleştirir. İçerik silinmişse veya kullanıcı içeriğe
+// erişemiyorsa nil döner.
+func (s *CollectionService) itemResponse(item *domain.CollectionItem, userID uint) (*domain.CollectionItemResponse, error) {
+	response := &domain.CollectionItemResponse{CollectionItem: *item}
+	switch item.ContentType {
+	case "note":
+		note, err := s.noteRepo.FindByID(item.ContentID)
+		if err != nil {
+			return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return nil, nil
+		}
+		response.Title = note.Title
+		response.OwnerID = note.UserID
+		response.IsPublic = note.IsPublic
+		response.UpdatedAt = note.UpdatedAt
+	case "pdf":
+		pdf, err := s.pdfRepo.FindByID(item.ContentID)
+		if err != nil {
+			return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+		}
+		if pdf == nil {
+			return nil, nil
+		}
+		response.Title = pdf.Title
+		response.Description = pdf.Description
+		response.OwnerID = pdf.UserID
+		response.IsPublic = pdf.IsPublic
+		response.UpdatedAt = pdf.UpdatedAt
+	default:
+		return nil, nil
+	}
+
+	if !response.IsPublic && (userID == 0 || response.OwnerID != userID) {
+		return nil, nil
+	}
+	return response, nil
+}
+
+// CreateFolder, koleksiyonda yeni bir klasör oluşturur. ParentID verilmezse klasör kökte oluşturulur;
+// position verilmezse klasör üst klasördeki diğer klasörlerin sonuna eklenir.
+func (s *CollectionService) CreateFolder(folder *domain.CollectionFolder, userID uint, position *int) error {
+	collection, err := s.findOwnedCollection(folder.CollectionID, userID)
+	if err != nil {
+		return err
+	}
+
+	name, err := validCollectionName(folder.Name)
+	if err != nil {
+		return err
+	}
+	folder.Name = name
+	folder.ParentID = folderRef(folder.ParentID)
+
+	folders, err := s.collectionRepo.FindFolders(collection.ID)
+	if err != nil {
+		return fmt.Errorf("klasör arama sırasında hata: %w", err)
+	}
+	if len(folders) >= MaxCollectionFolders {
+		return fmt.Errorf("%w: bir koleksiyonda en fazla %d klasör olabilir", ErrInvalidCollection, MaxCollectionFolders)
+	}
+
+	byID := make(map[uint]*domain.CollectionFolder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+	if folder.ParentID != nil {
+		if _, ok := byID[*folder.ParentID]; !ok {
+			return ErrCollectionFolderNotFound
+		}
+		if folderDepth(byID, *folder.ParentID)+1 > MaxCollectionFolderDepth {
+			return fmt.Errorf("%w: klasörler en fazla %d düzey iç içe olabilir", ErrInvalidCollection, MaxCollectionFolderDepth)
+		}
+	}
+
+	var siblings []uint
+	for _, f := range folders {
+		if sameFolder(f.ParentID, folder.ParentID) {
+			siblings = append(siblings, f.ID)
+		}
+	}
+	folder.Position = len(siblings)
+	if err := s.collectionRepo.CreateFolder(folder); err != nil {
+		return err
+	}
+
+	if position != nil && *position < len(siblings) {
+		order := insertAt(siblings, folder.ID, position)
+		if err := s.collectionRepo.ArrangeFolders(collection.ID, folder.ParentID, order); err != nil {
+			return fmt.Errorf("klasörler sıralanırken hata: %w", err)
+		}
+		folder.Position = indexOf(order, folder.ID)
+	}
+	return s.touch(collection)
+}
+
+// RenameFolder, klasörün adını değiştirir
+func (s *CollectionService) RenameFolder(collectionID, folderID, userID uint, name string) (*domain.CollectionFolder, error) {
+	collection, err := s.findOwnedCollection(collectionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := s.findFolder(collectionID, folderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if folder.Name, err = validCollectionName(name); err != nil {
+		return nil, err
+	}
+	if err := s.collectionRepo.UpdateFolder(folder); err != nil {
+		return nil, err
+	}
+	return folder, s.touch(collection)
+}
+
+// MoveFolder, klasörü alt klasörleri ve öğeleriyle birlikte başka bir üst klasöre (nil ise köke) ve
+// verilen konuma taşır. Klasör kendi alt klasörlerinden birine taşınamaz.
+func (s *CollectionService) MoveFolder(collectionID, folderID, userID uint, parentID *uint, position *int) error {
+	collection, err := s.findOwnedCollection(collectionID, userID)
+	if err != nil {
+		return err
+	}
+	folder, err := s.findFolder(collectionID, folderID)
+	if err != nil {
+		return err
+	}
+	parentID = folderRef(parentID)
+
+	folders, err := s.collectionRepo.FindFolders(collectionID)
+	if err != nil {
+		return fmt.Errorf("klasör arama sırasında hata: %w", err)
+	}
+	byID := make(map[uint]*domain.CollectionFolder, len(folders))
+	for _, f := range folders {
+		byID[f.ID] = f
+	}
+
+	if parentID != nil {
+		if _, ok := byID[*parentID]; !ok {
+			return ErrCollectionFolderNotFound
+		}
+		subtree, height := folderSubtree(folders, folderID)
+		for _, id := range subtree {
+			if id == *parentID {
+				return fmt.Errorf("%w: klasör kendisine veya alt klasörlerinden birine taşınamaz", ErrInvalidCollection)
+			}
+		}
+		if folderDepth(byID, *parentID)+height > MaxCollectionFolderDepth {
+			return fmt.Errorf("%w: klasörler en fazla %d düzey iç içe olabilir", ErrInvalidCollection, MaxCollectionFolderDepth)
+		}
+	}
+
+	var siblings, previous []uint
+	for _, f := range folders {
+		if sameFolder(f.ParentID, parentID) {
+			siblings = append(siblings, f.ID)
+		} else if sameFolder(f.ParentID, folder.ParentID) && f.ID != folderID {
+			previous = append(previous, f.ID)
+		}
+	}
+	if err := s.collectionRepo.ArrangeFolders(collectionID, parentID, insertAt(siblings, folderID, position)); err != nil {
+		return fmt.Errorf("klasörler sıralanırken hata: %w", err)
+	}
+	// Eski üst klasördeki konumları boşluksuz yeniden numarala
+	if !sameFolder(folder.ParentID, parentID) {
+		if err := s.collectionRepo.ArrangeFolders(collectionID, folder.ParentID, previous); err != nil {
+			return fmt.Errorf("klasörler sıralanırken hata: %w", err)
+		}
+	}
+	return s.touch(collection)
+}
+
+// DeleteFolder, klasörü alt klasörleri ve içlerindeki öğelerle birlikte koleksiyondan siler
+func (s *CollectionService) DeleteFolder(collectionID, folderID, userID uint) error {
+	collection, err := s.findOwnedCollection(collectionID, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findFolder(collectionID, folderID); err != nil {
+		return err
+	}
+
+	folders, err := s.collectionRepo.FindFolders(collectionID)
+	if err != nil {
+		return fmt.Errorf("klasör arama sırasında hata: %w", err)
+	}
+	subtree, _ := folderSubtree(folders, folderID)
+	if err := s.collectionRepo.DeleteFolders(collectionID, subtree); err != nil {
+		return err
+	}
+	return s.touch(collection)
+}
+
+// AddItem, koleksiyona bir not veya PDF ekler. Kullanıcı kendi içeriklerini ve başkalarının herkese açık
+// içeriklerini ekleyebilir. FolderID verilmezse öğe köke, position verilmezse klasörün sonuna eklenir.
+func (s *CollectionService) AddItem(item *domain.CollectionItem, userID uint, position *int) error {
+	collection, err := s.findOwnedCollection(item.CollectionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkAddable(item.ContentID, item.ContentType, userID); err != nil {
+		return err
+	}
+	item.FolderID = folderRef(item.FolderID)
+	if item.FolderID != nil {
+		if _, err := s.findFolder(collection.ID, *item.FolderID); err != nil {
+			return err
+		}
+	}
+
+	items, err := s.collectionRepo.FindItems(collection.ID)
+	if err != nil {
+		return fmt.Errorf("koleksiyon öğesi arama sırasında hata: %w", err)
+	}
+	if len(items) >= MaxCollectionItems {
+		return fmt.Errorf("%w: bir koleksiyonda en fazla %d öğe olabilir", ErrInvalidCollection, MaxCollectionItems)
+	}
+
+	var siblings []uint
+	for _, existing := range items {
+		if sameFolder(existing.FolderID, item.FolderID) {
+			siblings = append(siblings, existing.ID)
+		}
+	}
+	item.Position = len(siblings)
+	if err := s.collectionRepo.CreateItem(item); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return ErrCollectionItemExists
+		}
+		return err
+	}
+
+	if position != nil && *position < len(siblings) {
+		order := insertAt(siblings, item.ID, position)
+		if err := s.collectionRepo.ArrangeItems(collection.ID, item.FolderID, order); err != nil {
+			return fmt.Errorf("koleksiyon öğeleri sıralanırken hata: %w", err)
+		}
+		item.Position = indexOf(order, item.ID)
+	}
+	return s.touch(collection)
+}
+
+// checkAddable, içeriğin var olduğunu ve kullanıcının koleksiyona ekleyebileceğini kontrol eder
+func (s *CollectionService) checkAddable(contentID uint, contentType string, userID uint) error {
+	var ownerID uint
+	var isPublic bool
+	switch contentType {
+	case "note":
+		note, err := s.noteRepo.FindByID(contentID)
+		if err != nil {
+			return fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return ErrContentNotFound
+		}
+		ownerID, isPublic = note.UserID, note.IsPublic
+	case "pdf":
+		pdf, err := s.pdfRepo.FindByID(contentID)
+		if err != nil {
+			return fmt.Errorf("PDF arama sırasında hata: %w", err)
+		}
+		if pdf == nil {
+			return ErrContentNotFound
+		}
+		ownerID, isPublic = pdf.UserID, pdf.IsPublic
+	default:
+		return ErrInvalidType
+	}
+
+	if !isPublic && ownerID != userID {
+		return ErrNotAuthorized
+	}
+	return nil
+}
+
+// MoveItem, öğeyi aynı koleksiyondaki başka bir klasöre (nil ise köke) ve verilen konuma taşır
+func (s *CollectionService) MoveItem(collectionID, itemID, userID uint, folderID *uint, position *int) error {
+	collection, err := s.findOwnedCollection(collectionID, userID)
+	if err != nil {
+		return err
+	}
+	item, err := s.findItem(collectionID, itemID)
+	if err != nil {
+		return err
+	}
+	folderID = folderRef(folderID)
+	if folderID != nil {
+		if _, err := s.findFolder(collectionID, *folderID); err != nil {
+			return err
+		}
+	}
+
+	items, err := s.collectionRepo.FindItems(collectionID)
+	if err != nil {
+		return fmt.Errorf("koleksiyon öğesi arama sırasında hata: %w", err)
+	}
+	var siblings, previous []uint
+	for _, existing := range items {
+		if sameFolder(existing.FolderID, folderID) {
+			siblings = append(siblings, existing.ID)
+		} else if sameFolder(existing.FolderID, item.FolderID) && existing.ID != itemID {
+			previous = append(previous, existing.ID)
+		}
+	}
+	if err := s.collectionRepo.ArrangeItems(collectionID, folderID, insertAt(siblings, itemID, position)); err != nil {
+		return fmt.Errorf("koleksiyon öğeleri sıralanırken hata: %w", err)
+	}
+	// Eski klasördeki konumları boşluksuz yeniden numarala
+	if !sameFolder(item.FolderID, folderID) {
+		if err := s.collectionRepo.ArrangeItems(collectionID, item.FolderID, previous); err != nil {
+			return fmt.Errorf("koleksiyon öğeleri sıralanırken hata: %w", err)
+		}
+	}
+	return s.touch(collection)
+}
+
+// RemoveItem, öğeyi koleksiyondan çıkarır; içeriğin kendisi silinmez
+func (s *CollectionService) RemoveItem(collectionID, itemID, userID uint) error {
+	collection, err := s.findOwnedCollection(collectionID, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.findItem(collectionID, itemID); err != nil {
+		return err
+	}
+
+	if err := s.collectionRepo.DeleteItem(itemID); err != nil {
+		return err
+	}
+	return s.touch(collection)
+}
+
+// indexOf, ID'nin listedeki konumunu döndürür
+func indexOf(ids []uint, id uint) int {
+	for i, existing := range ids {
+		if existing == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// Ensure CollectionService implements domain.CollectionService
+var _ domain.CollectionService = (*CollectionService)(nil)
@@ -19,6 +19,7 @@ type CommentService struct {
 	pdfRepo        domain.PDFRepository
 	pdfCommentRepo domain.PDFCommentRepository
 	userRepo       domain.UserRepository
+	access         *ContentAccess
 }
 
 // NewCommentService, yeni bir CommentService örneği oluşturur
@@ -28,6 +29,7 @@ func NewCommentService(
 	pdfRepo domain.PDFRepository,
 	pdfCommentRepo domain.PDFCommentRepository,
 	userRepo domain.UserRepository,
+	access *ContentAccess,
 ) *CommentService {
 	return &CommentService{
 		noteRepo:       noteRepo,
@@ -35,6 +37,7 @@ func NewCommentService(
 		pdfRepo:        pdfRepo,
 		pdfCommentRepo: pdfCommentRepo,
 		userRepo:       userRepo,
+		access:         access,
 	}
 }
 
@@ -103,6 +106,7 @@ func (s *CommentService) EnrichPDFComments(comments []*domain.PDFComment) ([]*do
 			FullName:   fullName,
 			Content:    comment.Content,
 			PageNumber: comment.PageNumber,
+			Quote:      comment.Quote,
 			CreatedAt:  comment.CreatedAt,
 			UpdatedAt:  comment.UpdatedAt,
 		}
@@ -166,8 +170,8 @@ func (s *CommentService) CheckNoteAccess(noteID, userID uint) (bool, error) {
 		return false, commentErrNoteNotFound
 	}
 
-	// Not herkese açıksa veya kullanıcı notun sahibiyse erişim izni var
-	return note.IsPublic || note.UserID == userID, nil
+	// Not herkese açıksa, kullanıcı notun sahibiyse veya not kullanıcının grubuna paylaşılmışsa erişim izni var
+	return s.access.CanViewNote(note, userID)
 }
 
 // CheckPDFAccess, bir PDF'e erişim izni olup olmadığını kontrol eder
@@ -181,6 +185,6 @@ func (s *CommentService) CheckPDFAccess(pdfID, userID uint) (bool, error) {
 		return false, commentErrPDFNotFound
 	}
 
-	// PDF herkese açıksa veya kullanıcı PDF'in sahibiyse erişim izni var
-	return pdf.IsPublic || pdf.UserID == userID, nil
+	// PDF herkese açıksa, kullanıcı PDF'in sahibiyse veya PDF kullanıcının grubuna paylaşılmışsa erişim izni var
+	return s.access.CanViewPDF(pdf, userID)
 }
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// NoteConflictError, güncellenmek istenen notun istemcinin bildiği sürümden farklı
+// bir sürümde olduğunu belirtir. Current, notun sunucudaki güncel halidir.
+type NoteConflictError struct {
+	Current *domain.Note
+}
+
+// Error, hata mesajını döndürür
+func (e *NoteConflictError) Error() string {
+	return domain.ErrVersionConflict.Error()
+}
+
+// Unwrap, errors.Is(err, domain.ErrVersionConflict) kontrolünü sağlar
+func (e *NoteConflictError) Unwrap() error {
+	return domain.ErrVersionConflict
+}
+
+// PDFConflictError, güncellenmek istenen PDF'in istemcinin bildiği sürümden farklı
+// bir sürümde olduğunu belirtir. Current, PDF'in sunucudaki güncel halidir.
+type PDFConflictError struct {
+	Current *domain.PDF
+}
+
+// Error, hata mesajını döndürür
+func (e *PDFConflictError) Error() string {
+	return domain.ErrVersionConflict.Error()
+}
+
+// Unwrap, errors.Is(err, domain.ErrVersionConflict) kontrolünü sağlar
+func (e *PDFConflictError) Unwrap() error {
+	return domain.ErrVersionConflict
+}
+
+// noteConflict, notun güncel halini okuyarak bir NoteConflictError oluşturur
+func (s *NoteService) noteConflict(id uint) error {
+	current, err := s.noteRepo.FindByID(id)
+	if err != nil {
+		return fmt.Errorf("not arama sırasında hata: %w", err)
+	}
+	if current == nil {
+		return ErrNoteNotFound
+	}
+	return &NoteConflictError{Current: current}
+}
+
+// pdfConflict, PDF'in güncel halini okuyarak bir PDFConflictError oluşturur
+func (s *PDFService) pdfConflict(id uint) error {
+	current, err := s.pdfRepo.FindByID(id)
+	if err != nil {
+		return fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if current == nil {
+		return ErrPDFNotFound
+	}
+	return &PDFConflictError{Current: current}
+}
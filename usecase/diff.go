@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// maxDiffEditDistance, Myers algoritmasının arayacağı en fazla düzenleme sayısıdır.
+// Bu sınır aşılırsa (ör. tamamen yeniden yazılmış çok uzun notlar) değişen bölüm
+// tek bir silme + ekleme bloğu olarak gösterilir; böylece bellek kullanımı sınırlı kalır.
+const maxDiffEditDistance = 1000
+
+// diffLines, iki metin arasındaki satır bazlı farkı Myers algoritmasıyla hesaplar
+func diffLines(oldText, newText string) []domain.DiffLine {
+	a := splitLines(oldText)
+	b := splitLines(newText)
+
+	// Ortak önek ve sonekleri ayır; tipik düzenlemelerde aranacak alanı küçültür
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]domain.DiffLine, 0, len(a)+len(b))
+	for i := 0; i < prefix; i++ {
+		lines = append(lines, domain.DiffLine{Type: "equal", Content: a[i], OldLine: i + 1, NewLine: i + 1})
+	}
+
+	for _, op := range myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]) {
+		switch op.kind {
+		case "equal":
+			lines = append(lines, domain.DiffLine{Type: "equal", Content: a[prefix+op.oldIndex], OldLine: prefix + op.oldIndex + 1, NewLine: prefix + op.newIndex + 1})
+		case "delete":
+			lines = append(lines, domain.DiffLine{Type: "delete", Content: a[prefix+op.oldIndex], OldLine: prefix + op.oldIndex + 1})
+		case "insert":
+			lines = append(lines, domain.DiffLine{Type: "insert", Content: b[prefix+op.newIndex], NewLine: prefix + op.newIndex + 1})
+		}
+	}
+
+	for i := suffix; i > 0; i-- {
+		oldIndex, newIndex := len(a)-i, len(b)-i
+		lines = append(lines, domain.DiffLine{Type: "equal", Content: a[oldIndex], OldLine: oldIndex + 1, NewLine: newIndex + 1})
+	}
+	return lines
+}
+
+// splitLines, metni satırlara böler; sondaki satır sonu boş bir satır üretmez
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffOp, Myers algoritmasının ürettiği tek bir düzenleme adımıdır
+type diffOp struct {
+	kind     string
+	oldIndex int
+	newIndex int
+}
+
+// myers, a dizisini b dizisine dönüştüren en kısa düzenleme dizisini bulur
+func myers(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	maxD := n + m
+	if maxD > maxDiffEditDistance {
+		maxD = maxDiffEditDistance
+	}
+
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	// trace[d], d. adıma başlamadan önceki v değerlerinin [-d, d] aralığını tutar
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[offset-d:offset+d+1])
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // aşağı: ekleme
+			} else {
+				x = v[offset+k-1] + 1 // sağa: silme
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				return backtrack(trace, n, m)
+			}
+		}
+	}
+
+	// Düzenleme sınırı aşıldı: tümünü sil, tümünü ekle
+	ops := make([]diffOp, 0, n+m)
+	for i := 0; i < n; i++ {
+		ops = append(ops, diffOp{kind: "delete", oldIndex: i})
+	}
+	for j := 0; j < m; j++ {
+		ops = append(ops, diffOp{kind: "insert", newIndex: j})
+	}
+	return ops
+}
+
+// backtrack, Myers izinden düzenleme adımlarını baştan sona doğru yeniden oluşturur
+func backtrack(trace [][]int, n, m int) []diffOp {
+	var reversed []diffOp
+	x, y := n, m
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+d] < v[k+1+d]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+
+		prevX := 0
+		if d > 0 {
+			prevX = v[prevK+d]
+		}
+		prevY := prevX - prevK
+
+		// Köşegen boyunca eşit satırlar
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, diffOp{kind: "equal", oldIndex: x, newIndex: y})
+		}
+
+		if d > 0 {
+			if x == prevX {
+				y--
+				reversed = append(reversed, diffOp{kind: "insert", newIndex: y})
+			} else {
+				x--
+				reversed = append(reversed, diffOp{kind: "delete", oldIndex: x})
+			}
+		}
+	}
+
+	ops := make([]diffOp, len(reversed))
+	for i, op := range reversed {
+		ops[len(reversed)-1-i] = op
+	}
+	return ops
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	ErrInvalidTopic   = errors.New("geçersiz olay konusu")
+	ErrEventHubClosed = errors.New("olay akışı kapatıldı")
+)
+
+const (
+	// eventHistorySize, kopan bağlantıların Last-Event-ID ile devam edebilmesi için saklanan olay sayısı
+	eventHistorySize = 1000
+	// eventSubscriberBuffer, bir aboneye iletilmeyi bekleyebilecek en fazla olay sayısı
+	eventSubscriberBuffer = 64
+)
+
+// EventSubscription, bir istemcinin olay akışı aboneliğidir
+type EventSubscription struct {
+	topics map[string]bool
+	events chan *domain.Event
+	closed bool
+}
+
+// Events, aboneye iletilen olayları döndürür. Kanal kapandığında abonelik sona ermiştir
+// (sunucu kapanıyor veya istemci olayları yeterince hızlı okuyamadı).
+func (s *EventSubscription) Events() <-chan *domain.Event {
+	return s.events
+}
+
+// EventHub, use case'lerin yayınladığı olayları ilgili konuya abone olan istemcilere
+// ileten süreç içi yayın/abone merkezidir
+type EventHub struct {
+	noteRepo domain.NoteRepository
+	pdfRepo  domain.PDFRepository
+	access   *ContentAccess
+
+	mu          sync.Mutex
+	lastID      uint64
+	history     []*domain.Event
+	subscribers map[*EventSubscription]struct{}
+	closed      bool
+}
+
+// NewEventHub, yeni bir EventHub örneği oluşturur
+func NewEventHub(noteRepo domain.NoteRepository, pdfRepo domain.PDFRepository, access *ContentAccess) *EventHub {
+	return &EventHub{
+		noteRepo: noteRepo,
+		pdfRepo:  pdfRepo,
+		access:   access,
+		// Olay ID'leri başlangıç zamanından başlar; böylece sunucu yeniden başladıktan sonra
+		// gelen eski bir Last-Event-ID geçmişteki olaylarla karışmaz ve yeniden eşitleme istenir
+		lastID:      uint64(time.Now().UnixMicro()),
+		subscribers: make(map[*EventSubscription]struct{}),
+	}
+}
+
+// Publish, bir olayı yayınlar ve konuya abone olan istemcilere iletir
+func (h *EventHub) Publish(eventType, topic string, data interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	h.lastID++
+	event := &domain.Event{
+		ID:        h.lastID,
+		Type:      eventType,
+		Topic:     topic,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+
+	h.history = append(h.history, event)
+	if len(h.history) > eventHistorySize {
+		h.history = append([]*domain.Event(nil), h.history[len(h.history)-eventHistorySize:]...)
+	}
+
+	for sub := range h.subscribers {
+		if !sub.topics[topic] {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+			// Olayları okuyamayan istemcinin aboneliği sonlandırılır; istemci yeniden
+			// bağlandığında Last-Event-ID ile kaçırdığı olayları alır
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe, kullanıcıyı kendi olaylarına ve erişebildiği içeriklerin olaylarına abone eder.
+// topics "note:1", "pdf:2" biçimindedir. lastEventID boş değilse o olaydan sonra yayınlanmış
+// olaylar önce iletilir; bu olaylar artık saklanmıyorsa bir yeniden eşitleme olayı gönderilir.
+func (h *EventHub) Subscribe(userID uint, topics []string, lastEventID string) (*EventSubscription, error) {
+	sub := &EventSubscription{
+		topics: map[string]bool{domain.UserTopic(userID): true},
+	}
+	for _, topic := range topics {
+		if err := h.authorizeTopic(userID, topic); err != nil {
+			return nil, err
+		}
+		sub.topics[topic] = true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrEventHubClosed
+	}
+
+	// Kaçırılan olaylar ve kayıt aynı kilit altında yapılır; böylece arada olay kaybolmaz veya tekrarlanmaz
+	var missed []*domain.Event
+	if lastEventID != "" {
+		missed = h.missedEvents(sub, lastEventID)
+	}
+
+	sub.events = make(chan *domain.Event, eventSubscriberBuffer+len(missed))
+	for _, event := range missed {
+		sub.events <- event
+	}
+	h.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// Unsubscribe, aboneliği sonlandırır
+func (h *EventHub) Unsubscribe(sub *EventSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub)
+}
+
+// Close, tüm abonelikleri sonlandırır (sunucu kapanışında kullanılır)
+func (h *EventHub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		h.remove(sub)
+	}
+}
+
+// remove, aboneyi listeden çıkarır ve kanalını kapatır (kilit tutulmalıdır)
+func (h *EventHub) remove(sub *EventSubscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(h.subscribers, sub)
+	close(sub.events)
+}
+
+// missedEvents, lastEventID'den sonra yayınlanmış ve aboneyi ilgilendiren olayları döndürür (kilit tutulmalıdır)
+func (h *EventHub) missedEvents(sub *EventSubscription, lastEventID string) []*domain.Event {
+	oldest := h.lastID + 1
+	if len(h.history) > 0 {
+		oldest = h.history[0].ID
+	}
+
+	last, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil || last+1 < oldest || last > h.lastID {
+		// Olaylar artık saklanmıyor veya ID bu sunucuya ait değil
+		return []*domain.Event{{
+			ID:        h.lastID,
+			Type:      domain.EventResync,
+			Data:      map[string]interface{}{},
+			CreatedAt: time.Now(),
+		}}
+	}
+
+	var missed []*domain.Event
+	for _, event := range h.history {
+		if event.ID > last && sub.topics[event.Topic] {
+			missed = append(missed, event)
+		}
+	}
+	return missed
+}
+
+// authorizeTopic, kullanıcının bir içerik konusuna abone olup olamayacağını kontrol eder
+func (h *EventHub) authorizeTopic(userID uint, topic string) error {
+	contentType, idStr, ok := strings.Cut(topic, ":")
+	if !ok {
+		return ErrInvalidTopic
+	}
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil || id == 0 {
+		return ErrInvalidTopic
+	}
+
+	switch contentType {
+	case "note":
+		note, err := h.noteRepo.FindByID(uint(id))
+		if err != nil {
+			return fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return ErrContentNotFound
+		}
+		canView, err := h.access.CanViewNote(note, userID)
+		if err != nil {
+			return err
+		}
+		if !canView {
+			return ErrNotAuthorized
+		}
+	case "pdf":
+		pdf, err := h.pdfRepo.FindByID(uint(id))
+		if err != nil {
+			return fmt.Errorf("PDF arama sırasında hata: %w", err)
+		}
+		if pdf == nil {
+			return ErrContentNotFound
+		}
+		canView, err := h.access.CanViewPDF(pdf, userID)
+		if err != nil {
+			return err
+		}
+		if !canView {
+			return ErrNotAuthorized
+		}
+	default:
+		return ErrInvalidTopic
+	}
+	return nil
+}
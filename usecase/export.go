@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// ErrUnsupportedExportFormat, istenen dışa aktarma biçimi desteklenmediğinde döner
+var ErrUnsupportedExportFormat = errors.New("desteklenmeyen dışa aktarma biçimi")
+
+// ExportService, notları dosya olarak dışa aktarma iş mantığını içerir
+type ExportService struct {
+	noteRepo  domain.NoteRepository
+	userRepo  domain.UserRepository
+	access    *ContentAccess
+	exporters map[string]domain.NoteExporter
+}
+
+// NewExportService, yeni bir ExportService örneği oluşturur
+func NewExportService(noteRepo domain.NoteRepository, userRepo domain.UserRepository, access *ContentAccess, exporters ...domain.NoteExporter) *ExportService {
+	byFormat := make(map[string]domain.NoteExporter, len(exporters))
+	for _, exporter := range exporters {
+		byFormat[exporter.Format()] = exporter
+	}
+	return &ExportService{
+		noteRepo:  noteRepo,
+		userRepo:  userRepo,
+		access:    access,
+		exporters: byFormat,
+	}
+}
+
+// ExportNote, notu istenen biçimde dosyaya dönüştürür. Notu sahibi, herkese açıksa herkes, notun
+// paylaşıldığı grupların üyeleri veya not için oluşturulmuş geçerli bir davet bağlantısıyla gelen
+// kullanıcılar dışa aktarabilir.
+// userID 0 ise kullanıcı giriş yapmamıştır; invite nil olabilir. Dışa aktarma görüntülenme
+// sayısını artırmaz.
+func (s *ExportService) ExportNote(noteID, userID uint, invite *domain.Invite, format string) (*domain.ExportedFile, error) {
+	exporter, ok := s.exporters[strings.ToLower(format)]
+	if !ok {
+		return nil, ErrUnsupportedExportFormat
+	}
+
+	// Notu bul
+	note, err := s.noteRepo.FindByID(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+	}
+	if note == nil {
+		return nil, ErrNoteNotFound
+	}
+
+	invited := invite != nil && invite.Type == "note" && invite.ContentID == noteID
+	if !invited {
+		canView, err := s.access.CanViewNote(note, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, ErrNotAuthorized
+		}
+	}
+
+	doc := &domain.NoteDocument{
+		Title:     note.Title,
+		Author:    s.authorName(note.UserID),
+		Tags:      note.Tags,
+		Content:   note.Content,
+		CreatedAt: note.CreatedAt,
+		UpdatedAt: note.UpdatedAt,
+	}
+	content, err := exporter.Export(doc)
+	if err != nil {
+		return nil, fmt.Errorf("not dışa aktarılırken hata: %w", err)
+	}
+
+	return &domain.ExportedFile{
+		FileName:    exportFileName(note.Title, noteID) + "." + exporter.Format(),
+		ContentType: exporter.ContentType(),
+		Content:     content,
+	}, nil
+}
+
+// authorName, kullanıcının adını ve soyadını, yoksa kullanıcı adını döndürür. Kullanıcı bulunamazsa
+// belge yazarsız oluşturulur.
+func (s *ExportService) authorName(userID uint) string {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil || user == nil {
+		return ""
+	}
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	return user.Username
+}
+
+// exportFileName, not başlığından dosya adı olarak kullanılabilecek bir ad üretir. Dosya sistemlerinde
+// sorun çıkaran karakterler atılır; başlıktan ad üretilemezse not ID'si kullanılır.
+func exportFileName(title string, noteID uint) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(`/\:*?"<>|`, r), unicode.IsControl(r):
+			return -1
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return r
+	}, title)
+	name = strings.Join(strings.Fields(name), " ")
+	name = strings.Trim(name, ". ")
+
+	if runes := []rune(name); len(runes) > 100 {
+		name = strings.TrimSpace(string(runes[:100]))
+	}
+	if name == "" {
+		return fmt.Sprintf("not-%d", noteID)
+	}
+	return name
+}
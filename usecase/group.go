@@ -0,0 +1,650 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	ErrGroupNotFound            = errors.New("grup bulunamadı")
+	ErrGroupMemberNotFound      = errors.New("grup üyesi bulunamadı")
+	ErrGroupJoinRequestNotFound = errors.New("üyelik isteği bulunamadı")
+	ErrGroupContentNotFound     = errors.New("içerik grupta bulunamadı")
+	ErrGroupContentExists       = errors.New("içerik gruba zaten paylaşılmış")
+	ErrAlreadyGroupMember       = errors.New("kullanıcı grubun zaten üyesi")
+	ErrGroupJoinRequestExists   = errors.New("bekleyen bir üyelik isteği zaten var")
+	// ErrInvalidGroup, grup, üyelik veya istek işlemi geçersiz olduğunda döner; ayrıntılı hatalar bu
+	// hatayı sarar
+	ErrInvalidGroup = errors.New("geçersiz grup isteği")
+)
+
+const (
+	maxGroupNameLength           = 100
+	maxGroupDescriptionLength    = 2000
+	maxGroupRequestMessageLength = 500
+)
+
+// groupRoleRank, rolleri yetki sırasına göre karşılaştırmak için kullanılır
+var groupRoleRank = map[string]int{
+	domain.GroupRoleMember: 1,
+	domain.GroupRoleAdmin:  2,
+	domain.GroupRoleOwner:  3,
+}
+
+// GroupService, çalışma grubu ile ilgili iş mantığını içerir
+type GroupService struct {
+	groupRepo domain.GroupRepository
+	noteRepo  domain.NoteRepository
+	pdfRepo   domain.PDFRepository
+	userRepo  domain.UserRepository
+
+	inviteService *InviteService
+}
+
+// NewGroupService, yeni bir GroupService örneği oluşturur
+func NewGroupService(
+	groupRepo domain.GroupRepository,
+	noteRepo domain.NoteRepository,
+	pdfRepo domain.PDFRepository,
+	userRepo domain.UserRepository,
+	inviteService *InviteService,
+) *GroupService {
+	return &GroupService{
+		groupRepo:     groupRepo,
+		noteRepo:      noteRepo,
+		pdfRepo:       pdfRepo,
+		userRepo:      userRepo,
+		inviteService: inviteService,
+	}
+}
+
+// validGroup, adı kırpar ve ad ile açıklamanın uzunluk sınırlarını kontrol eder
+func validGroup(group *domain.Group) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return fmt.Errorf("%w: ad boş olamaz", ErrInvalidGroup)
+	}
+	if utf8.RuneCountInString(group.Name) > maxGroupNameLength {
+		return fmt.Errorf("%w: ad en fazla %d karakter olabilir", ErrInvalidGroup, maxGroupNameLength)
+	}
+	if utf8.RuneCountInString(group.Description) > maxGroupDescriptionLength {
+		return fmt.Errorf("%w: açıklama en fazla %d karakter olabilir", ErrInvalidGroup, maxGroupDescriptionLength)
+	}
+	return nil
+}
+
+// findGroup, grubu bulur
+func (s *GroupService) findGroup(id uint) (*domain.Group, error) {
+	group, err := s.groupRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("grup arama sırasında hata: %w", err)
+	}
+	if group == nil {
+		return nil, ErrGroupNotFound
+	}
+	return group, nil
+}
+
+// requireRole, grubu bulur ve kullanıcının en az verilen role sahip bir üye olduğunu kontrol eder
+func (s *GroupService) requireRole(groupID, userID uint, role string) (*domain.Group, *domain.GroupMember, error) {
+	group, err := s.findGroup(groupID)
+	if err != nil {
+		return nil, nil, err
+	}
+	member, err := s.groupRepo.FindMember(groupID, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("grup üyeliği arama sırasında hata: %w", err)
+	}
+	if member == nil || groupRoleRank[member.Role] < groupRoleRank[role] {
+		return nil, nil, ErrNotAuthorized
+	}
+	return group, member, nil
+}
+
+// logActivity, grup etkinlik akışına yeni bir kayıt ekler
+func (s *GroupService) logActivity(activity *domain.GroupActivity) error {
+	if err := s.groupRepo.CreateActivity(activity); err != nil {
+		return fmt.Errorf("grup etkinliği kaydı sırasında hata: %w", err)
+	}
+	return nil
+}
+
+// usernames, kullanıcı adlarını aynı listede tekrar tekrar aramamak için önbelleğe alır
+type usernames struct {
+	userRepo domain.UserRepository
+	cache    map[uint]string
+}
+
+func newUsernames(userRepo domain.UserRepository) *usernames {
+	return &usernames{userRepo: userRepo, cache: make(map[uint]string)}
+}
+
+// lookup, kullanıcının adını döndürür; kullanıcı bulunamazsa boş döner
+func (u *usernames) lookup(userID uint) (string, error) {
+	if name, ok := u.cache[userID]; ok {
+		return name, nil
+	}
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return "", fmt.Errorf("kullanıcı arama sırasında hata: %w", err)
+	}
+	name := ""
+	if user != nil {
+		name = user.Username
+	}
+	u.cache[userID] = name
+	return name, nil
+}
+
+// contentInfo, grup içeriğini içeriğin başlığı ve görünürlüğüyle zenginleştirir. İçerik silinmişse nil döner.
+func (s *GroupService) contentInfo(content *domain.GroupContent) (*domain.GroupContentResponse, uint, error) {
+	response := &domain.GroupContentResponse{GroupContent: *content}
+	var ownerID uint
+	switch content.ContentType {
+	case "note":
+		note, err := s.noteRepo.FindByID(content.ContentID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return nil, 0, nil
+		}
+		response.Title = note.Title
+		response.IsPublic = note.IsPublic
+		response.UpdatedAt = note.UpdatedAt
+		ownerID = note.UserID
+	case "pdf":
+		pdf, err := s.pdfRepo.FindByID(content.ContentID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("PDF arama sırasında hata: %w", err)
+		}
+		if pdf == nil {
+			return nil, 0, nil
+		}
+		response.Title = pdf.Title
+		response.Description = pdf.Description
+		response.IsPublic = pdf.IsPublic
+		response.UpdatedAt = pdf.UpdatedAt
+		ownerID = pdf.UserID
+	default:
+		return nil, 0, ErrInvalidType
+	}
+	return response, ownerID, nil
+}
+
+// addMember, kullanıcıyı gruba üye olarak ekler, bekleyen üyelik isteğini onaylanmış sayar ve etkinlik
+// akışına kaydeder. Kullanıcı zaten üyeyse ErrAlreadyGroupMember döner.
+func (s *GroupService) addMember(groupID, userID uint) error {
+	member := &domain.GroupMember{GroupID: groupID, UserID: userID, Role: domain.GroupRoleMember}
+	if err := s.groupRepo.CreateMember(member); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return ErrAlreadyGroupMember
+		}
+		return fmt.Errorf("grup üyeliği kaydı sırasında hata: %w", err)
+	}
+
+	request, err := s.groupRepo.FindPendingJoinRequest(groupID, userID)
+	if err != nil {
+		return fmt.Errorf("üyelik isteği arama sırasında hata: %w", err)
+	}
+	if request != nil {
+		request.Status = domain.GroupJoinRequestApproved
+		if err := s.groupRepo.UpdateJoinRequest(request); err != nil {
+			return fmt.Errorf("üyelik isteği güncelleme sırasında hata: %w", err)
+		}
+	}
+
+	return s.logActivity(&domain.GroupActivity{GroupID: groupID, UserID: userID, Type: domain.GroupActivityMemberJoined})
+}
+
+// CreateGroup, yeni bir çalışma grubu oluşturur; grubu oluşturan kullanıcı grubun sahibi olur
+func (s *GroupService) CreateGroup(group *domain.Group) error {
+	if err := validGroup(group); err != nil {
+		return err
+	}
+
+	if err := s.groupRepo.Create(group); err != nil {
+		return fmt.Errorf("grup kaydı sırasında hata: %w", err)
+	}
+	owner := &domain.GroupMember{GroupID: group.ID, UserID: group.OwnerID, Role: domain.GroupRoleOwner}
+	if err := s.groupRepo.CreateMember(owner); err != nil {
+		return fmt.Errorf("grup üyeliği kaydı sırasında hata: %w", err)
+	}
+	return s.logActivity(&domain.GroupActivity{GroupID: group.ID, UserID: group.OwnerID, Type: domain.GroupActivityCreated})
+}
+
+// UpdateGroup, grubun adını ve açıklamasını günceller. Grubun sahibi ve yöneticileri güncelleyebilir.
+func (s *GroupService) UpdateGroup(group *domain.Group, userID uint) error {
+	existing, _, err := s.requireRole(group.ID, userID, domain.GroupRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	existing.Name = group.Name
+	existing.Description = group.Description
+	if err := validGroup(existing); err != nil {
+		return err
+	}
+	if err := s.groupRepo.Update(existing); err != nil {
+		return fmt.Errorf("grup güncelleme sırasında hata: %w", err)
+	}
+	*group = *existing
+	return nil
+}
+
+// DeleteGroup, grubu üyelikleri, içerik paylaşımları ve etkinlikleriyle birlikte siler; notlar ve
+// PDF'ler silinmez. Yalnızca grubun sahibi silebilir.
+func (s *GroupService) DeleteGroup(id, userID uint) error {
+	if _, _, err := s.requireRole(id, userID, domain.GroupRoleOwner); err != nil {
+		return err
+	}
+	return s.groupRepo.Delete(id)
+}
+
+// GetGroup, grubu üye sayısı ve kullanıcının rolüyle birlikte getirir. Grubun temel bilgilerini
+// katılma isteği gönderebilmeleri için giriş yapmış tüm kullanıcılar görebilir.
+func (s *GroupService) GetGroup(id, userID uint) (*domain.GroupDetail, error) {
+	group, err := s.findGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.groupRepo.CountMembers(id)
+	if err != nil {
+		return nil, fmt.Errorf("grup üyelerini sayma sırasında hata: %w", err)
+	}
+
+	detail := &domain.GroupDetail{Group: group, MemberCount: count}
+	member, err := s.groupRepo.FindMember(id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("grup üyeliği arama sırasında hata: %w", err)
+	}
+	if member != nil {
+		detail.Role = member.Role
+	}
+	return detail, nil
+}
+
+// GetManagedGroup, kullanıcının sahibi veya yöneticisi olduğu grubu getirir
+func (s *GroupService) GetManagedGroup(id, userID uint) (*domain.Group, error) {
+	group, _, err := s.requireRole(id, userID, domain.GroupRoleAdmin)
+	return group, err
+}
+
+// GetUserGroups, kullanıcının üyesi olduğu grupları son güncellenenden başlayarak getirir
+func (s *GroupService) GetUserGroups(userID uint, limit, offset int) ([]*domain.Group, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.groupRepo.FindByUserID(userID, limit, offset)
+}
+
+// GetMembers, grubun üyelerini katılma sırasına göre getirir. Yalnızca grup üyeleri görebilir.
+func (s *GroupService) GetMembers(groupID, userID uint, limit, offset int) ([]*domain.GroupMember, error) {
+	if _, _, err := s.requireRole(groupID, userID, domain.GroupRoleMember); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	members, err := s.groupRepo.FindMembers(groupID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("grup üyeleri arama sırasında hata: %w", err)
+	}
+	names := newUsernames(s.userRepo)
+	for _, member := range members {
+		if member.Username, err = names.lookup(member.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+// ChangeMemberRole, bir üyenin rolünü "admin" veya "member" olarak değiştirir. Yalnızca grubun sahibi
+// rolleri değiştirebilir; sahibin rolü değiştirilemez.
+func (s *GroupService) ChangeMemberRole(groupID, memberID, userID uint, role string) (*domain.GroupMember, error) {
+	if role != domain.GroupRoleAdmin && role != domain.GroupRoleMember {
+		return nil, fmt.Errorf("%w: rol \"admin\" veya \"member\" olmalıdır", ErrInvalidGroup)
+	}
+	if _, _, err := s.requireRole(groupID, userID, domain.GroupRoleOwner); err != nil {
+		return nil, err
+	}
+
+	member, err := s.groupRepo.FindMember(groupID, memberID)
+	if err != nil {
+		return nil, fmt.Errorf("grup üyeliği arama sırasında hata: %w", err)
+	}
+	if member == nil {
+		return nil, ErrGroupMemberNotFound
+	}
+	if member.Role == domain.GroupRoleOwner {
+		return nil, fmt.Errorf("%w: grup sahibinin rolü değiştirilemez", ErrInvalidGroup)
+	}
+	if member.Role == role {
+		return member, nil
+	}
+
+	member.Role = role
+	if err := s.groupRepo.UpdateMember(member); err != nil {
+		return nil, fmt.Errorf("grup üyeliği güncelleme sırasında hata: %w", err)
+	}
+	if err := s.logActivity(&domain.GroupActivity{GroupID: groupID, UserID: userID, Type: domain.GroupActivityRoleChanged, TargetUserID: memberID, Role: role}); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember, bir üyeyi gruptan çıkarır; üyenin grup alanına paylaştığı içerikler de kaldırılır.
+// Yöneticiler yalnızca sıradan üyeleri, grubun sahibi ayrıca yöneticileri çıkarabilir.
+func (s *GroupService) RemoveMember(groupID, memberID, userID uint) error {
+	if memberID == userID {
+		return s.LeaveGroup(groupID, userID)
+	}
+	_, actor, err := s.requireRole(groupID, userID, domain.GroupRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.groupRepo.FindMember(groupID, memberID)
+	if err != nil {
+		return fmt.Errorf("grup üyeliği arama sırasında hata: %w", err)
+	}
+	if member == nil {
+		return ErrGroupMemberNotFound
+	}
+	if groupRoleRank[member.Role] >= groupRoleRank[actor.Role] {
+		return ErrNotAuthorized
+	}
+
+	if err := s.groupRepo.DeleteMember(groupID, memberID); err != nil {
+		return fmt.Errorf("grup üyeliği silme sırasında hata: %w", err)
+	}
+	return s.logActivity(&domain.GroupActivity{GroupID: groupID, UserID: userID, Type: domain.GroupActivityMemberRemoved, TargetUserID: memberID})
+}
+
+// LeaveGroup, kullanıcıyı gruptan çıkarır; kullanıcının grup alanına paylaştığı içerikler de kaldırılır.
+// Grubun sahibi gruptan ayrılamaz, grubu silebilir.
+func (s *GroupService) LeaveGroup(groupID, userID uint) error {
+	_, member, err := s.requireRole(groupID, userID, domain.GroupRoleMember)
+	if err != nil {
+		return err
+	}
+	if member.Role == domain.GroupRoleOwner {
+		return fmt.Errorf("%w: grup sahibi gruptan ayrılamaz", ErrInvalidGroup)
+	}
+
+	if err := s.groupRepo.DeleteMember(groupID, userID); err != nil {
+		return fmt.Errorf("grup üyeliği silme sırasında hata: %w", err)
+	}
+	return s.logActivity(&domain.GroupActivity{GroupID: groupID, UserID: userID, Type: domain.GroupActivityMemberLeft})
+}
+
+// RequestJoin, gruba katılma isteği gönderir. İstek grubun sahibi veya yöneticilerinden biri
+// onaylayınca kullanıcı üye olur.
+func (s *GroupService) RequestJoin(groupID, userID uint, message string) (*domain.GroupJoinRequest, error) {
+	message = strings.TrimSpace(message)
+	if utf8.RuneCountInString(message) > maxGroupRequestMessageLength {
+		return nil, fmt.Errorf("%w: mesaj en fazla %d karakter olabilir", ErrInvalidGroup, maxGroupRequestMessageLength)
+	}
+	if _, err := s.findGroup(groupID); err != nil {
+		return nil, err
+	}
+
+	member, err := s.groupRepo.FindMember(groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("grup üyeliği arama sırasında hata: %w", err)
+	}
+	if member != nil {
+		return nil, ErrAlreadyGroupMember
+	}
+	pending, err := s.groupRepo.FindPendingJoinRequest(groupID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("üyelik isteği arama sırasında hata: %w", err)
+	}
+	if pending != nil {
+		return nil, ErrGroupJoinRequestExists
+	}
+
+	request := &domain.GroupJoinRequest{
+		GroupID: groupID,
+		UserID:  userID,
+		Message: message,
+		Status:  domain.GroupJoinRequestPending,
+	}
+	if err := s.groupRepo.CreateJoinRequest(request); err != nil {
+		return nil, fmt.Errorf("üyelik isteği kaydı sırasında hata: %w", err)
+	}
+	return request, nil
+}
+
+// GetJoinRequests, grubun bekleyen üyelik isteklerini eskiden yeniye getirir. Grubun sahibi ve
+// yöneticileri görebilir.
+func (s *GroupService) GetJoinRequests(groupID, userID uint, limit, offset int) ([]*domain.GroupJoinRequest, error) {
+	if _, _, err := s.requireRole(groupID, userID, domain.GroupRoleAdmin); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	requests, err := s.groupRepo.FindJoinRequests(groupID, domain.GroupJoinRequestPending, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("üyelik isteği arama sırasında hata: %w", err)
+	}
+	names := newUsernames(s.userRepo)
+	for _, request := range requests {
+		if request.Username, err = names.lookup(request.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
+}
+
+// ReviewJoinRequest, bekleyen bir üyelik isteğini onaylar veya reddeder. Onaylanan isteğin sahibi
+// gruba üye olarak eklenir. Grubun sahibi ve yöneticileri sonuçlandırabilir.
+func (s *GroupService) ReviewJoinRequest(groupID, requestID, userID uint, approve bool) (*domain.GroupJoinRequest, error) {
+	if _, _, err := s.requireRole(groupID, userID, domain.GroupRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	request, err := s.groupRepo.FindJoinRequestByID(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("üyelik isteği arama sırasında hata: %w", err)
+	}
+	if request == nil || request.GroupID != groupID {
+		return nil, ErrGroupJoinRequestNotFound
+	}
+	if request.Status != domain.GroupJoinRequestPending {
+		return nil, fmt.Errorf("%w: üyelik isteği zaten sonuçlandırılmış", ErrInvalidGroup)
+	}
+
+	request.Status = domain.GroupJoinRequestRejected
+	if approve {
+		request.Status = domain.GroupJoinRequestApproved
+	}
+	request.ReviewedBy = &userID
+	if err := s.groupRepo.UpdateJoinRequest(request); err != nil {
+		return nil, fmt.Errorf("üyelik isteği güncelleme sırasında hata: %w", err)
+	}
+
+	if approve {
+		// İstek sahibi bu arada davet bağlantısıyla katılmış olabilir
+		if err := s.addMember(groupID, request.UserID); err != nil && err != ErrAlreadyGroupMember {
+			return nil, err
+		}
+	}
+	return request, nil
+}
+
+// JoinByInvite, grup için oluşturulmuş geçerli bir davet bağlantısıyla kullanıcıyı gruba üye olarak
+// ekler. Parola korumalı bağlantılar için password gerekir. Katılım davetin bir kullanım hakkını harcar;
+// kullanıcı zaten üyeyse hak harcanmaz ve grup bilgisi hatasız döner.
+func (s *GroupService) JoinByInvite(token, password string, userID uint) (*domain.GroupDetail, error) {
+	invite, err := s.inviteService.AuthorizeInvite(token, password, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if invite.Type != "group" {
+		return nil, ErrInvalidType
+	}
+
+	member, err := s.groupRepo.FindMember(invite.ContentID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("grup üyeliği arama sırasında hata: %w", err)
+	}
+	if member == nil {
+		if _, err := s.inviteService.RedeemInvite(invite, userID, ""); err != nil {
+			return nil, err
+		}
+		if err := s.addMember(invite.ContentID, userID); err != nil && err != ErrAlreadyGroupMember {
+			return nil, err
+		}
+	}
+	return s.GetGroup(invite.ContentID, userID)
+}
+
+// PostContent, üyenin kendi notunu veya PDF'ini grup alanına paylaşır. Özel içerikler bu sayede
+// yalnızca grup üyelerine görünür olur.
+func (s *GroupService) PostContent(content *domain.GroupContent) error {
+	if _, _, err := s.requireRole(content.GroupID, content.UserID, domain.GroupRoleMember); err != nil {
+		return err
+	}
+
+	info, ownerID, err := s.contentInfo(content)
+	if err != nil {
+		return err
+	}
+	if info == nil {
+		return ErrContentNotFound
+	}
+	if ownerID != content.UserID {
+		return ErrNotAuthorized
+	}
+
+	if err := s.groupRepo.CreateContent(content); err != nil {
+		if errors.Is(err, domain.ErrDuplicateEntry) {
+			return ErrGroupContentExists
+		}
+		return fmt.Errorf("grup içeriği kaydı sırasında hata: %w", err)
+	}
+	return s.logActivity(&domain.GroupActivity{
+		GroupID:     content.GroupID,
+		UserID:      content.UserID,
+		Type:        domain.GroupActivityContentPosted,
+		ContentID:   content.ContentID,
+		ContentType: content.ContentType,
+		Title:       info.Title,
+	})
+}
+
+// RemoveContent, içeriği grup alanından kaldırır; not veya PDF silinmez. İçeriği paylaşan üye ile
+// grubun sahibi ve yöneticileri kaldırabilir.
+func (s *GroupService) RemoveContent(groupID, contentID uint, contentType string, userID uint) error {
+	_, member, err := s.requireRole(groupID, userID, domain.GroupRoleMember)
+	if err != nil {
+		return err
+	}
+
+	content, err := s.groupRepo.FindContent(groupID, contentID, contentType)
+	if err != nil {
+		return fmt.Errorf("grup içeriği arama sırasında hata: %w", err)
+	}
+	if content == nil {
+		return ErrGroupContentNotFound
+	}
+	if content.UserID != userID && groupRoleRank[member.Role] < groupRoleRank[domain.GroupRoleAdmin] {
+		return ErrNotAuthorized
+	}
+
+	info, _, err := s.contentInfo(content)
+	if err != nil {
+		return err
+	}
+	if err := s.groupRepo.DeleteContent(groupID, contentID, contentType); err != nil {
+		return fmt.Errorf("grup içeriği silme sırasında hata: %w", err)
+	}
+
+	activity := &domain.GroupActivity{
+		GroupID:     groupID,
+		UserID:      userID,
+		Type:        domain.GroupActivityContentRemoved,
+		ContentID:   contentID,
+		ContentType: contentType,
+	}
+	if info != nil {
+		activity.Title = info.Title
+	}
+	return s.logActivity(activity)
+}
+
+// GetContents, grup alanındaki içerikleri son paylaşılandan başlayarak getirir. Yalnızca grup üyeleri
+// görebilir; silinmiş içerikler atlanır.
+func (s *GroupService) GetContents(groupID, userID uint, limit, offset int) ([]*domain.GroupContentResponse, error) {
+	if _, _, err := s.requireRole(groupID, userID, domain.GroupRoleMember); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	contents, err := s.groupRepo.FindContents(groupID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("grup içeriği arama sırasında hata: %w", err)
+	}
+	responses := make([]*domain.GroupContentResponse, 0, len(contents))
+	for _, content := range contents {
+		response, _, err := s.contentInfo(content)
+		if err != nil {
+			return nil, err
+		}
+		if response != nil {
+			responses = append(responses, response)
+		}
+	}
+	return responses, nil
+}
+
+// GetActivities, grubun etkinlik akışını en yeniden başlayarak getirir. Yalnızca grup üyeleri görebilir.
+func (s *GroupService) GetActivities(groupID, userID uint, limit, offset int) ([]*domain.GroupActivity, error) {
+	if _, _, err := s.requireRole(groupID, userID, domain.GroupRoleMember); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	activities, err := s.groupRepo.FindActivities(groupID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("grup etkinliği arama sırasında hata: %w", err)
+	}
+	names := newUsernames(s.userRepo)
+	for _, activity := range activities {
+		if activity.Username, err = names.lookup(activity.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return activities, nil
+}
+
+// Ensure GroupService implements domain.GroupService
+var _ domain.GroupService = (*GroupService)(nil)
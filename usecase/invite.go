@@ -5,9 +5,12 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/OmerFErdogan/uninote/domain"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // Diğer paketlerden hataları import et
@@ -15,16 +18,45 @@ import (
 // ErrNotAuthorized usecase/note.go'dan
 
 var (
-	ErrInviteNotFound  = errors.New("davet bağlantısı bulunamadı")
-	ErrInviteExpired   = errors.New("davet bağlantısı süresi dolmuş")
-	ErrInviteNotActive = errors.New("davet bağlantısı aktif değil")
+	ErrInviteNotFound         = errors.New("davet bağlantısı bulunamadı")
+	ErrInviteExpired          = errors.New("davet bağlantısı süresi dolmuş")
+	ErrInviteNotActive        = errors.New("davet bağlantısı aktif değil")
+	ErrInviteExhausted        = errors.New("davet bağlantısının kullanım hakkı dolmuş")
+	ErrInvitePasswordRequired = errors.New("davet bağlantısı parola gerektiriyor")
+	ErrInviteWrongPassword    = errors.New("davet bağlantısı parolası hatalı")
+	// ErrInvalidInvite, davet oluşturma isteği geçersiz olduğunda döner; ayrıntılı hatalar bu hatayı sarar
+	ErrInvalidInvite = errors.New("geçersiz davet bağlantısı isteği")
 )
 
+// Davet bağlantısı parolası ve QR kod görseli sınırları
+const (
+	minInvitePasswordLength = 4
+	maxInvitePasswordLength = 72 // bcrypt yalnızca ilk 72 baytı kullanır
+	defaultInviteQRSize     = 256
+	minInviteQRSize         = 64
+	maxInviteQRSize         = 1024
+)
+
+// invitePermissionLevels, davet yetki düzeylerinin sıralaması; her düzey bir öncekinin yetkilerini de içerir
+var invitePermissionLevels = map[string]int{
+	domain.InvitePermissionRead:     1,
+	domain.InvitePermissionComment:  2,
+	domain.InvitePermissionAnnotate: 3,
+}
+
 // InviteService, davet bağlantısı ile ilgili iş mantığını içerir
 type InviteService struct {
-	inviteRepo domain.InviteRepository
-	noteRepo   domain.NoteRepository
-	pdfRepo    domain.PDFRepository
+	inviteRepo     domain.InviteRepository
+	noteRepo       domain.NoteRepository
+	pdfRepo        domain.PDFRepository
+	collectionRepo domain.CollectionRepository
+	groupRepo      domain.GroupRepository
+	userRepo       domain.UserRepository
+	qrEncoder      domain.QRCodeEncoder
+	baseURL        string // QR kodlarına yazılan davet bağlantılarının ön yüz adresi
+
+	viewService         *ViewService
+	notificationService *NotificationService
 }
 
 // NewInviteService, yeni bir InviteService örneği oluşturur
@@ -32,42 +64,133 @@ func NewInviteService(
 	inviteRepo domain.InviteRepository,
 	noteRepo domain.NoteRepository,
 	pdfRepo domain.PDFRepository,
+	collectionRepo domain.CollectionRepository,
+	groupRepo domain.GroupRepository,
+	userRepo domain.UserRepository,
+	qrEncoder domain.QRCodeEncoder,
+	baseURL string,
+	viewService *ViewService,
+	notificationService *NotificationService,
 ) *InviteService {
 	return &InviteService{
-		inviteRepo: inviteRepo,
-		noteRepo:   noteRepo,
-		pdfRepo:    pdfRepo,
+		inviteRepo:          inviteRepo,
+		noteRepo:            noteRepo,
+		pdfRepo:             pdfRepo,
+		collectionRepo:      collectionRepo,
+		groupRepo:           groupRepo,
+		userRepo:            userRepo,
+		qrEncoder:           qrEncoder,
+		baseURL:             strings.TrimRight(baseURL, "/"),
+		viewService:         viewService,
+		notificationService: notificationService,
 	}
 }
 
-// CreateInvite, yeni bir davet bağlantısı oluşturur
-func (s *InviteService) CreateInvite(invite *domain.Invite) error {
-	// İçerik tipini kontrol et
-	if invite.Type != "note" && invite.Type != "pdf" {
-		return ErrInvalidType
+// invitePermits, davet bağlantısının verilen içerik için oluşturulduğunu ve en az istenen yetkiyi
+// verdiğini kontrol eder. invite nil olabilir.
+func invitePermits(invite *domain.Invite, contentType string, contentID uint, permission string) bool {
+	if invite == nil || invite.Type != contentType || invite.ContentID != contentID {
+		return false
 	}
+	return invitePermissionLevels[invite.Permission] >= invitePermissionLevels[permission]
+}
 
-	// İçeriğin var olduğunu ve kullanıcının sahibi olduğunu kontrol et
-	if invite.Type == "note" {
-		note, err := s.noteRepo.FindByID(invite.ContentID)
+// validateInviteOptions, davetin yetki düzeyini ve kullanım sınırını doğrular. Yetki belirtilmemişse
+// okuma yetkisi verilir. Yorum yetkisi yalnızca not ve PDF'ler, işaretleme yetkisi yalnızca PDF'ler için
+// verilebilir.
+func validateInviteOptions(invite *domain.Invite) error {
+	if invite.Permission == "" {
+		invite.Permission = domain.InvitePermissionRead
+	}
+	switch invite.Permission {
+	case domain.InvitePermissionRead:
+	case domain.InvitePermissionComment:
+		if invite.Type != "note" && invite.Type != "pdf" {
+			return fmt.Errorf("%w: yorum yetkisi yalnızca not ve PDF davetleri için verilebilir", ErrInvalidInvite)
+		}
+	case domain.InvitePermissionAnnotate:
+		if invite.Type != "pdf" {
+			return fmt.Errorf("%w: işaretleme yetkisi yalnızca PDF davetleri için verilebilir", ErrInvalidInvite)
+		}
+	default:
+		return fmt.Errorf("%w: yetki \"read\", \"comment\" veya \"annotate\" olmalı", ErrInvalidInvite)
+	}
+	if invite.MaxUses < 0 {
+		return fmt.Errorf("%w: kullanım sınırı negatif olamaz", ErrInvalidInvite)
+	}
+	return nil
+}
+
+// contentOwner, davet bağlantısıyla paylaşılan içeriğin (not, PDF, koleksiyon veya grup) sahibini döndürür.
+// İçerik türü geçersizse ErrInvalidType, içerik yoksa ErrContentNotFound döner.
+func (s *InviteService) contentOwner(contentID uint, contentType string) (uint, error) {
+	switch contentType {
+	case "note":
+		note, err := s.noteRepo.FindByID(contentID)
 		if err != nil {
-			return fmt.Errorf("not arama sırasında hata: %w", err)
+			return 0, fmt.Errorf("not arama sırasında hata: %w", err)
 		}
 		if note == nil {
-			return ErrContentNotFound
-		}
-		if note.UserID != invite.CreatedBy {
-			return ErrNotAuthorized
+			return 0, ErrContentNotFound
 		}
-	} else if invite.Type == "pdf" {
-		pdf, err := s.pdfRepo.FindByID(invite.ContentID)
+		return note.UserID, nil
+	case "pdf":
+		pdf, err := s.pdfRepo.FindByID(contentID)
 		if err != nil {
-			return fmt.Errorf("PDF arama sırasında hata: %w", err)
+			return 0, fmt.Errorf("PDF arama sırasında hata: %w", err)
 		}
 		if pdf == nil {
-			return ErrContentNotFound
+			return 0, ErrContentNotFound
+		}
+		return pdf.UserID, nil
+	case "collection":
+		collection, err := s.collectionRepo.FindByID(contentID)
+		if err != nil {
+			return 0, fmt.Errorf("koleksiyon arama sırasında hata: %w", err)
+		}
+		if collection == nil {
+			return 0, ErrContentNotFound
+		}
+		return collection.UserID, nil
+	case "group":
+		group, err := s.groupRepo.FindByID(contentID)
+		if err != nil {
+			return 0, fmt.Errorf("grup arama sırasında hata: %w", err)
+		}
+		if group == nil {
+			return 0, ErrContentNotFound
+		}
+		return group.OwnerID, nil
+	default:
+		return 0, ErrInvalidType
+	}
+}
+
+// CreateInvite, yeni bir davet bağlantısı oluşturur. Grup davetlerini grubun sahibi ve yöneticileri,
+// diğer davetleri yalnızca içeriğin sahibi oluşturabilir. password boş değilse bağlantı parola korumalı
+// olur; parola bcrypt özeti olarak saklanır.
+func (s *InviteService) CreateInvite(invite *domain.Invite, password string) error {
+	if err := validateInviteOptions(invite); err != nil {
+		return err
+	}
+	if password != "" && (len(password) < minInvitePasswordLength || len(password) > maxInvitePasswordLength) {
+		return fmt.Errorf("%w: parola %d-%d karakter olmalı", ErrInvalidInvite, minInvitePasswordLength, maxInvitePasswordLength)
+	}
+
+	// İçeriğin var olduğunu ve kullanıcının sahibi olduğunu kontrol et
+	ownerID, err := s.contentOwner(invite.ContentID, invite.Type)
+	if err != nil {
+		return err
+	}
+	if ownerID != invite.CreatedBy {
+		if invite.Type != "group" {
+			return ErrNotAuthorized
+		}
+		member, err := s.groupRepo.FindMember(invite.ContentID, invite.CreatedBy)
+		if err != nil {
+			return fmt.Errorf("grup üyeliği arama sırasında hata: %w", err)
 		}
-		if pdf.UserID != invite.CreatedBy {
+		if member == nil || member.Role != domain.GroupRoleAdmin {
 			return ErrNotAuthorized
 		}
 	}
@@ -79,11 +202,21 @@ func (s *InviteService) CreateInvite(invite *domain.Invite) error {
 	}
 	invite.Token = token
 
+	invite.PasswordHash = ""
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("parola şifreleme hatası: %w", err)
+		}
+		invite.PasswordHash = string(hash)
+	}
+
 	// Varsayılan değerleri ayarla
 	if invite.ExpiresAt.IsZero() {
 		// Varsayılan olarak 7 gün sonra sona erer
 		invite.ExpiresAt = time.Now().AddDate(0, 0, 7)
 	}
+	invite.UseCount = 0
 	invite.IsActive = true
 	invite.CreatedAt = time.Now()
 	invite.UpdatedAt = time.Now()
@@ -107,28 +240,9 @@ func (s *InviteService) GetInvite(token string) (*domain.Invite, error) {
 
 // GetInvitesByContent, bir içeriğin davet bağlantılarını getirir
 func (s *InviteService) GetInvitesByContent(contentID uint, contentType string) ([]*domain.Invite, error) {
-	// İçerik tipini kontrol et
-	if contentType != "note" && contentType != "pdf" {
-		return nil, ErrInvalidType
-	}
-
-	// İçeriğin var olduğunu kontrol et
-	if contentType == "note" {
-		note, err := s.noteRepo.FindByID(contentID)
-		if err != nil {
-			return nil, fmt.Errorf("not arama sırasında hata: %w", err)
-		}
-		if note == nil {
-			return nil, ErrContentNotFound
-		}
-	} else if contentType == "pdf" {
-		pdf, err := s.pdfRepo.FindByID(contentID)
-		if err != nil {
-			return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
-		}
-		if pdf == nil {
-			return nil, ErrContentNotFound
-		}
+	// İçerik tipini ve içeriğin var olduğunu kontrol et
+	if _, err := s.contentOwner(contentID, contentType); err != nil {
+		return nil, err
 	}
 
 	// Davet bağlantılarını getir
@@ -137,18 +251,10 @@ func (s *InviteService) GetInvitesByContent(contentID uint, contentType string)
 
 // DeactivateInvite, bir davet bağlantısını devre dışı bırakır
 func (s *InviteService) DeactivateInvite(id uint, userID uint) error {
-	// Daveti bul
-	invite, err := s.inviteRepo.FindByID(id)
+	// Daveti bul ve kullanıcı yetkisini kontrol et
+	invite, err := s.findOwnInvite(id, userID)
 	if err != nil {
-		return fmt.Errorf("davet bağlantısı arama sırasında hata: %w", err)
-	}
-	if invite == nil {
-		return ErrInviteNotFound
-	}
-
-	// Kullanıcı yetkisi kontrol et
-	if invite.CreatedBy != userID {
-		return ErrNotAuthorized
+		return err
 	}
 
 	// Daveti devre dışı bırak
@@ -180,25 +286,229 @@ func (s *InviteService) ValidateInvite(token string) (bool, *domain.Invite, erro
 	}
 
 	// İçeriğin var olduğunu kontrol et
-	if invite.Type == "note" {
-		note, err := s.noteRepo.FindByID(invite.ContentID)
+	if _, err := s.contentOwner(invite.ContentID, invite.Type); err != nil {
+		return false, invite, err
+	}
+
+	return true, invite, nil
+}
+
+// AuthorizeInvite, davet bağlantısını doğrular ve bağlantıyı kullanacak kişinin parolasını ve kalan
+// kullanım hakkını kontrol eder. Kullanım hakkı dolmuş bir bağlantıyı yalnızca onu daha önce kullanmış
+// olanlar açmaya devam edebilir: giriş yapmış kullanıcılar kullanıcı kimlikleriyle, giriş yapmamış
+// ziyaretçiler ise RedeemInvite'ın verdiği ziyaretçi anahtarıyla tanınır. Bağlantıyı oluşturan kullanıcı
+// parola ve kullanım sınırına tabi değildir. userID 0 ise kullanıcı giriş yapmamıştır.
+func (s *InviteService) AuthorizeInvite(token, password string, userID uint, visitorKey string) (*domain.Invite, error) {
+	_, invite, err := s.ValidateInvite(token)
+	if err != nil {
+		return nil, err
+	}
+	if userID != 0 && userID == invite.CreatedBy {
+		return invite, nil
+	}
+
+	if invite.HasPassword() {
+		if password == "" {
+			return nil, ErrInvitePasswordRequired
+		}
+		if bcrypt.CompareHashAndPassword([]byte(invite.PasswordHash), []byte(password)) != nil {
+			return nil, ErrInviteWrongPassword
+		}
+	}
+
+	if invite.Exhausted() {
+		redemption, err := s.findRedemption(invite.ID, userID, visitorKey)
 		if err != nil {
-			return false, invite, fmt.Errorf("not arama sırasında hata: %w", err)
+			return nil, err
 		}
-		if note == nil {
-			return false, invite, ErrContentNotFound
+		if redemption == nil {
+			return nil, ErrInviteExhausted
+		}
+	}
+	return invite, nil
+}
+
+// RedeemInvite, davet bağlantısıyla içeriğin açıldığını kaydeder. Her yeni kullanım bir kullanım hakkı
+// harcar ve kullanım kaydına eklenir; bağlantıyı daha önce kullanmış bir kullanıcının veya ziyaretçinin
+// tekrar açması yeni hak harcamaz, yalnızca son kullanım zamanını günceller. Giriş yapmamış ziyaretçinin
+// ilk kullanımında yeni bir ziyaretçi anahtarı üretilir ve döndürülür; ziyaretçi sonraki isteklerde bu
+// anahtarı göndererek aynı kullanıma bağlanır. Giriş yapmış kullanıcılar için anahtar boştur. Giriş
+// yapmış kullanıcıların not ve PDF açılışları görüntüleme olarak da kaydedilir ve içerik sahibine ilk
+// kullanımda bildirim gönderilir. Kullanım hakkı kalmamışsa ErrInviteExhausted döner. userID 0 ise
+// kullanıcı giriş yapmamıştır.
+func (s *InviteService) RedeemInvite(invite *domain.Invite, userID uint, visitorKey string) (string, error) {
+	// Bağlantıyı oluşturan kullanıcının kendi açılışları kaydedilmez
+	if userID != 0 && userID == invite.CreatedBy {
+		return "", nil
+	}
+
+	now := domain.Now()
+	redemption, err := s.findRedemption(invite.ID, userID, visitorKey)
+	if err != nil {
+		return "", err
+	}
+	if redemption != nil {
+		if err := s.touchRedemption(redemption, now); err != nil {
+			return "", err
 		}
-	} else if invite.Type == "pdf" {
-		pdf, err := s.pdfRepo.FindByID(invite.ContentID)
+		return redemption.VisitorKey, s.recordView(invite, userID)
+	}
+
+	redemption = &domain.InviteRedemption{
+		InviteID:   invite.ID,
+		UserID:     userID,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	if userID == 0 {
+		if redemption.VisitorKey, err = generateToken(); err != nil {
+			return "", fmt.Errorf("ziyaretçi anahtarı oluşturma sırasında hata: %w", err)
+		}
+	}
+	ok, err := s.inviteRepo.Redeem(redemption)
+	if errors.Is(err, domain.ErrDuplicateEntry) {
+		// Eşzamanlı bir istek aynı kullanıcının kaydını oluşturmuş; hak harcanmadı, yalnızca o kaydı güncelle
+		existing, err := s.findRedemption(invite.ID, userID, "")
 		if err != nil {
-			return false, invite, fmt.Errorf("PDF arama sırasında hata: %w", err)
+			return "", err
 		}
-		if pdf == nil {
-			return false, invite, ErrContentNotFound
+		if existing != nil {
+			if err := s.touchRedemption(existing, now); err != nil {
+				return "", err
+			}
 		}
+		return "", s.recordView(invite, userID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("davet kullanım kaydı oluşturma sırasında hata: %w", err)
 	}
+	if !ok {
+		return "", ErrInviteExhausted
+	}
+	invite.UseCount++
 
-	return true, invite, nil
+	// Kullanım kaydedildi; sonraki hatalarda da ziyaretçi anahtarı döndürülür ki hak yeniden harcanmasın
+	if err := s.recordView(invite, userID); err != nil {
+		return redemption.VisitorKey, err
+	}
+	// Gruba katılımlar grup etkinlik akışında görünür; sahibe ayrıca bildirim gönderilmez
+	if invite.Type == "group" {
+		return redemption.VisitorKey, nil
+	}
+	return redemption.VisitorKey, s.notificationService.NotifyInviteUsed(invite, userID)
+}
+
+// findRedemption, bağlantıyı daha önce kullanmış kişinin kullanım kaydını bulur. Giriş yapmış kullanıcılar
+// kullanıcı kimlikleriyle, giriş yapmamış ziyaretçiler anahtarlarıyla aranır; anahtarsız ziyaretçiler ve
+// bulunamayan kayıtlar için nil döner.
+func (s *InviteService) findRedemption(inviteID, userID uint, visitorKey string) (*domain.InviteRedemption, error) {
+	var (
+		redemption *domain.InviteRedemption
+		err        error
+	)
+	switch {
+	case userID != 0:
+		redemption, err = s.inviteRepo.FindRedemption(inviteID, userID)
+	case visitorKey != "":
+		redemption, err = s.inviteRepo.FindRedemptionByVisitorKey(inviteID, visitorKey)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("davet kullanım kaydı arama sırasında hata: %w", err)
+	}
+	return redemption, nil
+}
+
+// touchRedemption, davet kullanım kaydının son kullanım zamanını günceller
+func (s *InviteService) touchRedemption(redemption *domain.InviteRedemption, now time.Time) error {
+	redemption.LastUsedAt = now
+	if err := s.inviteRepo.UpdateRedemption(redemption); err != nil {
+		return fmt.Errorf("davet kullanım kaydı güncelleme sırasında hata: %w", err)
+	}
+	return nil
+}
+
+// recordView, giriş yapmış kullanıcının davetle açtığı notu veya PDF'i görüntüleme olarak kaydeder
+func (s *InviteService) recordView(invite *domain.Invite, userID uint) error {
+	if userID == 0 || (invite.Type != "note" && invite.Type != "pdf") {
+		return nil
+	}
+	if err := s.viewService.RecordView(userID, invite.ContentID, invite.Type); err != nil {
+		return fmt.Errorf("görüntüleme kaydı sırasında hata: %w", err)
+	}
+	return nil
+}
+
+// findOwnInvite, daveti bulur ve kullanıcının daveti oluşturan kişi olduğunu kontrol eder
+func (s *InviteService) findOwnInvite(id, userID uint) (*domain.Invite, error) {
+	invite, err := s.inviteRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("davet bağlantısı arama sırasında hata: %w", err)
+	}
+	if invite == nil {
+		return nil, ErrInviteNotFound
+	}
+	if invite.CreatedBy != userID {
+		return nil, ErrNotAuthorized
+	}
+	return invite, nil
+}
+
+// GetRedemptions, davet bağlantısının kullanım kayıtlarını kullanıcı adlarıyla birlikte son kullanılandan
+// başlayarak getirir. Yalnızca daveti oluşturan kullanıcı görebilir.
+func (s *InviteService) GetRedemptions(id, userID uint, limit, offset int) ([]*domain.InviteRedemption, error) {
+	if _, err := s.findOwnInvite(id, userID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	redemptions, err := s.inviteRepo.FindRedemptions(id, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("davet kullanım kayıtları arama sırasında hata: %w", err)
+	}
+	names := newUsernames(s.userRepo)
+	for _, redemption := range redemptions {
+		if redemption.UserID == 0 {
+			continue
+		}
+		if redemption.Username, err = names.lookup(redemption.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return redemptions, nil
+}
+
+// InviteURL, davet bağlantısının ön yüzde açılacağı adresi döndürür
+func (s *InviteService) InviteURL(invite *domain.Invite) string {
+	return s.baseURL + "/invite/" + url.PathEscape(invite.Token)
+}
+
+// GetInviteQRCode, davet bağlantısının adresini içeren PNG biçiminde bir QR kod üretir. size görselin
+// piksel cinsinden kenar uzunluğudur (0 ise varsayılan boyut kullanılır). Yalnızca daveti oluşturan
+// kullanıcı üretebilir.
+func (s *InviteService) GetInviteQRCode(id, userID uint, size int) ([]byte, error) {
+	if size == 0 {
+		size = defaultInviteQRSize
+	}
+	if size < minInviteQRSize || size > maxInviteQRSize {
+		return nil, fmt.Errorf("%w: QR kod boyutu %d-%d piksel olmalı", ErrInvalidInvite, minInviteQRSize, maxInviteQRSize)
+	}
+	invite, err := s.findOwnInvite(id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := s.qrEncoder.EncodePNG(s.InviteURL(invite), size)
+	if err != nil {
+		return nil, fmt.Errorf("QR kod oluşturma sırasında hata: %w", err)
+	}
+	return image, nil
 }
 
 // generateToken, benzersiz bir token oluşturur
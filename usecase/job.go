@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+)
+
+var (
+	ErrJobNotFound    = errors.New("iş bulunamadı")
+	ErrJobNotDead     = errors.New("yalnızca ölü işler yeniden denenebilir")
+	ErrUnknownJobType = errors.New("bilinmeyen iş türü")
+	// ErrJobPermanent, yeniden denemenin sonucu değiştirmeyeceği hataları işaretler; bu hatayı
+	// saran bir hata döndüren iş deneme hakkı beklenmeden ölü olarak işaretlenir
+	ErrJobPermanent = errors.New("kalıcı iş hatası")
+)
+
+const (
+	// jobLeaseDuration, bir worker'ın kiraladığı işi bitirmesi için tanınan süre; süre dolarsa
+	// worker'ın çöktüğü varsayılır ve iş başka bir worker tarafından yeniden kiralanabilir
+	jobLeaseDuration = 5 * time.Minute
+	// jobPollInterval, boştaki worker'ların kuyruğu yoklama aralığı (yeniden denemeler ve diğer
+	// sunuculardan eklenen işler için; bu süreçte eklenen işler worker'ları hemen uyandırır)
+	jobPollInterval = 5 * time.Second
+	// jobBackoffBase ve jobBackoffMax, başarısız işlerin yeniden deneme gecikmesinin sınırlarıdır
+	jobBackoffBase = 30 * time.Second
+	jobBackoffMax  = time.Hour
+)
+
+// JobHandler, bir iş türünü çalıştıran fonksiyondur. Bir iş kira süresi dolduğunda veya
+// worker çöktüğünde birden fazla kez çalıştırılabileceği için handler'lar tekrarlanabilir olmalıdır.
+type JobHandler func(job *domain.Job) error
+
+// JobService, kalıcı iş kuyruğunu ve işleri çalıştıran worker havuzunu yönetir
+type JobService struct {
+	jobRepo      domain.JobRepository
+	workers      int
+	maxAttempts  int
+	workerPrefix string
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+
+	wake      chan struct{}
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	closeOnce sync.Once
+}
+
+// NewJobService, yeni bir JobService örneği oluşturur
+func NewJobService(jobRepo domain.JobRepository, workers, maxAttempts int) *JobService {
+	if workers <= 0 {
+		workers = 1
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 1
+	}
+
+	// Worker ID'leri kiraların hangi sunucu sürecine ait olduğunu gösterir
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "worker"
+	}
+
+	return &JobService{
+		jobRepo:      jobRepo,
+		workers:      workers,
+		maxAttempts:  maxAttempts,
+		workerPrefix: fmt.Sprintf("%s-%d", host, os.Getpid()),
+		handlers:     make(map[string]JobHandler),
+		wake:         make(chan struct{}, workers),
+		stop:         make(chan struct{}),
+	}
+}
+
+// Register, bir iş türü için handler kaydeder. Start'tan önce çağrılmalıdır.
+func (s *JobService) Register(jobType string, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[jobType] = handler
+}
+
+// Enqueue, verilen türde bir işi yükü JSON olarak saklayarak kuyruğa ekler ve boştaki worker'ları uyandırır
+func (s *JobService) Enqueue(jobType string, payload interface{}) (*domain.Job, error) {
+	s.mu.RLock()
+	_, ok := s.handlers[jobType]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownJobType
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("iş yükü kodlanırken hata: %w", err)
+	}
+
+	job := &domain.Job{
+		Type:        jobType,
+		Payload:     string(data),
+		Status:      domain.JobStatusPending,
+		MaxAttempts: s.maxAttempts,
+		RunAt:       domain.Now().UTC(),
+	}
+	if err := s.jobRepo.Create(job); err != nil {
+		return nil, fmt.Errorf("iş oluşturma sırasında hata: %w", err)
+	}
+
+	s.notify()
+	return job, nil
+}
+
+// notify, boştaki bir worker'ı bekletmeden uyandırır
+func (s *JobService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start, worker havuzunu başlatır
+func (s *JobService) Start() {
+	s.startOnce.Do(func() {
+		for i := 1; i <= s.workers; i++ {
+			s.wg.Add(1)
+			go s.work(fmt.Sprintf("%s-%d", s.workerPrefix, i))
+		}
+		logger.Info("İş kuyruğu %d worker ile başlatıldı", s.workers)
+	})
+}
+
+// Close, worker'lara yeni iş almamalarını bildirir ve çalışmakta olan işlerin bitmesini bekler
+func (s *JobService) Close() {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+	})
+	s.wg.Wait()
+}
+
+// work, servis kapanana kadar kuyruktaki işleri kiralar ve çalıştırır
+func (s *JobService) work(workerID string) {
+	defer s.wg.Done()
+
+	for {
+		// Kuyrukta hazır iş kaldığı sürece beklemeden devam et
+		for s.runNext(workerID) {
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+		case <-time.After(jobPollInterval):
+		}
+	}
+}
+
+// runNext, hazır bir işi kiralar ve çalıştırır. Bir iş işlendiyse true döner.
+func (s *JobService) runNext(workerID string) bool {
+	now := domain.Now().UTC()
+	job, err := s.jobRepo.Lease(workerID, now, now.Add(jobLeaseDuration))
+	if err != nil {
+		logger.Error("İş kiralanırken hata oluştu: %v", err)
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	// Kirası dolarak geri gelen bir iş deneme hakkını aşmış olabilir (worker son denemede çöktü)
+	var runErr error
+	if job.Attempts > job.MaxAttempts {
+		runErr = fmt.Errorf("%w: deneme hakkı tükendi, son deneme kira süresi içinde tamamlanmadı", ErrJobPermanent)
+	} else {
+		runErr = s.run(job)
+	}
+
+	s.finish(job, workerID, runErr)
+	return true
+}
+
+// run, işi kayıtlı handler'ıyla çalıştırır; handler'daki panic hataya çevrilir
+func (s *JobService) run(job *domain.Job) (err error) {
+	s.mu.RLock()
+	handler, ok := s.handlers[job.Type]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %w: %s", ErrJobPermanent, ErrUnknownJobType, job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("iş panic ile sonlandı: %v", r)
+		}
+	}()
+	return handler(job)
+}
+
+// finish, iş sonucunu kaydeder. Başarısız işler deneme hakkı kaldıysa artan bekleme süresiyle
+// yeniden denenmek üzere kuyruğa döner, aksi halde ölü olarak işaretlenir.
+func (s *JobService) finish(job *domain.Job, workerID string, runErr error) {
+	now := domain.Now().UTC()
+
+	var err error
+	if runErr == nil {
+		err = s.jobRepo.Complete(job.ID, workerID, now)
+	} else {
+		var retryAt *time.Time
+		if !errors.Is(runErr, ErrJobPermanent) && job.Attempts < job.MaxAttempts {
+			t := now.Add(jobBackoff(job.Attempts))
+			retryAt = &t
+			logger.Error("İş %d (%s) %d. denemede başarısız oldu, %s yeniden denenecek: %v",
+				job.ID, job.Type, job.Attempts, t.Format(time.RFC3339), runErr)
+		} else {
+			logger.Error("İş %d (%s) %d. denemede başarısız oldu ve ölü olarak işaretlendi: %v",
+				job.ID, job.Type, job.Attempts, runErr)
+		}
+		err = s.jobRepo.Fail(job.ID, workerID, runErr.Error(), retryAt, now)
+	}
+
+	if errors.Is(err, domain.ErrJobLeaseLost) {
+		logger.Error("İş %d sonucu kaydedilmedi: kira süresi doldu ve iş başka bir worker'a geçti", job.ID)
+	} else if err != nil {
+		logger.Error("İş %d sonucu kaydedilirken hata oluştu: %v", job.ID, err)
+	}
+}
+
+// jobBackoff, verilen denemeden sonra beklenecek süreyi döndürür: her denemede iki katına çıkar,
+// jobBackoffMax ile sınırlanır ve aynı anda başarısız olan işler birlikte denenmesin diye %20'ye kadar rastgele uzatılır
+func jobBackoff(attempt int) time.Duration {
+	delay := jobBackoffBase
+	for i := 1; i < attempt && delay < jobBackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > jobBackoffMax {
+		delay = jobBackoffMax
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// ListJobs, işleri en yeniden eskiye listeler; boş durum ve tür filtrelenmez
+func (s *JobService) ListJobs(status, jobType string, limit, offset int) ([]*domain.Job, error) {
+	switch status {
+	case "", domain.JobStatusPending, domain.JobStatusRunning, domain.JobStatusSucceeded, domain.JobStatusDead:
+	default:
+		return nil, ErrInvalidParameters
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	jobs, err := s.jobRepo.Find(domain.JobFilter{Status: status, Type: jobType}, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("iş listeleme sırasında hata: %w", err)
+	}
+	return jobs, nil
+}
+
+// GetJob, ID'ye göre bir işi getirir
+func (s *JobService) GetJob(id uint) (*domain.Job, error) {
+	job, err := s.jobRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("iş arama sırasında hata: %w", err)
+	}
+	if job == nil {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// RetryJob, ölü bir işi deneme sayısını sıfırlayarak hemen yeniden çalıştırılmak üzere kuyruğa alır
+func (s *JobService) RetryJob(id uint) (*domain.Job, error) {
+	if err := s.jobRepo.Retry(id, domain.Now().UTC()); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			return nil, ErrJobNotFound
+		case errors.Is(err, domain.ErrInvalidInput):
+			return nil, ErrJobNotDead
+		}
+		return nil, fmt.Errorf("iş yeniden deneme sırasında hata: %w", err)
+	}
+
+	s.notify()
+	return s.GetJob(id)
+}
+
+// Stats, her durumdaki iş sayısını döndürür; hiç işi olmayan durumlar sıfır olarak yer alır
+func (s *JobService) Stats() (map[string]int, error) {
+	counts, err := s.jobRepo.CountByStatus()
+	if err != nil {
+		return nil, fmt.Errorf("iş sayıları alınırken hata: %w", err)
+	}
+
+	stats := map[string]int{
+		domain.JobStatusPending:   0,
+		domain.JobStatusRunning:   0,
+		domain.JobStatusSucceeded: 0,
+		domain.JobStatusDead:      0,
+	}
+	for status, count := range counts {
+		stats[status] = count
+	}
+	return stats, nil
+}
+
+// decodeJobPayload, işin JSON yükünü çözer; çözülemeyen yük kalıcı hatadır
+func decodeJobPayload(job *domain.Job, payload interface{}) error {
+	if err := json.Unmarshal([]byte(job.Payload), payload); err != nil {
+		return fmt.Errorf("%w: iş yükü çözülemedi: %v", ErrJobPermanent, err)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
 )
 
 var (
@@ -17,14 +18,19 @@ type LikeService struct {
 	likeRepo domain.LikeRepository
 	noteRepo domain.NoteRepository
 	pdfRepo  domain.PDFRepository
+
+	notificationService *NotificationService
+	eventHub            *EventHub
 }
 
 // NewLikeService, yeni bir LikeService örneği oluşturur
-func NewLikeService(likeRepo domain.LikeRepository, noteRepo domain.NoteRepository, pdfRepo domain.PDFRepository) *LikeService {
+func NewLikeService(likeRepo domain.LikeRepository, noteRepo domain.NoteRepository, pdfRepo domain.PDFRepository, notificationService *NotificationService, eventHub *EventHub) *LikeService {
 	return &LikeService{
-		likeRepo: likeRepo,
-		noteRepo: noteRepo,
-		pdfRepo:  pdfRepo,
+		likeRepo:            likeRepo,
+		noteRepo:            noteRepo,
+		pdfRepo:             pdfRepo,
+		notificationService: notificationService,
+		eventHub:            eventHub,
 	}
 }
 
@@ -71,7 +77,16 @@ func (s *LikeService) LikeContent(userID, contentID uint, contentType string) er
 		Type:      contentType,
 	}
 
-	return s.likeRepo.Create(like)
+	if err := s.likeRepo.Create(like); err != nil {
+		return err
+	}
+	s.publishLikeCount(contentID, contentType)
+
+	// İçerik sahibini bilgilendir; bildirim hatası beğeniyi geçersiz kılmaz
+	if err := s.notificationService.NotifyLike(userID, contentID, contentType); err != nil {
+		logger.Error("Beğeni bildirimi oluşturulurken hata oluştu: %v", err)
+	}
+	return nil
 }
 
 // UnlikeContent, bir içeriğin beğenisini kaldırır
@@ -101,7 +116,35 @@ func (s *LikeService) UnlikeContent(userID, contentID uint, contentType string)
 	}
 
 	// Beğeniyi kaldır
-	return s.likeRepo.DeleteByUserIDAndContent(userID, contentID, contentType)
+	if err := s.likeRepo.DeleteByUserIDAndContent(userID, contentID, contentType); err != nil {
+		return err
+	}
+	s.publishLikeCount(contentID, contentType)
+	return nil
+}
+
+// publishLikeCount, içeriğin güncel beğeni sayısını içeriği takip eden istemcilere iletir
+func (s *LikeService) publishLikeCount(contentID uint, contentType string) {
+	likeCount := 0
+	if contentType == "note" {
+		note, err := s.noteRepo.FindByID(contentID)
+		if err != nil || note == nil {
+			return
+		}
+		likeCount = note.LikeCount
+	} else {
+		pdf, err := s.pdfRepo.FindByID(contentID)
+		if err != nil || pdf == nil {
+			return
+		}
+		likeCount = pdf.LikeCount
+	}
+
+	s.eventHub.Publish(domain.EventLikeCount, domain.ContentTopic(contentType, contentID), &domain.LikeCountEvent{
+		ContentID:   contentID,
+		ContentType: contentType,
+		LikeCount:   likeCount,
+	})
 }
 
 // GetUserLikes, bir kullanıcının beğenilerini getirir
@@ -0,0 +1,670 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+)
+
+var (
+	ErrLiveReadOnly       = errors.New("bu notu düzenleme yetkiniz yok")
+	ErrLiveStaleRevision  = errors.New("düzenleme geçersiz bir revizyona dayanıyor; yeniden bağlanın")
+	ErrLiveDocumentTooBig = errors.New("not içeriği izin verilen boyutu aşıyor")
+	ErrLiveUnknownMessage = errors.New("bilinmeyen mesaj türü")
+	ErrLiveAccessRevoked  = errors.New("bu notu düzenleme yetkiniz kaldırıldı")
+)
+
+const (
+	// liveSendBuffer, bir istemci için bekletilebilecek en fazla giden mesaj sayısıdır.
+	// Tampon dolarsa istemci yavaş kabul edilir ve bağlantısı kapatılır.
+	liveSendBuffer = 256
+	// liveMaxHistory, geç kalan düzenlemeleri dönüştürmek için saklanan en fazla düzenleme sayısıdır
+	liveMaxHistory = 1000
+	// liveMaxDocumentLength, canlı düzenlenen bir notun UTF-16 kod birimi cinsinden en fazla uzunluğudur
+	liveMaxDocumentLength = 1 << 20
+	// liveGuestName, davet bağlantısıyla giriş yapmadan katılan kullanıcıların görünen adıdır
+	liveGuestName = "Misafir"
+	// liveAccessCheckInterval, düzenleme yetkisinin düzenleme gelirken en fazla hangi sıklıkla yeniden
+	// kontrol edileceğidir. Yetkisi kaldırılan istemcilerin bağlantısı bu süre içinde kapatılır.
+	liveAccessCheckInterval = 5 * time.Second
+)
+
+// LiveClient, bir nottaki canlı düzenleme oturumuna bağlı tek bir bağlantıdır
+type LiveClient struct {
+	id       string
+	userID   uint
+	username string
+	canEdit  bool
+	// accessCheckedAt, düzenleme yetkisinin son kontrol edildiği zamandır
+	accessCheckedAt time.Time
+	cursor          *domain.LiveCursor
+	room            *liveRoom
+	send            chan domain.LiveMessage
+	closed          bool
+}
+
+// ID, istemcinin oturumdaki kimliğini döndürür
+func (c *LiveClient) ID() string {
+	return c.id
+}
+
+// Messages, istemciye gönderilecek mesajları döndürür. Kanal, istemci oturumdan
+// çıkarıldığında (ayrılma, yavaş bağlantı veya sunucu kapanışı) kapatılır.
+func (c *LiveClient) Messages() <-chan domain.LiveMessage {
+	return c.send
+}
+
+// participant, istemcinin katılımcı bilgisini döndürür (oda kilidi tutulmalıdır)
+func (c *LiveClient) participant() domain.LiveParticipant {
+	p := domain.LiveParticipant{
+		ClientID: c.id,
+		UserID:   c.userID,
+		Username: c.username,
+		CanEdit:  c.canEdit,
+	}
+	if c.cursor != nil {
+		cursor := *c.cursor
+		p.Cursor = &cursor
+	}
+	return p
+}
+
+// liveRoom, tek bir notun canlı düzenleme durumudur
+type liveRoom struct {
+	noteID uint
+
+	mu      sync.Mutex
+	doc     []uint16
+	clients []*LiveClient
+	closed  bool
+
+	// revision, odada uygulanan düzenleme sayısıdır. history[i], historyBase+i+1
+	// numaralı revizyonu oluşturan düzenlemedir.
+	revision    int
+	history     []domain.TextOperation
+	historyBase int
+
+	// Kalıcı hale getirilen son durum
+	persistedRevision int
+	persistedContent  string
+	baseRecorded      bool
+
+	// edited, oturum boyunca istemcilerden düzenleme gelip gelmediğini belirtir
+	edited     bool
+	lastEditor uint
+
+	stop chan struct{}
+	// done, oda kapandıktan sonra son kaydı tamamlandığında kapatılır
+	done chan struct{}
+}
+
+// LiveService, notlar üzerinde gerçek zamanlı ortak düzenlemeyi yönetir.
+// Eşzamanlı düzenlemeler sunucuda operational transformation ile birleştirilir,
+// belge belirli aralıklarla NoteRepository üzerinden kaydedilir.
+type LiveService struct {
+	noteService  *NoteService
+	userRepo     domain.UserRepository
+	saveInterval time.Duration
+
+	mu    sync.Mutex
+	rooms map[uint]*liveRoom
+	// closing, kapanan ve son kaydı süren odalardır; bu notlara katılanlar kaydın bitmesini bekler
+	closing map[uint]*liveRoom
+	// generation, son kaydı tamamlanan oda sayısıdır. Yeni oda açılırken not bu arada kaydedildiyse
+	// okunan içerik eskimiş olacağından not yeniden okunur.
+	generation uint64
+}
+
+// NewLiveService, yeni bir LiveService örneği oluşturur
+func NewLiveService(noteService *NoteService, userRepo domain.UserRepository, saveInterval time.Duration) *LiveService {
+	if saveInterval <= 0 {
+		saveInterval = 5 * time.Second
+	}
+	return &LiveService{
+		noteService:  noteService,
+		userRepo:     userRepo,
+		saveInterval: saveInterval,
+		rooms:        make(map[uint]*liveRoom),
+		closing:      make(map[uint]*liveRoom),
+	}
+}
+
+// Join, kullanıcıyı notun canlı düzenleme oturumuna katar.
+//
+// Not sahibi ve notun editör olarak paylaşıldığı kullanıcılar düzenleyebilir. Nota ait geçerli bir davet
+// bağlantısıyla gelenler (giriş yapmamış olsalar da) ve görebildikleri notlarda (herkese açık, üyesi
+// oldukları bir gruba veya kendilerine paylaşılmış) diğer giriş yapmış kullanıcılar yalnızca izleyebilir.
+// userID 0 ise kullanıcı giriş yapmamıştır; invite nil olabilir.
+func (s *LiveService) Join(noteID, userID uint, invite *domain.Invite) (*LiveClient, error) {
+	note, err := s.noteService.noteRepo.FindByID(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+	}
+	if note == nil {
+		return nil, ErrNoteNotFound
+	}
+
+	// Erişim yetkisini belirle
+	canEdit := false
+	if userID != 0 {
+		canEdit, err = s.noteService.access.CanEditNote(note, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !canEdit && !invitePermits(invite, "note", noteID, domain.InvitePermissionRead) {
+		// Davet bağlantısı olmadan giriş yapmamış kullanıcılar oturumu izleyemez
+		if userID == 0 {
+			return nil, ErrNotAuthorized
+		}
+		canView, err := s.noteService.CanViewNote(note, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !canView {
+			return nil, ErrNotAuthorized
+		}
+	}
+
+	// Görünen adı belirle
+	username := liveGuestName
+	if userID != 0 {
+		user, err := s.userRepo.FindByID(userID)
+		if err != nil {
+			return nil, fmt.Errorf("kullanıcı arama sırasında hata: %w", err)
+		}
+		if user != nil {
+			username = user.Username
+		}
+	}
+
+	id, err := newLiveClientID()
+	if err != nil {
+		return nil, fmt.Errorf("istemci kimliği oluşturulamadı: %w", err)
+	}
+
+	room, err := s.lockRoom(noteID)
+	if err != nil {
+		return nil, err
+	}
+	defer room.mu.Unlock()
+
+	client := &LiveClient{
+		id:              id,
+		userID:          userID,
+		username:        username,
+		canEdit:         canEdit,
+		accessCheckedAt: time.Now(),
+		room:            room,
+		send:            make(chan domain.LiveMessage, liveSendBuffer),
+	}
+
+	room.clients = append(room.clients, client)
+
+	// Yeni istemciye belgenin mevcut halini, diğerlerine yeni katılımcıyı gönder
+	joined := client.participant()
+	room.send(client, domain.LiveMessage{
+		Type:         domain.LiveMessageInit,
+		Revision:     room.revision,
+		ClientID:     client.id,
+		Content:      decodeText(room.doc),
+		Participant:  &joined,
+		Participants: room.participants(),
+	})
+	room.broadcast(client, domain.LiveMessage{Type: domain.LiveMessageJoin, Revision: room.revision, Participant: &joined})
+
+	return client, nil
+}
+
+// lockRoom, notun açık odasını kilitleyerek döndürür; oda yoksa notun güncel içeriğiyle yeni bir oda açar.
+// Not kapanmakta olan bir odadaysa yeni oda, eski odanın son kaydı bittikten sonra açılır. Not veritabanından
+// servis kilidi tutulmadan okunur; dönen odanın kilidi çağıran tarafından bırakılmalıdır.
+func (s *LiveService) lockRoom(noteID uint) (*liveRoom, error) {
+	for {
+		s.mu.Lock()
+		if room, ok := s.rooms[noteID]; ok {
+			room.mu.Lock()
+			s.mu.Unlock()
+			return room, nil
+		}
+		if closing, ok := s.closing[noteID]; ok {
+			s.mu.Unlock()
+			<-closing.done
+			continue
+		}
+		generation := s.generation
+		s.mu.Unlock()
+
+		note, err := s.noteService.noteRepo.FindByID(noteID)
+		if err != nil {
+			return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return nil, ErrNoteNotFound
+		}
+
+		s.mu.Lock()
+		_, opened := s.rooms[noteID]
+		_, closing := s.closing[noteID]
+		if opened || closing || s.generation != generation {
+			s.mu.Unlock()
+			continue
+		}
+		room := &liveRoom{
+			noteID:           noteID,
+			doc:              encodeText(note.Content),
+			persistedContent: note.Content,
+			stop:             make(chan struct{}),
+			done:             make(chan struct{}),
+		}
+		s.rooms[noteID] = room
+		go s.runRoom(room)
+		room.mu.Lock()
+		s.mu.Unlock()
+		return room, nil
+	}
+}
+
+// Leave, istemciyi oturumdan çıkarır. Odada kimse kalmazsa belge son kez kaydedilir
+// ve oturum boyunca yapılan düzenlemeler tek bir revizyon olarak geçmişe eklenir.
+func (s *LiveService) Leave(client *LiveClient) {
+	s.mu.Lock()
+	room := client.room
+	room.mu.Lock()
+	for i, c := range room.clients {
+		if c == client {
+			room.clients = append(room.clients[:i], room.clients[i+1:]...)
+			break
+		}
+	}
+	room.disconnect(client)
+	room.broadcast(nil, domain.LiveMessage{Type: domain.LiveMessageLeave, Revision: room.revision, ClientID: client.id})
+	empty := len(room.clients) == 0 && !room.closed
+	if empty {
+		room.closed = true
+		delete(s.rooms, room.noteID)
+		s.closing[room.noteID] = room
+	}
+	room.mu.Unlock()
+	s.mu.Unlock()
+
+	// Kayıt servis kilidi tutulmadan yapılır; bu sırada nota katılanlar lockRoom'da kaydın bitmesini bekler
+	if empty {
+		s.closeRoom(room)
+	}
+}
+
+// closeRoom, kapatılan odanın son kaydını yapar ve odayı kapanan odalardan çıkarır
+func (s *LiveService) closeRoom(room *liveRoom) {
+	close(room.stop)
+	s.finishRoom(room)
+
+	s.mu.Lock()
+	delete(s.closing, room.noteID)
+	s.generation++
+	s.mu.Unlock()
+	close(room.done)
+}
+
+// Receive, istemciden gelen bir mesajı işler. Hatalar istemciye "error" mesajı olarak gönderilir.
+func (s *LiveService) Receive(client *LiveClient, msg domain.LiveMessage) {
+	if msg.Type == domain.LiveMessageOp {
+		s.checkEditAccess(client)
+	}
+
+	room := client.room
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	if client.closed {
+		return
+	}
+
+	var err error
+	switch msg.Type {
+	case domain.LiveMessageOp:
+		if !client.canEdit {
+			err = ErrLiveReadOnly
+			break
+		}
+		err = room.apply(msg.Revision, msg.Operation, client)
+	case domain.LiveMessageCursor:
+		err = room.moveCursor(client, msg.Revision, msg.Cursor)
+	default:
+		err = ErrLiveUnknownMessage
+	}
+
+	if err != nil {
+		room.send(client, domain.LiveMessage{Type: domain.LiveMessageError, Revision: room.revision, Error: err.Error()})
+	}
+}
+
+// checkEditAccess, düzenleme yetkisini son kontrolden liveAccessCheckInterval kadar süre geçtiyse yeniden
+// kontrol eder. Yetkisi kaldırılan istemci bilgilendirilir ve bağlantısı kapatılır. Not ve paylaşımlar
+// oda kilidi tutulmadan okunur.
+func (s *LiveService) checkEditAccess(client *LiveClient) {
+	room := client.room
+	room.mu.Lock()
+	due := client.canEdit && !client.closed && time.Since(client.accessCheckedAt) >= liveAccessCheckInterval
+	room.mu.Unlock()
+	if !due {
+		return
+	}
+
+	note, err := s.noteService.noteRepo.FindByID(room.noteID)
+	if err != nil || note == nil {
+		// Silinen notlar saveRoom'da ele alınır; geçici hatalarda bir sonraki düzenlemede yeniden denenir
+		return
+	}
+	canEdit, err := s.noteService.access.CanEditNote(note, client.userID)
+	if err != nil {
+		logger.Error("Canlı düzenleme yetkisi kontrol edilemedi (not %d): %v", room.noteID, err)
+		return
+	}
+
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	client.accessCheckedAt = time.Now()
+	if !canEdit {
+		room.send(client, domain.LiveMessage{Type: domain.LiveMessageError, Revision: room.revision, Error: ErrLiveAccessRevoked.Error()})
+		room.disconnect(client)
+	}
+}
+
+// Close, tüm oturumları kaydeder ve istemci bağlantılarını sonlandırır (sunucu kapanışında kullanılır)
+func (s *LiveService) Close() {
+	s.mu.Lock()
+	rooms := make([]*liveRoom, 0, len(s.rooms))
+	for noteID, room := range s.rooms {
+		room.mu.Lock()
+		for _, c := range room.clients {
+			room.disconnect(c)
+		}
+		room.clients = nil
+		room.closed = true
+		room.mu.Unlock()
+
+		delete(s.rooms, noteID)
+		s.closing[noteID] = room
+		rooms = append(rooms, room)
+	}
+	s.mu.Unlock()
+
+	for _, room := range rooms {
+		s.closeRoom(room)
+	}
+
+	// Son kaydı Leave'de süren odaları da bekle
+	for {
+		s.mu.Lock()
+		var pending *liveRoom
+		for _, room := range s.closing {
+			pending = room
+			break
+		}
+		s.mu.Unlock()
+		if pending == nil {
+			return
+		}
+		<-pending.done
+	}
+}
+
+// runRoom, oda kapanana kadar belgeyi belirli aralıklarla kaydeder
+func (s *LiveService) runRoom(room *liveRoom) {
+	ticker := time.NewTicker(s.saveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			room.mu.Lock()
+			if err := s.saveRoom(room); err != nil && !errors.Is(err, domain.ErrVersionConflict) {
+				logger.Error("Canlı not kaydedilirken hata oluştu (not %d): %v", room.noteID, err)
+			}
+			room.mu.Unlock()
+		case <-room.stop:
+			return
+		}
+	}
+}
+
+// finishRoom, kapanan odanın son halini kaydeder ve düzenleme yapıldıysa revizyon oluşturur
+func (s *LiveService) finishRoom(room *liveRoom) {
+	room.mu.Lock()
+	defer room.mu.Unlock()
+
+	// Araya giren REST güncellemeleriyle çakışma olursa birkaç kez yeniden dene
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		if err = s.saveRoom(room); !errors.Is(err, domain.ErrVersionConflict) {
+			break
+		}
+	}
+	if err != nil {
+		logger.Error("Canlı not kapanışta kaydedilemedi (not %d): %v", room.noteID, err)
+		return
+	}
+	if !room.edited {
+		return
+	}
+
+	note, err := s.noteService.noteRepo.FindByID(room.noteID)
+	if err != nil || note == nil {
+		return
+	}
+	authorID := room.lastEditor
+	if authorID == 0 {
+		authorID = note.UserID
+	}
+	if err := s.noteService.recordRevision(note, authorID, 0); err != nil {
+		logger.Error("Canlı düzenleme revizyonu kaydedilemedi (not %d): %v", room.noteID, err)
+	}
+}
+
+// saveRoom, odadaki belgeyi nota yazar (oda kilidi tutulmalıdır).
+// Son kayıttan bu yana not oturum dışından değiştirildiyse bu değişiklik de
+// eşzamanlı bir düzenleme gibi belgeyle birleştirilir.
+func (s *LiveService) saveRoom(room *liveRoom) error {
+	note, err := s.noteService.noteRepo.FindByID(room.noteID)
+	if err != nil {
+		return fmt.Errorf("not arama sırasında hata: %w", err)
+	}
+	if note == nil {
+		// Not silinmiş; bağlı istemcileri bilgilendir ve bağlantılarını kapat
+		for _, c := range room.clients {
+			room.send(c, domain.LiveMessage{Type: domain.LiveMessageError, Revision: room.revision, Error: ErrNoteNotFound.Error()})
+			room.disconnect(c)
+		}
+		room.edited = false
+		return nil
+	}
+
+	if note.Content != room.persistedContent {
+		external := replaceOperation(encodeText(room.persistedContent), encodeText(note.Content))
+		if err := room.apply(room.persistedRevision, external, nil); err != nil {
+			return fmt.Errorf("harici değişiklik birleştirilemedi: %w", err)
+		}
+		room.persistedContent = note.Content
+	}
+
+	content := decodeText(room.doc)
+	if content == note.Content {
+		room.persistedRevision = room.revision
+		return nil
+	}
+
+	// Revizyon geçmişi olmayan notların oturum öncesi halini kaybetmemek için önce onu kaydet
+	if !room.baseRecorded {
+		if err := s.noteService.ensureBaseRevision(note); err != nil {
+			return err
+		}
+		room.baseRecorded = true
+	}
+
+	note.Content = content
+	if err := s.noteService.noteRepo.Update(note); err != nil {
+		return err
+	}
+
+	room.persistedRevision = room.revision
+	room.persistedContent = content
+	room.broadcast(nil, domain.LiveMessage{Type: domain.LiveMessageSaved, Revision: room.revision, Version: note.Version})
+	return nil
+}
+
+// apply, baseRevision üzerine yazılmış bir düzenlemeyi o revizyondan sonraki
+// düzenlemelere göre dönüştürüp belgeye uygular (oda kilidi tutulmalıdır).
+// source nil ise düzenleme sunucudan gelir.
+func (r *liveRoom) apply(baseRevision int, op domain.TextOperation, source *LiveClient) error {
+	if baseRevision < r.historyBase || baseRevision > r.revision {
+		return ErrLiveStaleRevision
+	}
+
+	for _, concurrent := range r.history[baseRevision-r.historyBase:] {
+		var err error
+		if op, _, err = transformOperations(op, concurrent); err != nil {
+			return err
+		}
+	}
+
+	doc, err := applyOperation(r.doc, op)
+	if err != nil {
+		return err
+	}
+	if len(doc) > liveMaxDocumentLength {
+		return ErrLiveDocumentTooBig
+	}
+
+	r.doc = doc
+	r.revision++
+	r.history = append(r.history, op)
+	r.trimHistory()
+
+	if source != nil && !isNoop(op) {
+		r.edited = true
+		if source.userID != 0 {
+			r.lastEditor = source.userID
+		}
+	}
+
+	// İmleçleri yeni belgeye taşı
+	for _, c := range r.clients {
+		if c.cursor != nil {
+			c.cursor.Position = transformIndex(c.cursor.Position, op)
+			c.cursor.SelectionEnd = transformIndex(c.cursor.SelectionEnd, op)
+		}
+	}
+
+	sourceID := ""
+	if source != nil {
+		sourceID = source.id
+		r.send(source, domain.LiveMessage{Type: domain.LiveMessageAck, Revision: r.revision})
+	}
+	r.broadcast(source, domain.LiveMessage{Type: domain.LiveMessageOp, Revision: r.revision, ClientID: sourceID, Operation: op})
+	return nil
+}
+
+// trimHistory, geçmişi sınırlı tutar; kaydedilmemiş düzenlemeler harici değişikliklerin
+// birleştirilmesi için her zaman saklanır (oda kilidi tutulmalıdır)
+func (r *liveRoom) trimHistory() {
+	trimTo := r.revision - liveMaxHistory
+	if trimTo > r.persistedRevision {
+		trimTo = r.persistedRevision
+	}
+	if trimTo <= r.historyBase {
+		return
+	}
+	r.history = append([]domain.TextOperation(nil), r.history[trimTo-r.historyBase:]...)
+	r.historyBase = trimTo
+}
+
+// moveCursor, istemcinin imlecini günceller ve diğer katılımcılara bildirir (oda kilidi tutulmalıdır)
+func (r *liveRoom) moveCursor(client *LiveClient, baseRevision int, cursor *domain.LiveCursor) error {
+	if cursor == nil {
+		client.cursor = nil
+	} else {
+		if baseRevision < r.historyBase || baseRevision > r.revision {
+			return ErrLiveStaleRevision
+		}
+
+		// İmleç eski bir revizyona göre gönderildiyse aradaki düzenlemelere göre taşı
+		moved := *cursor
+		for _, op := range r.history[baseRevision-r.historyBase:] {
+			moved.Position = transformIndex(moved.Position, op)
+			moved.SelectionEnd = transformIndex(moved.SelectionEnd, op)
+		}
+		moved.Position = clamp(moved.Position, 0, len(r.doc))
+		moved.SelectionEnd = clamp(moved.SelectionEnd, 0, len(r.doc))
+		client.cursor = &moved
+	}
+
+	r.broadcast(client, domain.LiveMessage{Type: domain.LiveMessageCursor, Revision: r.revision, ClientID: client.id, Cursor: client.cursor})
+	return nil
+}
+
+// participants, odadaki bağlı katılımcıları döndürür (oda kilidi tutulmalıdır)
+func (r *liveRoom) participants() []domain.LiveParticipant {
+	participants := make([]domain.LiveParticipant, 0, len(r.clients))
+	for _, c := range r.clients {
+		if !c.closed {
+			participants = append(participants, c.participant())
+		}
+	}
+	return participants
+}
+
+// send, bir istemciye mesaj gönderir; istemcinin tamponu doluysa bağlantısını keser (oda kilidi tutulmalıdır)
+func (r *liveRoom) send(client *LiveClient, msg domain.LiveMessage) {
+	if client.closed {
+		return
+	}
+	select {
+	case client.send <- msg:
+	default:
+		r.disconnect(client)
+	}
+}
+
+// broadcast, except dışındaki tüm istemcilere mesaj gönderir (oda kilidi tutulmalıdır)
+func (r *liveRoom) broadcast(except *LiveClient, msg domain.LiveMessage) {
+	for _, c := range r.clients {
+		if c != except {
+			r.send(c, msg)
+		}
+	}
+}
+
+// disconnect, istemcinin mesaj kanalını kapatır (oda kilidi tutulmalıdır)
+func (r *liveRoom) disconnect(client *LiveClient) {
+	if !client.closed {
+		client.closed = true
+		close(client.send)
+	}
+}
+
+// newLiveClientID, rastgele bir istemci kimliği üretir
+func newLiveClientID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// clamp, değeri [lo, hi] aralığına sınırlar
+func clamp(value, lo, hi int) int {
+	if value < lo {
+		return lo
+	}
+	if value > hi {
+		return hi
+	}
+	return value
+}
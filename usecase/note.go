@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
 )
 
 var (
@@ -16,15 +17,29 @@ var (
 
 // NoteService, not ile ilgili iş mantığını içerir
 type NoteService struct {
-	noteRepo    domain.NoteRepository
-	commentRepo domain.CommentRepository
+	noteRepo     domain.NoteRepository
+	commentRepo  domain.CommentRepository
+	revisionRepo domain.NoteRevisionRepository
+	renderer     domain.ContentRenderer
+	renderCache  *renderCache
+	access       *ContentAccess
+
+	notificationService *NotificationService
+	eventHub            *EventHub
 }
 
-// NewNoteService, yeni bir NoteService örneği oluşturur
-func NewNoteService(noteRepo domain.NoteRepository, commentRepo domain.CommentRepository) *NoteService {
+// NewNoteService, yeni bir NoteService örneği oluşturur. renderCacheSize, önbellekte tutulacak en fazla
+// HTML çıktısı sayısıdır; 0 ise içerikler her istekte yeniden dönüştürülür.
+func NewNoteService(noteRepo domain.NoteRepository, commentRepo domain.CommentRepository, revisionRepo domain.NoteRevisionRepository, renderer domain.ContentRenderer, renderCacheSize int, access *ContentAccess, notificationService *NotificationService, eventHub *EventHub) *NoteService {
 	return &NoteService{
-		noteRepo:    noteRepo,
-		commentRepo: commentRepo,
+		noteRepo:            noteRepo,
+		commentRepo:         commentRepo,
+		revisionRepo:        revisionRepo,
+		renderer:            renderer,
+		renderCache:         newRenderCache(renderCacheSize),
+		access:              access,
+		notificationService: notificationService,
+		eventHub:            eventHub,
 	}
 }
 
@@ -34,7 +49,12 @@ func (s *NoteService) CreateNote(note *domain.Note) error {
 		return ErrInvalidParameters
 	}
 
-	return s.noteRepo.Create(note)
+	if err := s.noteRepo.Create(note); err != nil {
+		return err
+	}
+
+	// İlk revizyonu kaydet
+	return s.recordRevision(note, note.UserID, 0)
 }
 
 // UpdateNote, bir notu günceller
@@ -48,13 +68,45 @@ func (s *NoteService) UpdateNote(note *domain.Note) error {
 		return ErrNoteNotFound
 	}
 
-	// Kullanıcı yetkisi kontrol et
-	if existingNote.UserID != note.UserID {
+	// Kullanıcı yetkisi kontrol et; not sahibi ve editör olarak paylaşılan kullanıcılar düzenleyebilir
+	editorID := note.UserID
+	canEdit, err := s.access.CanEditNote(existingNote, editorID)
+	if err != nil {
+		return err
+	}
+	if !canEdit {
 		return ErrNotAuthorized
 	}
 
+	// Not sahibi değişmez; görünürlüğü yalnızca sahibi değiştirebilir
+	note.UserID = existingNote.UserID
+	if editorID != existingNote.UserID {
+		note.IsPublic = existingNote.IsPublic
+	}
+
+	// İstemci sürüm belirtmediyse son yazan kazanır; belirttiyse güncel sürümle eşleşmeli
+	if note.Version == 0 {
+		note.Version = existingNote.Version
+	}
+	if note.Version != existingNote.Version {
+		return &NoteConflictError{Current: existingNote}
+	}
+
+	// Revizyon geçmişi olmayan (eski) notların mevcut halini kaybetmemek için önce onu kaydet
+	if err := s.ensureBaseRevision(existingNote); err != nil {
+		return err
+	}
+
 	// Notu güncelle
-	return s.noteRepo.Update(note)
+	if err := s.noteRepo.Update(note); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return s.noteConflict(note.ID)
+		}
+		return err
+	}
+
+	// Yeni hali revizyon olarak kaydet
+	return s.recordRevision(note, editorID, 0)
 }
 
 // DeleteNote, bir notu siler
@@ -93,6 +145,22 @@ func (s *NoteService) GetNote(id uint) (*domain.Note, error) {
 	return note, nil
 }
 
+// CanViewNote, kullanıcının notu görüp göremeyeceğini döndürür: herkese açık notları herkes, özel
+// notları sahibi, notun paylaşıldığı grupların üyeleri ve notun doğrudan paylaşıldığı kullanıcılar
+// görebilir. userID 0 ise kullanıcı giriş yapmamıştır.
+func (s *NoteService) CanViewNote(note *domain.Note, userID uint) (bool, error) {
+	return s.access.CanViewNote(note, userID)
+}
+
+// CanViewNoteWithInvite, CanViewNote gibidir; not için oluşturulmuş geçerli bir davet bağlantısıyla
+// gelenler de notu görebilir. invite nil olabilir.
+func (s *NoteService) CanViewNoteWithInvite(note *domain.Note, userID uint, invite *domain.Invite) (bool, error) {
+	if invitePermits(invite, "note", note.ID, domain.InvitePermissionRead) {
+		return true, nil
+	}
+	return s.access.CanViewNote(note, userID)
+}
+
 // GetUserNotes, bir kullanıcının notlarını getirir
 func (s *NoteService) GetUserNotes(userID uint, limit, offset int) ([]*domain.Note, error) {
 	if limit <= 0 {
@@ -117,9 +185,9 @@ func (s *NoteService) GetPublicNotes(limit, offset int) ([]*domain.Note, error)
 	return s.noteRepo.FindPublic(limit, offset)
 }
 
-// SearchNotes, notları arar
-func (s *NoteService) SearchNotes(query string, limit, offset int) ([]*domain.Note, error) {
-	if query == "" {
+// GetNotesByTag, etikete sahip herkese açık notları getirir
+func (s *NoteService) GetNotesByTag(tag string, limit, offset int) ([]*domain.Note, error) {
+	if tag == "" {
 		return nil, ErrInvalidParameters
 	}
 	if limit <= 0 {
@@ -129,11 +197,24 @@ func (s *NoteService) SearchNotes(query string, limit, offset int) ([]*domain.No
 		offset = 0
 	}
 
-	return s.noteRepo.Search(query, limit, offset)
+	notes, err := s.noteRepo.FindByTag(tag, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Özel notlar etiket listelerinde gösterilmez
+	publicNotes := make([]*domain.Note, 0, len(notes))
+	for _, note := range notes {
+		if note.IsPublic {
+			publicNotes = append(publicNotes, note)
+		}
+	}
+	return publicNotes, nil
 }
 
-// AddComment, bir nota yorum ekler
-func (s *NoteService) AddComment(comment *domain.Comment) error {
+// AddComment, bir nota yorum ekler. Not için yorum yetkili bir davet bağlantısıyla gelen giriş yapmış
+// kullanıcılar özel notlara da yorum yapabilir; invite nil olabilir.
+func (s *NoteService) AddComment(comment *domain.Comment, invite *domain.Invite) error {
 	// Notu bul
 	note, err := s.noteRepo.FindByID(comment.NoteID)
 	if err != nil {
@@ -143,8 +224,40 @@ func (s *NoteService) AddComment(comment *domain.Comment) error {
 		return ErrNoteNotFound
 	}
 
+	// Yorum yetkisini kontrol et
+	if !invitePermits(invite, "note", note.ID, domain.InvitePermissionComment) {
+		canComment, err := s.access.CanCommentNote(note, comment.UserID)
+		if err != nil {
+			return err
+		}
+		if !canComment {
+			return ErrNotAuthorized
+		}
+	}
+
 	// Yorumu ekle
-	return s.commentRepo.Create(comment)
+	if err := s.commentRepo.Create(comment); err != nil {
+		return err
+	}
+
+	// Notu takip eden istemcilere yeni yorumu ve güncel yorum sayısını ilet
+	commentCount := note.CommentCount + 1
+	if updated, err := s.noteRepo.FindByID(note.ID); err == nil && updated != nil {
+		commentCount = updated.CommentCount
+	}
+	s.eventHub.Publish(domain.EventComment, domain.ContentTopic("note", note.ID), &domain.CommentEvent{
+		ContentID:    note.ID,
+		ContentType:  "note",
+		CommentID:    comment.ID,
+		UserID:       comment.UserID,
+		CommentCount: commentCount,
+	})
+
+	// Not sahibini ve yorumda bahsedilen kullanıcıları bilgilendir; bildirim hatası yorumu geçersiz kılmaz
+	if err := s.notificationService.NotifyComment(comment.UserID, note.ID, "note", comment.Content); err != nil {
+		logger.Error("Yorum bildirimi oluşturulurken hata oluştu: %v", err)
+	}
+	return nil
 }
 
 // GetComments, bir notun yorumlarını getirir
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("bildirim bulunamadı")
+)
+
+// mentionPattern, yorumlardaki @kullaniciadi bahsetmelerini yakalar
+var mentionPattern = regexp.MustCompile(`@([\p{L}\p{N}_.\-]+)`)
+
+// notificationSnippetLength, bildirim mesajlarına eklenen yorum alıntısının en fazla karakter sayısı
+const notificationSnippetLength = 100
+
+// NotificationService, bildirim ile ilgili iş mantığını içerir
+type NotificationService struct {
+	notificationRepo domain.NotificationRepository
+	preferenceRepo   domain.NotificationPreferenceRepository
+	userRepo         domain.UserRepository
+	noteRepo         domain.NoteRepository
+	pdfRepo          domain.PDFRepository
+	collectionRepo   domain.CollectionRepository
+	eventHub         *EventHub
+}
+
+// NewNotificationService, yeni bir NotificationService örneği oluşturur
+func NewNotificationService(
+	notificationRepo domain.NotificationRepository,
+	preferenceRepo domain.NotificationPreferenceRepository,
+	userRepo domain.UserRepository,
+	noteRepo domain.NoteRepository,
+	pdfRepo domain.PDFRepository,
+	collectionRepo domain.CollectionRepository,
+	eventHub *EventHub,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		preferenceRepo:   preferenceRepo,
+		userRepo:         userRepo,
+		noteRepo:         noteRepo,
+		pdfRepo:          pdfRepo,
+		collectionRepo:   collectionRepo,
+		eventHub:         eventHub,
+	}
+}
+
+// notifiedContent, bildirimi yapılan içeriğin sahibi ve başlığıdır
+type notifiedContent struct {
+	ownerID  uint
+	title    string
+	isPublic bool
+}
+
+// NotifyLike, içerik sahibine içeriğinin beğenildiğini bildirir
+func (s *NotificationService) NotifyLike(actorID, contentID uint, contentType string) error {
+	content, err := s.findContent(contentID, contentType)
+	if err != nil {
+		return err
+	}
+	if content.ownerID == actorID {
+		return nil
+	}
+
+	actor, err := s.actorName(actorID)
+	if err != nil {
+		return err
+	}
+
+	target := "notunuzu"
+	if contentType == "pdf" {
+		target = "PDF'inizi"
+	}
+
+	// Beğen/beğenmekten vazgeç tekrarlarında aynı bildirimi çoğaltma
+	return s.notify(&domain.Notification{
+		UserID:      content.ownerID,
+		ActorID:     actorID,
+		Type:        domain.NotificationLike,
+		ContentID:   contentID,
+		ContentType: contentType,
+		Message:     fmt.Sprintf("%s \"%s\" %s beğendi", actor, content.title, target),
+	}, true)
+}
+
+// NotifyComment, içerik sahibine yeni yorumu, yorumda bahsedilen kullanıcılara da bahsetmeyi bildirir
+func (s *NotificationService) NotifyComment(actorID, contentID uint, contentType, text string) error {
+	content, err := s.findContent(contentID, contentType)
+	if err != nil {
+		return err
+	}
+
+	actor, err := s.actorName(actorID)
+	if err != nil {
+		return err
+	}
+
+	if content.ownerID != actorID {
+		target := "notunuza"
+		if contentType == "pdf" {
+			target = "PDF'inize"
+		}
+
+		if err := s.notify(&domain.Notification{
+			UserID:      content.ownerID,
+			ActorID:     actorID,
+			Type:        domain.NotificationComment,
+			ContentID:   contentID,
+			ContentType: contentType,
+			Message:     fmt.Sprintf("%s \"%s\" %s yorum yaptı: %s", actor, content.title, target, snippet(text)),
+		}, false); err != nil {
+			return err
+		}
+	}
+
+	for _, username := range mentionedUsernames(text) {
+		user, err := s.userRepo.FindByUsername(username)
+		if err != nil {
+			return fmt.Errorf("kullanıcı arama sırasında hata: %w", err)
+		}
+		// Yorumu yazan ve zaten yorum bildirimi alan içerik sahibi atlanır
+		if user == nil || user.ID == actorID || user.ID == content.ownerID {
+			continue
+		}
+		// Özel içeriklerin başlığı erişimi olmayan kullanıcılara gösterilmez
+		if !content.isPublic {
+			continue
+		}
+
+		if err := s.notify(&domain.Notification{
+			UserID:      user.ID,
+			ActorID:     actorID,
+			Type:        domain.NotificationMention,
+			ContentID:   contentID,
+			ContentType: contentType,
+			Message:     fmt.Sprintf("%s \"%s\" üzerindeki bir yorumda sizden bahsetti: %s", actor, content.title, snippet(text)),
+		}, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NotifyInviteUsed, içerik sahibine davet bağlantısının kullanıldığını bildirir.
+// userID, bağlantıyı kullanan kullanıcıdır (giriş yapmamış kullanıcılar için 0).
+func (s *NotificationService) NotifyInviteUsed(invite *domain.Invite, userID uint) error {
+	content, err := s.findContent(invite.ContentID, invite.Type)
+	if err != nil {
+		return err
+	}
+	if content.ownerID == userID {
+		return nil
+	}
+
+	actor, err := s.actorName(userID)
+	if err != nil {
+		return err
+	}
+
+	target := "notunuzun"
+	switch invite.Type {
+	case "pdf":
+		target = "PDF'inizin"
+	case "collection":
+		target = "koleksiyonunuzun"
+	}
+
+	// Bağlantının her açılışında yeni bildirim oluşturma
+	return s.notify(&domain.Notification{
+		UserID:      content.ownerID,
+		ActorID:     userID,
+		Type:        domain.NotificationInvite,
+		ContentID:   invite.ContentID,
+		ContentType: invite.Type,
+		Message:     fmt.Sprintf("%s \"%s\" %s davet bağlantısını kullandı", actor, content.title, target),
+	}, true)
+}
+
+// GetNotifications, kullanıcının bildirimlerini getirir
+func (s *NotificationService) GetNotifications(userID uint, unreadOnly bool, limit, offset int) ([]*domain.Notification, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.notificationRepo.FindByUserID(userID, unreadOnly, limit, offset)
+}
+
+// GetUnreadCount, kullanıcının okunmamış bildirim sayısını getirir
+func (s *NotificationService) GetUnreadCount(userID uint) (int64, error) {
+	return s.notificationRepo.CountUnread(userID)
+}
+
+// MarkAsRead, bir bildirimi okundu olarak işaretler
+func (s *NotificationService) MarkAsRead(id, userID uint) error {
+	notification, err := s.notificationRepo.FindByID(id)
+	if err != nil {
+		return fmt.Errorf("bildirim arama sırasında hata: %w", err)
+	}
+	if notification == nil {
+		return ErrNotificationNotFound
+	}
+
+	// Kullanıcı yetkisi kontrol et
+	if notification.UserID != userID {
+		return ErrNotAuthorized
+	}
+
+	if notification.IsRead {
+		return nil
+	}
+	return s.notificationRepo.MarkRead(id)
+}
+
+// MarkAllAsRead, kullanıcının tüm bildirimlerini okundu olarak işaretler
+func (s *NotificationService) MarkAllAsRead(userID uint) error {
+	return s.notificationRepo.MarkAllRead(userID)
+}
+
+// GetPreferences, kullanıcının bildirim tercihlerini getirir; kayıt yoksa varsayılanları döndürür
+func (s *NotificationService) GetPreferences(userID uint) (*domain.NotificationPreferences, error) {
+	preferences, err := s.preferenceRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("bildirim tercihleri arama sırasında hata: %w", err)
+	}
+	if preferences == nil {
+		return domain.DefaultNotificationPreferences(userID), nil
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences, kullanıcının bildirim tercihlerini günceller
+func (s *NotificationService) UpdatePreferences(preferences *domain.NotificationPreferences) error {
+	if preferences.UserID == 0 {
+		return ErrInvalidParameters
+	}
+	return s.preferenceRepo.Save(preferences)
+}
+
+// notify, alıcının tercihlerine uygunsa bildirimi kaydeder. deduplicate true ise
+// aynı kişiden aynı içerik için okunmamış bir bildirim varsa yenisi oluşturulmaz.
+func (s *NotificationService) notify(notification *domain.Notification, deduplicate bool) error {
+	preferences, err := s.GetPreferences(notification.UserID)
+	if err != nil {
+		return err
+	}
+	if !preferences.Allows(notification.Type) {
+		return nil
+	}
+
+	if deduplicate {
+		exists, err := s.notificationRepo.ExistsUnread(notification)
+		if err != nil {
+			return fmt.Errorf("bildirim arama sırasında hata: %w", err)
+		}
+		if exists {
+			return nil
+		}
+	}
+
+	if err := s.notificationRepo.Create(notification); err != nil {
+		return err
+	}
+
+	// Alıcı bağlıysa bildirimi anında ilet
+	s.eventHub.Publish(domain.EventNotification, domain.UserTopic(notification.UserID), notification)
+	return nil
+}
+
+// findContent, bildirimi yapılan içeriğin sahibini ve başlığını getirir
+func (s *NotificationService) findContent(contentID uint, contentType string) (*notifiedContent, error) {
+	switch contentType {
+	case "note":
+		note, err := s.noteRepo.FindByID(contentID)
+		if err != nil {
+			return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return nil, ErrContentNotFound
+		}
+		return &notifiedContent{ownerID: note.UserID, title: note.Title, isPublic: note.IsPublic}, nil
+	case "pdf":
+		pdf, err := s.pdfRepo.FindByID(contentID)
+		if err != nil {
+			return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+		}
+		if pdf == nil {
+			return nil, ErrContentNotFound
+		}
+		return &notifiedContent{ownerID: pdf.UserID, title: pdf.Title, isPublic: pdf.IsPublic}, nil
+	case "collection":
+		collection, err := s.collectionRepo.FindByID(contentID)
+		if err != nil {
+			return nil, fmt.Errorf("koleksiyon arama sırasında hata: %w", err)
+		}
+		if collection == nil {
+			return nil, ErrContentNotFound
+		}
+		return &notifiedContent{ownerID: collection.UserID, title: collection.Name, isPublic: collection.IsPublic}, nil
+	default:
+		return nil, ErrInvalidType
+	}
+}
+
+// actorName, bildirim mesajlarında gösterilecek kullanıcı adını döndürür
+func (s *NotificationService) actorName(userID uint) (string, error) {
+	if userID == 0 {
+		return "Bir misafir", nil
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return "", fmt.Errorf("kullanıcı arama sırasında hata: %w", err)
+	}
+	if user == nil {
+		return "Silinmiş Kullanıcı", nil
+	}
+	return user.Username, nil
+}
+
+// mentionedUsernames, metinde @ ile bahsedilen kullanıcı adlarını tekrarsız döndürür
+func mentionedUsernames(text string) []string {
+	var usernames []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		// Cümle sonundaki noktalama kullanıcı adına dahil değildir ("@ahmet." gibi)
+		username := strings.TrimRight(match[1], ".-")
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+// snippet, metni bildirim mesajına sığacak şekilde kısaltır
+func snippet(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= notificationSnippetLength {
+		return text
+	}
+	return string(runes[:notificationSnippetLength]) + "…"
+}
+
+// Ensure NotificationService implements domain.NotificationService
+var _ domain.NotificationService = (*NotificationService)(nil)
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"unicode/utf16"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// Metin düzenlemeleri (operational transformation) ot.js ile aynı anlamı taşır.
+// Uzunluklar ve konumlar, tarayıcılardaki JavaScript dizgileriyle uyumlu olması için
+// UTF-16 kod birimi cinsindendir.
+
+var (
+	ErrInvalidOperation = errors.New("geçersiz düzenleme")
+)
+
+// utf16Len, bir metnin UTF-16 kod birimi cinsinden uzunluğunu döndürür
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// encodeText, metni UTF-16 kod birimlerine dönüştürür
+func encodeText(s string) []uint16 {
+	return utf16.Encode([]rune(s))
+}
+
+// decodeText, UTF-16 kod birimlerini metne dönüştürür
+func decodeText(units []uint16) string {
+	return string(utf16.Decode(units))
+}
+
+// opRetain, düzenlemenin sonuna n karakter atlama ekler
+func opRetain(op domain.TextOperation, n int) domain.TextOperation {
+	if n <= 0 {
+		return op
+	}
+	if last := len(op) - 1; last >= 0 && op[last].Retain > 0 {
+		op[last].Retain += n
+		return op
+	}
+	return append(op, domain.OperationComponent{Retain: n})
+}
+
+// opInsert, düzenlemenin sonuna metin ekleme ekler. Ardışık silme ve eklemelerde
+// ekleme her zaman silmeden önce tutulur; böylece eşdeğer düzenlemeler aynı biçimde olur.
+func opInsert(op domain.TextOperation, s string) domain.TextOperation {
+	if s == "" {
+		return op
+	}
+	last := len(op) - 1
+	switch {
+	case last >= 0 && op[last].Insert != "":
+		op[last].Insert += s
+		return op
+	case last >= 0 && op[last].Delete > 0:
+		if last >= 1 && op[last-1].Insert != "" {
+			op[last-1].Insert += s
+			return op
+		}
+		deleted := op[last]
+		op[last] = domain.OperationComponent{Insert: s}
+		return append(op, deleted)
+	}
+	return append(op, domain.OperationComponent{Insert: s})
+}
+
+// opDelete, düzenlemenin sonuna n karakter silme ekler
+func opDelete(op domain.TextOperation, n int) domain.TextOperation {
+	if n <= 0 {
+		return op
+	}
+	if last := len(op) - 1; last >= 0 && op[last].Delete > 0 {
+		op[last].Delete += n
+		return op
+	}
+	return append(op, domain.OperationComponent{Delete: n})
+}
+
+// operationBaseLength, düzenlemenin uygulanabileceği belgenin uzunluğunu döndürür. Uzunluk max'ı
+// aşarsa veya bileşenlerden biri negatifse toplama taşmadan durur ve false döner.
+func operationBaseLength(op domain.TextOperation, max int) (int, bool) {
+	n := 0
+	for _, c := range op {
+		if c.Retain < 0 || c.Delete < 0 || c.Retain > max-n {
+			return 0, false
+		}
+		n += c.Retain
+		if c.Delete > max-n {
+			return 0, false
+		}
+		n += c.Delete
+	}
+	return n, true
+}
+
+// isNoop, düzenlemenin belgeyi değiştirip değiştirmediğini kontrol eder
+func isNoop(op domain.TextOperation) bool {
+	for _, c := range op {
+		if c.Insert != "" || c.Delete > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// applyOperation, düzenlemeyi belgeye uygular ve yeni belgeyi döndürür
+func applyOperation(doc []uint16, op domain.TextOperation) ([]uint16, error) {
+	if n, ok := operationBaseLength(op, len(doc)); !ok || n != len(doc) {
+		return nil, ErrInvalidOperation
+	}
+
+	result := make([]uint16, 0, len(doc))
+	pos := 0
+	for _, c := range op {
+		switch {
+		case c.Retain > 0:
+			if c.Retain > len(doc)-pos {
+				return nil, ErrInvalidOperation
+			}
+			result = append(result, doc[pos:pos+c.Retain]...)
+			pos += c.Retain
+		case c.Delete > 0:
+			if c.Delete > len(doc)-pos {
+				return nil, ErrInvalidOperation
+			}
+			pos += c.Delete
+		case c.Insert != "":
+			result = append(result, encodeText(c.Insert)...)
+		default:
+			return nil, ErrInvalidOperation
+		}
+	}
+	return result, nil
+}
+
+// transformOperations, aynı belgeye eşzamanlı uygulanan a ve b düzenlemelerini
+// birbirine göre dönüştürür: apply(apply(doc, a), b') == apply(apply(doc, b), a').
+// Aynı konuma yapılan eklemelerde a'nın eklemesi önce gelir.
+func transformOperations(a, b domain.TextOperation) (domain.TextOperation, domain.TextOperation, error) {
+	lengthB, ok := operationBaseLength(b, math.MaxInt)
+	if !ok {
+		return nil, nil, ErrInvalidOperation
+	}
+	if lengthA, ok := operationBaseLength(a, lengthB); !ok || lengthA != lengthB {
+		return nil, nil, ErrInvalidOperation
+	}
+
+	var aPrime, bPrime domain.TextOperation
+	i, j := 0, 0
+	var opA, opB *domain.OperationComponent
+	next := func(op domain.TextOperation, index *int) *domain.OperationComponent {
+		if *index >= len(op) {
+			return nil
+		}
+		c := op[*index]
+		*index++
+		return &c
+	}
+	opA, opB = next(a, &i), next(b, &j)
+
+	for opA != nil || opB != nil {
+		// Eklemeler diğer tarafta atlama olarak görünür
+		if opA != nil && opA.Insert != "" {
+			aPrime = opInsert(aPrime, opA.Insert)
+			bPrime = opRetain(bPrime, utf16Len(opA.Insert))
+			opA = next(a, &i)
+			continue
+		}
+		if opB != nil && opB.Insert != "" {
+			aPrime = opRetain(aPrime, utf16Len(opB.Insert))
+			bPrime = opInsert(bPrime, opB.Insert)
+			opB = next(b, &j)
+			continue
+		}
+		if opA == nil || opB == nil {
+			return nil, nil, ErrInvalidOperation
+		}
+
+		// Her iki taraftaki atlama/silme bileşenlerinden kısa olanı kadar ilerle
+		lenA, lenB := opA.Retain+opA.Delete, opB.Retain+opB.Delete
+		n := lenA
+		if lenB < n {
+			n = lenB
+		}
+
+		switch {
+		case opA.Retain > 0 && opB.Retain > 0:
+			aPrime = opRetain(aPrime, n)
+			bPrime = opRetain(bPrime, n)
+		case opA.Delete > 0 && opB.Retain > 0:
+			aPrime = opDelete(aPrime, n)
+		case opA.Retain > 0 && opB.Delete > 0:
+			bPrime = opDelete(bPrime, n)
+		}
+		// İki taraf da aynı bölümü sildiyse dönüştürülmüş düzenlemelere bir şey eklenmez
+
+		if lenA == n {
+			opA = next(a, &i)
+		} else if opA.Retain > 0 {
+			opA.Retain -= n
+		} else {
+			opA.Delete -= n
+		}
+		if lenB == n {
+			opB = next(b, &j)
+		} else if opB.Retain > 0 {
+			opB.Retain -= n
+		} else {
+			opB.Delete -= n
+		}
+	}
+
+	return aPrime, bPrime, nil
+}
+
+// transformIndex, bir konumu düzenleme uygulandıktan sonraki karşılığına taşır
+func transformIndex(index int, op domain.TextOperation) int {
+	newIndex := index
+	for _, c := range op {
+		switch {
+		case c.Retain > 0:
+			index -= c.Retain
+		case c.Insert != "":
+			newIndex += utf16Len(c.Insert)
+		default:
+			if index < c.Delete {
+				newIndex -= index
+			} else {
+				newIndex -= c.Delete
+			}
+			index -= c.Delete
+		}
+		if index < 0 {
+			break
+		}
+	}
+	return newIndex
+}
+
+// replaceOperation, old belgesini new belgesine dönüştüren düzenlemeyi üretir.
+// Ortak önek ve sonek korunur, aradaki bölüm silinip yeniden eklenir.
+func replaceOperation(old, new []uint16) domain.TextOperation {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	// Vekil çiftlerin (surrogate pair) ortasından bölme
+	if prefix > 0 && utf16.IsSurrogate(rune(old[prefix-1])) && old[prefix-1] < 0xdc00 {
+		prefix--
+	}
+
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+	if suffix > 0 && utf16.IsSurrogate(rune(old[len(old)-suffix])) && old[len(old)-suffix] >= 0xdc00 {
+		suffix--
+	}
+
+	var op domain.TextOperation
+	op = opRetain(op, prefix)
+	op = opInsert(op, decodeText(new[prefix:len(new)-suffix]))
+	op = opDelete(op, len(old)-prefix-suffix)
+	op = opRetain(op, suffix)
+	return op
+}
@@ -1,23 +1,57 @@
 package usecase
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
 
 	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
 )
 
 var (
 	ErrPDFNotFound = errors.New("PDF bulunamadı")
 	ErrFileStorage = errors.New("dosya depolama hatası")
+	ErrTextExtract = errors.New("PDF metni çıkarılamadı")
+	ErrPDFTooLarge = errors.New("PDF dosyası izin verilen boyutu aşıyor")
+
+	ErrPDFActiveContent = errors.New("PDF dosyası JavaScript, dış uygulama başlatma veya gömülü dosya içeriyor")
 )
 
+// maxPublicDuplicates, aynı içerikli herkese açık PDF'ler listelenirken döndürülen en fazla kayıt sayısı
+const maxPublicDuplicates = 10
+
 // PDFService, PDF ile ilgili iş mantığını içerir
 type PDFService struct {
 	pdfRepo        domain.PDFRepository
 	pdfCommentRepo domain.PDFCommentRepository
 	pdfAnnotRepo   domain.PDFAnnotationRepository
+	pdfPageRepo    domain.PDFPageRepository
+	userRepo       domain.UserRepository
 	pdfStorage     domain.PDFStorage
+	maxFileSize    int64         // Bayt cinsinden; 0 veya negatifse sınır yoktur
+	contentURLTTL  time.Duration // 0'dan büyükse ve depo destekliyorsa içerik süreli bağlantıyla sunulur
+	textExtractor  domain.PDFTextExtractor
+	inspector      domain.PDFInspector
+	// rejectActiveContent true ise etkin içerik (JavaScript, Launch, gömülü dosya) bulunan yüklemeler
+	// reddedilir; false ise kabul edilir ve PDF'in ActiveContent alanında işaretlenir
+	rejectActiveContent bool
+	previews            PDFPreviewOptions
+
+	// blobMu, aynı dosyayı paylaşan PDF'lerin referans sayımını korur: kaydedilen dosyayı PDF'e bağlayan
+	// işlemler okuma kilidi, referansı kalmayan dosyayı silen işlemler yazma kilidi alır. Böylece
+	// yeni yüklenen bir kopyanın dosyası, son referansı silinen eski PDF ile birlikte silinmez.
+	blobMu sync.RWMutex
+
+	access              *ContentAccess
+	jobService          *JobService
+	notificationService *NotificationService
+	eventHub            *EventHub
 }
 
 // NewPDFService, yeni bir PDFService örneği oluşturur
@@ -25,39 +59,209 @@ func NewPDFService(
 	pdfRepo domain.PDFRepository,
 	pdfCommentRepo domain.PDFCommentRepository,
 	pdfAnnotRepo domain.PDFAnnotationRepository,
+	pdfPageRepo domain.PDFPageRepository,
+	userRepo domain.UserRepository,
 	pdfStorage domain.PDFStorage,
+	maxFileSize int64,
+	contentURLTTL time.Duration,
+	textExtractor domain.PDFTextExtractor,
+	inspector domain.PDFInspector,
+	rejectActiveContent bool,
+	previews PDFPreviewOptions,
+	access *ContentAccess,
+	jobService *JobService,
+	notificationService *NotificationService,
+	eventHub *EventHub,
 ) *PDFService {
 	return &PDFService{
-		pdfRepo:        pdfRepo,
-		pdfCommentRepo: pdfCommentRepo,
-		pdfAnnotRepo:   pdfAnnotRepo,
-		pdfStorage:     pdfStorage,
+		pdfRepo:             pdfRepo,
+		pdfCommentRepo:      pdfCommentRepo,
+		pdfAnnotRepo:        pdfAnnotRepo,
+		pdfPageRepo:         pdfPageRepo,
+		userRepo:            userRepo,
+		pdfStorage:          pdfStorage,
+		maxFileSize:         maxFileSize,
+		contentURLTTL:       contentURLTTL,
+		textExtractor:       textExtractor,
+		inspector:           inspector,
+		rejectActiveContent: rejectActiveContent,
+		previews:            previews,
+		access:              access,
+		jobService:          jobService,
+		notificationService: notificationService,
+		eventHub:            eventHub,
 	}
 }
 
-// UploadPDF, yeni bir PDF yükler
-func (s *PDFService) UploadPDF(pdf *domain.PDF, fileContent []byte) error {
-	if pdf.Title == "" || len(fileContent) == 0 {
+// CanViewPDF, kullanıcının PDF'i görüp göremeyeceğini döndürür: herkese açık PDF'leri herkes, özel
+// PDF'leri sahibi, PDF'in paylaşıldığı grupların üyeleri ve PDF'in doğrudan paylaşıldığı kullanıcılar
+// görebilir. userID 0 ise kullanıcı giriş yapmamıştır.
+func (s *PDFService) CanViewPDF(pdf *domain.PDF, userID uint) (bool, error) {
+	return s.access.CanViewPDF(pdf, userID)
+}
+
+// CanViewPDFWithInvite, CanViewPDF gibidir; PDF için oluşturulmuş geçerli bir davet bağlantısıyla
+// gelenler de PDF'i görebilir. invite nil olabilir.
+func (s *PDFService) CanViewPDFWithInvite(pdf *domain.PDF, userID uint, invite *domain.Invite) (bool, error) {
+	return s.canViewPDF(pdf, userID, invite)
+}
+
+// MaxFileSize, yüklenebilecek en büyük PDF dosyasının bayt cinsinden boyutunu döndürür (0: sınırsız)
+func (s *PDFService) MaxFileSize() int64 {
+	return s.maxFileSize
+}
+
+// UploadPDF, yeni bir PDF yükler. İçerik belleğe alınmadan depoya akış halinde yazılır;
+// boyut sınırını aşan dosyalar için ErrPDFTooLarge döner. Kaydedilen dosya PDF olarak ayrıştırılır:
+// PDF olmayan veya bozuk dosyalar domain.ErrPDFMalformed, şifrelenmiş dosyalar domain.ErrPDFEncrypted
+// ile reddedilir; sürüm, sayfa sayısı ve bulunan etkin içerik PDF'e kaydedilir.
+func (s *PDFService) UploadPDF(pdf *domain.PDF, content io.Reader) error {
+	if pdf.Title == "" || content == nil {
+		return ErrInvalidParameters
+	}
+
+	// Boş ve PDF başlığı taşımayan dosyaları depoya yazmadan reddet
+	buffered := bufio.NewReader(content)
+	header, err := buffered.Peek(len(pdfHeader))
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("dosya okuma hatası: %w", err)
+	}
+	if len(header) == 0 {
 		return ErrInvalidParameters
 	}
+	if !bytes.Equal(header, pdfHeader) {
+		return fmt.Errorf("%w: %%PDF- başlığı bulunamadı", domain.ErrPDFMalformed)
+	}
 
-	// Dosya boyutunu ayarla
-	pdf.FileSize = int64(len(fileContent))
+	// Dosyayı kaydet; boyut sınırı yazma sırasında uygulanır. Aynı içerik daha önce yüklendiyse
+	// depo mevcut dosyayı döndürür ve PDF o dosyayı paylaşır.
+	var reader io.Reader = buffered
+	if s.maxFileSize > 0 {
+		reader = &maxSizeReader{r: buffered, remaining: s.maxFileSize}
+	}
 
-	// Dosya adını oluştur (ID henüz yok, bu yüzden timestamp ve kullanıcı ID'si kullanılabilir)
-	fileName := fmt.Sprintf("%d_%s.pdf", pdf.UserID, pdf.Title)
+	// İçerik geçici bir dosyaya da yazılır; dosya PDF'e bağlanmadan depodan silinirse buradan yeniden kaydedilir
+	spool, err := os.CreateTemp("", "uninote-upload-*.pdf")
+	if err != nil {
+		return fmt.Errorf("geçici dosya oluşturma hatası: %w", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
 
-	// Dosyayı kaydet
-	filePath, err := s.pdfStorage.Save(fileContent, fileName)
+	stored, err := s.pdfStorage.Save(io.TeeReader(reader, spool))
 	if err != nil {
+		if errors.Is(err, ErrPDFTooLarge) {
+			return ErrPDFTooLarge
+		}
 		return fmt.Errorf("dosya kaydetme hatası: %w", err)
 	}
 
-	// Dosya yolunu PDF nesnesine ekle
-	pdf.FilePath = filePath
+	// Kaydedilen dosyanın yapısını doğrula
+	inspection, err := s.inspectFile(stored)
+	if err == nil && s.rejectActiveContent && len(inspection.ActiveContent) > 0 {
+		err = ErrPDFActiveContent
+	}
+	if err != nil {
+		s.releaseBlob(stored.Path)
+		return err
+	}
+
+	// Dosya bilgilerini PDF nesnesine ekle
+	pdf.FilePath = stored.Path
+	pdf.FileSize = stored.Size
+	pdf.ContentHash = stored.SHA256
+	pdf.PDFVersion = inspection.Version
+	pdf.PageCount = inspection.PageCount
+	pdf.ActiveContent = inspection.ActiveContent
 
 	// PDF'i veritabanına kaydet
-	return s.pdfRepo.Create(pdf)
+	if err := s.createWithBlob(pdf, spool); err != nil {
+		return err
+	}
+
+	// Metin çıkarma ve görsel üretimi yüklemeyi bekletmez; arka plan işi olarak kuyruğa alınır. Kuyruğa
+	// alınamazsa yükleme yine başarılıdır, PDF sahibi ExtractText ile metni çıkarabilir.
+	jobTypes := []string{domain.JobExtractPDFText}
+	if s.previewsEnabled() {
+		jobTypes = append(jobTypes, domain.JobRenderPDFPages)
+	}
+	for _, jobType := range jobTypes {
+		if _, err := s.jobService.Enqueue(jobType, domain.PDFJobPayload{PDFID: pdf.ID}); err != nil {
+			logger.Error("PDF %d için %s işi kuyruğa alınamadı: %v", pdf.ID, jobType, err)
+		}
+	}
+	return nil
+}
+
+// createWithBlob, yüklenen PDF'i kaydedilmiş dosyasına bağlayarak veritabanına ekler. Kaydetme ve
+// doğrulama kilitsiz yapıldığından, bu sırada aynı içerikli son PDF silinip dosya depodan kaldırılmış
+// olabilir; dosya kilit altında yeniden denetlenir. Kaldırılmışsa silmeler beklesin diye yazma kilidi
+// alınır ve dosya yüklemenin geçici kopyasından (spool) yeniden kaydedilir. PDF eklenemezse başka PDF'in
+// kullanmadığı dosya silinir.
+func (s *PDFService) createWithBlob(pdf *domain.PDF, spool io.ReadSeeker) error {
+	s.blobMu.RLock()
+	if s.blobExists(pdf.FilePath) {
+		err := s.pdfRepo.Create(pdf)
+		s.blobMu.RUnlock()
+		if err != nil {
+			s.releaseBlob(pdf.FilePath)
+		}
+		return err
+	}
+	s.blobMu.RUnlock()
+
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("dosya kaydetme hatası: %w", err)
+	}
+	stored, err := s.pdfStorage.Save(spool)
+	if err != nil {
+		return fmt.Errorf("dosya kaydetme hatası: %w", err)
+	}
+	pdf.FilePath = stored.Path
+	if err := s.pdfRepo.Create(pdf); err != nil {
+		if err := s.deleteBlobIfUnused(stored.Path); err != nil {
+			logger.Error("Kullanılmayan dosya silinemedi (%s): %v", stored.Path, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// blobExists, dosyanın depoda bulunup bulunmadığını kontrol eder
+func (s *PDFService) blobExists(filePath string) bool {
+	file, err := s.pdfStorage.Open(filePath)
+	if err != nil {
+		return false
+	}
+	file.Close()
+	return true
+}
+
+// pdfHeader, her PDF dosyasının başladığı imza
+var pdfHeader = []byte("%PDF-")
+
+// inspectFile, depodaki dosyayı açar ve PDF yapısını denetler. Depo rastgele erişimli okuma
+// (io.ReaderAt) sunmuyorsa dosya belleğe okunur.
+func (s *PDFService) inspectFile(stored *domain.StoredFile) (*domain.PDFInspection, error) {
+	file, err := s.pdfStorage.Open(stored.Path)
+	if err != nil {
+		return nil, fmt.Errorf("dosya okuma hatası: %w", err)
+	}
+	defer file.Close()
+
+	readerAt, ok := file.(io.ReaderAt)
+	if !ok {
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return nil, fmt.Errorf("dosya okuma hatası: %w", err)
+		}
+		readerAt = bytes.NewReader(content)
+	}
+	return s.inspector.Inspect(readerAt, stored.Size)
 }
 
 // UpdatePDF, bir PDF'i günceller
@@ -71,17 +275,47 @@ func (s *PDFService) UpdatePDF(pdf *domain.PDF) error {
 		return ErrPDFNotFound
 	}
 
-	// Kullanıcı yetkisi kontrol et
-	if existingPDF.UserID != pdf.UserID {
+	// Kullanıcı yetkisi kontrol et; PDF sahibi ve editör olarak paylaşılan kullanıcılar düzenleyebilir
+	canEdit, err := s.access.CanEditPDF(existingPDF, pdf.UserID)
+	if err != nil {
+		return err
+	}
+	if !canEdit {
 		return ErrNotAuthorized
 	}
 
+	// PDF sahibi değişmez; görünürlüğü yalnızca sahibi değiştirebilir
+	if pdf.UserID != existingPDF.UserID {
+		pdf.UserID = existingPDF.UserID
+		pdf.IsPublic = existingPDF.IsPublic
+	}
+
+	// İstemci sürüm belirtmediyse son yazan kazanır; belirttiyse güncel sürümle eşleşmeli
+	if pdf.Version == 0 {
+		pdf.Version = existingPDF.Version
+	}
+	if pdf.Version != existingPDF.Version {
+		return &PDFConflictError{Current: existingPDF}
+	}
+
 	// Dosya yolunu koru
 	pdf.FilePath = existingPDF.FilePath
 	pdf.FileSize = existingPDF.FileSize
+	pdf.ContentHash = existingPDF.ContentHash
+	pdf.PageCount = existingPDF.PageCount
+	pdf.PDFVersion = existingPDF.PDFVersion
+	pdf.ActiveContent = existingPDF.ActiveContent
+	pdf.HasThumbnail = existingPDF.HasThumbnail
+	pdf.PreviewPages = existingPDF.PreviewPages
 
 	// PDF'i güncelle
-	return s.pdfRepo.Update(pdf)
+	if err := s.pdfRepo.Update(pdf); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return s.pdfConflict(pdf.ID)
+		}
+		return err
+	}
+	return nil
 }
 
 // DeletePDF, bir PDF'i siler
@@ -100,13 +334,43 @@ func (s *PDFService) DeletePDF(id uint, userID uint) error {
 		return ErrNotAuthorized
 	}
 
-	// Dosyayı sil
-	if err := s.pdfStorage.Delete(pdf.FilePath); err != nil {
-		return fmt.Errorf("dosya silme hatası: %w", err)
+	// PDF'i veritabanından sil; dosya yalnızca ona başvuran son PDF silindiğinde silinir
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	if err := s.pdfRepo.Delete(id); err != nil {
+		return err
 	}
+	if err := s.deleteBlobIfUnused(pdf.FilePath); err != nil {
+		// PDF silindi; yetim kalan dosya kullanıcıya hata olarak yansıtılmaz
+		logger.Error("PDF %d dosyası silinemedi: %v", id, err)
+	}
+	s.deleteImagesIfUnused(pdf)
+	return nil
+}
+
+// releaseBlob, dosyaya başvuran PDF kalmadıysa dosyayı siler. Kaydedilen dosya bir PDF'e
+// bağlanamadığında çağrılır; hatalar yalnızca kaydedilir.
+func (s *PDFService) releaseBlob(filePath string) {
+	s.blobMu.Lock()
+	defer s.blobMu.Unlock()
+	if err := s.deleteBlobIfUnused(filePath); err != nil {
+		logger.Error("Kullanılmayan dosya silinemedi (%s): %v", filePath, err)
+	}
+}
 
-	// PDF'i veritabanından sil
-	return s.pdfRepo.Delete(id)
+// deleteBlobIfUnused, dosyaya başvuran PDF kalmadıysa dosyayı depodan siler. Çağıran blobMu yazma kilidini tutmalıdır.
+func (s *PDFService) deleteBlobIfUnused(filePath string) error {
+	refs, err := s.pdfRepo.CountByFilePath(filePath)
+	if err != nil {
+		return fmt.Errorf("dosya referansları sayılırken hata: %w", err)
+	}
+	if refs > 0 {
+		return nil
+	}
+	if err := s.pdfStorage.Delete(filePath); err != nil {
+		return fmt.Errorf("dosya silme hatası: %w", err)
+	}
+	return nil
 }
 
 // GetPDF, bir PDF'i getirir
@@ -125,8 +389,13 @@ func (s *PDFService) GetPDF(id uint) (*domain.PDF, error) {
 	return pdf, nil
 }
 
-// GetPDFContent, bir PDF'in içeriğini getirir
-func (s *PDFService) GetPDFContent(id uint) ([]byte, error) {
+// GetPDFContent, kullanıcının görebildiği bir PDF'i ve dosyasına erişim bilgisini getirir. Depo süreli
+// bağlantı üretebiliyorsa ve bağlantı süresi tanımlıysa istemcinin dosyayı doğrudan depodan indireceği
+// bağlantı döner; aksi halde dosya okunmak üzere açılır ve kapatmak çağıranın sorumluluğundadır. Erişim
+// kontrolü dosya açılmadan ve bağlantı üretilmeden önce yapılır; kullanıcı PDF'i göremiyorsa
+// ErrNotAuthorized döner. Görüntüleyiciler aynı dosya için çok sayıda aralık (Range) isteği gönderdiğinden
+// görüntülenme sayısı artırılmaz. userID 0 ise kullanıcı giriş yapmamıştır.
+func (s *PDFService) GetPDFContent(id, userID uint) (*domain.PDFContent, error) {
 	// PDF'i bul
 	pdf, err := s.pdfRepo.FindByID(id)
 	if err != nil {
@@ -136,15 +405,72 @@ func (s *PDFService) GetPDFContent(id uint) ([]byte, error) {
 		return nil, ErrPDFNotFound
 	}
 
-	// Dosyayı oku
-	content, err := s.pdfStorage.Get(pdf.FilePath)
+	// Erişim kontrolü
+	canView, err := s.access.CanViewPDF(pdf, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrNotAuthorized
+	}
+
+	// Etkin içerik bulunan PDF'ler, sunucunun indirme olarak gönderebilmesi için bağlantıyla sunulmaz
+	if signer, ok := s.pdfStorage.(domain.PDFURLSigner); ok && s.contentURLTTL > 0 && len(pdf.ActiveContent) == 0 {
+		url, err := signer.PresignedURL(pdf.FilePath, pdf.Title+".pdf", s.contentURLTTL)
+		if err != nil {
+			return nil, fmt.Errorf("dosya bağlantısı oluşturma hatası: %w", err)
+		}
+		return &domain.PDFContent{PDF: pdf, URL: url}, nil
+	}
+
+	// Dosyayı aç
+	file, err := s.pdfStorage.Open(pdf.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("dosya okuma hatası: %w", err)
+	}
+
+	return &domain.PDFContent{PDF: pdf, File: file}, nil
+}
+
+// readFile, dosyanın tamamını okur. Yalnızca ayrıştırıcı gibi içeriğin tamamına ihtiyaç duyan
+// işlemler için kullanılır; kullanıcıya sunulan dosyalar GetPDFContent ile akış halinde okunur.
+func (s *PDFService) readFile(filePath string) ([]byte, error) {
+	file, err := s.pdfStorage.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("dosya okuma hatası: %w", err)
 	}
+	defer file.Close()
 
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("dosya okuma hatası: %w", err)
+	}
 	return content, nil
 }
 
+// maxSizeReader, altındaki okuyucudan izin verilen bayt sayısından fazlası okunduğunda ErrPDFTooLarge döndürür
+type maxSizeReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read, io.Reader arayüzünü uygular
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	if m.remaining < 0 {
+		return 0, ErrPDFTooLarge
+	}
+	// Sınırı aştığını anlamak için en fazla bir bayt fazla oku
+	if int64(len(p)) > m.remaining+1 {
+		p = p[:m.remaining+1]
+	}
+	n, err := m.r.Read(p)
+	m.remaining -= int64(n)
+	if m.remaining < 0 {
+		return n, ErrPDFTooLarge
+	}
+	return n, err
+}
+
 // GetUserPDFs, bir kullanıcının PDF'lerini getirir
 func (s *PDFService) GetUserPDFs(userID uint, limit, offset int) ([]*domain.PDF, error) {
 	if limit <= 0 {
@@ -169,9 +495,9 @@ func (s *PDFService) GetPublicPDFs(limit, offset int) ([]*domain.PDF, error) {
 	return s.pdfRepo.FindPublic(limit, offset)
 }
 
-// SearchPDFs, PDF'leri arar
-func (s *PDFService) SearchPDFs(query string, limit, offset int) ([]*domain.PDF, error) {
-	if query == "" {
+// GetPDFsByTag, etikete sahip herkese açık PDF'leri getirir
+func (s *PDFService) GetPDFsByTag(tag string, limit, offset int) ([]*domain.PDF, error) {
+	if tag == "" {
 		return nil, ErrInvalidParameters
 	}
 	if limit <= 0 {
@@ -181,11 +507,64 @@ func (s *PDFService) SearchPDFs(query string, limit, offset int) ([]*domain.PDF,
 		offset = 0
 	}
 
-	return s.pdfRepo.Search(query, limit, offset)
+	pdfs, err := s.pdfRepo.FindByTag(tag, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	// Özel PDF'ler etiket listelerinde gösterilmez
+	publicPDFs := make([]*domain.PDF, 0, len(pdfs))
+	for _, pdf := range pdfs {
+		if pdf.IsPublic {
+			publicPDFs = append(publicPDFs, pdf)
+		}
+	}
+	return publicPDFs, nil
 }
 
-// AddComment, bir PDF'e yorum ekler
-func (s *PDFService) AddComment(comment *domain.PDFComment) error {
+// FindPublicDuplicates, içerik özeti verilen dosyayla aynı olan herkese açık PDF'leri getirir.
+// Yükleyen kullanıcıya aynı belgenin zaten paylaşıldığını göstermek için kullanılır; excludeID
+// sıfırdan farklıysa o PDF (ör. yeni yüklenen) listeye alınmaz.
+func (s *PDFService) FindPublicDuplicates(contentHash string, excludeID uint) ([]*domain.PDF, error) {
+	if !isSHA256Hex(contentHash) {
+		return nil, ErrInvalidParameters
+	}
+
+	pdfs, err := s.pdfRepo.FindPublicByContentHash(contentHash, maxPublicDuplicates+1)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]*domain.PDF, 0, len(pdfs))
+	for _, pdf := range pdfs {
+		if pdf.ID != excludeID && len(duplicates) < maxPublicDuplicates {
+			duplicates = append(duplicates, pdf)
+		}
+	}
+	return duplicates, nil
+}
+
+// isSHA256Hex, değerin küçük harfli onaltılık bir SHA-256 özeti olup olmadığını kontrol eder
+func isSHA256Hex(value string) bool {
+	if len(value) != 64 {
+		return false
+	}
+	for _, c := range value {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// AddComment, bir PDF'e yorum ekler. Yorum bir metin seçimine bağlıysa alıntı doğrulanır. PDF için yorum
+// veya işaretleme yetkili bir davet bağlantısıyla gelen giriş yapmış kullanıcılar özel PDF'lere de yorum
+// yapabilir; invite nil olabilir.
+func (s *PDFService) AddComment(comment *domain.PDFComment, invite *domain.Invite) error {
+	if err := validateQuote(comment.Quote); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidParameters, err)
+	}
+
 	// PDF'i bul
 	pdf, err := s.pdfRepo.FindByID(comment.PDFID)
 	if err != nil {
@@ -195,8 +574,40 @@ func (s *PDFService) AddComment(comment *domain.PDFComment) error {
 		return ErrPDFNotFound
 	}
 
+	// Yorum yetkisini kontrol et
+	if !invitePermits(invite, "pdf", pdf.ID, domain.InvitePermissionComment) {
+		canComment, err := s.access.CanCommentPDF(pdf, comment.UserID)
+		if err != nil {
+			return err
+		}
+		if !canComment {
+			return ErrNotAuthorized
+		}
+	}
+
 	// Yorumu ekle
-	return s.pdfCommentRepo.Create(comment)
+	if err := s.pdfCommentRepo.Create(comment); err != nil {
+		return err
+	}
+
+	// PDF'i takip eden istemcilere yeni yorumu ve güncel yorum sayısını ilet
+	commentCount := pdf.CommentCount + 1
+	if updated, err := s.pdfRepo.FindByID(pdf.ID); err == nil && updated != nil {
+		commentCount = updated.CommentCount
+	}
+	s.eventHub.Publish(domain.EventComment, domain.ContentTopic("pdf", pdf.ID), &domain.CommentEvent{
+		ContentID:    pdf.ID,
+		ContentType:  "pdf",
+		CommentID:    comment.ID,
+		UserID:       comment.UserID,
+		CommentCount: commentCount,
+	})
+
+	// PDF sahibini ve yorumda bahsedilen kullanıcıları bilgilendir; bildirim hatası yorumu geçersiz kılmaz
+	if err := s.notificationService.NotifyComment(comment.UserID, pdf.ID, "pdf", comment.Content); err != nil {
+		logger.Error("Yorum bildirimi oluşturulurken hata oluştu: %v", err)
+	}
+	return nil
 }
 
 // GetComments, bir PDF'in yorumlarını getirir
@@ -220,8 +631,11 @@ func (s *PDFService) GetComments(pdfID uint, limit, offset int) ([]*domain.PDFCo
 	return s.pdfCommentRepo.FindByPDFID(pdfID, limit, offset)
 }
 
-// AddAnnotation, bir PDF'e işaretleme ekler
-func (s *PDFService) AddAnnotation(annotation *domain.PDFAnnotation) error {
+// AddAnnotation, bir PDF'e işaretleme ekler. PDF'i görebilen kullanıcılar (sahibi, herkese açıksa
+// herkes, paylaşıldığı grupların üyeleri veya kendisiyle paylaşılanlar) ve PDF için işaretleme yetkili
+// bir davet bağlantısıyla gelenler işaretleme ekleyebilir; invite nil olabilir. Konum sayfa boyutuna göre normalleştirilmiş
+// koordinatlarla verilmelidir; işaretleme geçersizse ErrInvalidAnnotation döner.
+func (s *PDFService) AddAnnotation(annotation *domain.PDFAnnotation, invite *domain.Invite) error {
 	// PDF'i bul
 	pdf, err := s.pdfRepo.FindByID(annotation.PDFID)
 	if err != nil {
@@ -230,6 +644,17 @@ func (s *PDFService) AddAnnotation(annotation *domain.PDFAnnotation) error {
 	if pdf == nil {
 		return ErrPDFNotFound
 	}
+	canAnnotate, err := s.canAnnotatePDF(pdf, annotation.UserID, invite)
+	if err != nil {
+		return err
+	}
+	if !canAnnotate {
+		return ErrNotAuthorized
+	}
+
+	if err := prepareAnnotation(annotation, pdf); err != nil {
+		return err
+	}
 
 	// İşaretlemeyi ekle
 	return s.pdfAnnotRepo.Create(annotation)
@@ -249,6 +674,142 @@ func (s *PDFService) GetAnnotations(pdfID uint, userID uint) ([]*domain.PDFAnnot
 	return s.pdfAnnotRepo.FindByPDFIDAndUserID(pdfID, userID)
 }
 
+// ExtractText, PDF dosyasının metnini yeniden çıkarır ve saklanan sayfa metinlerinin yerine yazar.
+// Metin çıkarma özelliğinden önce yüklenen veya çıkarma sırasında hata alan PDF'ler için kullanılır;
+// yalnızca PDF sahibi çalıştırabilir. Metin içeren sayfaları döndürür.
+func (s *PDFService) ExtractText(pdfID uint, userID uint) ([]*domain.PDFPage, error) {
+	// PDF'i bul
+	pdf, err := s.pdfRepo.FindByID(pdfID)
+	if err != nil {
+		return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil {
+		return nil, ErrPDFNotFound
+	}
+
+	// Kullanıcı yetkisi kontrol et
+	if pdf.UserID != userID {
+		return nil, ErrNotAuthorized
+	}
+
+	// Dosyayı oku
+	content, err := s.readFile(pdf.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storePages(pdf.ID, content)
+}
+
+// GetPages, PDF'in sayfalarından çıkarılan metinleri sayfa numarasına göre sıralı getirir.
+// Metni olmayan (ör. taranmış) sayfalar listede yer almaz. Özel PDF'lerin metnini yalnızca sahibi ve
+// PDF'in paylaşıldığı grupların üyeleri görebilir.
+func (s *PDFService) GetPages(pdfID uint, userID uint, limit, offset int) ([]*domain.PDFPage, error) {
+	// PDF'i bul
+	pdf, err := s.pdfRepo.FindByID(pdfID)
+	if err != nil {
+		return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil {
+		return nil, ErrPDFNotFound
+	}
+	canView, err := s.access.CanViewPDF(pdf, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrNotAuthorized
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.pdfPageRepo.FindByPDFID(pdfID, limit, offset)
+}
+
+// storePages, dosya içeriğinden sayfa metinlerini çıkarır ve PDF'in saklanan sayfa metinlerinin yerine yazar
+func (s *PDFService) storePages(pdfID uint, fileContent []byte) ([]*domain.PDFPage, error) {
+	texts, err := s.textExtractor.ExtractPages(fileContent)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTextExtract, err)
+	}
+
+	// Metni olmayan sayfalar saklanmaz
+	pages := make([]*domain.PDFPage, 0, len(texts))
+	for i, text := range texts {
+		if text != "" {
+			pages = append(pages, &domain.PDFPage{PDFID: pdfID, PageNumber: i + 1, Text: text})
+		}
+	}
+
+	if err := s.pdfPageRepo.Replace(pdfID, pages); err != nil {
+		return nil, fmt.Errorf("sayfa metinleri kaydedilirken hata: %w", err)
+	}
+	return pages, nil
+}
+
+// RunExtractTextJob, JobExtractPDFText işini çalıştırır: PDF dosyasının sayfa metinlerini çıkarır ve saklar.
+// PDF silinmişse yapılacak iş yoktur; ayrıştırılamayan dosyalar yeniden denenmez.
+func (s *PDFService) RunExtractTextJob(job *domain.Job) error {
+	pdf, content, err := s.loadJobPDF(job)
+	if err != nil || pdf == nil {
+		return err
+	}
+
+	if _, err := s.storePages(pdf.ID, content); err != nil {
+		if errors.Is(err, ErrTextExtract) {
+			return fmt.Errorf("%w: %w", ErrJobPermanent, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// RunCountPagesJob, JobCountPDFPages işini çalıştırır: PDF'in sayfa sayısını okur ve kaydeder.
+// PDF silinmişse yapılacak iş yoktur; ayrıştırılamayan dosyalar yeniden denenmez.
+func (s *PDFService) RunCountPagesJob(job *domain.Job) error {
+	pdf, content, err := s.loadJobPDF(job)
+	if err != nil || pdf == nil {
+		return err
+	}
+
+	count, err := s.textExtractor.CountPages(content)
+	if err != nil {
+		return fmt.Errorf("%w: sayfa sayısı okunamadı: %v", ErrJobPermanent, err)
+	}
+
+	if err := s.pdfRepo.UpdatePageCount(pdf.ID, count); err != nil {
+		return fmt.Errorf("sayfa sayısı kaydedilirken hata: %w", err)
+	}
+	return nil
+}
+
+// loadJobPDF, PDF işinin hedeflediği PDF'i ve dosya içeriğini getirir. PDF artık yoksa nil döner.
+func (s *PDFService) loadJobPDF(job *domain.Job) (*domain.PDF, []byte, error) {
+	var payload domain.PDFJobPayload
+	if err := decodeJobPayload(job, &payload); err != nil {
+		return nil, nil, err
+	}
+
+	pdf, err := s.pdfRepo.FindByID(payload.PDFID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil {
+		return nil, nil, nil // PDF silinmiş
+	}
+
+	content, err := s.readFile(pdf.FilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pdf, content, nil
+}
+
 // LikePDF, bir PDF'i beğenir
 func (s *PDFService) LikePDF(pdfID uint, userID uint) error {
 	// PDF'i bul
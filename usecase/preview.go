@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+)
+
+// ErrPreviewNotFound, istenen kapak veya sayfa görseli henüz üretilmediğinde ya da üretilemediğinde döner
+var ErrPreviewNotFound = errors.New("PDF görseli bulunamadı")
+
+// PDFPreviewOptions, PDF kapak ve sayfa önizleme görsellerinin üretim ayarlarını içerir
+type PDFPreviewOptions struct {
+	Renderer     domain.PDFRenderer // nil ise yeni görsel üretilmez; mevcut görseller sunulmaya devam eder
+	Images       domain.PDFImageStorage
+	CoverWidth   int // Kapak görselinin piksel genişliği
+	PreviewWidth int // Sayfa önizlemelerinin piksel genişliği
+	PreviewPages int // Önizlemesi üretilecek ilk sayfa sayısı; 0 ise yalnızca kapak üretilir
+}
+
+// previewsEnabled, yüklenen PDF'ler için görsel üretilip üretilmeyeceğini döndürür
+func (s *PDFService) previewsEnabled() bool {
+	return s.previews.Renderer != nil && s.previews.Images != nil
+}
+
+// imagePrefix, PDF'in görsellerinin saklandığı önek. Görseller içerik özetine göre saklandığından
+// aynı dosyayı paylaşan PDF'ler görselleri de paylaşır; özeti olmayan eski PDF'ler ID'leriyle saklanır.
+func imagePrefix(pdf *domain.PDF) string {
+	if pdf.ContentHash != "" {
+		return pdf.ContentHash
+	}
+	return fmt.Sprintf("pdf-%d", pdf.ID)
+}
+
+// coverKey, PDF'in kapak görselinin anahtarı
+func coverKey(pdf *domain.PDF) string {
+	return imagePrefix(pdf) + "/cover.jpg"
+}
+
+// pageImageKey, PDF'in bir sayfasının önizleme görselinin anahtarı
+func pageImageKey(pdf *domain.PDF, page int) string {
+	return fmt.Sprintf("%s/page-%d.jpg", imagePrefix(pdf), page)
+}
+
+// GetPDFImage, PDF'in kapak görselini (page 0) veya bir sayfasının önizleme görselini getirir.
+// Özel PDF'lerin görsellerini yalnızca sahibi ve PDF'in paylaşıldığı grupların üyeleri görebilir. Görsel
+// henüz üretilmediyse ErrPreviewNotFound döner. Dönen dosyayı kapatmak çağıranın sorumluluğundadır.
+func (s *PDFService) GetPDFImage(pdfID uint, userID uint, page int) (*domain.PDFImage, error) {
+	// PDF'i bul
+	pdf, err := s.pdfRepo.FindByID(pdfID)
+	if err != nil {
+		return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil {
+		return nil, ErrPDFNotFound
+	}
+	canView, err := s.access.CanViewPDF(pdf, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrNotAuthorized
+	}
+	if page < 0 {
+		return nil, ErrInvalidParameters
+	}
+	if s.previews.Images == nil {
+		return nil, ErrPreviewNotFound
+	}
+
+	key := coverKey(pdf)
+	if page > 0 {
+		if page > pdf.PreviewPages {
+			return nil, ErrPreviewNotFound
+		}
+		key = pageImageKey(pdf, page)
+	} else if !pdf.HasThumbnail {
+		return nil, ErrPreviewNotFound
+	}
+
+	file, err := s.previews.Images.Open(key)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrPreviewNotFound
+		}
+		return nil, fmt.Errorf("görsel okuma hatası: %w", err)
+	}
+
+	return &domain.PDFImage{
+		PDF:         pdf,
+		File:        file,
+		ContentType: "image/jpeg",
+		ETag:        strings.ReplaceAll(key, "/", "-"),
+	}, nil
+}
+
+// RunRenderPagesJob, JobRenderPDFPages işini çalıştırır: PDF'in kapak görselini ve ilk sayfalarının
+// önizlemelerini üretir ve saklar. Aynı içerikli bir PDF için görseller zaten üretildiyse yeniden
+// üretilmez. PDF silinmişse yapılacak iş yoktur.
+func (s *PDFService) RunRenderPagesJob(job *domain.Job) error {
+	if !s.previewsEnabled() {
+		return fmt.Errorf("%w: PDF görselleştirme yapılandırılmamış", ErrJobPermanent)
+	}
+
+	pdf, content, err := s.loadJobPDF(job)
+	if err != nil || pdf == nil {
+		return err
+	}
+
+	// Sayfa sayısı biliniyorsa belgede olmayan sayfalar istenmez
+	pages := s.previews.PreviewPages
+	if pdf.PageCount > 0 && pages > pdf.PageCount {
+		pages = pdf.PageCount
+	}
+
+	var cover []byte
+	var previews [][]byte
+	if !s.imagesExist(pdf, pages) {
+		covers, err := s.previews.Renderer.RenderPages(content, 1, 1, s.previews.CoverWidth)
+		if err != nil {
+			return fmt.Errorf("kapak görseli üretilemedi: %w", err)
+		}
+		cover = covers[0]
+
+		if pages > 0 {
+			previews, err = s.previews.Renderer.RenderPages(content, 1, pages, s.previews.PreviewWidth)
+			if err != nil {
+				return fmt.Errorf("sayfa önizlemeleri üretilemedi: %w", err)
+			}
+			// Sayfa sayısı bilinmiyorsa belge istenenden kısa olabilir
+			pages = len(previews)
+		}
+	}
+
+	// Görseller, aynı içerikli son PDF silinirken saklanmasın diye dosya kilidi altında kaydedilir
+	s.blobMu.RLock()
+	defer s.blobMu.RUnlock()
+
+	current, err := s.pdfRepo.FindByID(pdf.ID)
+	if err != nil {
+		return fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if current == nil {
+		return nil // PDF görseller üretilirken silindi
+	}
+
+	if cover != nil {
+		if err := s.previews.Images.Save(coverKey(pdf), cover); err != nil {
+			return err
+		}
+		for i, preview := range previews {
+			if err := s.previews.Images.Save(pageImageKey(pdf, i+1), preview); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.pdfRepo.UpdatePreviews(pdf.ID, true, pages); err != nil {
+		return fmt.Errorf("görsel bilgileri kaydedilirken hata: %w", err)
+	}
+	return nil
+}
+
+// imagesExist, PDF'in kapak görselinin ve istenen son sayfa önizlemesinin depoda bulunduğunu kontrol eder
+func (s *PDFService) imagesExist(pdf *domain.PDF, pages int) bool {
+	keys := []string{coverKey(pdf)}
+	if pages > 0 {
+		keys = append(keys, pageImageKey(pdf, pages))
+	}
+	for _, key := range keys {
+		file, err := s.previews.Images.Open(key)
+		if err != nil {
+			return false
+		}
+		file.Close()
+	}
+	return true
+}
+
+// deleteImagesIfUnused, silinen PDF'in görsellerini, aynı görselleri kullanan başka PDF kalmadıysa
+// siler. Çağıran blobMu yazma kilidini tutmalıdır; hatalar yalnızca kaydedilir.
+func (s *PDFService) deleteImagesIfUnused(pdf *domain.PDF) {
+	if s.previews.Images == nil {
+		return
+	}
+	if pdf.ContentHash != "" {
+		refs, err := s.pdfRepo.CountByContentHash(pdf.ContentHash)
+		if err != nil {
+			logger.Error("PDF %d görsel referansları sayılırken hata: %v", pdf.ID, err)
+			return
+		}
+		if refs > 0 {
+			return
+		}
+	}
+	if err := s.previews.Images.DeleteAll(imagePrefix(pdf)); err != nil {
+		logger.Error("PDF %d görselleri silinemedi: %v", pdf.ID, err)
+	}
+}
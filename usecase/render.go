@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+// renderKey, önbellekteki bir HTML çıktısını tanımlar. Not içeriği her değiştiğinde sürüm arttığından
+// aynı sürümün çıktısı değişmez; eski sürümlerin çıktıları zamanla önbellekten düşer.
+type renderKey struct {
+	noteID  uint
+	version int
+}
+
+// renderEntry, önbellekteki bir HTML çıktısı
+type renderEntry struct {
+	key  renderKey
+	html string
+}
+
+// renderCache, notların HTML çıktılarını en son kullanılma sırasına göre (LRU) sınırlı sayıda saklar
+type renderCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Öndeki öğe en son kullanılandır
+	entries  map[renderKey]*list.Element
+}
+
+// newRenderCache, en fazla capacity çıktı saklayan bir önbellek oluşturur; capacity <= 0 ise önbellek kullanılmaz
+func newRenderCache(capacity int) *renderCache {
+	return &renderCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[renderKey]*list.Element),
+	}
+}
+
+// get, anahtarın çıktısını döndürür
+func (c *renderCache) get(key renderKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*renderEntry).html, true
+}
+
+// put, anahtarın çıktısını saklar; önbellek doluysa en uzun süredir kullanılmayan çıktı atılır
+func (c *renderCache) put(key renderKey, html string) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*renderEntry).html = html
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&renderEntry{key: key, html: html})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*renderEntry).key)
+	}
+}
+
+// RenderNotes, notların Markdown içeriklerini güvenli HTML'e dönüştürerek ContentHTML alanlarına yazar.
+// Çıktılar not sürümüne göre önbelleğe alınır; içeriği değişmeyen notlar yeniden dönüştürülmez.
+func (s *NoteService) RenderNotes(notes ...*domain.Note) error {
+	for _, note := range notes {
+		key := renderKey{noteID: note.ID, version: note.Version}
+		if html, ok := s.renderCache.get(key); ok {
+			note.ContentHTML = html
+			continue
+		}
+
+		html, err := s.renderer.RenderHTML(note.Content)
+		if err != nil {
+			return fmt.Errorf("not %d içeriği dönüştürülürken hata: %w", note.ID, err)
+		}
+		s.renderCache.put(key, html)
+		note.ContentHTML = html
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	ErrRevisionNotFound = errors.New("revizyon bulunamadı")
+)
+
+// recordRevision, notun mevcut halini yeni bir revizyon olarak kaydeder
+func (s *NoteService) recordRevision(note *domain.Note, authorID uint, restoredFrom int) error {
+	revision := &domain.NoteRevision{
+		NoteID:       note.ID,
+		AuthorID:     authorID,
+		Title:        note.Title,
+		Content:      note.Content,
+		Tags:         note.Tags,
+		RestoredFrom: restoredFrom,
+	}
+	if err := s.revisionRepo.Create(revision); err != nil {
+		return fmt.Errorf("revizyon kaydı sırasında hata: %w", err)
+	}
+
+	// Notu takip eden istemcilere yeni revizyonu ilet
+	s.eventHub.Publish(domain.EventRevision, domain.ContentTopic("note", note.ID), &domain.RevisionEvent{
+		NoteID:       revision.NoteID,
+		Number:       revision.Number,
+		AuthorID:     revision.AuthorID,
+		RestoredFrom: revision.RestoredFrom,
+		CreatedAt:    revision.CreatedAt,
+	})
+	return nil
+}
+
+// ensureBaseRevision, revizyon geçmişi olmayan bir not için mevcut hali ilk revizyon olarak kaydeder
+func (s *NoteService) ensureBaseRevision(note *domain.Note) error {
+	latest, err := s.revisionRepo.FindLatest(note.ID)
+	if err != nil {
+		return fmt.Errorf("revizyon arama sırasında hata: %w", err)
+	}
+	if latest != nil {
+		return nil
+	}
+
+	revision := &domain.NoteRevision{
+		NoteID:    note.ID,
+		AuthorID:  note.UserID,
+		Title:     note.Title,
+		Content:   note.Content,
+		Tags:      note.Tags,
+		CreatedAt: note.UpdatedAt,
+	}
+	if err := s.revisionRepo.Create(revision); err != nil {
+		return fmt.Errorf("revizyon kaydı sırasında hata: %w", err)
+	}
+	return nil
+}
+
+// findReadableNote, notu bulur ve kullanıcının okuma yetkisini kontrol eder. Özel notları sahibi,
+// notun paylaşıldığı grupların üyeleri ve notun doğrudan paylaşıldığı kullanıcılar okuyabilir.
+func (s *NoteService) findReadableNote(noteID, userID uint) (*domain.Note, error) {
+	note, err := s.noteRepo.FindByID(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+	}
+	if note == nil {
+		return nil, ErrNoteNotFound
+	}
+
+	// Özel notların geçmişini notu görebilen herkes görebilir
+	canView, err := s.access.CanViewNote(note, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView {
+		return nil, ErrNotAuthorized
+	}
+	return note, nil
+}
+
+// GetRevisions, bir notun revizyonlarını en yeniden eskiye getirir
+func (s *NoteService) GetRevisions(noteID, userID uint, limit, offset int) ([]*domain.NoteRevision, error) {
+	if _, err := s.findReadableNote(noteID, userID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.revisionRepo.FindByNoteID(noteID, limit, offset)
+}
+
+// GetRevision, bir notun belirli bir revizyonunu getirir
+func (s *NoteService) GetRevision(noteID, userID uint, number int) (*domain.NoteRevision, error) {
+	if _, err := s.findReadableNote(noteID, userID); err != nil {
+		return nil, err
+	}
+
+	revision, err := s.revisionRepo.FindByNumber(noteID, number)
+	if err != nil {
+		return nil, fmt.Errorf("revizyon arama sırasında hata: %w", err)
+	}
+	if revision == nil {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, nil
+}
+
+// DiffRevisions, bir notun iki revizyonu arasındaki satır bazlı farkı hesaplar
+func (s *NoteService) DiffRevisions(noteID, userID uint, from, to int) (*domain.RevisionDiff, error) {
+	oldRevision, err := s.GetRevision(noteID, userID, from)
+	if err != nil {
+		return nil, err
+	}
+	newRevision, err := s.GetRevision(noteID, userID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &domain.RevisionDiff{
+		NoteID:      noteID,
+		From:        from,
+		To:          to,
+		OldTitle:    oldRevision.Title,
+		NewTitle:    newRevision.Title,
+		AddedTags:   tagDifference(newRevision.Tags, oldRevision.Tags),
+		RemovedTags: tagDifference(oldRevision.Tags, newRevision.Tags),
+		Lines:       diffLines(oldRevision.Content, newRevision.Content),
+	}
+	for _, line := range diff.Lines {
+		switch line.Type {
+		case "insert":
+			diff.Additions++
+		case "delete":
+			diff.Deletions++
+		}
+	}
+	return diff, nil
+}
+
+// RestoreRevision, bir notu önceki bir revizyonuna geri döndürür. Notu düzenleyebilen herkes (sahibi
+// ve editör olarak paylaşılan kullanıcılar) geri yükleyebilir; notun görünürlüğü değişmez.
+// Geçmiş değiştirilmez; geri yükleme yeni bir revizyon olarak kaydedilir.
+func (s *NoteService) RestoreRevision(noteID, userID uint, number int) (*domain.Note, error) {
+	note, err := s.noteRepo.FindByID(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+	}
+	if note == nil {
+		return nil, ErrNoteNotFound
+	}
+
+	// Geri yükleme bir düzenlemedir; not sahibi ve editör olarak paylaşılan kullanıcılar geri yükleyebilir
+	canEdit, err := s.access.CanEditNote(note, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, ErrNotAuthorized
+	}
+
+	revision, err := s.revisionRepo.FindByNumber(noteID, number)
+	if err != nil {
+		return nil, fmt.Errorf("revizyon arama sırasında hata: %w", err)
+	}
+	if revision == nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	if err := s.ensureBaseRevision(note); err != nil {
+		return nil, err
+	}
+
+	// Revizyondaki içeriği nota uygula
+	note.Title = revision.Title
+	note.Content = revision.Content
+	note.Tags = revision.Tags
+	if err := s.noteRepo.Update(note); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return nil, s.noteConflict(noteID)
+		}
+		return nil, fmt.Errorf("not güncelleme sırasında hata: %w", err)
+	}
+
+	if err := s.recordRevision(note, userID, number); err != nil {
+		return nil, err
+	}
+
+	return s.noteRepo.FindByID(noteID)
+}
+
+// tagDifference, a'da olup b'de olmayan etiketleri döndürür
+func tagDifference(a, b []string) []string {
+	seen := make(map[string]bool, len(b))
+	for _, tag := range b {
+		seen[tag] = true
+	}
+
+	diff := make([]string, 0)
+	for _, tag := range a {
+		if !seen[tag] {
+			diff = append(diff, tag)
+		}
+	}
+	return diff
+}
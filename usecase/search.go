@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+)
+
+var (
+	ErrInvalidSearchLanguage = errors.New("geçersiz arama dili")
+)
+
+const (
+	// maxSearchTerms, bir sorguda dikkate alınan en fazla terim sayısı
+	maxSearchTerms = 16
+	// maxSearchLimit, tek sayfada döndürülebilecek en fazla sonuç sayısı
+	maxSearchLimit = 100
+)
+
+// SearchService, notlar ve PDF'ler üzerinde tam metin arama iş mantığını içerir
+type SearchService struct {
+	noteRepo        domain.NoteRepository
+	pdfRepo         domain.PDFRepository
+	defaultLanguage string
+}
+
+// NewSearchService, yeni bir SearchService örneği oluşturur. defaultLanguage, istekte dil
+// belirtilmediğinde kullanılan arama dilidir ("turkish" veya "english").
+func NewSearchService(noteRepo domain.NoteRepository, pdfRepo domain.PDFRepository, defaultLanguage string) *SearchService {
+	language, err := resolveSearchLanguage(defaultLanguage, domain.SearchLanguageTurkish)
+	if err != nil {
+		logger.Error("Geçersiz varsayılan arama dili %q, Türkçe kullanılacak", defaultLanguage)
+		language = domain.SearchLanguageTurkish
+	}
+
+	return &SearchService{
+		noteRepo:        noteRepo,
+		pdfRepo:         pdfRepo,
+		defaultLanguage: language,
+	}
+}
+
+// Search, sorguya uyan notları ve PDF'leri puana göre sıralı döndürür.
+// contentType "note", "pdf" veya tümü için boş olabilir. userID 0 değilse kullanıcının
+// özel içerikleri de aranır.
+//
+// Sorgu sözdizimi: kelimeler birlikte aranır, "tırnak içindeki ifadeler" art arda geçmelidir,
+// sonu * ile biten kelimeler önek olarak eşleşir ve - ile başlayan terimler hariç tutulur.
+func (s *SearchService) Search(text, contentType, language string, userID uint, limit, offset int) ([]*domain.SearchHit, error) {
+	terms := parseSearchTerms(text)
+	if !hasPositiveTerm(terms) {
+		return nil, ErrInvalidParameters
+	}
+
+	language, err := resolveSearchLanguage(language, s.defaultLanguage)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := &domain.SearchQuery{
+		Terms:    terms,
+		Language: language,
+		UserID:   userID,
+		Limit:    limit,
+		Offset:   offset,
+	}
+
+	switch contentType {
+	case "note":
+		return s.noteRepo.Search(query)
+	case "pdf":
+		return s.pdfRepo.Search(query)
+	case "":
+		return s.searchAll(query)
+	default:
+		return nil, ErrInvalidType
+	}
+}
+
+// searchAll, notları ve PDF'leri ayrı ayrı arar ve sonuçları puana göre birleştirir.
+// İstenen sayfanın doğru oluşması için her iki kaynaktan da sayfa sonuna kadarki sonuçlar alınır.
+func (s *SearchService) searchAll(query *domain.SearchQuery) ([]*domain.SearchHit, error) {
+	perSource := *query
+	perSource.Limit = query.Offset + query.Limit
+	perSource.Offset = 0
+
+	notes, err := s.noteRepo.Search(&perSource)
+	if err != nil {
+		return nil, err
+	}
+	pdfs, err := s.pdfRepo.Search(&perSource)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := append(notes, pdfs...)
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		return hitCreatedAt(hits[i]).After(hitCreatedAt(hits[j]))
+	})
+
+	if query.Offset >= len(hits) {
+		return []*domain.SearchHit{}, nil
+	}
+	hits = hits[query.Offset:]
+	if len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+	return hits, nil
+}
+
+// resolveSearchLanguage, istekteki dil değerini metin arama yapılandırmasına çevirir
+func resolveSearchLanguage(language, fallback string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(language)) {
+	case "":
+		return fallback, nil
+	case "tr", domain.SearchLanguageTurkish:
+		return domain.SearchLanguageTurkish, nil
+	case "en", domain.SearchLanguageEnglish:
+		return domain.SearchLanguageEnglish, nil
+	default:
+		return "", ErrInvalidSearchLanguage
+	}
+}
+
+// parseSearchTerms, arama metnini terimlere ayırır. Terimlerdeki harf ve rakam dışındaki
+// karakterler kelime ayıracı kabul edilir ("e-posta" bir ifade olarak aranır).
+func parseSearchTerms(text string) []domain.SearchTerm {
+	var terms []domain.SearchTerm
+	runes := []rune(text)
+	for i := 0; i < len(runes) && len(terms) < maxSearchTerms; {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		term := domain.SearchTerm{}
+		if runes[i] == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+			term.Exclude = true
+			i++
+		}
+
+		// Tırnak içindeki ifade veya boşluğa kadar olan kelime
+		var chunk []rune
+		if runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			chunk = runes[i+1 : end]
+			i = end + 1
+		} else {
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) {
+				end++
+			}
+			chunk = runes[i:end]
+			i = end
+		}
+
+		// "ifade"* ve kelime* önek aramasıdır
+		if i < len(runes) && runes[i] == '*' {
+			term.Prefix = true
+			i++
+		}
+		if strings.HasSuffix(string(chunk), "*") {
+			term.Prefix = true
+		}
+
+		// Türkçe "İ" küçültüldüğünde oluşan birleşik nokta işareti kelimeyi bölmesin
+		lower := strings.ReplaceAll(strings.ToLower(string(chunk)), "\u0307", "")
+		term.Words = strings.FieldsFunc(lower, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(term.Words) > 0 {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// hasPositiveTerm, sorguda hariç tutulmayan en az bir terim olup olmadığını kontrol eder
+func hasPositiveTerm(terms []domain.SearchTerm) bool {
+	for _, term := range terms {
+		if !term.Exclude {
+			return true
+		}
+	}
+	return false
+}
+
+// hitCreatedAt, sonucun içerik oluşturulma zamanını döndürür
+func hitCreatedAt(hit *domain.SearchHit) time.Time {
+	if hit.Note != nil {
+		return hit.Note.CreatedAt
+	}
+	if hit.PDF != nil {
+		return hit.PDF.CreatedAt
+	}
+	return time.Time{}
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/OmerFErdogan/uninote/domain"
+)
+
+var (
+	ErrShareNotFound     = errors.New("paylaşım bulunamadı")
+	ErrShareUserNotFound = errors.New("paylaşılacak kullanıcı bulunamadı")
+	// ErrInvalidShare, paylaşım isteği geçersiz olduğunda döner; ayrıntılı hatalar bu hatayı sarar
+	ErrInvalidShare = errors.New("geçersiz paylaşım isteği")
+)
+
+// ShareService, not ve PDF'lerin belirli kullanıcılarla paylaşılmasıyla ilgili iş mantığını içerir
+type ShareService struct {
+	shareRepo domain.ShareRepository
+	noteRepo  domain.NoteRepository
+	pdfRepo   domain.PDFRepository
+	userRepo  domain.UserRepository
+}
+
+// NewShareService, yeni bir ShareService örneği oluşturur
+func NewShareService(
+	shareRepo domain.ShareRepository,
+	noteRepo domain.NoteRepository,
+	pdfRepo domain.PDFRepository,
+	userRepo domain.UserRepository,
+) *ShareService {
+	return &ShareService{
+		shareRepo: shareRepo,
+		noteRepo:  noteRepo,
+		pdfRepo:   pdfRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// validShareRole, rolün geçerli bir paylaşım rolü olduğunu kontrol eder
+func validShareRole(role string) error {
+	if _, ok := shareRoleLevels[role]; !ok {
+		return fmt.Errorf("%w: rol \"viewer\", \"commenter\" veya \"editor\" olmalı", ErrInvalidShare)
+	}
+	return nil
+}
+
+// sharedContent, paylaşılan içeriğin sahibini ve listelemede gösterilen bilgilerini tutar
+type sharedContent struct {
+	ownerID     uint
+	title       string
+	description string
+}
+
+// findContent, paylaşılan içeriği bulur. İçerik yoksa nil döner.
+func (s *ShareService) findContent(contentID uint, contentType string) (*sharedContent, error) {
+	switch contentType {
+	case "note":
+		note, err := s.noteRepo.FindByID(contentID)
+		if err != nil {
+			return nil, fmt.Errorf("not arama sırasında hata: %w", err)
+		}
+		if note == nil {
+			return nil, nil
+		}
+		return &sharedContent{ownerID: note.UserID, title: note.Title}, nil
+	case "pdf":
+		pdf, err := s.pdfRepo.FindByID(contentID)
+		if err != nil {
+			return nil, fmt.Errorf("PDF arama sırasında hata: %w", err)
+		}
+		if pdf == nil {
+			return nil, nil
+		}
+		return &sharedContent{ownerID: pdf.UserID, title: pdf.Title, description: pdf.Description}, nil
+	default:
+		return nil, ErrInvalidType
+	}
+}
+
+// requireOwner, içeriği bulur ve kullanıcının içeriğin sahibi olduğunu kontrol eder
+func (s *ShareService) requireOwner(contentID uint, contentType string, userID uint) (*sharedContent, error) {
+	content, err := s.findContent(contentID, contentType)
+	if err != nil {
+		return nil, err
+	}
+	if content == nil {
+		return nil, ErrContentNotFound
+	}
+	if content.ownerID != userID {
+		return nil, ErrNotAuthorized
+	}
+	return content, nil
+}
+
+// findRecipient, kullanıcıyı e-posta adresine veya kullanıcı adına göre bulur
+func (s *ShareService) findRecipient(recipient string) (*domain.User, error) {
+	recipient = strings.TrimSpace(recipient)
+	if recipient == "" {
+		return nil, fmt.Errorf("%w: kullanıcı adı veya e-posta boş olamaz", ErrInvalidShare)
+	}
+
+	var user *domain.User
+	var err error
+	if strings.Contains(recipient, "@") {
+		user, err = s.userRepo.FindByEmail(recipient)
+	} else {
+		user, err = s.userRepo.FindByUsername(recipient)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı arama sırasında hata: %w", err)
+	}
+	if user == nil {
+		return nil, ErrShareUserNotFound
+	}
+	return user, nil
+}
+
+// ShareContent, içeriği kullanıcı adı veya e-posta adresiyle belirtilen kullanıcıyla verilen rolde paylaşır.
+// İçerik kullanıcıyla zaten paylaşılmışsa paylaşımın rolü güncellenir. Yalnızca içeriğin sahibi paylaşabilir.
+func (s *ShareService) ShareContent(contentID uint, contentType string, ownerID uint, recipient, role string) (*domain.ContentShare, error) {
+	if err := validShareRole(role); err != nil {
+		return nil, err
+	}
+	if _, err := s.requireOwner(contentID, contentType, ownerID); err != nil {
+		return nil, err
+	}
+	user, err := s.findRecipient(recipient)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == ownerID {
+		return nil, fmt.Errorf("%w: içerik kendinizle paylaşılamaz", ErrInvalidShare)
+	}
+
+	existing, err := s.shareRepo.Find(contentID, contentType, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("paylaşım arama sırasında hata: %w", err)
+	}
+	if existing == nil {
+		share := &domain.ContentShare{
+			ContentID:   contentID,
+			ContentType: contentType,
+			OwnerID:     ownerID,
+			UserID:      user.ID,
+			Role:        role,
+		}
+		err := s.shareRepo.Create(share)
+		if err == nil {
+			share.Username = user.Username
+			return share, nil
+		}
+		if !errors.Is(err, domain.ErrDuplicateEntry) {
+			return nil, fmt.Errorf("paylaşım kaydı sırasında hata: %w", err)
+		}
+
+		// Eşzamanlı bir istek aynı paylaşımı oluşturmuş; onun rolünü güncelle
+		if existing, err = s.shareRepo.Find(contentID, contentType, user.ID); err != nil {
+			return nil, fmt.Errorf("paylaşım arama sırasında hata: %w", err)
+		}
+		if existing == nil {
+			return nil, ErrShareNotFound
+		}
+	}
+
+	existing.Role = role
+	if err := s.shareRepo.Update(existing); err != nil {
+		return nil, fmt.Errorf("paylaşım güncelleme sırasında hata: %w", err)
+	}
+	existing.Username = user.Username
+	return existing, nil
+}
+
+// GetShares, içeriğin paylaşıldığı kullanıcıları paylaşım sırasına göre getirir. Yalnızca içeriğin sahibi görebilir.
+func (s *ShareService) GetShares(contentID uint, contentType string, ownerID uint, limit, offset int) ([]*domain.ContentShare, error) {
+	if _, err := s.requireOwner(contentID, contentType, ownerID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	shares, err := s.shareRepo.FindByContent(contentID, contentType, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("paylaşım arama sırasında hata: %w", err)
+	}
+	names := newUsernames(s.userRepo)
+	for _, share := range shares {
+		if share.Username, err = names.lookup(share.UserID); err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
+
+// findShare, paylaşımı bulur
+func (s *ShareService) findShare(id uint) (*domain.ContentShare, error) {
+	share, err := s.shareRepo.FindByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("paylaşım arama sırasında hata: %w", err)
+	}
+	if share == nil {
+		return nil, ErrShareNotFound
+	}
+	return share, nil
+}
+
+// UpdateShare, paylaşımın rolünü değiştirir. Yalnızca içeriğin sahibi değiştirebilir.
+func (s *ShareService) UpdateShare(id, ownerID uint, role string) (*domain.ContentShare, error) {
+	if err := validShareRole(role); err != nil {
+		return nil, err
+	}
+	share, err := s.findShare(id)
+	if err != nil {
+		return nil, err
+	}
+	if share.OwnerID != ownerID {
+		return nil, ErrNotAuthorized
+	}
+
+	share.Role = role
+	if err := s.shareRepo.Update(share); err != nil {
+		return nil, fmt.Errorf("paylaşım güncelleme sırasında hata: %w", err)
+	}
+	if share.Username, err = newUsernames(s.userRepo).lookup(share.UserID); err != nil {
+		return nil, err
+	}
+	return share, nil
+}
+
+// RevokeShare, paylaşımı kaldırır. İçeriğin sahibi paylaşımı geri alabilir, paylaşılan kullanıcı da
+// içerikten kendini çıkarabilir.
+func (s *ShareService) RevokeShare(id, userID uint) error {
+	share, err := s.findShare(id)
+	if err != nil {
+		return err
+	}
+	if share.OwnerID != userID && share.UserID != userID {
+		return ErrNotAuthorized
+	}
+	return s.shareRepo.Delete(id)
+}
+
+// GetSharedWithMe, kullanıcıyla paylaşılan içerikleri son paylaşılandan başlayarak getirir.
+// contentType boşsa notlar ve PDF'ler birlikte döner; silinmiş içerikler atlanır.
+func (s *ShareService) GetSharedWithMe(userID uint, contentType string, limit, offset int) ([]*domain.SharedContentResponse, error) {
+	if contentType != "" && contentType != "note" && contentType != "pdf" {
+		return nil, ErrInvalidType
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	shares, err := s.shareRepo.FindByUserID(userID, contentType, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("paylaşım arama sırasında hata: %w", err)
+	}
+	names := newUsernames(s.userRepo)
+	responses := make([]*domain.SharedContentResponse, 0, len(shares))
+	for _, share := range shares {
+		content, err := s.findContent(share.ContentID, share.ContentType)
+		if err != nil {
+			return nil, err
+		}
+		if content == nil {
+			continue
+		}
+		ownerName, err := names.lookup(content.ownerID)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, &domain.SharedContentResponse{
+			ContentShare:  *share,
+			Title:         content.title,
+			Description:   content.description,
+			OwnerUsername: ownerName,
+		})
+	}
+	return responses, nil
+}
+
+// Ensure ShareService implements domain.ShareService
+var _ domain.ShareService = (*ShareService)(nil)
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/OmerFErdogan/uninote/domain"
+	"github.com/OmerFErdogan/uninote/infrastructure/logger"
+)
+
+// storageMigrationBatch, depo taşıma sırasında veritabanından tek seferde okunan PDF sayısı
+const storageMigrationBatch = 100
+
+// StorageMigrationResult, depo taşıma işleminin özetidir
+type StorageMigrationResult struct {
+	Copied  int // Yeni depoya kopyalanan ve dosya yolu güncellenen PDF'ler
+	Skipped int // Kaynak depoda dosyası bulunmayan (önceden taşınmış veya eksik) PDF'ler
+	Failed  int // Kopyalanamayan veya özeti eşleşmeyen PDF'ler
+}
+
+// MigrateStorage, PDF dosyalarını source deposundan servisin kullandığı depoya kopyalar ve her PDF'in
+// dosya yolunu yeni depodaki konumuyla günceller. Kaynakta dosyası bulunmayan PDF'ler atlandığı için
+// işlem yarıda kalırsa yeniden çalıştırılabilir. deleteSource true ise kopyalanan dosyalar kaynaktan silinir.
+// Tek tek PDF'lerdeki hatalar kaydedilip geçilir; veritabanı hataları işlemi durdurur.
+func (s *PDFService) MigrateStorage(source domain.PDFStorage, deleteSource bool) (*StorageMigrationResult, error) {
+	result := &StorageMigrationResult{}
+
+	for offset := 0; ; offset += storageMigrationBatch {
+		pdfs, err := s.pdfRepo.FindAll(storageMigrationBatch, offset)
+		if err != nil {
+			return result, fmt.Errorf("PDF listeleme sırasında hata: %w", err)
+		}
+
+		for _, pdf := range pdfs {
+			file, err := source.Open(pdf.FilePath)
+			if err != nil {
+				result.Skipped++
+				continue
+			}
+
+			s.blobMu.RLock()
+			stored, err := s.pdfStorage.Save(file)
+			file.Close()
+			if err != nil {
+				s.blobMu.RUnlock()
+				logger.Error("PDF %d kopyalanamadı: %v", pdf.ID, err)
+				result.Failed++
+				continue
+			}
+
+			// Kopyanın yüklemedeki içerikle aynı olduğunu doğrula
+			if pdf.ContentHash != "" && stored.SHA256 != pdf.ContentHash {
+				s.blobMu.RUnlock()
+				logger.Error("PDF %d kopyasının özeti eşleşmiyor (beklenen %s, bulunan %s)", pdf.ID, pdf.ContentHash, stored.SHA256)
+				s.releaseBlob(stored.Path)
+				result.Failed++
+				continue
+			}
+
+			err = s.pdfRepo.UpdateFilePath(pdf.ID, stored.Path)
+			s.blobMu.RUnlock()
+			if err != nil {
+				return result, fmt.Errorf("PDF %d dosya yolu güncellenirken hata: %w", pdf.ID, err)
+			}
+			result.Copied++
+
+			// Kaynak dosya, aynı dosyayı paylaşan PDF'lerin tümü taşındıktan sonra silinir
+			if deleteSource {
+				if refs, err := s.pdfRepo.CountByFilePath(pdf.FilePath); err != nil {
+					logger.Error("PDF %d kaynak dosyasının referansları sayılamadı: %v", pdf.ID, err)
+				} else if refs == 0 {
+					if err := source.Delete(pdf.FilePath); err != nil {
+						logger.Error("PDF %d kaynak dosyası silinemedi: %v", pdf.ID, err)
+					}
+				}
+			}
+		}
+
+		if len(pdfs) < storageMigrationBatch {
+			return result, nil
+		}
+	}
+}
+
+// EnqueueContentHashBackfill, içerik özeti olmayan (özet sütunu eklenmeden önce yüklenmiş) PDF'ler için
+// JobHashPDFContent işlerini kuyruğa alır. Bu tür bir işi zaten bulunan PDF'ler atlandığı için sunucu her
+// başladığında çağrılabilir. Kuyruğa alınan iş sayısını döndürür.
+func (s *PDFService) EnqueueContentHashBackfill() (int, error) {
+	queued := make(map[uint]bool)
+	for offset := 0; ; offset += storageMigrationBatch {
+		jobs, err := s.jobService.ListJobs("", domain.JobHashPDFContent, storageMigrationBatch, offset)
+		if err != nil {
+			return 0, err
+		}
+		for _, job := range jobs {
+			var payload domain.PDFJobPayload
+			if decodeJobPayload(job, &payload) == nil {
+				queued[payload.PDFID] = true
+			}
+		}
+		if len(jobs) < storageMigrationBatch {
+			break
+		}
+	}
+
+	count := 0
+	var afterID uint
+	for {
+		pdfs, err := s.pdfRepo.FindWithoutContentHash(afterID, storageMigrationBatch)
+		if err != nil {
+			return count, fmt.Errorf("PDF listeleme sırasında hata: %w", err)
+		}
+		for _, pdf := range pdfs {
+			afterID = pdf.ID
+			if queued[pdf.ID] {
+				continue
+			}
+			if _, err := s.jobService.Enqueue(domain.JobHashPDFContent, domain.PDFJobPayload{PDFID: pdf.ID}); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(pdfs) < storageMigrationBatch {
+			return count, nil
+		}
+	}
+}
+
+// RunHashContentJob, JobHashPDFContent işini çalıştırır: içerik özeti olmayan eski PDF'in dosyasını depoya
+// yeniden kaydeder. Depo dosyayı içerik özetinden türetilen konuma yazdığı (aynı içerik zaten saklanıyorsa
+// onu kullandığı) için eski PDF de yeni yüklemelerle dosyayı paylaşır ve kopya aramalarında bulunur. Özet
+// ve yeni dosya yolu PDF'e kaydedilir, başka PDF'in kullanmadığı eski dosya silinir ve PDF ID'siyle
+// saklanan görseller içerik özetiyle saklanan konuma taşınır. PDF silinmişse veya özeti zaten varsa
+// yapılacak iş yoktur.
+func (s *PDFService) RunHashContentJob(job *domain.Job) error {
+	var payload domain.PDFJobPayload
+	if err := decodeJobPayload(job, &payload); err != nil {
+		return err
+	}
+
+	pdf, err := s.pdfRepo.FindByID(payload.PDFID)
+	if err != nil {
+		return fmt.Errorf("PDF arama sırasında hata: %w", err)
+	}
+	if pdf == nil || pdf.ContentHash != "" {
+		return nil
+	}
+
+	file, err := s.pdfStorage.Open(pdf.FilePath)
+	if err != nil {
+		return fmt.Errorf("dosya okuma hatası: %w", err)
+	}
+
+	// Dosya, aynı içerikli son PDF silinirken bağlanmasın diye kilit altında kaydedilir ve PDF'e bağlanır
+	s.blobMu.RLock()
+	stored, err := s.pdfStorage.Save(file)
+	file.Close()
+	if err != nil {
+		s.blobMu.RUnlock()
+		return fmt.Errorf("dosya kaydetme hatası: %w", err)
+	}
+	err = s.pdfRepo.UpdateContentHash(pdf.ID, stored.Path, stored.SHA256)
+	if err == nil {
+		s.moveLegacyImages(pdf, stored.SHA256)
+	}
+	s.blobMu.RUnlock()
+
+	if err != nil {
+		if stored.Path != pdf.FilePath {
+			s.releaseBlob(stored.Path)
+		}
+		return fmt.Errorf("içerik özeti kaydedilirken hata: %w", err)
+	}
+	if stored.Path != pdf.FilePath {
+		s.releaseBlob(pdf.FilePath)
+	}
+	return nil
+}
+
+// moveLegacyImages, içerik özeti yeni hesaplanan PDF'in ID'siyle saklanan görsellerini içerik özetiyle
+// saklanan konuma kopyalar ve eskilerini siler; aynı içerikli bir PDF'in görselleri zaten varsa onlar
+// kullanılır. Kopyalanamazsa görseller yeniden üretilmek üzere kuyruğa alınır. Çağıran blobMu okuma
+// kilidini tutmalıdır; hatalar yalnızca kaydedilir.
+func (s *PDFService) moveLegacyImages(legacy *domain.PDF, contentHash string) {
+	if s.previews.Images == nil || !legacy.HasThumbnail {
+		return
+	}
+	hashed := *legacy
+	hashed.ContentHash = contentHash
+
+	keys := map[string]string{coverKey(legacy): coverKey(&hashed)}
+	for page := 1; page <= legacy.PreviewPages; page++ {
+		keys[pageImageKey(legacy, page)] = pageImageKey(&hashed, page)
+	}
+	for from, to := range keys {
+		if err := s.copyImage(from, to); err != nil {
+			logger.Error("PDF %d görselleri taşınamadı: %v", legacy.ID, err)
+			if s.previewsEnabled() {
+				if _, err := s.jobService.Enqueue(domain.JobRenderPDFPages, domain.PDFJobPayload{PDFID: legacy.ID}); err != nil {
+					logger.Error("PDF %d için %s işi kuyruğa alınamadı: %v", legacy.ID, domain.JobRenderPDFPages, err)
+				}
+			}
+			return
+		}
+	}
+	if err := s.previews.Images.DeleteAll(imagePrefix(legacy)); err != nil {
+		logger.Error("PDF %d eski görselleri silinemedi: %v", legacy.ID, err)
+	}
+}
+
+// copyImage, görseli hedef anahtar altında yoksa kopyalar
+func (s *PDFService) copyImage(from, to string) error {
+	if file, err := s.previews.Images.Open(to); err == nil {
+		file.Close()
+		return nil
+	}
+
+	file, err := s.previews.Images.Open(from)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+	return s.previews.Images.Save(to, content)
+}